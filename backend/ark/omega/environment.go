@@ -1,10 +1,17 @@
 package omega
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"fmt"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 type Environments struct {
 	DBConfig
 	ServerConfig
+	MigrationConfig
+	StatConfig
+	UniqueConfig
 }
 
 func LoadConfig() (*Environments, error) {
@@ -15,6 +22,26 @@ func LoadConfig() (*Environments, error) {
 	return &cfg, nil
 }
 
+// LoadDBConfig サーバーを起動しないマイグレーションなどのコマンド向けにDBの設定のみを読み込む
+func LoadDBConfig() (*DBConfig, error) {
+	var cfg DBConfig
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// LoadCatalogConfig サーバーを起動せずにユースケースを用いるコマンド向けに、ADDRESS以外の設定を読み込む
+func LoadCatalogConfig() (*Environments, error) {
+	var cfg Environments
+	for _, spec := range []any{&cfg.DBConfig, &cfg.MigrationConfig, &cfg.StatConfig, &cfg.UniqueConfig} {
+		if err := envconfig.Process("", spec); err != nil {
+			return nil, err
+		}
+	}
+	return &cfg, nil
+}
+
 type DBConfig struct {
 	DBUsername   string `envconfig:"DB_USERNAME" required:"true"`
 	DBPassword   string `envconfig:"DB_PASSWORD" required:"true"`
@@ -23,6 +50,35 @@ type DBConfig struct {
 	DatabaseURL  string `envconfig:"DB_URL"`
 }
 
+func (c DBConfig) DSN() string {
+	return fmt.Sprintf(
+		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		c.DBUsername,
+		c.DBPassword,
+		c.DatabaseURL,
+		c.Port,
+		c.DatabaseName,
+	)
+}
+
 type ServerConfig struct {
 	Address string `envconfig:"ADDRESS" required:"true"`
 }
+
+type MigrationConfig struct {
+	// AutoMigrate サーバー起動時に未適用のマイグレーションを適用する
+	AutoMigrate bool `envconfig:"AUTO_MIGRATE" default:"false"`
+}
+
+// StatConfig サーバー設定のPerLevelStatsMultiplierを"health:2,melee:1.5"の形式でステータス毎に指定する
+type StatConfig struct {
+	WildLevelMultipliers     map[string]float32 `envconfig:"STAT_WILD_LEVEL_MULTIPLIERS"`
+	TamedLevelMultipliers    map[string]float32 `envconfig:"STAT_TAMED_LEVEL_MULTIPLIERS"`
+	TamedAddMultipliers      map[string]float32 `envconfig:"STAT_TAMED_ADD_MULTIPLIERS"`
+	TamedAffinityMultipliers map[string]float32 `envconfig:"STAT_TAMED_AFFINITY_MULTIPLIERS"`
+}
+
+// UniqueConfig MODによって異なるユニークの仕様を指定する
+type UniqueConfig struct {
+	MaxUniqueVariants uint `envconfig:"UNIQUE_MAX_VARIANTS" default:"2"`
+}
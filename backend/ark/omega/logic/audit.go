@@ -0,0 +1,78 @@
+package logic
+
+import "context"
+
+// Actor 変更を行った利用者。認証されていないリクエストはAnonymousとして記録する
+type Actor string
+
+const Anonymous Actor = "anonymous"
+
+func (a Actor) Value() string { return string(a) }
+
+type actorKey struct{}
+
+func SetActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorOf(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok && actor != "" {
+		return actor
+	}
+	return Anonymous
+}
+
+// AuditEntity 変更履歴を記録するカタログの対象
+type AuditEntity string
+
+const (
+	AuditDinosaur AuditEntity = "dinosaur"
+	AuditUnique   AuditEntity = "unique"
+	AuditVariant  AuditEntity = "variant"
+	AuditGroup    AuditEntity = "group"
+	AuditRule     AuditEntity = "rule"
+)
+
+func (e AuditEntity) Value() string { return string(e) }
+
+type AuditAction string
+
+const (
+	AuditCreate  AuditAction = "create"
+	AuditUpdate  AuditAction = "update"
+	AuditDelete  AuditAction = "delete"
+	AuditRestore AuditAction = "restore"
+)
+
+func (a AuditAction) Value() string { return string(a) }
+
+// Change usecaseで行った1件の変更。作成と復元ではBefore、削除ではAfterがnilになる
+type Change struct {
+	Entity AuditEntity
+	ID     int
+	Action AuditAction
+	Before any
+	After  any
+}
+
+func Created(entity AuditEntity, id int, after any) Change {
+	return Change{Entity: entity, ID: id, Action: AuditCreate, After: after}
+}
+
+func Updated(entity AuditEntity, id int, before, after any) Change {
+	return Change{Entity: entity, ID: id, Action: AuditUpdate, Before: before, After: after}
+}
+
+func Deleted(entity AuditEntity, id int, before any) Change {
+	return Change{Entity: entity, ID: id, Action: AuditDelete, Before: before}
+}
+
+// Restored ゴミ箱から戻した変更。削除前のスナップショットは記録済みのためAfterのみ記録する
+func Restored(entity AuditEntity, id int, after any) Change {
+	return Change{Entity: entity, ID: id, Action: AuditRestore, After: after}
+}
+
+// AuditRecorder 変更と同じトランザクションで変更履歴を記録する
+type AuditRecorder interface {
+	Record(context.Context, Change) error
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"mods-explore/ark/omega/logic"
+)
+
+type EntryID int
+
+func (i EntryID) Value() int { return int(i) }
+
+// Entry 1件の変更履歴。変更前後の状態は記録時点のJSONのまま保持する
+type Entry struct {
+	id        EntryID
+	entity    logic.AuditEntity
+	entityID  int
+	action    logic.AuditAction
+	before    json.RawMessage
+	after     json.RawMessage
+	actor     logic.Actor
+	createdAt time.Time
+}
+
+func NewEntry(
+	id EntryID,
+	entity logic.AuditEntity,
+	entityID int,
+	action logic.AuditAction,
+	before, after json.RawMessage,
+	actor logic.Actor,
+	createdAt time.Time,
+) Entry {
+	return Entry{
+		id:        id,
+		entity:    entity,
+		entityID:  entityID,
+		action:    action,
+		before:    before,
+		after:     after,
+		actor:     actor,
+		createdAt: createdAt,
+	}
+}
+
+func (e Entry) ID() EntryID               { return e.id }
+func (e Entry) Entity() logic.AuditEntity { return e.entity }
+func (e Entry) EntityID() int             { return e.entityID }
+func (e Entry) Action() logic.AuditAction { return e.action }
+
+// Before 作成の履歴ではnil
+func (e Entry) Before() json.RawMessage { return e.before }
+
+// After 削除の履歴ではnil
+func (e Entry) After() json.RawMessage { return e.after }
+func (e Entry) Actor() logic.Actor     { return e.actor }
+func (e Entry) CreatedAt() time.Time   { return e.createdAt }
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+)
+
+// CreateEntry 記録日時はトランザクションの開始日時をデータベースで付与する
+type CreateEntry struct {
+	change logic.Change
+	before json.RawMessage
+	after  json.RawMessage
+	actor  logic.Actor
+}
+
+func NewCreateEntry(change logic.Change, before, after json.RawMessage, actor logic.Actor) CreateEntry {
+	return CreateEntry{change: change, before: before, after: after, actor: actor}
+}
+
+func (e CreateEntry) Entity() logic.AuditEntity { return e.change.Entity }
+func (e CreateEntry) EntityID() int             { return e.change.ID }
+func (e CreateEntry) Action() logic.AuditAction { return e.change.Action }
+func (e CreateEntry) Before() json.RawMessage   { return e.before }
+func (e CreateEntry) After() json.RawMessage    { return e.after }
+func (e CreateEntry) Actor() logic.Actor        { return e.actor }
+
+// ListHistory 1つの対象の変更履歴を記録順に取得する
+type ListHistory struct {
+	logic.PageRequest
+	entity   logic.AuditEntity
+	entityID int
+}
+
+func NewListHistory(page logic.PageRequest, entity logic.AuditEntity, entityID int) ListHistory {
+	return ListHistory{PageRequest: page, entity: entity, entityID: entityID}
+}
+
+func (l ListHistory) Entity() logic.AuditEntity { return l.entity }
+func (l ListHistory) EntityID() int             { return l.entityID }
+
+// ListEntries sinceがゼロ値の場合は全ての変更履歴を対象とする
+type ListEntries struct {
+	logic.PageRequest
+	since time.Time
+}
+
+func NewListEntries(page logic.PageRequest, since time.Time) ListEntries {
+	return ListEntries{PageRequest: page, since: since}
+}
+
+func (l ListEntries) Since() time.Time { return l.since }
+
+type AuditRepository interface {
+	Insert(context.Context, CreateEntry) error
+	ListHistory(context.Context, ListHistory) (*logic.Page[model.Entry], error)
+	ListEntries(context.Context, ListEntries) (*logic.Page[model.Entry], error)
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+)
+
+type AuditUsecase interface {
+	logic.AuditRecorder
+	History(context.Context, service.ListHistory) (*logic.Page[model.Entry], error)
+	List(context.Context, service.ListEntries) (*logic.Page[model.Entry], error)
+}
+
+type Audit struct {
+	repository service.AuditRepository
+}
+
+func NewAudit(injector *do.Injector) (AuditUsecase, error) {
+	return &Audit{
+		repository: do.MustInvoke[service.AuditRepository](injector),
+	}, nil
+}
+
+// Record 呼び出し元のトランザクション内で実行し、記録に失敗した場合は変更ごと取り消す
+func (a Audit) Record(ctx context.Context, change logic.Change) error {
+	before, err := snapshot(change.Before)
+	if err != nil {
+		return err
+	}
+	after, err := snapshot(change.After)
+	if err != nil {
+		return err
+	}
+
+	if err = a.repository.Insert(
+		ctx, service.NewCreateEntry(change, before, after, logic.ActorOf(ctx)),
+	); err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	return nil
+}
+
+func snapshot(value any) (json.RawMessage, error) {
+	if value == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, failure.Wrap(err)
+	}
+	return b, nil
+}
+
+// History 削除済みの対象も履歴は残るため、対象の存在は確認しない
+func (a Audit) History(ctx context.Context, query service.ListHistory) (*logic.Page[model.Entry], error) {
+	entries, err := a.repository.ListHistory(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return entries, nil
+}
+
+func (a Audit) List(ctx context.Context, query service.ListEntries) (*logic.Page[model.Entry], error) {
+	entries, err := a.repository.ListEntries(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return entries, nil
+}
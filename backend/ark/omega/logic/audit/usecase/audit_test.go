@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+)
+
+type AuditTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockAudit
+	usecase AuditUsecase
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, &AuditTestSuite{})
+}
+
+const (
+	insertEntry  = "Insert"
+	listHistory  = "ListHistory"
+	listEntries  = "ListEntries"
+	actor        = logic.Actor("editor")
+	groupSummary = `{"id":1,"name":"Cosmic"}`
+)
+
+func (s *AuditTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.mockDB = newMockAudit()
+	do.ProvideValue[service.AuditRepository](injector, s.mockDB)
+	usecase, err := NewAudit(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+func (s *AuditTestSuite) TestRecord() {
+	group := map[string]any{"id": id, "name": "Cosmic"}
+	{
+		s.T().Log("作成の履歴を変更前の状態なしで記録するテスト")
+		change := logic.Created(logic.AuditGroup, id, group)
+		s.mockDB.On(
+			insertEntry, ctx, service.NewCreateEntry(change, nil, json.RawMessage(groupSummary), logic.Anonymous),
+		).Return(nil).Once()
+
+		s.NoError(s.usecase.Record(ctx, change))
+	}
+	{
+		s.T().Log("変更を行った利用者を記録するテスト")
+		ctx := logic.SetActor(ctx, actor)
+		change := logic.Deleted(logic.AuditGroup, id, group)
+		s.mockDB.On(
+			insertEntry, ctx, service.NewCreateEntry(change, json.RawMessage(groupSummary), nil, actor),
+		).Return(nil).Once()
+
+		s.NoError(s.usecase.Record(ctx, change))
+	}
+	{
+		s.T().Log("JSONにできない状態は記録しないテスト")
+		change := logic.Created(logic.AuditGroup, id, map[string]any{"id": func() {}})
+
+		s.Error(s.usecase.Record(ctx, change))
+		s.mockDB.AssertNumberOfCalls(s.T(), insertEntry, 2)
+	}
+	{
+		change := logic.Deleted(logic.AuditGroup, errID, group)
+		s.mockDB.On(
+			insertEntry, ctx, service.NewCreateEntry(change, json.RawMessage(groupSummary), nil, logic.Anonymous),
+		).Return(service.IntervalServerError).Once()
+
+		s.True(failure.Is(s.usecase.Record(ctx, change), logic.IntervalServerError))
+	}
+}
+
+func (s *AuditTestSuite) TestHistory() {
+	page, err := logic.NewPageRequest(0, "", logic.Asc)
+	s.Require().NoError(err)
+	entries := logic.NewPage([]model.Entry{
+		model.NewEntry(
+			1, logic.AuditUnique, id, logic.AuditCreate, nil, json.RawMessage(`{}`), logic.Anonymous, time.Now(),
+		),
+	}, "")
+	{
+		s.T().Log("対象の変更履歴を取得するテスト")
+		query := service.NewListHistory(page, logic.AuditUnique, id)
+		s.mockDB.On(listHistory, ctx, query).Return(&entries, nil).Once()
+
+		r, err := s.usecase.History(ctx, query)
+		s.NoError(err)
+		s.Equal(&entries, r)
+	}
+	{
+		query := service.NewListHistory(page, logic.AuditUnique, errID)
+		s.mockDB.On(listHistory, ctx, query).Return(nil, service.IntervalServerError).Once()
+
+		_, err := s.usecase.History(ctx, query)
+		s.True(failure.Is(err, logic.IntervalServerError))
+	}
+}
+
+func (s *AuditTestSuite) TestList() {
+	page, err := logic.NewPageRequest(0, "", logic.Asc)
+	s.Require().NoError(err)
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	{
+		s.T().Log("指定日時以降の変更履歴を取得するテスト")
+		query := service.NewListEntries(page, since)
+		entries := logic.NewPage([]model.Entry{}, "")
+		s.mockDB.On(listEntries, ctx, query).Return(&entries, nil).Once()
+
+		r, err := s.usecase.List(ctx, query)
+		s.NoError(err)
+		s.Equal(&entries, r)
+	}
+	{
+		query := service.NewListEntries(page, since.Add(time.Hour))
+		s.mockDB.On(listEntries, ctx, query).Return(nil, e).Once()
+
+		_, err := s.usecase.List(ctx, query)
+		s.Error(err)
+	}
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+const (
+	id = iota + 1
+	errID
+)
+
+var _ service.AuditRepository = (*mockAudit)(nil)
+
+type mockAudit struct {
+	mock.Mock
+}
+
+func newMockAudit() *mockAudit { return &mockAudit{} }
+
+func (m *mockAudit) Insert(ctx context.Context, create service.CreateEntry) error {
+	args := m.Called(ctx, create)
+	return args.Error(0)
+}
+
+func (m *mockAudit) ListHistory(ctx context.Context, query service.ListHistory) (*logic.Page[model.Entry], error) {
+	args := m.Called(ctx, query)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*logic.Page[model.Entry]), args.Error(1)
+}
+
+func (m *mockAudit) ListEntries(ctx context.Context, query service.ListEntries) (*logic.Page[model.Entry], error) {
+	args := m.Called(ctx, query)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*logic.Page[model.Entry]), args.Error(1)
+}
@@ -0,0 +1,74 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// Role APIトークンに与える権限。上位の権限は下位の権限の操作を全て行える
+type Role string
+
+const (
+	// RoleAnonymous 認証を求めない操作に指定する。トークンには与えない
+	RoleAnonymous Role = ""
+	RoleViewer    Role = "viewer"
+	RoleEditor    Role = "editor"
+	RoleAdmin     Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleAnonymous: 0,
+	RoleViewer:    1,
+	RoleEditor:    2,
+	RoleAdmin:     3,
+}
+
+func NewRole(value string) (Role, error) {
+	switch r := Role(value); r {
+	case RoleViewer, RoleEditor, RoleAdmin:
+		return r, nil
+	default:
+		return "", i18n.Errorf("%qは不明な権限です", value)
+	}
+}
+
+func (r Role) Value() string { return string(r) }
+
+// Includes rの権限でotherの権限を求める操作を行えるか
+func (r Role) Includes(other Role) bool { return roleRanks[r] >= roleRanks[other] }
+
+// Principal 認証された利用者。認証されていないリクエストのcontextには設定しない
+type Principal struct {
+	Actor Actor
+	Role  Role
+}
+
+type principalKey struct{}
+
+// SetPrincipal 変更履歴に記録するActorも合わせて設定する
+func SetPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(SetActor(ctx, principal.Actor), principalKey{}, principal)
+}
+
+func PrincipalOf(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	return principal, ok
+}
+
+// Authorize contextの利用者がroleの権限を持つか検証する。認証されていなければUnauthorized、権限が足りなければForbiddenを返す
+func Authorize(ctx context.Context, role Role) error {
+	if role == RoleAnonymous {
+		return nil
+	}
+	principal, ok := PrincipalOf(ctx)
+	if !ok {
+		return failure.Translate(i18n.Errorf("認証が必要です"), Unauthorized)
+	}
+	if !principal.Role.Includes(role) {
+		return failure.Translate(i18n.Errorf("%sの権限が必要です", role), Forbidden)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+type TokenID int
+
+func (id TokenID) Value() int { return int(id) }
+
+const maxTokenNameLength = 100
+
+// TokenName トークンの用途や利用者。変更履歴の利用者として記録する
+type TokenName string
+
+func (n TokenName) Value() string { return string(n) }
+
+func NewTokenName(value string) (TokenName, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", i18n.Errorf("トークンの名前を指定してください")
+	}
+	if utf8.RuneCountInString(value) > maxTokenNameLength {
+		return "", i18n.Errorf("トークンの名前は%d文字以下にしてください", maxTokenNameLength)
+	}
+	return TokenName(value), nil
+}
+
+const secretBytes = 32
+
+// Secret 発行時に一度だけ利用者へ渡す平文のトークン。データベースにはハッシュのみを保存する
+type Secret string
+
+func NewSecret() (Secret, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return Secret(hex.EncodeToString(b)), nil
+}
+
+func (s Secret) Value() string { return string(s) }
+
+// Hash 十分な長さの乱数のため、ソルトやストレッチングは行わずSHA-256のみで照合する
+func (s Secret) Hash() TokenHash {
+	sum := sha256.Sum256([]byte(s))
+	return TokenHash(hex.EncodeToString(sum[:]))
+}
+
+type TokenHash string
+
+func (h TokenHash) Value() string { return string(h) }
+
+// Token APIトークン。失効したトークンは削除せず、失効日時を残す
+type Token struct {
+	id        TokenID
+	name      TokenName
+	role      logic.Role
+	createdAt time.Time
+	revokedAt *time.Time
+}
+
+func NewToken(id TokenID, name TokenName, role logic.Role, createdAt time.Time, revokedAt *time.Time) Token {
+	return Token{id: id, name: name, role: role, createdAt: createdAt, revokedAt: revokedAt}
+}
+
+func (t Token) ID() TokenID           { return t.id }
+func (t Token) Name() TokenName       { return t.name }
+func (t Token) Role() logic.Role      { return t.role }
+func (t Token) CreatedAt() time.Time  { return t.createdAt }
+func (t Token) RevokedAt() *time.Time { return t.revokedAt }
+func (t Token) Revoked() bool         { return t.revokedAt != nil }
+
+func (t Token) Principal() logic.Principal {
+	return logic.Principal{Actor: logic.Actor(t.name), Role: t.role}
+}
+
+// IssuedToken 発行したトークンと平文。平文は後から取得できない
+type IssuedToken struct {
+	Token
+	secret Secret
+}
+
+func NewIssuedToken(token Token, secret Secret) IssuedToken {
+	return IssuedToken{Token: token, secret: secret}
+}
+
+func (t IssuedToken) Secret() Secret { return t.secret }
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/auth/domain/model"
+)
+
+type IssueToken struct {
+	name model.TokenName
+	role logic.Role
+	hash model.TokenHash
+}
+
+func NewIssueToken(name model.TokenName, role logic.Role, hash model.TokenHash) IssueToken {
+	return IssueToken{name: name, role: role, hash: hash}
+}
+
+func (i IssueToken) Name() model.TokenName { return i.name }
+func (i IssueToken) Role() logic.Role      { return i.role }
+func (i IssueToken) Hash() model.TokenHash { return i.hash }
+
+type TokenRepository interface {
+	// SelectByHash 失効したトークンも返すため、呼び出し側で失効しているか確認する
+	SelectByHash(context.Context, model.TokenHash) (*model.Token, error)
+	List(context.Context) ([]model.Token, error)
+	Insert(context.Context, IssueToken) (*model.Token, error)
+	// Revoke 失効済みのトークンは最初の失効日時のままにする
+	Revoke(context.Context, model.TokenID) error
+}
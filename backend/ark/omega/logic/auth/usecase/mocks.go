@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic/auth/domain/model"
+	"mods-explore/ark/omega/logic/auth/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+var _ service.TokenRepository = (*mockToken)(nil)
+
+type mockToken struct {
+	mock.Mock
+}
+
+func newMockToken() *mockToken { return &mockToken{} }
+
+func (m *mockToken) SelectByHash(ctx context.Context, hash model.TokenHash) (*model.Token, error) {
+	args := m.Called(ctx, hash)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Token), args.Error(1)
+}
+
+func (m *mockToken) List(ctx context.Context) ([]model.Token, error) {
+	args := m.Called(ctx)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.([]model.Token), args.Error(1)
+}
+
+func (m *mockToken) Insert(ctx context.Context, issue service.IssueToken) (*model.Token, error) {
+	args := m.Called(ctx, issue)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Token), args.Error(1)
+}
+
+func (m *mockToken) Revoke(ctx context.Context, id model.TokenID) error {
+	args := m.Called(ctx, id)
+
+	return args.Error(0)
+}
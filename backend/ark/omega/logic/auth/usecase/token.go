@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/auth/domain/model"
+	"mods-explore/ark/omega/logic/auth/domain/service"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+type TokenUsecase interface {
+	Authenticate(context.Context, model.Secret) (*logic.Principal, error)
+	Issue(context.Context, model.TokenName, logic.Role) (*model.IssuedToken, error)
+	List(context.Context) ([]model.Token, error)
+	Revoke(context.Context, model.TokenID) error
+}
+
+type Token struct {
+	repository service.TokenRepository
+}
+
+func NewToken(injector *do.Injector) (TokenUsecase, error) {
+	return &Token{
+		repository: do.MustInvoke[service.TokenRepository](injector),
+	}, nil
+}
+
+// Authenticate 存在しないトークンと失効したトークンを区別せずUnauthorizedにする
+func (t Token) Authenticate(ctx context.Context, secret model.Secret) (*logic.Principal, error) {
+	token, err := t.repository.SelectByHash(ctx, secret.Hash())
+	if err != nil {
+		if errors.Is(err, service.NotFound) {
+			return nil, failure.Translate(i18n.Errorf("APIトークンが不正です"), logic.Unauthorized)
+		}
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	if token.Revoked() {
+		return nil, failure.Translate(i18n.Errorf("APIトークンが不正です"), logic.Unauthorized)
+	}
+
+	principal := token.Principal()
+	return &principal, nil
+}
+
+func (t Token) Issue(ctx context.Context, name model.TokenName, role logic.Role) (*model.IssuedToken, error) {
+	secret, err := model.NewSecret()
+	if err != nil {
+		return nil, failure.Wrap(err)
+	}
+
+	token, err := t.repository.Insert(ctx, service.NewIssueToken(name, role, secret.Hash()))
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+
+	issued := model.NewIssuedToken(*token, secret)
+	return &issued, nil
+}
+
+func (t Token) List(ctx context.Context) ([]model.Token, error) {
+	tokens, err := t.repository.List(ctx)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return tokens, nil
+}
+
+func (t Token) Revoke(ctx context.Context, id model.TokenID) error {
+	if err := t.repository.Revoke(ctx, id); err != nil {
+		if errors.Is(err, service.NotFound) {
+			return failure.New(logic.NotFound)
+		}
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	return nil
+}
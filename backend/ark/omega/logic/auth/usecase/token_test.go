@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/auth/domain/model"
+	"mods-explore/ark/omega/logic/auth/domain/service"
+)
+
+type TokenTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockToken
+	usecase TokenUsecase
+}
+
+func TestTokenSuite(t *testing.T) {
+	suite.Run(t, &TokenTestSuite{})
+}
+
+const (
+	selectByHash = "SelectByHash"
+	insertToken  = "Insert"
+	revokeToken  = "Revoke"
+)
+
+var createdAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func (s *TokenTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.mockDB = newMockToken()
+	do.ProvideValue[service.TokenRepository](injector, s.mockDB)
+	usecase, err := NewToken(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+func (s *TokenTestSuite) TestAuthenticate() {
+	secret := model.Secret("secret")
+	{
+		s.T().Log("トークンの名前と権限を利用者として返すかテスト")
+		token := model.NewToken(1, "editor-bot", logic.RoleEditor, createdAt, nil)
+		s.mockDB.On(selectByHash, ctx, secret.Hash()).Return(&token, nil).Once()
+
+		principal, err := s.usecase.Authenticate(ctx, secret)
+		s.NoError(err)
+		s.Equal(&logic.Principal{Actor: "editor-bot", Role: logic.RoleEditor}, principal)
+	}
+	{
+		s.T().Log("失効したトークンはUnauthorizedになるかテスト")
+		token := model.NewToken(1, "editor-bot", logic.RoleEditor, createdAt, lo.ToPtr(createdAt.Add(time.Hour)))
+		s.mockDB.On(selectByHash, ctx, secret.Hash()).Return(&token, nil).Once()
+
+		_, err := s.usecase.Authenticate(ctx, secret)
+		s.True(failure.Is(err, logic.Unauthorized))
+	}
+	{
+		s.T().Log("存在しないトークンはUnauthorizedになるかテスト")
+		s.mockDB.On(selectByHash, ctx, secret.Hash()).Return(nil, service.NotFound).Once()
+
+		_, err := s.usecase.Authenticate(ctx, secret)
+		s.True(failure.Is(err, logic.Unauthorized))
+	}
+	{
+		s.mockDB.On(selectByHash, ctx, secret.Hash()).Return(nil, service.IntervalServerError).Once()
+
+		_, err := s.usecase.Authenticate(ctx, secret)
+		s.True(failure.Is(err, logic.IntervalServerError))
+	}
+}
+
+func (s *TokenTestSuite) TestIssue() {
+	{
+		s.T().Log("平文ではなくハッシュを保存し、平文を返すかテスト")
+		var saved model.TokenHash
+		s.mockDB.On(insertToken, ctx, mock.MatchedBy(func(issue service.IssueToken) bool {
+			saved = issue.Hash()
+			return issue.Name() == "editor-bot" && issue.Role() == logic.RoleEditor
+		})).Return(lo.ToPtr(model.NewToken(1, "editor-bot", logic.RoleEditor, createdAt, nil)), nil).Once()
+
+		issued, err := s.usecase.Issue(ctx, "editor-bot", logic.RoleEditor)
+		s.NoError(err)
+		s.Len(issued.Secret().Value(), 64)
+		s.Equal(saved, issued.Secret().Hash())
+		s.NotEqual(issued.Secret().Value(), saved.Value())
+	}
+	{
+		s.mockDB.On(insertToken, ctx, mock.Anything).Return(nil, e).Once()
+
+		_, err := s.usecase.Issue(ctx, "editor-bot", logic.RoleEditor)
+		s.True(errors.Is(err, e))
+	}
+}
+
+func (s *TokenTestSuite) TestRevoke() {
+	{
+		s.mockDB.On(revokeToken, ctx, model.TokenID(1)).Return(nil).Once()
+		s.NoError(s.usecase.Revoke(ctx, 1))
+	}
+	{
+		s.mockDB.On(revokeToken, ctx, model.TokenID(2)).Return(service.NotFound).Once()
+		s.True(failure.Is(s.usecase.Revoke(ctx, 2), logic.NotFound))
+	}
+}
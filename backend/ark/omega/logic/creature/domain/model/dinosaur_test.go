@@ -19,3 +19,32 @@ func Test_InitHealth(t *testing.T) {
 		}
 	})
 }
+
+func Test_InitStats(t *testing.T) {
+	t.Run("0を許容しないステータスの生成テスト", func(t *testing.T) {
+		if _, err := NewStamina(0); err == nil {
+			t.Error("スタミナ0でエラーになっていません")
+		}
+		if _, err := NewFood(0); err == nil {
+			t.Error("食料0でエラーになっていません")
+		}
+		if _, err := NewWeight(0); err == nil {
+			t.Error("重量0でエラーになっていません")
+		}
+		if _, err := NewTorpidity(0); err == nil {
+			t.Error("気絶値0でエラーになっていません")
+		}
+	})
+
+	t.Run("移動速度の百分率の範囲テスト", func(t *testing.T) {
+		if _, err := NewMovementSpeed(100); err != nil {
+			t.Errorf("正常な移動速度の生成ができませんでした %s", err.Error())
+		}
+		if _, err := NewMovementSpeed(0); err == nil {
+			t.Error("移動速度0%でエラーになっていません")
+		}
+		if _, err := NewMovementSpeed(maxMovementSpeed + 1); err == nil {
+			t.Error("上限を超えた移動速度でエラーになっていません")
+		}
+	})
+}
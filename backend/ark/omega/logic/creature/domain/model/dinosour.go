@@ -1,7 +1,11 @@
 package model
 
 import (
-	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
 )
 
 type Health uint
@@ -10,7 +14,7 @@ func (h Health) Value() uint { return uint(h) }
 
 func NewHealth(value uint) (Health, error) {
 	if 0 == value {
-		return 0, errors.New("体力0は許容されない不正な値です")
+		return 0, i18n.Errorf("体力0は許容されない不正な値です")
 	}
 	return Health(value), nil
 }
@@ -22,31 +26,208 @@ func (m Melee) Value() uint { return uint(m) }
 // NewMelee 攻撃不可な生物も存在するのでその場合は0を指定
 func NewMelee(value uint) Melee { return Melee(value) }
 
+type Stamina uint
+
+func (s Stamina) Value() uint { return uint(s) }
+
+func NewStamina(value uint) (Stamina, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("スタミナ0は許容されない不正な値です")
+	}
+	return Stamina(value), nil
+}
+
+type Oxygen uint
+
+func (o Oxygen) Value() uint { return uint(o) }
+
+// NewOxygen 酸素を消費しない生物も存在するのでその場合は0を指定
+func NewOxygen(value uint) Oxygen { return Oxygen(value) }
+
+type Food uint
+
+func (f Food) Value() uint { return uint(f) }
+
+func NewFood(value uint) (Food, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("食料0は許容されない不正な値です")
+	}
+	return Food(value), nil
+}
+
+type Weight uint
+
+func (w Weight) Value() uint { return uint(w) }
+
+func NewWeight(value uint) (Weight, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("重量0は許容されない不正な値です")
+	}
+	return Weight(value), nil
+}
+
+const maxMovementSpeed uint = 1000
+
+// MovementSpeed 移動速度を百分率で表す。100が標準の速度
+type MovementSpeed uint
+
+func (s MovementSpeed) Value() uint { return uint(s) }
+
+func NewMovementSpeed(value uint) (MovementSpeed, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("移動速度0%は許容されない不正な値です")
+	}
+	if maxMovementSpeed < value {
+		return 0, i18n.Errorf("移動速度は%d%%以下にしてください", maxMovementSpeed)
+	}
+	return MovementSpeed(value), nil
+}
+
+type Torpidity uint
+
+func (t Torpidity) Value() uint { return uint(t) }
+
+func NewTorpidity(value uint) (Torpidity, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("気絶値0は許容されない不正な値です")
+	}
+	return Torpidity(value), nil
+}
+
+type Armor uint
+
+func (a Armor) Value() uint { return uint(a) }
+
+// NewArmor 装甲を持たない生物が大半なのでその場合は0を指定
+func NewArmor(value uint) Armor { return Armor(value) }
+
+// DinosaurStats 生物の基礎ステータス
+type DinosaurStats struct {
+	health        Health
+	stamina       Stamina
+	oxygen        Oxygen
+	food          Food
+	weight        Weight
+	melee         Melee
+	movementSpeed MovementSpeed
+	torpidity     Torpidity
+	armor         Armor
+}
+
+func NewDinosaurStats(
+	health Health,
+	stamina Stamina,
+	oxygen Oxygen,
+	food Food,
+	weight Weight,
+	melee Melee,
+	movementSpeed MovementSpeed,
+	torpidity Torpidity,
+	armor Armor,
+) DinosaurStats {
+	return DinosaurStats{
+		health:        health,
+		stamina:       stamina,
+		oxygen:        oxygen,
+		food:          food,
+		weight:        weight,
+		melee:         melee,
+		movementSpeed: movementSpeed,
+		torpidity:     torpidity,
+		armor:         armor,
+	}
+}
+
+func (s DinosaurStats) Health() Health               { return s.health }
+func (s DinosaurStats) Stamina() Stamina             { return s.stamina }
+func (s DinosaurStats) Oxygen() Oxygen               { return s.oxygen }
+func (s DinosaurStats) Food() Food                   { return s.food }
+func (s DinosaurStats) Weight() Weight               { return s.weight }
+func (s DinosaurStats) Melee() Melee                 { return s.melee }
+func (s DinosaurStats) MovementSpeed() MovementSpeed { return s.movementSpeed }
+func (s DinosaurStats) Torpidity() Torpidity         { return s.torpidity }
+func (s DinosaurStats) Armor() Armor                 { return s.armor }
+
 type DinosaurID int
 
 func (i DinosaurID) Value() int { return int(i) }
 
+const maxDinosaurNameLength = 100
+
 type DinosaurName string
 
 func (n DinosaurName) Value() string { return string(n) }
 
+// NewDinosaurName 種の名前は大文字小文字を区別せずに一意とする
+func NewDinosaurName(value string) (DinosaurName, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", i18n.Errorf("生物の名前を指定してください")
+	}
+	if utf8.RuneCountInString(value) > maxDinosaurNameLength {
+		return "", i18n.Errorf("生物の名前は%d文字以下にしてください", maxDinosaurNameLength)
+	}
+	return DinosaurName(value), nil
+}
+
 type Dinosaur struct {
-	id         DinosaurID
-	name       DinosaurName
-	baseHealth Health
-	baseMelee  Melee
+	id          DinosaurID
+	name        DinosaurName
+	displayName DinosaurName
+	stats       DinosaurStats
+	statGrowth  SpeciesStatGrowth
+	version     logic.Version
 }
 
-func NewDinosaur(id DinosaurID, name DinosaurName, health Health, Melee Melee) Dinosaur {
+func NewDinosaur(id DinosaurID, name DinosaurName, stats DinosaurStats) Dinosaur {
 	return Dinosaur{
-		id:         id,
-		name:       name,
-		baseHealth: health,
-		baseMelee:  Melee,
+		id:    id,
+		name:  name,
+		stats: stats,
+	}
+}
+
+func (d Dinosaur) BaseID() DinosaurID           { return d.id }
+func (d Dinosaur) BaseName() DinosaurName       { return d.name }
+func (d Dinosaur) Stats() DinosaurStats         { return d.stats }
+func (d Dinosaur) Health() Health               { return d.stats.health }
+func (d Dinosaur) Stamina() Stamina             { return d.stats.stamina }
+func (d Dinosaur) Oxygen() Oxygen               { return d.stats.oxygen }
+func (d Dinosaur) Food() Food                   { return d.stats.food }
+func (d Dinosaur) Weight() Weight               { return d.stats.weight }
+func (d Dinosaur) Melee() Melee                 { return d.stats.melee }
+func (d Dinosaur) MovementSpeed() MovementSpeed { return d.stats.movementSpeed }
+func (d Dinosaur) Torpidity() Torpidity         { return d.stats.torpidity }
+func (d Dinosaur) Armor() Armor                 { return d.stats.armor }
+
+// BaseDisplayName リクエストの言語の表示名。表示名が無い場合は元の名前
+func (d Dinosaur) BaseDisplayName() DinosaurName {
+	if d.displayName == "" {
+		return d.name
 	}
+	return d.displayName
 }
 
-func (d Dinosaur) BaseID() DinosaurID     { return d.id }
-func (d Dinosaur) BaseName() DinosaurName { return d.name }
-func (d Dinosaur) Health() Health         { return d.baseHealth }
-func (d Dinosaur) Melee() Melee           { return d.baseMelee }
+// WithBaseDisplayName 表示名を読み込んだ生物を返す。BaseNameは保存された名前のまま変えない
+func (d Dinosaur) WithBaseDisplayName(name DinosaurName) Dinosaur {
+	d.displayName = name
+	return d
+}
+
+// StatGrowth 登録されたレベル毎の成長値。一覧では読み込まないため空になる
+func (d Dinosaur) StatGrowth() SpeciesStatGrowth { return d.statGrowth }
+
+// WithStatGrowth 成長値を読み込んだ生物を返す
+func (d Dinosaur) WithStatGrowth(growth SpeciesStatGrowth) Dinosaur {
+	d.statGrowth = growth
+	return d
+}
+
+// Version 更新の度に増える行のバージョン。ETagとしてクライアントに渡す
+func (d Dinosaur) Version() logic.Version { return d.version }
+
+// WithVersion 更新時の競合の検出に用いるバージョンを読み込んだ生物を返す
+func (d Dinosaur) WithVersion(version logic.Version) Dinosaur {
+	d.version = version
+	return d
+}
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// StatKind レベルによる成長値やサーバー設定の倍率をステータス毎に指定するための種類
+type StatKind string
+
+const (
+	StatHealth        StatKind = "health"
+	StatStamina       StatKind = "stamina"
+	StatOxygen        StatKind = "oxygen"
+	StatFood          StatKind = "food"
+	StatWeight        StatKind = "weight"
+	StatMelee         StatKind = "melee"
+	StatMovementSpeed StatKind = "movement_speed"
+	StatTorpidity     StatKind = "torpidity"
+	StatArmor         StatKind = "armor"
+)
+
+func (k StatKind) Value() string { return string(k) }
+
+func StatKinds() []StatKind {
+	return []StatKind{
+		StatHealth, StatStamina, StatOxygen, StatFood, StatWeight,
+		StatMelee, StatMovementSpeed, StatTorpidity, StatArmor,
+	}
+}
+
+func NewStatKind(value string) (StatKind, error) {
+	for _, k := range StatKinds() {
+		if k.Value() == value {
+			return k, nil
+		}
+	}
+	return "", i18n.Errorf("%qは不明なステータスです", value)
+}
+
+var (
+	// wildLeveledStats 野生のレベルアップでポイントが振られるステータス。気絶値は全てのポイントを受け取る
+	wildLeveledStats = []StatKind{StatHealth, StatStamina, StatOxygen, StatFood, StatWeight, StatMelee}
+	// tamedLeveledStats テイム後のレベルアップでポイントを振れるステータス
+	tamedLeveledStats = []StatKind{StatHealth, StatStamina, StatOxygen, StatFood, StatWeight, StatMelee, StatMovementSpeed}
+	// imprintedStats 刷り込みボーナスが適用されるステータス
+	imprintedStats = []StatKind{StatHealth, StatFood, StatWeight, StatMelee, StatMovementSpeed, StatTorpidity}
+)
+
+// imprintBonus 刷り込み100%で増加する割合
+const imprintBonus float32 = 0.2
+
+func containsStat(kinds []StatKind, kind StatKind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// StatGrowth レベル毎の成長値。加算値以外は基礎値に対する割合で表す
+type StatGrowth struct {
+	wildIncrease  float32
+	tamedIncrease float32
+	tamedAdd      float32
+	tamedAffinity float32
+}
+
+func NewStatGrowth(wildIncrease, tamedIncrease, tamedAdd, tamedAffinity float32) (StatGrowth, error) {
+	if wildIncrease < 0 || tamedIncrease < 0 {
+		return StatGrowth{}, i18n.Errorf("レベル毎の成長値は0以上にしてください")
+	}
+	return StatGrowth{
+		wildIncrease:  wildIncrease,
+		tamedIncrease: tamedIncrease,
+		tamedAdd:      tamedAdd,
+		tamedAffinity: tamedAffinity,
+	}, nil
+}
+
+func (g StatGrowth) WildIncrease() float32  { return g.wildIncrease }
+func (g StatGrowth) TamedIncrease() float32 { return g.tamedIncrease }
+func (g StatGrowth) TamedAdd() float32      { return g.tamedAdd }
+func (g StatGrowth) TamedAffinity() float32 { return g.tamedAffinity }
+
+// defaultStatGrowths 成長値が登録されていない種に用いる代表的な値
+var defaultStatGrowths = map[StatKind]StatGrowth{
+	StatHealth:        {wildIncrease: 0.2, tamedIncrease: 0.27},
+	StatStamina:       {wildIncrease: 0.1, tamedIncrease: 0.1},
+	StatOxygen:        {wildIncrease: 0.1, tamedIncrease: 0.1},
+	StatFood:          {wildIncrease: 0.1, tamedIncrease: 0.1},
+	StatWeight:        {wildIncrease: 0.02, tamedIncrease: 0.04},
+	StatMelee:         {wildIncrease: 0.05, tamedIncrease: 0.1},
+	StatMovementSpeed: {tamedIncrease: 0.01},
+	StatTorpidity:     {wildIncrease: 0.06},
+	StatArmor:         {},
+}
+
+// SpeciesStatGrowth 種毎の成長値。登録されていないステータスは代表的な値を用いる
+type SpeciesStatGrowth map[StatKind]StatGrowth
+
+func (g SpeciesStatGrowth) Of(kind StatKind) StatGrowth {
+	if growth, ok := g[kind]; ok {
+		return growth
+	}
+	return defaultStatGrowths[kind]
+}
+
+// ServerStatMultipliers サーバー設定のPerLevelStatsMultiplier。設定されていないステータスは等倍とする
+type ServerStatMultipliers struct {
+	wild          map[StatKind]float32
+	tamed         map[StatKind]float32
+	tamedAdd      map[StatKind]float32
+	tamedAffinity map[StatKind]float32
+}
+
+func NewServerStatMultipliers(
+	wild, tamed, tamedAdd, tamedAffinity map[StatKind]float32,
+) ServerStatMultipliers {
+	return ServerStatMultipliers{
+		wild:          wild,
+		tamed:         tamed,
+		tamedAdd:      tamedAdd,
+		tamedAffinity: tamedAffinity,
+	}
+}
+
+func multiplierOf(multipliers map[StatKind]float32, kind StatKind) float32 {
+	if m, ok := multipliers[kind]; ok {
+		return m
+	}
+	return 1
+}
+
+type Level uint
+
+func (l Level) Value() uint { return uint(l) }
+
+func NewLevel(value uint) (Level, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("レベルは1以上にしてください")
+	}
+	return Level(value), nil
+}
+
+// TamingInput テイム後のステータスを計算する場合に指定する。テイム効果と刷り込みは0から1の割合
+type TamingInput struct {
+	effectiveness float32
+	imprint       float32
+	levels        uint
+}
+
+func NewTamingInput(effectiveness, imprint float32, levels uint) (TamingInput, error) {
+	if effectiveness < 0 || 1 < effectiveness {
+		return TamingInput{}, i18n.Errorf("テイム効果は0から1の間にしてください")
+	}
+	if imprint < 0 || 1 < imprint {
+		return TamingInput{}, i18n.Errorf("刷り込みは0から1の間にしてください")
+	}
+	return TamingInput{effectiveness: effectiveness, imprint: imprint, levels: levels}, nil
+}
+
+func (t TamingInput) Effectiveness() float32 { return t.effectiveness }
+func (t TamingInput) Imprint() float32       { return t.imprint }
+func (t TamingInput) Levels() uint           { return t.levels }
+
+// CalculatedStats レベルとユニークの倍率を反映したステータス
+type CalculatedStats struct {
+	level         Level
+	taming        *TamingInput
+	health        UniqueMultipliedStatus[Health]
+	stamina       UniqueMultipliedStatus[Stamina]
+	oxygen        UniqueMultipliedStatus[Oxygen]
+	food          UniqueMultipliedStatus[Food]
+	weight        UniqueMultipliedStatus[Weight]
+	melee         UniqueMultipliedStatus[Melee]
+	movementSpeed UniqueMultipliedStatus[MovementSpeed]
+	torpidity     UniqueMultipliedStatus[Torpidity]
+	armor         UniqueMultipliedStatus[Armor]
+}
+
+func (s CalculatedStats) Level() Level                             { return s.level }
+func (s CalculatedStats) Taming() *TamingInput                     { return s.taming }
+func (s CalculatedStats) Health() UniqueMultipliedStatus[Health]   { return s.health }
+func (s CalculatedStats) Stamina() UniqueMultipliedStatus[Stamina] { return s.stamina }
+func (s CalculatedStats) Oxygen() UniqueMultipliedStatus[Oxygen]   { return s.oxygen }
+func (s CalculatedStats) Food() UniqueMultipliedStatus[Food]       { return s.food }
+func (s CalculatedStats) Weight() UniqueMultipliedStatus[Weight]   { return s.weight }
+func (s CalculatedStats) Melee() UniqueMultipliedStatus[Melee]     { return s.melee }
+func (s CalculatedStats) MovementSpeed() UniqueMultipliedStatus[MovementSpeed] {
+	return s.movementSpeed
+}
+func (s CalculatedStats) Torpidity() UniqueMultipliedStatus[Torpidity] { return s.torpidity }
+func (s CalculatedStats) Armor() UniqueMultipliedStatus[Armor]         { return s.armor }
+
+// StatCalculator ゲーム内の計算式でレベル毎のステータスを求める
+//
+//	V = (B × (1 + Lw × Iw × IwM) × (1 + IB × 0.2) + Ta × TaM) × (1 + TE × Tm × TmM) × (1 + Ld × Id × IdM)
+//
+// Bはユニークの倍率を掛けた基礎値。レベルのポイントは振り分けが分からないため対象のステータスに均等に振られたものとする
+type StatCalculator struct {
+	growth SpeciesStatGrowth
+	server ServerStatMultipliers
+}
+
+func NewStatCalculator(growth SpeciesStatGrowth, server ServerStatMultipliers) StatCalculator {
+	return StatCalculator{growth: growth, server: server}
+}
+
+// Calculate tamingがnilの場合は野生のステータスを返す
+func (c StatCalculator) Calculate(unique UniqueDinosaur, level Level, taming *TamingInput) CalculatedStats {
+	m := unique.Multipliers()
+	return CalculatedStats{
+		level:         level,
+		taming:        taming,
+		health:        calculateStat(c, StatHealth, unique.Dinosaur.Health(), m.health, level, taming),
+		stamina:       calculateStat(c, StatStamina, unique.Dinosaur.Stamina(), m.stamina, level, taming),
+		oxygen:        calculateStat(c, StatOxygen, unique.Dinosaur.Oxygen(), m.oxygen, level, taming),
+		food:          calculateStat(c, StatFood, unique.Dinosaur.Food(), m.food, level, taming),
+		weight:        calculateStat(c, StatWeight, unique.Dinosaur.Weight(), m.weight, level, taming),
+		melee:         calculateStat(c, StatMelee, unique.Dinosaur.Melee(), m.melee, level, taming),
+		movementSpeed: calculateStat(c, StatMovementSpeed, unique.Dinosaur.MovementSpeed(), m.movementSpeed, level, taming),
+		torpidity:     calculateStat(c, StatTorpidity, unique.Dinosaur.Torpidity(), m.torpidity, level, taming),
+		armor:         calculateStat(c, StatArmor, unique.Dinosaur.Armor(), m.armor, level, taming),
+	}
+}
+
+// wildLevels 野生のレベル1からの上昇分をステータスに振り分けたポイント
+func wildLevels(kind StatKind, level Level) float32 {
+	points := float32(level.Value() - 1)
+	switch {
+	case kind == StatTorpidity:
+		return points
+	case containsStat(wildLeveledStats, kind):
+		return points / float32(len(wildLeveledStats))
+	default:
+		return 0
+	}
+}
+
+func tamedLevels(kind StatKind, taming TamingInput) float32 {
+	if !containsStat(tamedLeveledStats, kind) {
+		return 0
+	}
+	return float32(taming.levels) / float32(len(tamedLeveledStats))
+}
+
+func calculateStat[T DinosaurStatus](
+	c StatCalculator, kind StatKind, base T, multiplier UniqueMultiplier[T], level Level, taming *TamingInput,
+) UniqueMultipliedStatus[T] {
+	growth := c.growth.Of(kind)
+	v := float32(multiplier.multiple(base)) *
+		(1 + wildLevels(kind, level)*growth.wildIncrease*multiplierOf(c.server.wild, kind))
+	if taming == nil {
+		return UniqueMultipliedStatus[T](v)
+	}
+
+	if containsStat(imprintedStats, kind) {
+		v *= 1 + taming.imprint*imprintBonus
+	}
+	v += growth.tamedAdd * multiplierOf(c.server.tamedAdd, kind)
+	v *= 1 + taming.effectiveness*growth.tamedAffinity*multiplierOf(c.server.tamedAffinity, kind)
+	v *= 1 + tamedLevels(kind, *taming)*growth.tamedIncrease*multiplierOf(c.server.tamed, kind)
+	return UniqueMultipliedStatus[T](v)
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatCalculatorTestSuite struct {
+	suite.Suite
+
+	unique UniqueDinosaur
+}
+
+func TestStatCalculator(t *testing.T) {
+	base, err := NewUniqueDinosaurTestSuite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	suite.Run(t, &StatCalculatorTestSuite{
+		unique: NewUniqueDinosaur(
+			base.baseDino, base.defaultID, base.defaultName,
+			base.multipliers,
+			base.variants,
+		),
+	})
+}
+
+func (s *StatCalculatorTestSuite) level(v uint) Level {
+	level, err := NewLevel(v)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	return level
+}
+
+func (s *StatCalculatorTestSuite) TestLevelOne() {
+	s.T().Log("レベル1ではユニークの倍率を掛けた基礎値と一致するかテスト")
+
+	stats := NewStatCalculator(SpeciesStatGrowth{}, ServerStatMultipliers{}).Calculate(s.unique, s.level(1), nil)
+	s.Equal(s.unique.Health(), stats.Health())
+	s.Equal(s.unique.Stamina(), stats.Stamina())
+	s.Equal(s.unique.Torpidity(), stats.Torpidity())
+}
+
+func (s *StatCalculatorTestSuite) TestWildLevel() {
+	s.T().Log("野生のレベルのポイントが均等に振られ、成長値とサーバー設定の倍率が反映されるかテスト")
+
+	growth, err := NewStatGrowth(0.2, 0, 0, 0)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	calculator := NewStatCalculator(
+		SpeciesStatGrowth{StatHealth: growth},
+		NewServerStatMultipliers(map[StatKind]float32{StatHealth: 2}, nil, nil, nil),
+	)
+
+	// 6ポイントが体力に1ポイント振られ、1 + 1 × 0.2 × 2 倍になる
+	stats := calculator.Calculate(s.unique, s.level(7), nil)
+	s.InDelta(multipliedHealth*1.4, stats.Health().Value(), 0.001)
+	// 移動速度は野生のレベルで上昇しない
+	s.Equal(s.unique.MovementSpeed(), stats.MovementSpeed())
+	// 気絶値は全てのポイントを受け取る
+	s.InDelta(float32(s.unique.Torpidity())*(1+6*0.06), stats.Torpidity().Value(), 0.001)
+}
+
+func (s *StatCalculatorTestSuite) TestTamed() {
+	s.T().Log("刷り込みボーナスが対象のステータスにのみ反映されるかテスト")
+
+	taming, err := NewTamingInput(1, 1, 0)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	stats := NewStatCalculator(SpeciesStatGrowth{}, ServerStatMultipliers{}).Calculate(s.unique, s.level(1), &taming)
+	s.InDelta(multipliedHealth*1.2, stats.Health().Value(), 0.001)
+	s.Equal(s.unique.Stamina(), stats.Stamina())
+}
+
+func (s *StatCalculatorTestSuite) TestErrInput() {
+	s.T().Log("不正な計算条件のエラーケースのテスト")
+
+	if _, err := NewLevel(0); err == nil {
+		s.T().Error("レベル0でエラーになっていません")
+	}
+	if _, err := NewTamingInput(1.1, 0, 0); err == nil {
+		s.T().Error("テイム効果が1を超えてエラーになっていません")
+	}
+	if _, err := NewTamingInput(0, -0.1, 0); err == nil {
+		s.T().Error("刷り込みが負の値でエラーになっていません")
+	}
+	if _, err := NewStatKind("luck"); err == nil {
+		s.T().Error("不明なステータスでエラーになっていません")
+	}
+}
@@ -1,47 +1,73 @@
 package model
 
 import (
-	"errors"
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/variant/domain/model"
 )
 
 var (
-	errUniqueMinMultiplier float32 = 0.0
+	errUniqueMinMultiplier float32          = 0.0
+	defaultMultiplier      StatusMultiplier = 1.0
 )
 
 type UniqueDinosaur struct {
 	Dinosaur
 
-	uniqueDinoID     UniqueDinosaurID
-	uniqueName       UniqueName
-	healthMultiplier UniqueMultiplier[Health]
-	damageMultiplier UniqueMultiplier[Melee]
-	uniqueVariant    UniqueVariant
+	uniqueDinoID  UniqueDinosaurID
+	uniqueName    UniqueName
+	displayName   UniqueName
+	multipliers   UniqueMultipliers
+	uniqueVariant UniqueVariant
+	version       logic.Version
 }
 
 func NewUniqueDinosaur(
 	base Dinosaur,
 	id UniqueDinosaurID,
 	name UniqueName,
-	healthMultiplier UniqueMultiplier[Health],
-	damageMultiplier UniqueMultiplier[Melee],
+	multipliers UniqueMultipliers,
 	uniqueVariant UniqueVariant,
 ) UniqueDinosaur {
 	return UniqueDinosaur{
-		Dinosaur:         base,
-		uniqueDinoID:     id,
-		uniqueName:       name,
-		healthMultiplier: healthMultiplier,
-		damageMultiplier: damageMultiplier,
-		uniqueVariant:    uniqueVariant,
+		Dinosaur:      base,
+		uniqueDinoID:  id,
+		uniqueName:    name,
+		multipliers:   multipliers,
+		uniqueVariant: uniqueVariant,
 	}
 }
 
 func (d UniqueDinosaur) UniqueID() UniqueDinosaurID                 { return d.uniqueDinoID }
 func (d UniqueDinosaur) UniqueName() UniqueName                     { return d.uniqueName }
-func (d UniqueDinosaur) HealthMultiplier() UniqueMultiplier[Health] { return d.healthMultiplier }
-func (d UniqueDinosaur) DamageMultiplier() UniqueMultiplier[Melee]  { return d.damageMultiplier }
+func (d UniqueDinosaur) Multipliers() UniqueMultipliers             { return d.multipliers }
+func (d UniqueDinosaur) HealthMultiplier() UniqueMultiplier[Health] { return d.multipliers.health }
+func (d UniqueDinosaur) DamageMultiplier() UniqueMultiplier[Melee]  { return d.multipliers.melee }
 func (d UniqueDinosaur) UniqueVariant() UniqueVariant               { return d.uniqueVariant }
 
+// UniqueDisplayName リクエストの言語の表示名。表示名が無い場合は元の名前
+func (d UniqueDinosaur) UniqueDisplayName() UniqueName {
+	if d.displayName == "" {
+		return d.uniqueName
+	}
+	return d.displayName
+}
+
+// WithUniqueDisplayName 表示名を読み込んだユニークを返す。UniqueNameは保存された名前のまま変えない
+func (d UniqueDinosaur) WithUniqueDisplayName(name UniqueName) UniqueDinosaur {
+	d.displayName = name
+	return d
+}
+
+// Version 更新の度に増える行のバージョン。ETagとしてクライアントに渡す
+func (d UniqueDinosaur) Version() logic.Version { return d.version }
+
+// WithVersion 更新時の競合の検出に用いるバージョンを読み込んだユニークを返す
+func (d UniqueDinosaur) WithVersion(version logic.Version) UniqueDinosaur {
+	d.version = version
+	return d
+}
+
 type UniqueDinosaurs []UniqueDinosaur
 
 type UniqueDinosaurID int
@@ -54,20 +80,27 @@ func (n UniqueName) Value() string { return string(n) }
 
 // DinosaurStatus multiplierでfloat32との計算に用いるため、数値型のみに限定する
 type DinosaurStatus interface {
-	Health | Melee
+	Health | Stamina | Oxygen | Food | Weight | Melee | MovementSpeed | Torpidity | Armor
 }
 
 type UniqueMultiplier[T DinosaurStatus] struct{ value StatusMultiplier }
 
 func NewUniqueMultiplier[T DinosaurStatus](v StatusMultiplier) (*UniqueMultiplier[T], error) {
 	if errUniqueMinMultiplier >= v.ToFloat32() {
-		return nil, errors.New("ユニーク生物のステータス倍率は0より大きくしてください")
+		return nil, i18n.Errorf("ユニーク生物のステータス倍率は0より大きくしてください")
 	}
 	return &UniqueMultiplier[T]{value: v}, nil
 }
 
+// DefaultUniqueMultiplier 倍率を指定しないステータスには等倍を用いる
+func DefaultUniqueMultiplier[T DinosaurStatus]() UniqueMultiplier[T] {
+	return UniqueMultiplier[T]{value: defaultMultiplier}
+}
+
 type UniqueMultipliedStatus[T DinosaurStatus] float32
 
+func (s UniqueMultipliedStatus[T]) Value() float32 { return float32(s) }
+
 func (u UniqueMultiplier[T]) Value() float32 { return u.value.ToFloat32() }
 
 // multiple UniqueMultiplierに与えた型引数と同じ型のbaseを与えないとエラーになるようにする
@@ -75,15 +108,121 @@ func (u UniqueMultiplier[T]) multiple(base T) UniqueMultipliedStatus[T] {
 	return UniqueMultipliedStatus[T](float32(base) * u.value.ToFloat32())
 }
 
+// UniqueMultipliers ステータス毎の倍率。型引数によりステータスと倍率の取り違えを防ぐ
+type UniqueMultipliers struct {
+	health        UniqueMultiplier[Health]
+	stamina       UniqueMultiplier[Stamina]
+	oxygen        UniqueMultiplier[Oxygen]
+	food          UniqueMultiplier[Food]
+	weight        UniqueMultiplier[Weight]
+	melee         UniqueMultiplier[Melee]
+	movementSpeed UniqueMultiplier[MovementSpeed]
+	torpidity     UniqueMultiplier[Torpidity]
+	armor         UniqueMultiplier[Armor]
+}
+
+func NewUniqueMultipliers(
+	health UniqueMultiplier[Health],
+	stamina UniqueMultiplier[Stamina],
+	oxygen UniqueMultiplier[Oxygen],
+	food UniqueMultiplier[Food],
+	weight UniqueMultiplier[Weight],
+	melee UniqueMultiplier[Melee],
+	movementSpeed UniqueMultiplier[MovementSpeed],
+	torpidity UniqueMultiplier[Torpidity],
+	armor UniqueMultiplier[Armor],
+) UniqueMultipliers {
+	return UniqueMultipliers{
+		health:        health,
+		stamina:       stamina,
+		oxygen:        oxygen,
+		food:          food,
+		weight:        weight,
+		melee:         melee,
+		movementSpeed: movementSpeed,
+		torpidity:     torpidity,
+		armor:         armor,
+	}
+}
+
+func (m UniqueMultipliers) Health() UniqueMultiplier[Health]               { return m.health }
+func (m UniqueMultipliers) Stamina() UniqueMultiplier[Stamina]             { return m.stamina }
+func (m UniqueMultipliers) Oxygen() UniqueMultiplier[Oxygen]               { return m.oxygen }
+func (m UniqueMultipliers) Food() UniqueMultiplier[Food]                   { return m.food }
+func (m UniqueMultipliers) Weight() UniqueMultiplier[Weight]               { return m.weight }
+func (m UniqueMultipliers) Melee() UniqueMultiplier[Melee]                 { return m.melee }
+func (m UniqueMultipliers) MovementSpeed() UniqueMultiplier[MovementSpeed] { return m.movementSpeed }
+func (m UniqueMultipliers) Torpidity() UniqueMultiplier[Torpidity]         { return m.torpidity }
+func (m UniqueMultipliers) Armor() UniqueMultiplier[Armor]                 { return m.armor }
+
 func (d UniqueDinosaur) Health() UniqueMultipliedStatus[Health] {
-	return d.healthMultiplier.multiple(d.baseHealth)
+	return d.multipliers.health.multiple(d.stats.health)
+}
+
+func (d UniqueDinosaur) Stamina() UniqueMultipliedStatus[Stamina] {
+	return d.multipliers.stamina.multiple(d.stats.stamina)
+}
+
+func (d UniqueDinosaur) Oxygen() UniqueMultipliedStatus[Oxygen] {
+	return d.multipliers.oxygen.multiple(d.stats.oxygen)
+}
+
+func (d UniqueDinosaur) Food() UniqueMultipliedStatus[Food] {
+	return d.multipliers.food.multiple(d.stats.food)
+}
+
+func (d UniqueDinosaur) Weight() UniqueMultipliedStatus[Weight] {
+	return d.multipliers.weight.multiple(d.stats.weight)
 }
 
 func (d UniqueDinosaur) Damage() UniqueMultipliedStatus[Melee] {
-	return d.damageMultiplier.multiple(d.baseMelee)
+	return d.multipliers.melee.multiple(d.stats.melee)
+}
+
+func (d UniqueDinosaur) MovementSpeed() UniqueMultipliedStatus[MovementSpeed] {
+	return d.multipliers.movementSpeed.multiple(d.stats.movementSpeed)
+}
+
+func (d UniqueDinosaur) Torpidity() UniqueMultipliedStatus[Torpidity] {
+	return d.multipliers.torpidity.multiple(d.stats.torpidity)
+}
+
+func (d UniqueDinosaur) Armor() UniqueMultipliedStatus[Armor] {
+	return d.multipliers.armor.multiple(d.stats.armor)
 }
 
-type UniqueVariant [2]DinosaurVariant
+// UniqueVariant 付与された順序を保持する
+type UniqueVariant []DinosaurVariant
+
+// MaxUniqueVariants MODによって異なる、ユニークに付与できるバリアント数の上限
+type MaxUniqueVariants uint
+
+func (m MaxUniqueVariants) Value() uint { return uint(m) }
+
+func NewMaxUniqueVariants(value uint) (MaxUniqueVariants, error) {
+	if 0 == value {
+		return 0, i18n.Errorf("バリアント数の上限は1以上にしてください")
+	}
+	return MaxUniqueVariants(value), nil
+}
+
+// Validate ユニークには1つ以上上限以下の重複しないバリアントを付与する
+func (m MaxUniqueVariants) Validate(ids []model.VariantID) error {
+	if len(ids) == 0 {
+		return i18n.Errorf("バリアントを1つ以上指定してください")
+	}
+	if uint(len(ids)) > m.Value() {
+		return i18n.Errorf("バリアントは%d個以下にしてください", m.Value())
+	}
+	seen := make(map[model.VariantID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return i18n.Errorf("バリアント%dが重複しています", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
 
 type StatusMultiplier float32
 
@@ -18,6 +18,7 @@ type UniqueDinosaurTestSuite struct {
 	variants                UniqueVariant
 	defaultHealthMultiplier UniqueMultiplier[Health]
 	defaultDamageMultiplier UniqueMultiplier[Melee]
+	multipliers             UniqueMultipliers
 }
 
 const (
@@ -29,6 +30,10 @@ const (
 	meleeValue             = 2
 	meleeUniqueMultiplier  = 36.0
 	multipliedMelee        = 72.0
+	staminaValue           = 100
+	staminaMultiplier      = 2.5
+	multipliedStamina      = 250.0
+	speedValue             = 100
 )
 
 func TestUniqueDinosaur(t *testing.T) {
@@ -45,12 +50,34 @@ func NewUniqueDinosaurTestSuite() (*UniqueDinosaurTestSuite, error) {
 		return nil, err
 	}
 	baseMelee := NewMelee(meleeValue)
+	baseStamina, err := NewStamina(staminaValue)
+	if err != nil {
+		return nil, err
+	}
+	food, err := NewFood(100)
+	if err != nil {
+		return nil, err
+	}
+	weight, err := NewWeight(50)
+	if err != nil {
+		return nil, err
+	}
+	speed, err := NewMovementSpeed(speedValue)
+	if err != nil {
+		return nil, err
+	}
+	torpidity, err := NewTorpidity(50)
+	if err != nil {
+		return nil, err
+	}
 
 	dino := NewDinosaur(
 		DinosaurID(1),
 		"Dodo",
-		baseHealth,
-		baseMelee,
+		NewDinosaurStats(
+			baseHealth, baseStamina, NewOxygen(150), food, weight,
+			baseMelee, speed, torpidity, NewArmor(0),
+		),
 	)
 
 	variants := UniqueVariant(
@@ -79,6 +106,10 @@ func NewUniqueDinosaurTestSuite() (*UniqueDinosaurTestSuite, error) {
 	if err != nil {
 		return nil, err
 	}
+	defaultStaminaMultiplier, err := NewUniqueMultiplier[Stamina](staminaMultiplier)
+	if err != nil {
+		return nil, err
+	}
 
 	return &UniqueDinosaurTestSuite{
 		baseDino: dino,
@@ -89,6 +120,17 @@ func NewUniqueDinosaurTestSuite() (*UniqueDinosaurTestSuite, error) {
 		variants:                variants,
 		defaultHealthMultiplier: *defaultHealthMultiplier,
 		defaultDamageMultiplier: *defaultDamageMultiplier,
+		multipliers: NewUniqueMultipliers(
+			*defaultHealthMultiplier,
+			*defaultStaminaMultiplier,
+			DefaultUniqueMultiplier[Oxygen](),
+			DefaultUniqueMultiplier[Food](),
+			DefaultUniqueMultiplier[Weight](),
+			*defaultDamageMultiplier,
+			DefaultUniqueMultiplier[MovementSpeed](),
+			DefaultUniqueMultiplier[Torpidity](),
+			DefaultUniqueMultiplier[Armor](),
+		),
 	}, nil
 }
 
@@ -97,7 +139,7 @@ func (s *UniqueDinosaurTestSuite) TestMultiplierHealth() {
 
 	uniqueDino := NewUniqueDinosaur(
 		s.baseDino, UniqueDinosaurID(1), s.defaultName,
-		s.defaultHealthMultiplier, s.defaultDamageMultiplier,
+		s.multipliers,
 		s.variants,
 	)
 
@@ -119,7 +161,7 @@ func (s *UniqueDinosaurTestSuite) TestMultiplierDamage() {
 
 	uniqueDino := NewUniqueDinosaur(
 		s.baseDino, UniqueDinosaurID(1), "Kenny",
-		s.defaultHealthMultiplier, s.defaultDamageMultiplier,
+		s.multipliers,
 		s.variants,
 	)
 
@@ -135,3 +177,56 @@ func (s *UniqueDinosaurTestSuite) TestErrMultiplierDamageZero() {
 		s.T().Errorf("攻撃力型の倍率が0でエラーになっていません")
 	}
 }
+
+func (s *UniqueDinosaurTestSuite) TestMultiplierStamina() {
+	s.T().Log("体力と攻撃力以外のステータスも倍率の型とベース値の計算が可能かテスト")
+
+	uniqueDino := NewUniqueDinosaur(
+		s.baseDino, UniqueDinosaurID(1), s.defaultName,
+		s.multipliers,
+		s.variants,
+	)
+
+	s.Equal(UniqueMultipliedStatus[Stamina](multipliedStamina), uniqueDino.Stamina())
+	s.Equal(UniqueMultipliedStatus[MovementSpeed](speedValue), uniqueDino.MovementSpeed())
+}
+
+func (s *UniqueDinosaurTestSuite) TestDefaultMultiplier() {
+	s.T().Log("倍率を指定しないステータスは等倍になるかテスト")
+
+	s.Equal(float32(1.0), DefaultUniqueMultiplier[Torpidity]().Value())
+}
+
+func (s *UniqueDinosaurTestSuite) TestMaxUniqueVariants() {
+	s.T().Log("バリアント数が1つ以上上限以下かつ重複しない場合のみ許容するかテスト")
+
+	if _, err := NewMaxUniqueVariants(0); err == nil {
+		s.T().Error("上限が0でエラーになっていません")
+	}
+	max, err := NewMaxUniqueVariants(3)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.Nil(max.Validate([]model.VariantID{1}))
+	s.Nil(max.Validate([]model.VariantID{1, 2, 3}))
+	s.Error(max.Validate([]model.VariantID{}))
+	s.Error(max.Validate([]model.VariantID{1, 2, 3, 4}))
+	s.Error(max.Validate([]model.VariantID{1, 1}))
+}
+
+func (s *UniqueDinosaurTestSuite) TestDisplayName() {
+	s.T().Log("表示名を読み込んでも保存された名前は変わらず、表示名が無い場合は元の名前になるかテスト")
+
+	uniqueDino := NewUniqueDinosaur(
+		s.baseDino.WithBaseDisplayName("ドードー"), UniqueDinosaurID(1), s.defaultName,
+		s.multipliers,
+		s.variants,
+	)
+	s.Equal(s.defaultName, uniqueDino.UniqueDisplayName())
+	s.Equal(DinosaurName("ドードー"), uniqueDino.BaseDisplayName())
+	s.Equal(DinosaurName("Dodo"), uniqueDino.BaseName())
+
+	uniqueDino = uniqueDino.WithUniqueDisplayName("ケニー")
+	s.Equal(UniqueName("ケニー"), uniqueDino.UniqueDisplayName())
+	s.Equal(s.defaultName, uniqueDino.UniqueName())
+}
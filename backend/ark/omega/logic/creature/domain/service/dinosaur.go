@@ -3,85 +3,145 @@ package service
 import (
 	"context"
 
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
 )
 
+type DinosaurQueryRepository interface {
+	Select(context.Context, model.DinosaurID) (*model.Dinosaur, error)
+	// SelectByName 大文字小文字を区別せずに名前が一致する種を返す
+	SelectByName(context.Context, model.DinosaurName) (*model.Dinosaur, error)
+	List(context.Context, ListDinosaurs) (*logic.Page[model.Dinosaur], error)
+	// SelectStatGrowths 一覧では読み込まない成長値をまとめて取得する。成長値が登録されていない種は結果に含めない
+	SelectStatGrowths(context.Context, []model.DinosaurID) (map[model.DinosaurID]model.SpeciesStatGrowth, error)
+}
+
 type DinosaurCommandRepository interface {
 	Insert(context.Context, CreateDinosaur) (model.DinosaurID, error)
 	Update(context.Context, UpdateDinosaur) error
-	Delete(context.Context, model.DinosaurID) error
+	Delete(context.Context, model.DinosaurID, logic.Version) error
+	// Restore ゴミ箱にない種はNotFoundを返す
+	Restore(context.Context, model.DinosaurID) error
 }
 
 type CreateDinosaur struct {
-	name       model.DinosaurName
-	baseHealth model.Health
-	baseMelee  model.Melee
+	name   model.DinosaurName
+	stats  model.DinosaurStats
+	growth model.SpeciesStatGrowth
 }
 
-func (d CreateDinosaur) Name() model.DinosaurName { return d.name }
-func (d CreateDinosaur) Health() model.Health     { return d.baseHealth }
-func (d CreateDinosaur) Melee() model.Melee       { return d.baseMelee }
+func (d CreateDinosaur) Name() model.DinosaurName            { return d.name }
+func (d CreateDinosaur) Stats() model.DinosaurStats          { return d.stats }
+func (d CreateDinosaur) StatGrowth() model.SpeciesStatGrowth { return d.growth }
+
+// WithStatGrowth レベル毎の成長値も登録する。登録しないステータスは代表的な値を用いる
+func (d CreateDinosaur) WithStatGrowth(growth model.SpeciesStatGrowth) CreateDinosaur {
+	d.growth = growth
+	return d
+}
 
 func NewCreateDinosaur(
 	name model.DinosaurName,
-	baseHealth model.Health,
-	baseMelee model.Melee,
+	stats model.DinosaurStats,
 ) CreateDinosaur {
 	return CreateDinosaur{
-		name:       name,
-		baseHealth: baseHealth,
-		baseMelee:  baseMelee,
+		name:  name,
+		stats: stats,
 	}
 }
 
+// UpdateDinosaur versionはクライアントが取得した時点のバージョンで、一致する場合のみ更新する
 type UpdateDinosaur struct {
-	id         model.DinosaurID
-	name       model.DinosaurName
-	baseHealth model.Health
-	baseMelee  model.Melee
+	id      model.DinosaurID
+	name    model.DinosaurName
+	stats   model.DinosaurStats
+	growth  model.SpeciesStatGrowth
+	version logic.Version
 }
 
-func (d UpdateDinosaur) ID() model.DinosaurID     { return d.id }
-func (d UpdateDinosaur) Name() model.DinosaurName { return d.name }
-func (d UpdateDinosaur) Health() model.Health     { return d.baseHealth }
-func (d UpdateDinosaur) Melee() model.Melee       { return d.baseMelee }
+func (d UpdateDinosaur) ID() model.DinosaurID                { return d.id }
+func (d UpdateDinosaur) Name() model.DinosaurName            { return d.name }
+func (d UpdateDinosaur) Stats() model.DinosaurStats          { return d.stats }
+func (d UpdateDinosaur) StatGrowth() model.SpeciesStatGrowth { return d.growth }
+func (d UpdateDinosaur) Version() logic.Version              { return d.version }
+
+// WithStatGrowth 登録済みの成長値を全て置き換える。指定しない場合は登録済みの成長値を変えない
+func (d UpdateDinosaur) WithStatGrowth(growth model.SpeciesStatGrowth) UpdateDinosaur {
+	d.growth = growth
+	return d
+}
 
 func NewUpdateDinosaur(
 	id model.DinosaurID,
 	name model.DinosaurName,
-	baseHealth model.Health,
-	baseMelee model.Melee,
+	stats model.DinosaurStats,
+	version logic.Version,
 ) UpdateDinosaur {
 	return UpdateDinosaur{
-		id:         id,
-		name:       name,
-		baseHealth: baseHealth,
-		baseMelee:  baseMelee,
+		id:      id,
+		name:    name,
+		stats:   stats,
+		version: version,
 	}
 }
 
 type ResponseDinosaur struct {
-	id         model.DinosaurID
-	name       model.DinosaurName
-	baseHealth model.Health
-	baseMelee  model.Melee
+	id          model.DinosaurID
+	name        model.DinosaurName
+	displayName model.DinosaurName
+	stats       model.DinosaurStats
 }
 
 func NewResponseDinosaur(
 	id model.DinosaurID,
 	name model.DinosaurName,
-	baseHealth model.Health,
-	baseMelee model.Melee,
+	stats model.DinosaurStats,
 ) ResponseDinosaur {
 	return ResponseDinosaur{
-		id:         id,
-		name:       name,
-		baseHealth: baseHealth,
-		baseMelee:  baseMelee,
+		id:    id,
+		name:  name,
+		stats: stats,
+	}
+}
+
+func (d ResponseDinosaur) ID() model.DinosaurID       { return d.id }
+func (d ResponseDinosaur) Name() model.DinosaurName   { return d.name }
+func (d ResponseDinosaur) Stats() model.DinosaurStats { return d.stats }
+
+// WithDisplayName 保存された名前とは別に、リクエストの言語の表示名を持たせる
+func (d ResponseDinosaur) WithDisplayName(name model.DinosaurName) ResponseDinosaur {
+	d.displayName = name
+	return d
+}
+
+type DinosaurSortKey string
+
+const (
+	DinosaurSortByID        DinosaurSortKey = "id"
+	DinosaurSortByName      DinosaurSortKey = "name"
+	DinosaurSortByUpdatedAt DinosaurSortKey = "updated_at"
+)
+
+func (k DinosaurSortKey) Value() string { return string(k) }
+
+type ListDinosaurs struct {
+	logic.PageRequest
+	sortKey DinosaurSortKey
+}
+
+// NewListDinosaurs ソートキーが空の場合はid順とする
+func NewListDinosaurs(page logic.PageRequest, sortKey DinosaurSortKey) (ListDinosaurs, error) {
+	switch sortKey {
+	case "":
+		sortKey = DinosaurSortByID
+	case DinosaurSortByID, DinosaurSortByName, DinosaurSortByUpdatedAt:
+	default:
+		return ListDinosaurs{}, failure.Translate(i18n.Errorf("%qは不明な並び替えのキーです", sortKey), logic.InvalidArgument)
 	}
+	return ListDinosaurs{PageRequest: page, sortKey: sortKey}, nil
 }
 
-func (d ResponseDinosaur) ID() model.DinosaurID     { return d.id }
-func (d ResponseDinosaur) Name() model.DinosaurName { return d.name }
-func (d ResponseDinosaur) Health() model.Health     { return d.baseHealth }
-func (d ResponseDinosaur) Melee() model.Melee       { return d.baseMelee }
+func (l ListDinosaurs) SortKey() DinosaurSortKey { return l.sortKey }
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic/creature/domain/model"
+)
+
+// StatGrowthRepository 成長値が登録されていない種は空のSpeciesStatGrowthを返す
+type StatGrowthRepository interface {
+	FindStatGrowth(context.Context, model.DinosaurID) (model.SpeciesStatGrowth, error)
+}
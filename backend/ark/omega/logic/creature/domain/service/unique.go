@@ -3,55 +3,44 @@ package service
 import (
 	"context"
 
+	"github.com/morikuni/failure"
 	"github.com/samber/lo"
 
+	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
 	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
 )
 
 type UniqueCommandRepository interface {
 	Insert(context.Context, CreateUniqueDinosaur) (model.UniqueDinosaurID, error)
 	Update(context.Context, UpdateUniqueDinosaur) error
-	Delete(context.Context, model.UniqueDinosaurID) error
+	Delete(context.Context, model.UniqueDinosaurID, logic.Version) error
+	// Restore ゴミ箱にないユニークはNotFoundを返す
+	Restore(context.Context, model.UniqueDinosaurID) error
 }
 
+// CreateCreature ユニークは登録済みの種を参照して作成する
 type CreateCreature struct {
-	DinoName   model.DinosaurName
-	BaseHealth model.Health
-	BaseMelee  model.Melee
+	DinosaurID model.DinosaurID
 
-	UniqueName       model.UniqueName
-	HealthMultiplier model.UniqueMultiplier[model.Health]
-	DamageMultiplier model.UniqueMultiplier[model.Melee]
+	UniqueName  model.UniqueName
+	Multipliers model.UniqueMultipliers
 
-	VariantIDs [2]variantModel.VariantID
+	VariantIDs []variantModel.VariantID
 }
 
 func NewCreateCreature(
-	dinoName model.DinosaurName,
-	baseHealth model.Health,
-	baseMelee model.Melee,
+	dinosaurID model.DinosaurID,
 	uniqueName model.UniqueName,
-	healthMultiplier model.UniqueMultiplier[model.Health],
-	damageMultiplier model.UniqueMultiplier[model.Melee],
-	variantIDs [2]variantModel.VariantID,
+	multipliers model.UniqueMultipliers,
+	variantIDs []variantModel.VariantID,
 ) CreateCreature {
 	return CreateCreature{
-		DinoName:         dinoName,
-		BaseHealth:       baseHealth,
-		BaseMelee:        baseMelee,
-		UniqueName:       uniqueName,
-		HealthMultiplier: healthMultiplier,
-		DamageMultiplier: damageMultiplier,
-		VariantIDs:       variantIDs,
-	}
-}
-
-func (c CreateCreature) Dino() CreateDinosaur {
-	return CreateDinosaur{
-		name:       c.DinoName,
-		baseHealth: c.BaseHealth,
-		baseMelee:  c.BaseMelee,
+		DinosaurID:  dinosaurID,
+		UniqueName:  uniqueName,
+		Multipliers: multipliers,
+		VariantIDs:  variantIDs,
 	}
 }
 
@@ -62,101 +51,74 @@ func (c CreateCreature) UniqueVariants(id model.UniqueDinosaurID) CreateVariants
 	}
 }
 
-func (c CreateCreature) UniqueDinosaur(
-	dinoID model.DinosaurID,
-) CreateUniqueDinosaur {
+func (c CreateCreature) UniqueDinosaur() CreateUniqueDinosaur {
 	return CreateUniqueDinosaur{
-		name:             c.UniqueName,
-		healthMultiplier: c.HealthMultiplier,
-		damageMultiplier: c.DamageMultiplier,
-		dinosaurID:       dinoID,
+		name:        c.UniqueName,
+		multipliers: c.Multipliers,
+		dinosaurID:  c.DinosaurID,
 	}
 }
 
 type CreateUniqueDinosaur struct {
-	name             model.UniqueName
-	healthMultiplier model.UniqueMultiplier[model.Health]
-	damageMultiplier model.UniqueMultiplier[model.Melee]
-	dinosaurID       model.DinosaurID
+	name        model.UniqueName
+	multipliers model.UniqueMultipliers
+	dinosaurID  model.DinosaurID
 }
 
 func NewCreateUniqueDinosaur(
 	name model.UniqueName,
-	healthMultiplier model.UniqueMultiplier[model.Health],
-	damageMultiplier model.UniqueMultiplier[model.Melee],
+	multipliers model.UniqueMultipliers,
 	dinosaurID model.DinosaurID,
 ) CreateUniqueDinosaur {
 	return CreateUniqueDinosaur{
-		name:             name,
-		healthMultiplier: healthMultiplier,
-		damageMultiplier: damageMultiplier,
-		dinosaurID:       dinosaurID,
+		name:        name,
+		multipliers: multipliers,
+		dinosaurID:  dinosaurID,
 	}
 }
 
-func (d CreateUniqueDinosaur) Name() model.UniqueName       { return d.name }
-func (d CreateUniqueDinosaur) DinosaurID() model.DinosaurID { return d.dinosaurID }
-func (d CreateUniqueDinosaur) HealthMultiplier() model.UniqueMultiplier[model.Health] {
-	return d.healthMultiplier
-}
-func (d CreateUniqueDinosaur) DamageMultiplier() model.UniqueMultiplier[model.Melee] {
-	return d.damageMultiplier
-}
+func (d CreateUniqueDinosaur) Name() model.UniqueName               { return d.name }
+func (d CreateUniqueDinosaur) DinosaurID() model.DinosaurID         { return d.dinosaurID }
+func (d CreateUniqueDinosaur) Multipliers() model.UniqueMultipliers { return d.multipliers }
 
+// UpdateCreature versionはクライアントが取得した時点のバージョンで、一致する場合のみ更新する
 type UpdateCreature struct {
-	dinoID           model.DinosaurID
-	dinoName         model.DinosaurName
-	baseHealth       model.Health
-	baseMelee        model.Melee
-	uniqueID         model.UniqueDinosaurID
-	uniqueName       model.UniqueName
-	healthMultiplier model.UniqueMultiplier[model.Health]
-	damageMultiplier model.UniqueMultiplier[model.Melee]
-	variantsID       model.UniqueVariantID
-	variantsIDs      [2]variantModel.VariantID
+	dinoID      model.DinosaurID
+	uniqueID    model.UniqueDinosaurID
+	uniqueName  model.UniqueName
+	multipliers model.UniqueMultipliers
+	variantsIDs []variantModel.VariantID
+	version     logic.Version
 }
 
 func NewUpdateCreature(
 	dinoID model.DinosaurID,
-	dinoName model.DinosaurName,
-	baseHealth model.Health,
-	baseMelee model.Melee,
 	uniqueDinoID model.UniqueDinosaurID,
 	uniqueName model.UniqueName,
-	healthMultiplier model.UniqueMultiplier[model.Health],
-	damageMultiplier model.UniqueMultiplier[model.Melee],
-	variantsID model.UniqueVariantID,
-	variantsIDs [2]variantModel.VariantID,
+	multipliers model.UniqueMultipliers,
+	variantsIDs []variantModel.VariantID,
+	version logic.Version,
 ) UpdateCreature {
 	return UpdateCreature{
-		dinoID:           dinoID,
-		dinoName:         dinoName,
-		baseHealth:       baseHealth,
-		baseMelee:        baseMelee,
-		uniqueID:         uniqueDinoID,
-		uniqueName:       uniqueName,
-		healthMultiplier: healthMultiplier,
-		damageMultiplier: damageMultiplier,
-		variantsID:       variantsID,
-		variantsIDs:      variantsIDs,
+		dinoID:      dinoID,
+		uniqueID:    uniqueDinoID,
+		uniqueName:  uniqueName,
+		multipliers: multipliers,
+		variantsIDs: variantsIDs,
+		version:     version,
 	}
 }
 
-func (c UpdateCreature) Dino() UpdateDinosaur {
-	return UpdateDinosaur{
-		id:         c.dinoID,
-		name:       c.dinoName,
-		baseHealth: c.baseHealth,
-		baseMelee:  c.baseMelee,
-	}
-}
+func (c UpdateCreature) DinosaurID() model.DinosaurID         { return c.dinoID }
+func (c UpdateCreature) VariantIDs() []variantModel.VariantID { return c.variantsIDs }
 
 func (c UpdateCreature) Unique() UpdateUniqueDinosaur {
 	return UpdateUniqueDinosaur{
-		uniqueDinoID:     c.uniqueID,
-		name:             c.uniqueName,
-		healthMultiplier: c.healthMultiplier,
-		damageMultiplier: c.damageMultiplier,
+		uniqueDinoID: c.uniqueID,
+		dinosaurID:   c.dinoID,
+		name:         c.uniqueName,
+		multipliers:  c.multipliers,
+		version:      c.version,
 	}
 }
 
@@ -168,48 +130,51 @@ func (c UpdateCreature) Variants() UpdateVariants {
 }
 
 type UpdateUniqueDinosaur struct {
-	uniqueDinoID     model.UniqueDinosaurID
-	dinosaurID       model.DinosaurID
-	name             model.UniqueName
-	healthMultiplier model.UniqueMultiplier[model.Health]
-	damageMultiplier model.UniqueMultiplier[model.Melee]
+	uniqueDinoID model.UniqueDinosaurID
+	dinosaurID   model.DinosaurID
+	name         model.UniqueName
+	multipliers  model.UniqueMultipliers
+	version      logic.Version
 }
 
-func (d UpdateUniqueDinosaur) ID() model.UniqueDinosaurID   { return d.uniqueDinoID }
-func (d UpdateUniqueDinosaur) Name() model.UniqueName       { return d.name }
-func (d UpdateUniqueDinosaur) DinosaurID() model.DinosaurID { return d.dinosaurID }
-func (d UpdateUniqueDinosaur) HealthMultiplier() model.UniqueMultiplier[model.Health] {
-	return d.healthMultiplier
-}
-func (d UpdateUniqueDinosaur) DamageMultiplier() model.UniqueMultiplier[model.Melee] {
-	return d.damageMultiplier
-}
+func (d UpdateUniqueDinosaur) ID() model.UniqueDinosaurID           { return d.uniqueDinoID }
+func (d UpdateUniqueDinosaur) Version() logic.Version               { return d.version }
+func (d UpdateUniqueDinosaur) Name() model.UniqueName               { return d.name }
+func (d UpdateUniqueDinosaur) DinosaurID() model.DinosaurID         { return d.dinosaurID }
+func (d UpdateUniqueDinosaur) Multipliers() model.UniqueMultipliers { return d.multipliers }
 
 type ResponseUnique struct {
-	id               model.UniqueDinosaurID
-	name             model.UniqueName
-	healthMultiplier model.UniqueMultiplier[model.Health]
-	damageMultiplier model.UniqueMultiplier[model.Melee]
+	id          model.UniqueDinosaurID
+	name        model.UniqueName
+	displayName model.UniqueName
+	multipliers model.UniqueMultipliers
+	version     logic.Version
 }
 
 func NewResponseUnique(
 	id model.UniqueDinosaurID,
 	name model.UniqueName,
-	healthMultiplier model.UniqueMultiplier[model.Health],
-	damageMultiplier model.UniqueMultiplier[model.Melee],
+	multipliers model.UniqueMultipliers,
 ) ResponseUnique {
-	return ResponseUnique{id, name, healthMultiplier, damageMultiplier}
+	return ResponseUnique{id: id, name: name, multipliers: multipliers}
 }
 
-func (u ResponseUnique) ID() model.UniqueDinosaurID { return u.id }
-func (u ResponseUnique) Name() model.UniqueName     { return u.name }
-func (u ResponseUnique) HealthMultiplier() model.UniqueMultiplier[model.Health] {
-	return u.healthMultiplier
+func (u ResponseUnique) WithVersion(version logic.Version) ResponseUnique {
+	u.version = version
+	return u
 }
-func (u ResponseUnique) MeleeMultiplier() model.UniqueMultiplier[model.Melee] {
-	return u.damageMultiplier
+
+// WithDisplayName 保存された名前とは別に、リクエストの言語の表示名を持たせる
+func (u ResponseUnique) WithDisplayName(name model.UniqueName) ResponseUnique {
+	u.displayName = name
+	return u
 }
 
+func (u ResponseUnique) ID() model.UniqueDinosaurID           { return u.id }
+func (u ResponseUnique) Name() model.UniqueName               { return u.name }
+func (u ResponseUnique) Multipliers() model.UniqueMultipliers { return u.multipliers }
+func (u ResponseUnique) Version() logic.Version               { return u.version }
+
 type ResponseCreature struct {
 	ResponseDinosaur
 	ResponseVariants
@@ -217,22 +182,70 @@ type ResponseCreature struct {
 }
 
 func (c ResponseCreature) ToUniqueDinosaur() model.UniqueDinosaur {
-	variants := c.ResponseVariants.Values()
-	vs := lo.Map(variants[:], func(item model.DinosaurVariant, _ int) model.DinosaurVariant {
-		return model.NewDinosaurVariant(
-			variantModel.NewVariant(item.ID(), item.Group(), item.Name()),
-			model.VariantDescriptions{},
-		)
+	// 表示名を保つため、バリアントは読み込んだものをそのまま用いる
+	vs := lo.Map(c.ResponseVariants.Values(), func(item model.DinosaurVariant, _ int) model.DinosaurVariant {
+		return model.NewDinosaurVariant(item.Variant, item.Descriptions())
 	})
 	return model.NewUniqueDinosaur(
 		model.NewDinosaur(
 			c.ResponseDinosaur.ID(), c.ResponseDinosaur.Name(),
-			c.ResponseDinosaur.Health(), c.ResponseDinosaur.Melee(),
-		),
+			c.ResponseDinosaur.Stats(),
+		).WithBaseDisplayName(c.ResponseDinosaur.displayName),
 		c.ResponseUnique.ID(), c.ResponseUnique.Name(),
-		c.ResponseUnique.HealthMultiplier(), c.ResponseUnique.MeleeMultiplier(),
+		c.ResponseUnique.Multipliers(),
 		model.UniqueVariant(vs),
-	)
+	).WithUniqueDisplayName(c.ResponseUnique.displayName).WithVersion(c.ResponseUnique.Version())
 }
 
 type ResponseCreatures []ResponseCreature
+
+type UniqueSortKey string
+
+const (
+	UniqueSortByID        UniqueSortKey = "id"
+	UniqueSortByName      UniqueSortKey = "name"
+	UniqueSortByHealth    UniqueSortKey = "health"
+	UniqueSortByDamage    UniqueSortKey = "damage"
+	UniqueSortByUpdatedAt UniqueSortKey = "updated_at"
+)
+
+func (k UniqueSortKey) Value() string { return string(k) }
+
+// UniqueFilter nilの条件は絞り込みに用いない
+type UniqueFilter struct {
+	DinosaurID          *model.DinosaurID
+	VariantGroupID      *variantModel.VariantGroupID
+	VariantID           *variantModel.VariantID
+	BaseName            *model.DinosaurName
+	MinHealthMultiplier *model.StatusMultiplier
+	MaxHealthMultiplier *model.StatusMultiplier
+	MinDamageMultiplier *model.StatusMultiplier
+	MaxDamageMultiplier *model.StatusMultiplier
+}
+
+type ListUniques struct {
+	logic.PageRequest
+	sortKey UniqueSortKey
+	filter  UniqueFilter
+}
+
+// NewListUniques ソートキーが空の場合はid順とする
+func NewListUniques(page logic.PageRequest, sortKey UniqueSortKey, filter UniqueFilter) (ListUniques, error) {
+	switch sortKey {
+	case "":
+		sortKey = UniqueSortByID
+	case UniqueSortByID, UniqueSortByName, UniqueSortByHealth, UniqueSortByDamage, UniqueSortByUpdatedAt:
+	default:
+		return ListUniques{}, failure.Translate(i18n.Errorf("%qは不明な並び替えのキーです", sortKey), logic.InvalidArgument)
+	}
+	return ListUniques{PageRequest: page, sortKey: sortKey, filter: filter}, nil
+}
+
+func (l ListUniques) SortKey() UniqueSortKey { return l.sortKey }
+func (l ListUniques) Filter() UniqueFilter   { return l.filter }
+
+// WithDinosaurID 種に属するユニークのみに絞り込む
+func (l ListUniques) WithDinosaurID(id model.DinosaurID) ListUniques {
+	l.filter.DinosaurID = &id
+	return l
+}
@@ -10,39 +10,45 @@ import (
 
 type UniqueVariantsCommand interface {
 	Insert(context.Context, CreateVariants) error
+	// Update ユニークに付与されたバリアントを指定した順序で置き換える
 	Update(context.Context, UpdateVariants) error
 	Delete(context.Context, model.UniqueVariantID) error
 }
 
+// VariantRuleValidator ユニークに付与するバリアントの組み合わせを検証する。規則はバリアントのモジュールで管理する
+type VariantRuleValidator interface {
+	Validate(context.Context, []variantModel.VariantID) error
+}
+
 type CreateVariants struct {
 	uniqueDinosaurID model.UniqueDinosaurID
-	variantIDs       [2]variantModel.VariantID
+	variantIDs       []variantModel.VariantID
 }
 
 func (v CreateVariants) UniqueDinosaurID() model.UniqueDinosaurID { return v.uniqueDinosaurID }
 
-func (v CreateVariants) VariantIDs() [2]variantModel.VariantID {
+func (v CreateVariants) VariantIDs() []variantModel.VariantID {
 	return v.variantIDs
 }
 
 type UpdateVariants struct {
 	uniqueDinosaurID model.UniqueDinosaurID
-	variantIDs       [2]variantModel.VariantID
+	variantIDs       []variantModel.VariantID
 }
 
 func (v UpdateVariants) UniqueDinosaurID() model.UniqueDinosaurID { return v.uniqueDinosaurID }
-func (v UpdateVariants) VariantIDs() [2]variantModel.VariantID {
+func (v UpdateVariants) VariantIDs() []variantModel.VariantID {
 	return v.variantIDs
 }
 
 type ResponseVariants struct {
-	variants [2]model.DinosaurVariant
+	variants []model.DinosaurVariant
 }
 
 func NewResponseVariants(
-	variants [2]model.DinosaurVariant,
+	variants []model.DinosaurVariant,
 ) ResponseVariants {
 	return ResponseVariants{variants: variants}
 }
 
-func (v ResponseVariants) Values() [2]model.DinosaurVariant { return v.variants }
+func (v ResponseVariants) Values() []model.DinosaurVariant { return v.variants }
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/creature/domain/model"
+)
+
+type statsAudit struct {
+	Health        uint `json:"health"`
+	Stamina       uint `json:"stamina"`
+	Oxygen        uint `json:"oxygen"`
+	Food          uint `json:"food"`
+	Weight        uint `json:"weight"`
+	Melee         uint `json:"melee"`
+	MovementSpeed uint `json:"movement_speed"`
+	Torpidity     uint `json:"torpidity"`
+	Armor         uint `json:"armor"`
+}
+
+type statGrowthAudit struct {
+	WildIncrease  float32 `json:"wild_increase"`
+	TamedIncrease float32 `json:"tamed_increase"`
+	TamedAdd      float32 `json:"tamed_add"`
+	TamedAffinity float32 `json:"tamed_affinity"`
+}
+
+// dinosaurAudit 変更履歴に記録する種の状態。成長値は登録されたステータスのみ記録する
+type dinosaurAudit struct {
+	ID          int                        `json:"id"`
+	Name        string                     `json:"name"`
+	Stats       statsAudit                 `json:"stats"`
+	StatGrowths map[string]statGrowthAudit `json:"stat_growths,omitempty"`
+}
+
+func newDinosaurAudit(d model.Dinosaur) dinosaurAudit {
+	s := d.Stats()
+	return dinosaurAudit{
+		ID:   d.BaseID().Value(),
+		Name: d.BaseName().Value(),
+		Stats: statsAudit{
+			Health:        s.Health().Value(),
+			Stamina:       s.Stamina().Value(),
+			Oxygen:        s.Oxygen().Value(),
+			Food:          s.Food().Value(),
+			Weight:        s.Weight().Value(),
+			Melee:         s.Melee().Value(),
+			MovementSpeed: s.MovementSpeed().Value(),
+			Torpidity:     s.Torpidity().Value(),
+			Armor:         s.Armor().Value(),
+		},
+		StatGrowths: lo.MapEntries(d.StatGrowth(), func(k model.StatKind, g model.StatGrowth) (string, statGrowthAudit) {
+			return k.Value(), statGrowthAudit{
+				WildIncrease:  g.WildIncrease(),
+				TamedIncrease: g.TamedIncrease(),
+				TamedAdd:      g.TamedAdd(),
+				TamedAffinity: g.TamedAffinity(),
+			}
+		}),
+	}
+}
+
+type multipliersAudit struct {
+	Health        float32 `json:"health"`
+	Stamina       float32 `json:"stamina"`
+	Oxygen        float32 `json:"oxygen"`
+	Food          float32 `json:"food"`
+	Weight        float32 `json:"weight"`
+	Melee         float32 `json:"melee"`
+	MovementSpeed float32 `json:"movement_speed"`
+	Torpidity     float32 `json:"torpidity"`
+	Armor         float32 `json:"armor"`
+}
+
+// uniqueAudit 種の状態は種の履歴に記録するため、ユニークには種のIDのみを記録する
+type uniqueAudit struct {
+	ID          int              `json:"id"`
+	DinosaurID  int              `json:"dinosaur_id"`
+	Name        string           `json:"name"`
+	Multipliers multipliersAudit `json:"multipliers"`
+	VariantIDs  []int            `json:"variant_ids"`
+	Version     int              `json:"version"`
+}
+
+func newUniqueAudit(u model.UniqueDinosaur) uniqueAudit {
+	m := u.Multipliers()
+	return uniqueAudit{
+		ID:         u.UniqueID().Value(),
+		DinosaurID: u.BaseID().Value(),
+		Name:       u.UniqueName().Value(),
+		Multipliers: multipliersAudit{
+			Health:        m.Health().Value(),
+			Stamina:       m.Stamina().Value(),
+			Oxygen:        m.Oxygen().Value(),
+			Food:          m.Food().Value(),
+			Weight:        m.Weight().Value(),
+			Melee:         m.Melee().Value(),
+			MovementSpeed: m.MovementSpeed().Value(),
+			Torpidity:     m.Torpidity().Value(),
+			Armor:         m.Armor().Value(),
+		},
+		VariantIDs: lo.Map(u.UniqueVariant(), func(v model.DinosaurVariant, _ int) int {
+			return v.ID().Value()
+		}),
+		Version: u.Version().Value(),
+	}
+}
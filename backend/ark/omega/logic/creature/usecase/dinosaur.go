@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+type DinosaurUsecase interface {
+	Find(context.Context, model.DinosaurID) (*model.Dinosaur, error)
+	List(context.Context, service.ListDinosaurs) (*logic.Page[model.Dinosaur], error)
+	ListStatGrowths(context.Context, []model.DinosaurID) (map[model.DinosaurID]model.SpeciesStatGrowth, error)
+	Create(context.Context, service.CreateDinosaur) (*model.Dinosaur, error)
+	Update(context.Context, service.UpdateDinosaur) (*model.Dinosaur, error)
+	Delete(context.Context, model.DinosaurID, logic.Version) error
+	Restore(context.Context, model.DinosaurID) (*model.Dinosaur, error)
+	ListUniques(context.Context, model.DinosaurID, service.ListUniques) (*logic.Page[model.UniqueDinosaur], error)
+}
+
+type Dinosaur struct {
+	query       service.DinosaurQueryRepository
+	command     service.DinosaurCommandRepository
+	uniqueQuery UniqueQueryRepository
+	recorder    logic.AuditRecorder
+}
+
+func NewDinosaur(injector *do.Injector) (DinosaurUsecase, error) {
+	return &Dinosaur{
+		query:       do.MustInvoke[service.DinosaurQueryRepository](injector),
+		command:     do.MustInvoke[service.DinosaurCommandRepository](injector),
+		uniqueQuery: do.MustInvoke[UniqueQueryRepository](injector),
+		recorder:    do.MustInvoke[logic.AuditRecorder](injector),
+	}, nil
+}
+
+func (d Dinosaur) Find(ctx context.Context, id model.DinosaurID) (*model.Dinosaur, error) {
+	dino, err := d.query.Select(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.NotFound) {
+			return nil, failure.New(logic.NotFound)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return dino, nil
+}
+
+func (d Dinosaur) List(ctx context.Context, query service.ListDinosaurs) (*logic.Page[model.Dinosaur], error) {
+	dinos, err := d.query.List(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return dinos, nil
+}
+
+// ListStatGrowths 一覧の種の成長値を1度に読み込む。成長値が登録されていない種は結果に含めない
+func (d Dinosaur) ListStatGrowths(
+	ctx context.Context, ids []model.DinosaurID,
+) (map[model.DinosaurID]model.SpeciesStatGrowth, error) {
+	growths, err := d.query.SelectStatGrowths(ctx, ids)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return growths, nil
+}
+
+func (d Dinosaur) Create(ctx context.Context, create service.CreateDinosaur) (*model.Dinosaur, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Dinosaur, error) {
+		if err := d.uniqueName(ctx, create.Name(), nil); err != nil {
+			return nil, err
+		}
+
+		id, err := d.command.Insert(ctx, create)
+		if err != nil {
+			if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		dino, err := d.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = d.recorder.Record(
+			ctx, logic.Created(logic.AuditDinosaur, id.Value(), newDinosaurAudit(*dino)),
+		); err != nil {
+			return nil, err
+		}
+		return dino, nil
+	})
+}
+
+func (d Dinosaur) Update(ctx context.Context, update service.UpdateDinosaur) (*model.Dinosaur, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Dinosaur, error) {
+		before, err := d.Find(ctx, update.ID())
+		if err != nil {
+			return nil, err
+		}
+		id := update.ID()
+		if err := d.uniqueName(ctx, update.Name(), &id); err != nil {
+			return nil, err
+		}
+
+		if err := d.command.Update(ctx, update); err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return nil, failure.New(logic.PreconditionFailed)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		dino, err := d.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = d.recorder.Record(
+			ctx, logic.Updated(logic.AuditDinosaur, id.Value(), newDinosaurAudit(*before), newDinosaurAudit(*dino)),
+		); err != nil {
+			return nil, err
+		}
+		return dino, nil
+	})
+}
+
+// Delete ユニークから参照されている種は削除できない
+func (d Dinosaur) Delete(ctx context.Context, id model.DinosaurID, version logic.Version) error {
+	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
+		before, err := d.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		page, err := logic.NewPageRequest(1, "", logic.Asc)
+		if err != nil {
+			return err
+		}
+		query, err := service.NewListUniques(page, service.UniqueSortByID, service.UniqueFilter{})
+		if err != nil {
+			return err
+		}
+		uniques, err := d.uniqueQuery.List(ctx, query.WithDinosaurID(id))
+		if err != nil {
+			if errors.Is(err, service.IntervalServerError) {
+				return failure.New(logic.IntervalServerError)
+			}
+			return failure.Wrap(err)
+		}
+		if len(uniques.Items()) != 0 {
+			return failure.Translate(i18n.Errorf("種はユニークから参照されています"), logic.Conflict)
+		}
+
+		if err = d.command.Delete(ctx, id, version); err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return failure.New(logic.PreconditionFailed)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return failure.New(logic.IntervalServerError)
+			}
+			return failure.Wrap(err)
+		}
+		return d.recorder.Record(ctx, logic.Deleted(logic.AuditDinosaur, id.Value(), newDinosaurAudit(*before)))
+	})
+}
+
+// Restore ゴミ箱から戻した種を返す。同じ名前の種が作成済みの場合はConflictとする
+func (d Dinosaur) Restore(ctx context.Context, id model.DinosaurID) (*model.Dinosaur, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Dinosaur, error) {
+		if err := d.command.Restore(ctx, id); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		dino, err := d.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = d.recorder.Record(
+			ctx, logic.Restored(logic.AuditDinosaur, id.Value(), newDinosaurAudit(*dino)),
+		); err != nil {
+			return nil, err
+		}
+		return dino, nil
+	})
+}
+
+func (d Dinosaur) ListUniques(
+	ctx context.Context, id model.DinosaurID, query service.ListUniques,
+) (*logic.Page[model.UniqueDinosaur], error) {
+	if _, err := d.Find(ctx, id); err != nil {
+		return nil, err
+	}
+
+	resp, err := d.uniqueQuery.List(ctx, query.WithDinosaurID(id))
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+
+	uniques := logic.MapPage(*resp, func(r service.ResponseCreature) model.UniqueDinosaur {
+		return r.ToUniqueDinosaur()
+	})
+	return &uniques, nil
+}
+
+// uniqueName excludeには更新対象の種を指定し、自身との重複は許容する
+func (d Dinosaur) uniqueName(ctx context.Context, name model.DinosaurName, exclude *model.DinosaurID) error {
+	dino, err := d.query.SelectByName(ctx, name)
+	if errors.Is(err, service.NotFound) {
+		return nil
+	}
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	if exclude != nil && dino.BaseID() == *exclude {
+		return nil
+	}
+	return failure.Translate(i18n.Errorf("種%qは既に存在します", name), logic.Conflict)
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/creature/domain/service"
+)
+
+type DinosaurTestSuite struct {
+	suite.Suite
+
+	mockDinoQuery   *mockDinoQueryRepo
+	mockDinoCommand *mockDinoCommandRepo
+	mockUniqueQuery *mockUniqueQueryRepo
+	mockRecorder    *mockAuditRecorder
+	usecase         DinosaurUsecase
+
+	dino    model.Dinosaur
+	other   model.Dinosaur
+	uniques service.ListUniques
+}
+
+func TestDinosaurSuite(t *testing.T) {
+	suite.Run(t, &DinosaurTestSuite{})
+}
+
+const (
+	findDinosaurByName = "SelectByName"
+	deleteDinosaur     = "Delete"
+	restoreDinosaur    = "Restore"
+	otherCreatureID    = 1
+)
+
+func (s *DinosaurTestSuite) SetupSuite() {
+	injector := do.New()
+	s.mockDinoQuery = newMockDinoQueryRepo()
+	do.ProvideValue[service.DinosaurQueryRepository](injector, s.mockDinoQuery)
+	s.mockDinoCommand = newMockDinoCommandRepo()
+	do.ProvideValue[service.DinosaurCommandRepository](injector, s.mockDinoCommand)
+	s.mockUniqueQuery = newMockUniqueQuery()
+	do.ProvideValue[UniqueQueryRepository](injector, s.mockUniqueQuery)
+	s.mockRecorder = newMockAuditRecorder()
+	do.ProvideValue[logic.AuditRecorder](injector, s.mockRecorder)
+
+	usecase, err := NewDinosaur(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+
+	h, err := model.NewHealth(health)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	st, err := model.NewStamina(stamina)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	f, err := model.NewFood(food)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	w, err := model.NewWeight(weight)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	sp, err := model.NewMovementSpeed(speed)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	t, err := model.NewTorpidity(torpidity)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	stats := model.NewDinosaurStats(h, st, model.NewOxygen(oxygen), f, w, model.NewMelee(melee), sp, t, model.NewArmor(armor))
+	s.dino = model.NewDinosaur(creatureID, creatureName, stats).WithVersion(version)
+	s.other = model.NewDinosaur(otherCreatureID, creatureName, stats)
+
+	page, err := logic.NewPageRequest(0, "", "")
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.uniques, err = service.NewListUniques(page, "", service.UniqueFilter{})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+}
+
+func (s *DinosaurTestSuite) TestCreate() {
+	create := service.NewCreateDinosaur(creatureName, s.dino.Stats())
+	{
+		s.T().Log("種を作成できるかテスト")
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(nil, service.NotFound).Once()
+		s.mockDinoCommand.On(insert, ctx, create).Return(model.DinosaurID(creatureID), nil).Once()
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Once()
+		s.mockRecorder.On(
+			recordChange, ctx, logic.Created(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino)),
+		).Return(nil).Once()
+
+		r, err := s.usecase.Create(ctx, create)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		s.Equal(&s.dino, r)
+	}
+	{
+		s.T().Log("同名の種が存在する場合のテスト")
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(&s.other, nil).Once()
+
+		_, err := s.usecase.Create(ctx, create)
+		s.True(failure.Is(err, logic.Conflict))
+	}
+	{
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(nil, service.NotFound).Once()
+		s.mockDinoCommand.On(insert, ctx, create).Return(nil, e).Once()
+
+		_, err := s.usecase.Create(ctx, create)
+		s.True(errors.Is(err, e))
+	}
+}
+
+func (s *DinosaurTestSuite) TestUpdate() {
+	upd := service.NewUpdateDinosaur(creatureID, creatureName, s.dino.Stats(), version)
+	{
+		s.T().Log("自身と同名のまま更新できるかテスト")
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Twice()
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(&s.dino, nil).Once()
+		s.mockDinoCommand.On(update, ctx, upd).Return(nil).Once()
+		s.mockRecorder.On(
+			recordChange,
+			ctx,
+			logic.Updated(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino), newDinosaurAudit(s.dino)),
+		).Return(nil).Once()
+
+		r, err := s.usecase.Update(ctx, upd)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		s.Equal(&s.dino, r)
+	}
+	{
+		s.T().Log("成長値を置き換えた場合は変更履歴に成長値を記録するかテスト")
+		growth, err := model.NewStatGrowth(0.2, 0.27, 0, 0)
+		if err != nil {
+			s.T().Fatal(err)
+		}
+		grown := s.dino.WithStatGrowth(model.SpeciesStatGrowth{model.StatHealth: growth})
+		withGrowth := upd.WithStatGrowth(grown.StatGrowth())
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Once()
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(&s.dino, nil).Once()
+		s.mockDinoCommand.On(update, ctx, withGrowth).Return(nil).Once()
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&grown, nil).Once()
+		after := newDinosaurAudit(grown)
+		s.Contains(after.StatGrowths, model.StatHealth.Value())
+		s.mockRecorder.On(
+			recordChange,
+			ctx,
+			logic.Updated(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino), after),
+		).Return(nil).Once()
+
+		r, err := s.usecase.Update(ctx, withGrowth)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		s.Equal(&grown, r)
+	}
+	{
+		s.T().Log("他の変更で古くなったバージョンで更新した場合のテスト")
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Once()
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(&s.dino, nil).Once()
+		s.mockDinoCommand.On(update, ctx, upd).Return(service.VersionMismatch).Once()
+
+		_, err := s.usecase.Update(ctx, upd)
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
+	{
+		s.T().Log("他の種と名前が重複する場合のテスト")
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Once()
+		s.mockDinoQuery.On(findDinosaurByName, ctx, model.DinosaurName(creatureName)).Return(&s.other, nil).Once()
+
+		_, err := s.usecase.Update(ctx, upd)
+		s.True(failure.Is(err, logic.Conflict))
+	}
+	{
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(nil, service.NotFound).Once()
+
+		_, err := s.usecase.Update(ctx, upd)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+}
+
+func (s *DinosaurTestSuite) TestDelete() {
+	id := model.DinosaurID(creatureID)
+	page, err := logic.NewPageRequest(1, "", logic.Asc)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query, err := service.NewListUniques(page, service.UniqueSortByID, service.UniqueFilter{})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query = query.WithDinosaurID(id)
+	{
+		s.T().Log("ユニークから参照されていない種を削除できるかテスト")
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		empty := logic.NewPage([]service.ResponseCreature{}, "")
+		s.mockUniqueQuery.On(list, ctx, query).Return(&empty, nil).Once()
+		s.mockDinoCommand.On(deleteDinosaur, ctx, id, version).Return(nil).Once()
+		s.mockRecorder.On(
+			recordChange, ctx, logic.Deleted(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino)),
+		).Return(nil).Once()
+
+		s.Nil(s.usecase.Delete(ctx, id, version))
+	}
+	{
+		s.T().Log("変更履歴を記録できない場合は削除も失敗させるテスト")
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		empty := logic.NewPage([]service.ResponseCreature{}, "")
+		s.mockUniqueQuery.On(list, ctx, query).Return(&empty, nil).Once()
+		s.mockDinoCommand.On(deleteDinosaur, ctx, id, version).Return(nil).Once()
+		s.mockRecorder.On(
+			recordChange, ctx, logic.Deleted(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino)),
+		).Return(e).Once()
+
+		s.True(errors.Is(s.usecase.Delete(ctx, id, version), e))
+	}
+	{
+		s.T().Log("他の変更で古くなったバージョンで削除した場合のテスト")
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		empty := logic.NewPage([]service.ResponseCreature{}, "")
+		s.mockUniqueQuery.On(list, ctx, query).Return(&empty, nil).Once()
+		s.mockDinoCommand.On(deleteDinosaur, ctx, id, version).Return(service.VersionMismatch).Once()
+
+		s.True(failure.Is(s.usecase.Delete(ctx, id, version), logic.PreconditionFailed))
+	}
+	{
+		s.T().Log("ユニークから参照されている種を削除できないかテスト")
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		referenced := logic.NewPage([]service.ResponseCreature{{}}, "")
+		s.mockUniqueQuery.On(list, ctx, query).Return(&referenced, nil).Once()
+
+		s.True(failure.Is(s.usecase.Delete(ctx, id, version), logic.Conflict))
+	}
+}
+
+func (s *DinosaurTestSuite) TestRestore() {
+	id := model.DinosaurID(creatureID)
+	{
+		s.T().Log("ゴミ箱の種を復元できるかテスト")
+		s.mockDinoCommand.On(restoreDinosaur, ctx, id).Return(nil).Once()
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		s.mockRecorder.On(
+			recordChange, ctx, logic.Restored(logic.AuditDinosaur, creatureID, newDinosaurAudit(s.dino)),
+		).Return(nil).Once()
+
+		dino, err := s.usecase.Restore(ctx, id)
+		s.Nil(err)
+		s.Equal(&s.dino, dino)
+	}
+	{
+		s.T().Log("ゴミ箱に無い種はNotFoundになるかテスト")
+		s.mockDinoCommand.On(restoreDinosaur, ctx, id).Return(service.NotFound).Once()
+
+		_, err := s.usecase.Restore(ctx, id)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+}
+
+func (s *DinosaurTestSuite) TestListUniques() {
+	id := model.DinosaurID(creatureID)
+	{
+		s.T().Log("種に属するユニークのみに絞り込むかテスト")
+		s.mockDinoQuery.On(find, ctx, id).Return(&s.dino, nil).Once()
+		page := logic.NewPage([]service.ResponseCreature{}, "")
+		s.mockUniqueQuery.On(list, ctx, s.uniques.WithDinosaurID(id)).Return(&page, nil).Once()
+
+		r, err := s.usecase.ListUniques(ctx, id, s.uniques)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		s.Empty(r.Items())
+	}
+	{
+		s.mockDinoQuery.On(find, ctx, id).Return(nil, service.NotFound).Once()
+
+		_, err := s.usecase.ListUniques(ctx, id, s.uniques)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"sort"
 
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
@@ -11,35 +12,50 @@ import (
 	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
 	"mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/i18n"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
 )
 
 // UniqueQueryRepository 集約内のテーブルをjoinしてレコードを取得する処理を定義
 type UniqueQueryRepository interface {
 	Select(context.Context, model.UniqueDinosaurID) (*service.ResponseCreature, error)
-	List(context.Context) (service.ResponseCreatures, error)
+	List(context.Context, service.ListUniques) (*logic.Page[service.ResponseCreature], error)
 }
 
 type UniqueUsecase interface {
 	Find(context.Context, model.UniqueDinosaurID) (*model.UniqueDinosaur, error)
-	List(context.Context) (model.UniqueDinosaurs, error)
+	List(context.Context, service.ListUniques) (*logic.Page[model.UniqueDinosaur], error)
 	Create(context.Context, service.CreateCreature) (*model.UniqueDinosaur, error)
 	Update(context.Context, service.UpdateCreature) (*model.UniqueDinosaur, error)
-	Delete(context.Context, model.UniqueDinosaurID) error
+	Delete(context.Context, model.UniqueDinosaurID, logic.Version) error
+	Restore(context.Context, model.UniqueDinosaurID) (*model.UniqueDinosaur, error)
+	DetachVariants(context.Context, []variantModel.VariantID) error
+	Stats(context.Context, model.UniqueDinosaurID, model.Level, *model.TamingInput) (*model.CalculatedStats, error)
 }
 
 type Unique struct {
-	dinoCommand    service.DinosaurCommandRepository
+	dinoQuery      service.DinosaurQueryRepository
 	uniqueQuery    UniqueQueryRepository
 	uniqueCommand  service.UniqueCommandRepository
 	variantCommand service.UniqueVariantsCommand
+	variantRules   service.VariantRuleValidator
+	statGrowth     service.StatGrowthRepository
+	serverStats    model.ServerStatMultipliers
+	maxVariants    model.MaxUniqueVariants
+	recorder       logic.AuditRecorder
 }
 
 func NewUnique(injector *do.Injector) (UniqueUsecase, error) {
 	return &Unique{
-		dinoCommand:    do.MustInvoke[service.DinosaurCommandRepository](injector),
+		dinoQuery:      do.MustInvoke[service.DinosaurQueryRepository](injector),
 		uniqueQuery:    do.MustInvoke[UniqueQueryRepository](injector),
 		uniqueCommand:  do.MustInvoke[service.UniqueCommandRepository](injector),
 		variantCommand: do.MustInvoke[service.UniqueVariantsCommand](injector),
+		variantRules:   do.MustInvoke[service.VariantRuleValidator](injector),
+		statGrowth:     do.MustInvoke[service.StatGrowthRepository](injector),
+		serverStats:    do.MustInvoke[model.ServerStatMultipliers](injector),
+		maxVariants:    do.MustInvoke[model.MaxUniqueVariants](injector),
+		recorder:       do.MustInvoke[logic.AuditRecorder](injector),
 	}, nil
 }
 
@@ -58,8 +74,8 @@ func (u Unique) Find(ctx context.Context, id model.UniqueDinosaurID) (*model.Uni
 	return &unique, nil
 }
 
-func (u Unique) List(ctx context.Context) (model.UniqueDinosaurs, error) {
-	resp, err := u.uniqueQuery.List(ctx)
+func (u Unique) List(ctx context.Context, query service.ListUniques) (*logic.Page[model.UniqueDinosaur], error) {
+	resp, err := u.uniqueQuery.List(ctx, query)
 	if err != nil {
 		if errors.Is(err, service.IntervalServerError) {
 			return nil, failure.New(logic.IntervalServerError)
@@ -67,27 +83,27 @@ func (u Unique) List(ctx context.Context) (model.UniqueDinosaurs, error) {
 		return nil, failure.Wrap(err)
 	}
 
-	uniques := lo.Map(resp, func(r service.ResponseCreature, _ int) model.UniqueDinosaur {
+	uniques := logic.MapPage(*resp, func(r service.ResponseCreature) model.UniqueDinosaur {
 		return r.ToUniqueDinosaur()
 	})
-	return uniques, nil
+	return &uniques, nil
 }
 
 func (u Unique) Create(ctx context.Context, create service.CreateCreature) (_ *model.UniqueDinosaur, err error) {
+	if err = u.maxVariants.Validate(create.VariantIDs); err != nil {
+		return nil, logic.WrapInvalidArgument(err)
+	}
 	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.UniqueDinosaur, error) {
-		var dinoID model.DinosaurID
-		if dinoID, err = u.dinoCommand.Insert(
-			ctx,
-			create.Dino(),
-		); err != nil {
-			return nil, failure.Wrap(err)
+		if err = u.existsDinosaur(ctx, create.DinosaurID); err != nil {
+			return nil, err
+		}
+		if err = u.variantRules.Validate(ctx, create.VariantIDs); err != nil {
+			return nil, err
 		}
 		var uniqueID model.UniqueDinosaurID
 		if uniqueID, err = u.uniqueCommand.Insert(
 			ctx,
-			service.NewCreateUniqueDinosaur(
-				create.UniqueName, create.HealthMultiplier, create.DamageMultiplier, dinoID,
-			),
+			create.UniqueDinosaur(),
 		); err != nil {
 			return nil, failure.Wrap(err)
 		}
@@ -110,28 +126,39 @@ func (u Unique) Create(ctx context.Context, create service.CreateCreature) (_ *m
 		}
 
 		unique := resp.ToUniqueDinosaur()
+		if err = u.recorder.Record(
+			ctx, logic.Created(logic.AuditUnique, uniqueID.Value(), newUniqueAudit(unique)),
+		); err != nil {
+			return nil, err
+		}
 		return &unique, nil
 	})
 }
 
 func (u Unique) Update(ctx context.Context, update service.UpdateCreature) (_ *model.UniqueDinosaur, err error) {
+	if err = u.maxVariants.Validate(update.VariantIDs()); err != nil {
+		return nil, logic.WrapInvalidArgument(err)
+	}
 	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.UniqueDinosaur, error) {
-		if _, err = u.uniqueQuery.Select(ctx, update.Unique().ID()); err != nil {
+		before, err := u.uniqueQuery.Select(ctx, update.Unique().ID())
+		if err != nil {
 			if errors.Is(err, service.NotFound) {
 				return nil, failure.New(logic.NotFound)
 			}
 			return nil, failure.Wrap(err)
 		}
 
-		if err = u.dinoCommand.Update(ctx, update.Dino()); err != nil {
-			if errors.Is(err, service.IntervalServerError) {
-				return nil, failure.New(logic.IntervalServerError)
-			}
-			return nil, failure.Wrap(err)
+		if err = u.existsDinosaur(ctx, update.DinosaurID()); err != nil {
+			return nil, err
+		}
+		if err = u.variantRules.Validate(ctx, update.VariantIDs()); err != nil {
+			return nil, err
 		}
 
 		if err = u.uniqueCommand.Update(ctx, update.Unique()); err != nil {
-			if errors.Is(err, service.IntervalServerError) {
+			if errors.Is(err, service.VersionMismatch) {
+				return nil, failure.New(logic.PreconditionFailed)
+			} else if errors.Is(err, service.IntervalServerError) {
 				return nil, failure.New(logic.IntervalServerError)
 			}
 			return nil, failure.Wrap(err)
@@ -156,24 +183,157 @@ func (u Unique) Update(ctx context.Context, update service.UpdateCreature) (_ *m
 		}
 
 		unique := resp.ToUniqueDinosaur()
+		if err = u.recorder.Record(ctx, logic.Updated(
+			logic.AuditUnique, unique.UniqueID().Value(), newUniqueAudit(before.ToUniqueDinosaur()), newUniqueAudit(unique),
+		)); err != nil {
+			return nil, err
+		}
 		return &unique, nil
 	})
 }
 
-func (u Unique) Delete(ctx context.Context, id model.UniqueDinosaurID) error {
+func (u Unique) Delete(ctx context.Context, id model.UniqueDinosaurID, version logic.Version) error {
 	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
-		if _, err := u.uniqueQuery.Select(ctx, id); err != nil {
+		before, err := u.uniqueQuery.Select(ctx, id)
+		if err != nil {
 			if errors.Is(err, service.NotFound); err != nil {
 				return failure.New(logic.NotFound)
 			}
 			return failure.Wrap(err)
 		}
-		if err := u.uniqueCommand.Delete(ctx, id); err != nil {
-			if errors.Is(err, service.IntervalServerError) {
+		if err = u.uniqueCommand.Delete(ctx, id, version); err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return failure.New(logic.PreconditionFailed)
+			} else if errors.Is(err, service.IntervalServerError) {
 				return failure.New(logic.IntervalServerError)
 			}
 			return failure.Wrap(err)
 		}
+		return u.recorder.Record(
+			ctx, logic.Deleted(logic.AuditUnique, id.Value(), newUniqueAudit(before.ToUniqueDinosaur())),
+		)
+	})
+}
+
+// Restore ゴミ箱から戻したユニークを返す。種か付与したバリアントがゴミ箱にある場合は先にそちらを戻す必要がある
+func (u Unique) Restore(ctx context.Context, id model.UniqueDinosaurID) (*model.UniqueDinosaur, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.UniqueDinosaur, error) {
+		if err := u.uniqueCommand.Restore(ctx, id); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			} else if errors.Is(err, service.ParentDeleted) {
+				return nil, failure.Translate(i18n.Errorf("ユニークの種か付与したバリアントがゴミ箱にあります"), logic.Conflict)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		unique, err := u.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = u.recorder.Record(
+			ctx, logic.Restored(logic.AuditUnique, id.Value(), newUniqueAudit(*unique)),
+		); err != nil {
+			return nil, err
+		}
+		return unique, nil
+	})
+}
+
+// DetachVariants ゴミ箱に移すバリアントを付与したユニークから外し、外したユニークはそれぞれ更新として記録する。
+// 付与したバリアントが無くなるユニークがある場合は、何も外さずにそのユニークを示すConflictとする
+func (u Unique) DetachVariants(ctx context.Context, ids []variantModel.VariantID) error {
+	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
+		uniques, err := u.listByVariants(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		var emptied logic.Dependents
+		updates := make([]service.UpdateCreature, 0, len(uniques))
+		for _, unique := range uniques {
+			remaining := lo.FilterMap(unique.UniqueVariant(), func(v model.DinosaurVariant, _ int) (variantModel.VariantID, bool) {
+				return v.ID(), !lo.Contains(ids, v.ID())
+			})
+			if len(remaining) == 0 {
+				emptied = append(emptied, logic.Dependent{
+					Entity: logic.AuditUnique, ID: unique.UniqueID().Value(), Name: unique.UniqueDisplayName().Value(),
+				})
+				continue
+			}
+			updates = append(updates, service.NewUpdateCreature(
+				unique.BaseID(), unique.UniqueID(), unique.UniqueName(), unique.Multipliers(), remaining, unique.Version(),
+			))
+		}
+		if len(emptied) != 0 {
+			return failure.Translate(i18n.Wrapf(emptied, "バリアントが残らないユニークがあります"), logic.Conflict)
+		}
+
+		// 残りのバリアントの組み合わせも個別の更新と同じく検証する
+		for _, update := range updates {
+			if _, err = u.Update(ctx, update); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
+
+// listByVariants いずれかのバリアントを付与したゴミ箱にないユニークをID順に返す
+func (u Unique) listByVariants(ctx context.Context, ids []variantModel.VariantID) ([]model.UniqueDinosaur, error) {
+	var uniques []model.UniqueDinosaur
+	for _, id := range ids {
+		id := id
+		found, err := logic.ListAll(func(page logic.PageRequest) (*logic.Page[model.UniqueDinosaur], error) {
+			query, err := service.NewListUniques(page, service.UniqueSortByID, service.UniqueFilter{VariantID: &id})
+			if err != nil {
+				return nil, err
+			}
+			return u.List(ctx, query)
+		})
+		if err != nil {
+			return nil, err
+		}
+		uniques = append(uniques, found...)
+	}
+
+	uniques = lo.UniqBy(uniques, func(unique model.UniqueDinosaur) model.UniqueDinosaurID { return unique.UniqueID() })
+	sort.Slice(uniques, func(i, j int) bool { return uniques[i].UniqueID() < uniques[j].UniqueID() })
+	return uniques, nil
+}
+
+// existsDinosaur 参照する種が存在しない場合はリクエストの誤りとする
+func (u Unique) existsDinosaur(ctx context.Context, id model.DinosaurID) error {
+	if _, err := u.dinoQuery.Select(ctx, id); err != nil {
+		if errors.Is(err, service.NotFound) {
+			return failure.Translate(i18n.Errorf("種%dは存在しません", id), logic.InvalidArgument)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	return nil
+}
+
+// Stats tamingがnilの場合は野生のステータスを返す
+func (u Unique) Stats(
+	ctx context.Context, id model.UniqueDinosaurID, level model.Level, taming *model.TamingInput,
+) (*model.CalculatedStats, error) {
+	unique, err := u.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	growth, err := u.statGrowth.FindStatGrowth(ctx, unique.BaseID())
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+
+	stats := model.NewStatCalculator(growth, u.serverStats).Calculate(*unique, level, taming)
+	return &stats, nil
+}
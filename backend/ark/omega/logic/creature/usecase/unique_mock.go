@@ -9,6 +9,7 @@ import (
 	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
 	"mods-explore/ark/omega/logic/creature/domain/service"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
 )
 
 var (
@@ -49,8 +50,8 @@ func (g *mockDinoCommandRepo) Update(
 	return args.Error(0)
 }
 
-func (g *mockDinoCommandRepo) Delete(ctx context.Context, id model.DinosaurID) error {
-	args := g.Called(ctx, id)
+func (g *mockDinoCommandRepo) Delete(ctx context.Context, id model.DinosaurID, version logic.Version) error {
+	args := g.Called(ctx, id, version)
 
 	r := args.Get(0)
 	if r == nil {
@@ -59,6 +60,12 @@ func (g *mockDinoCommandRepo) Delete(ctx context.Context, id model.DinosaurID) e
 	return args.Error(0)
 }
 
+func (g *mockDinoCommandRepo) Restore(ctx context.Context, id model.DinosaurID) error {
+	args := g.Called(ctx, id)
+
+	return args.Error(0)
+}
+
 var _ logic.Transactioner = (*mockUniqueQueryRepo)(nil)
 var _ UniqueQueryRepository = (*mockUniqueQueryRepo)(nil)
 
@@ -82,14 +89,14 @@ func (g *mockUniqueQueryRepo) Select(ctx context.Context, id model.UniqueDinosau
 	return args.Get(0).(*service.ResponseCreature), args.Error(1)
 }
 
-func (g *mockUniqueQueryRepo) List(ctx context.Context) (service.ResponseCreatures, error) {
-	args := g.Called(ctx)
+func (g *mockUniqueQueryRepo) List(ctx context.Context, query service.ListUniques) (*logic.Page[service.ResponseCreature], error) {
+	args := g.Called(ctx, query)
 
 	r := args.Get(0)
 	if r == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(service.ResponseCreatures), nil
+	return args.Get(0).(*logic.Page[service.ResponseCreature]), nil
 }
 
 var _ logic.Transactioner = (*mockUniqueCommandRepo)(nil)
@@ -125,8 +132,8 @@ func (g *mockUniqueCommandRepo) Update(
 	return args.Error(0)
 }
 
-func (g *mockUniqueCommandRepo) Delete(ctx context.Context, id model.UniqueDinosaurID) error {
-	args := g.Called(ctx, id)
+func (g *mockUniqueCommandRepo) Delete(ctx context.Context, id model.UniqueDinosaurID, version logic.Version) error {
+	args := g.Called(ctx, id, version)
 
 	r := args.Get(0)
 	if r == nil {
@@ -135,6 +142,12 @@ func (g *mockUniqueCommandRepo) Delete(ctx context.Context, id model.UniqueDinos
 	return args.Error(0)
 }
 
+func (g *mockUniqueCommandRepo) Restore(ctx context.Context, id model.UniqueDinosaurID) error {
+	args := g.Called(ctx, id)
+
+	return args.Error(0)
+}
+
 var _ logic.Transactioner = (*mockVariantsCommandRepo)(nil)
 var _ service.UniqueVariantsCommand = (*mockVariantsCommandRepo)(nil)
 
@@ -172,3 +185,97 @@ func (e *mockVariantsCommandRepo) Delete(ctx context.Context, id model.UniqueVar
 	}
 	return args.Error(0)
 }
+
+var _ service.StatGrowthRepository = (*mockStatGrowthRepo)(nil)
+
+type mockStatGrowthRepo struct {
+	mock.Mock
+}
+
+func newMockStatGrowth() *mockStatGrowthRepo { return &mockStatGrowthRepo{} }
+
+func (g *mockStatGrowthRepo) FindStatGrowth(ctx context.Context, id model.DinosaurID) (model.SpeciesStatGrowth, error) {
+	args := g.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(model.SpeciesStatGrowth), args.Error(1)
+}
+
+var _ service.DinosaurQueryRepository = (*mockDinoQueryRepo)(nil)
+
+type mockDinoQueryRepo struct {
+	mock.Mock
+}
+
+func newMockDinoQueryRepo() *mockDinoQueryRepo { return &mockDinoQueryRepo{} }
+
+func (g *mockDinoQueryRepo) Select(ctx context.Context, id model.DinosaurID) (*model.Dinosaur, error) {
+	args := g.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Dinosaur), args.Error(1)
+}
+
+func (g *mockDinoQueryRepo) SelectByName(ctx context.Context, name model.DinosaurName) (*model.Dinosaur, error) {
+	args := g.Called(ctx, name)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Dinosaur), args.Error(1)
+}
+
+func (g *mockDinoQueryRepo) List(ctx context.Context, query service.ListDinosaurs) (*logic.Page[model.Dinosaur], error) {
+	args := g.Called(ctx, query)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*logic.Page[model.Dinosaur]), args.Error(1)
+}
+
+func (g *mockDinoQueryRepo) SelectStatGrowths(
+	ctx context.Context, ids []model.DinosaurID,
+) (map[model.DinosaurID]model.SpeciesStatGrowth, error) {
+	args := g.Called(ctx, ids)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(map[model.DinosaurID]model.SpeciesStatGrowth), args.Error(1)
+}
+
+var _ service.VariantRuleValidator = (*mockVariantRuleValidator)(nil)
+
+type mockVariantRuleValidator struct {
+	mock.Mock
+}
+
+func newMockVariantRuleValidator() *mockVariantRuleValidator { return &mockVariantRuleValidator{} }
+
+func (v *mockVariantRuleValidator) Validate(ctx context.Context, ids []variantModel.VariantID) error {
+	args := v.Called(ctx, ids)
+	return args.Error(0)
+}
+
+var _ logic.AuditRecorder = (*mockAuditRecorder)(nil)
+
+type mockAuditRecorder struct {
+	mock.Mock
+}
+
+func newMockAuditRecorder() *mockAuditRecorder { return &mockAuditRecorder{} }
+
+func (r *mockAuditRecorder) Record(ctx context.Context, change logic.Change) error {
+	args := r.Called(ctx, change)
+	return args.Error(0)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"mods-explore/ark/omega/logic"
@@ -24,10 +25,12 @@ const (
 type UniqueDinosaurTestSuite struct {
 	suite.Suite
 
-	mockDinoCommand     *mockDinoCommandRepo
+	mockDinoQuery       *mockDinoQueryRepo
 	mockUniqueQuery     *mockUniqueQueryRepo
 	mockUniqueCommand   *mockUniqueCommandRepo
 	mockVariantsCommand *mockVariantsCommandRepo
+	mockVariantRules    *mockVariantRuleValidator
+	mockStatGrowth      *mockStatGrowthRepo
 	usecase             UniqueUsecase
 
 	create service.CreateCreature
@@ -39,6 +42,7 @@ type UniqueDinosaurTestSuite struct {
 
 	response service.ResponseCreature
 	unique   model.UniqueDinosaur
+	dino     model.Dinosaur
 }
 
 func newTestUniqueDinosaurSuite() *UniqueDinosaurTestSuite { return &UniqueDinosaurTestSuite{} }
@@ -48,18 +52,23 @@ func TestUniqueDinosaurSuite(t *testing.T) {
 }
 
 const (
-	find   = "Select"
-	list   = "List"
-	insert = "Insert"
-	update = "Update"
+	find     = "Select"
+	list     = "List"
+	insert   = "Insert"
+	update   = "Update"
+	validate = "Validate"
+
+	recordChange = "Record"
+
+	version = logic.Version(1)
 )
 
 func (s *UniqueDinosaurTestSuite) SetupSuite() {
 	{
 		injector := do.New()
-		mockDinoCommand := newMockDinoCommandRepo()
-		do.ProvideValue[service.DinosaurCommandRepository](injector, mockDinoCommand)
-		s.mockDinoCommand = mockDinoCommand
+		mockDinoQuery := newMockDinoQueryRepo()
+		do.ProvideValue[service.DinosaurQueryRepository](injector, mockDinoQuery)
+		s.mockDinoQuery = mockDinoQuery
 		mockUniqueQuery := newMockUniqueQuery()
 		do.ProvideValue[UniqueQueryRepository](injector, mockUniqueQuery)
 		s.mockUniqueQuery = mockUniqueQuery
@@ -69,6 +78,17 @@ func (s *UniqueDinosaurTestSuite) SetupSuite() {
 		mockVariantsCommand := newMockVariantsCommand()
 		do.ProvideValue[service.UniqueVariantsCommand](injector, mockVariantsCommand)
 		s.mockVariantsCommand = mockVariantsCommand
+		mockVariantRules := newMockVariantRuleValidator()
+		do.ProvideValue[service.VariantRuleValidator](injector, mockVariantRules)
+		s.mockVariantRules = mockVariantRules
+		mockStatGrowth := newMockStatGrowth()
+		do.ProvideValue[service.StatGrowthRepository](injector, mockStatGrowth)
+		s.mockStatGrowth = mockStatGrowth
+		do.ProvideValue(injector, model.NewServerStatMultipliers(nil, nil, nil, nil))
+		do.ProvideValue(injector, model.MaxUniqueVariants(maxVariants))
+		recorder := newMockAuditRecorder()
+		recorder.On(recordChange, mock.Anything, mock.Anything).Return(nil)
+		do.ProvideValue[logic.AuditRecorder](injector, recorder)
 
 		usecase, err := NewUnique(injector)
 		if err != nil {
@@ -83,6 +103,32 @@ func (s *UniqueDinosaurTestSuite) SetupSuite() {
 			return
 		}
 		m := model.NewMelee(melee)
+		st, err := model.NewStamina(stamina)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		f, err := model.NewFood(food)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		w, err := model.NewWeight(weight)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		sp, err := model.NewMovementSpeed(speed)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		t, err := model.NewTorpidity(torpidity)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		stats := model.NewDinosaurStats(h, st, model.NewOxygen(oxygen), f, w, m, sp, t, model.NewArmor(armor))
 		healthMultiplier, err := model.NewUniqueMultiplier[model.Health](multiplierHealth)
 		if err != nil {
 			s.T().Error(err)
@@ -93,6 +139,17 @@ func (s *UniqueDinosaurTestSuite) SetupSuite() {
 			s.T().Error(err)
 			return
 		}
+		multipliers := model.NewUniqueMultipliers(
+			*healthMultiplier,
+			model.DefaultUniqueMultiplier[model.Stamina](),
+			model.DefaultUniqueMultiplier[model.Oxygen](),
+			model.DefaultUniqueMultiplier[model.Food](),
+			model.DefaultUniqueMultiplier[model.Weight](),
+			*meleeMultiplier,
+			model.DefaultUniqueMultiplier[model.MovementSpeed](),
+			model.DefaultUniqueMultiplier[model.Torpidity](),
+			model.DefaultUniqueMultiplier[model.Armor](),
+		)
 		variants := model.UniqueVariant{
 			model.NewDinosaurVariant(
 				variantModel.NewVariant(cosmicID, cosmic, singularity),
@@ -105,19 +162,22 @@ func (s *UniqueDinosaurTestSuite) SetupSuite() {
 		}
 		{
 			s.create = service.NewCreateCreature(
-				creatureName, h, m, uniqueName, *healthMultiplier, *meleeMultiplier, [2]variantModel.VariantID{cosmicID, natureID},
+				creatureID, uniqueName, multipliers, []variantModel.VariantID{cosmicID, natureID},
 			)
 		}
+		s.mockVariantRules.On(validate, ctx, []variantModel.VariantID{cosmicID, natureID}).Return(nil)
+		s.mockVariantRules.On(validate, ctx, []variantModel.VariantID{variantsID}).Return(nil)
 		{
 			s.update = service.NewUpdateCreature(
-				creatureID, creatureName, health, melee,
-				uniqueID, uniqueName, *healthMultiplier, *meleeMultiplier,
-				variantsID, [2]variantModel.VariantID{variantsID},
+				creatureID,
+				uniqueID, uniqueName, multipliers,
+				[]variantModel.VariantID{variantsID},
+				version,
 			)
 		}
 		{
-			s.dinoResponse = service.NewResponseDinosaur(creatureID, creatureName, h, m)
-			s.uniqueResponse = service.NewResponseUnique(uniqueID, uniqueName, *healthMultiplier, *meleeMultiplier)
+			s.dinoResponse = service.NewResponseDinosaur(creatureID, creatureName, stats)
+			s.uniqueResponse = service.NewResponseUnique(uniqueID, uniqueName, multipliers)
 			s.variantsResponse = service.NewResponseVariants(variants)
 
 			s.response = service.ResponseCreature{
@@ -125,10 +185,11 @@ func (s *UniqueDinosaurTestSuite) SetupSuite() {
 				ResponseVariants: s.variantsResponse,
 				ResponseUnique:   s.uniqueResponse,
 			}
+			s.dino = model.NewDinosaur(creatureID, creatureName, stats)
 			s.unique = model.NewUniqueDinosaur(
-				model.NewDinosaur(creatureID, creatureName, h, m),
+				model.NewDinosaur(creatureID, creatureName, stats),
 				uniqueID, uniqueName,
-				*healthMultiplier, *meleeMultiplier,
+				multipliers,
 				variants,
 			)
 		}
@@ -140,6 +201,13 @@ const (
 	health           = 1
 	multiplierMelee  = 36.0
 	melee            = 0
+	stamina          = 100
+	oxygen           = 150
+	food             = 450
+	weight           = 50
+	speed            = 100
+	torpidity        = 50
+	armor            = 0
 	cosmicID         = iota
 	natureID
 	creatureID   = 0
@@ -151,6 +219,7 @@ const (
 	nature       = "nature"
 	thunderstorm = "thunderstorm"
 	variantsID   = 0
+	maxVariants  = 2
 )
 
 func (s *UniqueDinosaurTestSuite) TestFind() {
@@ -206,56 +275,65 @@ func (s *UniqueDinosaurTestSuite) TestFind() {
 }
 
 func (s *UniqueDinosaurTestSuite) TestList() {
+	pageRequest, err := logic.NewPageRequest(0, "", logic.Asc)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query, err := service.NewListUniques(pageRequest, service.UniqueSortByName, service.UniqueFilter{})
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	responses := logic.NewPage([]service.ResponseCreature{s.response}, "next")
+	uniques := logic.NewPage([]model.UniqueDinosaur{s.unique}, "next")
+
 	{
 		s.mockUniqueQuery.On(
 			list,
 			ctx,
+			query,
 		).
-			Return(service.ResponseCreatures{s.response}, nil).
+			Return(&responses, nil).
 			Once()
-		r, err := s.usecase.List(ctx)
+		r, err := s.usecase.List(ctx, query)
 		if err != nil {
 			s.T().Error(err)
 			return
 		}
 
-		s.Equal(model.UniqueDinosaurs{s.unique}, r)
+		s.Equal(&uniques, r)
 	}
 	{
 		s.mockUniqueQuery.On(
 			list,
 			ctx,
+			query,
 		).
 			Return(nil, service.IntervalServerError).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 	{
 		s.mockUniqueQuery.On(
 			list,
 			ctx,
+			query,
 		).
 			Return(nil, failure.Wrap(e)).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(errors.Is(err, e))
 	}
 }
 
 func (s *UniqueDinosaurTestSuite) TestInsert() {
+	dinoID := model.DinosaurID(creatureID)
 	{
-		s.mockDinoCommand.On(
-			insert,
-			ctx,
-			s.create.Dino(),
-		).
-			Return(model.DinosaurID(creatureID), nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			insert,
 			ctx,
-			s.create.UniqueDinosaur(creatureID),
+			s.create.UniqueDinosaur(),
 		).
 			Return(model.UniqueDinosaurID(uniqueID), nil).
 			Once()
@@ -283,28 +361,22 @@ func (s *UniqueDinosaurTestSuite) TestInsert() {
 		s.Equal(&s.unique, r)
 	}
 	{
-		s.mockDinoCommand.On(
-			insert,
-			ctx,
-			s.create.Dino(),
-		).
-			Return(nil, e).
-			Once()
+		s.T().Log("参照する種が存在しない場合のテスト")
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(nil, service.NotFound).Once()
+		_, err := s.usecase.Create(ctx, s.create)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(nil, e).Once()
 		_, err := s.usecase.Create(ctx, s.create)
 		s.True(errors.Is(err, e))
 	}
 	{
-		s.mockDinoCommand.On(
-			insert,
-			ctx,
-			s.create.Dino(),
-		).
-			Return(model.DinosaurID(creatureID), nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			insert,
 			ctx,
-			s.create.UniqueDinosaur(creatureID),
+			s.create.UniqueDinosaur(),
 		).
 			Return(nil, e).
 			Once()
@@ -312,17 +384,11 @@ func (s *UniqueDinosaurTestSuite) TestInsert() {
 		s.True(errors.Is(err, e))
 	}
 	{
-		s.mockDinoCommand.On(
-			insert,
-			ctx,
-			s.create.Dino(),
-		).
-			Return(model.DinosaurID(creatureID), nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			insert,
 			ctx,
-			s.create.UniqueDinosaur(creatureID),
+			s.create.UniqueDinosaur(),
 		).
 			Return(model.UniqueDinosaurID(uniqueID), nil).
 			Once()
@@ -336,19 +402,40 @@ func (s *UniqueDinosaurTestSuite) TestInsert() {
 		_, err := s.usecase.Create(ctx, s.create)
 		s.True(errors.Is(err, e))
 	}
+	{
+		s.T().Log("バリアントの組み合わせの規則に違反する場合のテスト")
+		ids := []variantModel.VariantID{natureID, cosmicID}
+		create := service.NewCreateCreature(creatureID, uniqueName, s.create.Multipliers, ids)
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
+		s.mockVariantRules.On(validate, ctx, ids).Return(failure.New(logic.InvalidArgument)).Once()
+		_, err := s.usecase.Create(ctx, create)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.T().Log("バリアント数が上限を超える場合のテスト")
+		create := service.NewCreateCreature(
+			creatureID, uniqueName, s.create.Multipliers,
+			[]variantModel.VariantID{cosmicID, natureID, natureID + 1},
+		)
+		_, err := s.usecase.Create(ctx, create)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.T().Log("バリアントが重複する場合のテスト")
+		create := service.NewCreateCreature(
+			creatureID, uniqueName, s.create.Multipliers, []variantModel.VariantID{cosmicID, cosmicID},
+		)
+		_, err := s.usecase.Create(ctx, create)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
 }
 
 func (s *UniqueDinosaurTestSuite) TestUpdate() {
 	id := s.update.Unique().ID()
-	s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Times(7)
+	dinoID := s.update.DinosaurID()
+	s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Times(8)
 	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockVariantsCommand.On(
 			update,
 			ctx,
@@ -373,24 +460,18 @@ func (s *UniqueDinosaurTestSuite) TestUpdate() {
 		s.Equal(&s.unique, r)
 	}
 	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(service.IntervalServerError).
-			Once()
+		s.T().Log("参照する種が存在しない場合のテスト")
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(nil, service.NotFound).Once()
+		_, err := s.usecase.Update(ctx, s.update)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(nil, service.IntervalServerError).Once()
 		_, err := s.usecase.Update(ctx, s.update)
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			update,
 			ctx,
@@ -402,13 +483,20 @@ func (s *UniqueDinosaurTestSuite) TestUpdate() {
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 	{
-		s.mockDinoCommand.On(
+		s.T().Log("他の更新でバージョンが変わっている場合のテスト")
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
+		s.mockUniqueCommand.On(
 			update,
 			ctx,
-			s.update.Dino(),
+			s.update.Unique(),
 		).
-			Return(nil).
+			Return(service.VersionMismatch).
 			Once()
+		_, err := s.usecase.Update(ctx, s.update)
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
+	{
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			update,
 			ctx,
@@ -426,26 +514,8 @@ func (s *UniqueDinosaurTestSuite) TestUpdate() {
 		_, err := s.usecase.Update(ctx, s.update)
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
-
-	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(e).
-			Once()
-		_, err := s.usecase.Update(ctx, s.update)
-		s.True(errors.Is(err, e))
-	}
 	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			update,
 			ctx,
@@ -457,13 +527,7 @@ func (s *UniqueDinosaurTestSuite) TestUpdate() {
 		s.True(errors.Is(err, e))
 	}
 	{
-		s.mockDinoCommand.On(
-			update,
-			ctx,
-			s.update.Dino(),
-		).
-			Return(nil).
-			Once()
+		s.mockDinoQuery.On(find, ctx, dinoID).Return(&s.dino, nil).Once()
 		s.mockUniqueCommand.On(
 			update,
 			ctx,
@@ -485,17 +549,121 @@ func (s *UniqueDinosaurTestSuite) TestUpdate() {
 
 func (s *UniqueDinosaurTestSuite) TestDelete() {
 	id := model.UniqueDinosaurID(uniqueID)
-	s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Times(3)
+	s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Times(4)
+	{
+		s.mockUniqueCommand.On("Delete", ctx, id, version).Return(nil).Once()
+		s.Nil(s.usecase.Delete(ctx, id, version))
+	}
 	{
-		s.mockUniqueCommand.On("Delete", ctx, id).Return(nil).Once()
-		s.Nil(s.usecase.Delete(ctx, id))
+		s.mockUniqueCommand.On("Delete", ctx, id, version).Return(service.IntervalServerError).Once()
+		s.True(failure.Is(s.usecase.Delete(ctx, id, version), logic.IntervalServerError))
 	}
 	{
-		s.mockUniqueCommand.On("Delete", ctx, id).Return(service.IntervalServerError).Once()
-		s.True(failure.Is(s.usecase.Delete(ctx, id), logic.IntervalServerError))
+		s.mockUniqueCommand.On("Delete", ctx, id, version).Return(e).Once()
+		s.True(errors.Is(s.usecase.Delete(ctx, id, version), e))
+	}
+	{
+		s.T().Log("他の更新でバージョンが変わっている場合のテスト")
+		s.mockUniqueCommand.On("Delete", ctx, id, version).Return(service.VersionMismatch).Once()
+		s.True(failure.Is(s.usecase.Delete(ctx, id, version), logic.PreconditionFailed))
+	}
+}
+
+func (s *UniqueDinosaurTestSuite) TestRestore() {
+	id := model.UniqueDinosaurID(uniqueID)
+	{
+		s.mockUniqueCommand.On("Restore", ctx, id).Return(nil).Once()
+		s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Once()
+		unique, err := s.usecase.Restore(ctx, id)
+		s.Nil(err)
+		s.Equal(s.response.ToUniqueDinosaur(), *unique)
 	}
 	{
-		s.mockUniqueCommand.On("Delete", ctx, id).Return(e).Once()
-		s.True(errors.Is(s.usecase.Delete(ctx, id), e))
+		s.mockUniqueCommand.On("Restore", ctx, id).Return(service.NotFound).Once()
+		_, err := s.usecase.Restore(ctx, id)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+	{
+		s.T().Log("種かバリアントがゴミ箱にある場合のテスト")
+		s.mockUniqueCommand.On("Restore", ctx, id).Return(service.ParentDeleted).Once()
+		_, err := s.usecase.Restore(ctx, id)
+		s.True(failure.Is(err, logic.Conflict))
+	}
+}
+
+func (s *UniqueDinosaurTestSuite) TestDetachVariants() {
+	listByVariant := func(id variantModel.VariantID) service.ListUniques {
+		page, err := logic.NewPageRequest(logic.MaxPageLimit, "", logic.Asc)
+		s.Require().NoError(err)
+		query, err := service.NewListUniques(page, service.UniqueSortByID, service.UniqueFilter{VariantID: &id})
+		s.Require().NoError(err)
+		return query
+	}
+	responses := logic.NewPage([]service.ResponseCreature{s.response}, "")
+
+	{
+		s.T().Log("外したバリアント以外を残してユニークを更新するかテスト")
+		detached := service.NewUpdateCreature(
+			creatureID, uniqueID, uniqueName, s.unique.Multipliers(), []variantModel.VariantID{natureID}, s.unique.Version(),
+		)
+		s.mockUniqueQuery.On(list, ctx, listByVariant(cosmicID)).Return(&responses, nil).Once()
+		s.mockUniqueQuery.On(find, ctx, model.UniqueDinosaurID(uniqueID)).Return(&s.response, nil).Twice()
+		s.mockDinoQuery.On(find, ctx, model.DinosaurID(creatureID)).Return(&s.dino, nil).Once()
+		s.mockVariantRules.On(validate, ctx, []variantModel.VariantID{natureID}).Return(nil).Once()
+		s.mockUniqueCommand.On(update, ctx, detached.Unique()).Return(nil).Once()
+		s.mockVariantsCommand.On(update, ctx, detached.Variants()).Return(nil).Once()
+
+		s.Nil(s.usecase.DetachVariants(ctx, []variantModel.VariantID{cosmicID}))
+		s.mockVariantsCommand.AssertCalled(s.T(), update, ctx, detached.Variants())
+	}
+	{
+		s.T().Log("バリアントが無くなるユニークがある場合は外さずにConflictになるかテスト")
+		s.mockUniqueQuery.On(list, ctx, listByVariant(cosmicID)).Return(&responses, nil).Once()
+		s.mockUniqueQuery.On(list, ctx, listByVariant(natureID)).Return(&responses, nil).Once()
+
+		err := s.usecase.DetachVariants(ctx, []variantModel.VariantID{cosmicID, natureID})
+		s.True(failure.Is(err, logic.Conflict))
+		var dependents logic.Dependents
+		s.True(errors.As(err, &dependents))
+		s.Equal(logic.Dependents{{Entity: logic.AuditUnique, ID: uniqueID, Name: uniqueName}}, dependents)
+	}
+}
+
+func (s *UniqueDinosaurTestSuite) TestStats() {
+	id := model.UniqueDinosaurID(uniqueID)
+	level, err := model.NewLevel(1)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	{
+		s.T().Log("成長値を取得してレベル毎のステータスを計算できるかテスト")
+		s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Once()
+		s.mockStatGrowth.On("FindStatGrowth", ctx, model.DinosaurID(creatureID)).
+			Return(model.SpeciesStatGrowth{}, nil).Once()
+
+		stats, err := s.usecase.Stats(ctx, id, level, nil)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+		s.Equal(s.unique.Health(), stats.Health())
+		s.Equal(level, stats.Level())
+	}
+	{
+		s.T().Log("ユニークが存在しない場合のテスト")
+		s.mockUniqueQuery.On(find, ctx, model.UniqueDinosaurID(notExistUniqueID)).
+			Return(nil, service.NotFound).Once()
+
+		_, err := s.usecase.Stats(ctx, model.UniqueDinosaurID(notExistUniqueID), level, nil)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+	{
+		s.T().Log("成長値の取得に失敗した場合のテスト")
+		s.mockUniqueQuery.On(find, ctx, id).Return(&s.response, nil).Once()
+		s.mockStatGrowth.On("FindStatGrowth", ctx, model.DinosaurID(creatureID)).
+			Return(nil, service.IntervalServerError).Once()
+
+		_, err := s.usecase.Stats(ctx, id, level, nil)
+		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 }
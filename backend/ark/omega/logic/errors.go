@@ -3,13 +3,90 @@ package logic
 // マイクロサービスとして分離を考えると、モジュール毎に定義するのが無難?
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/morikuni/failure"
 )
 
 // レスポンスに含めることを考えると、ドメインサービスで定義したエラーとは異なるステータスコードを意識したエラーを定義
+// Conflictは一意制約や参照されているレコードの削除など既存のデータとの矛盾、
+// UnprocessableEntityは存在しないレコードの参照や値の制約違反などリクエストの内容を処理できないことを表す
+// PreconditionFailedは更新時に指定されたバージョンが古いこと、PreconditionRequiredはバージョンが指定されていないことを表す
+// Unauthorizedはトークンが無いか不正なこと、Forbiddenはトークンの権限が足りないことを表す
 var (
-	InvalidArgument     failure.StringCode = "InvalidArgument"
-	NotFound            failure.StringCode = "NotFound"
-	Forbidden           failure.StringCode = "Forbidden"
-	IntervalServerError failure.StringCode = "IntervalServerError"
+	InvalidArgument      failure.StringCode = "InvalidArgument"
+	NotFound             failure.StringCode = "NotFound"
+	Unauthorized         failure.StringCode = "Unauthorized"
+	Forbidden            failure.StringCode = "Forbidden"
+	Conflict             failure.StringCode = "Conflict"
+	UnprocessableEntity  failure.StringCode = "UnprocessableEntity"
+	PreconditionFailed   failure.StringCode = "PreconditionFailed"
+	PreconditionRequired failure.StringCode = "PreconditionRequired"
+	IntervalServerError  failure.StringCode = "IntervalServerError"
 )
+
+// WrapInvalidArgument ドメインモデルの検証エラーを、その理由をメッセージとして持つInvalidArgumentにする
+func WrapInvalidArgument(err error) error {
+	return failure.Translate(err, InvalidArgument, failure.Message(err.Error()))
+}
+
+// ConstraintKind 違反したデータベースの制約の種類
+type ConstraintKind string
+
+const (
+	ConstraintUnique     ConstraintKind = "unique"
+	ConstraintForeignKey ConstraintKind = "foreign_key"
+	ConstraintCheck      ConstraintKind = "check"
+	ConstraintNotNull    ConstraintKind = "not_null"
+)
+
+// ConstraintViolation レスポンスに違反した制約と項目を含めるため、ストレージのエラーから変換して保持する
+type ConstraintViolation struct {
+	Kind       ConstraintKind
+	Constraint string
+	Field      string
+	Err        error
+}
+
+func (e *ConstraintViolation) Error() string {
+	return fmt.Sprintf("%s constraint %q violated (field: %q): %v", e.Kind, e.Constraint, e.Field, e.Err)
+}
+
+func (e *ConstraintViolation) Unwrap() error { return e.Err }
+
+// FieldError リクエストの項目毎の検証エラー。Paramには最大文字数などの検証の条件が入る
+type FieldError struct {
+	Field string
+	Rule  string
+	Param string
+}
+
+// FieldErrors レスポンスに全ての項目の検証エラーを含めるため、まとめて保持する
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, f := range e {
+		messages = append(messages, fmt.Sprintf("%s: %s", f.Field, f.Rule))
+	}
+	return "invalid fields: " + strings.Join(messages, ", ")
+}
+
+// Dependent 削除しようとした行を参照している行
+type Dependent struct {
+	Entity AuditEntity
+	ID     int
+	Name   string
+}
+
+// Dependents 削除を拒否した理由としてレスポンスに参照している行を含めるため、まとめて保持する
+type Dependents []Dependent
+
+func (e Dependents) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, d := range e {
+		messages = append(messages, fmt.Sprintf("%s %d", d.Entity, d.ID))
+	}
+	return "referenced by " + strings.Join(messages, ", ")
+}
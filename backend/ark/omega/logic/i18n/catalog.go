@@ -0,0 +1,147 @@
+package i18n
+
+// messages 日本語の書式をキーとした各言語の書式。登録されていない言語とメッセージは日本語のまま返す
+var messages = map[Locale]map[string]string{
+	English: {
+		// 生物
+		"体力0は許容されない不正な値です":            "health must be greater than 0",
+		"スタミナ0は許容されない不正な値です":          "stamina must be greater than 0",
+		"食料0は許容されない不正な値です":            "food must be greater than 0",
+		"重量0は許容されない不正な値です":            "weight must be greater than 0",
+		"移動速度0%は許容されない不正な値です":         "movement speed must be greater than 0%",
+		"移動速度は%d%%以下にしてください":          "movement speed must be less than or equal to %d%%",
+		"気絶値0は許容されない不正な値です":           "torpidity must be greater than 0",
+		"生物の名前を指定してください":              "dinosaur name is required",
+		"生物の名前は%d文字以下にしてください":         "dinosaur name must be at most %d characters",
+		"%qは不明なステータスです":               "unknown stat %q",
+		"レベル毎の成長値は0以上にしてください":         "stat growth per level must be greater than or equal to 0",
+		"レベルは1以上にしてください":              "level must be greater than or equal to 1",
+		"テイム効果は0から1の間にしてください":         "taming effectiveness must be between 0 and 1",
+		"刷り込みは0から1の間にしてください":          "imprint must be between 0 and 1",
+		"ユニーク生物のステータス倍率は0より大きくしてください": "unique stat multipliers must be greater than 0",
+		"バリアント数の上限は1以上にしてください":        "maximum number of variants must be at least 1",
+		"バリアントを1つ以上指定してください":          "at least one variant is required",
+		"バリアントは%d個以下にしてください":          "at most %d variants are allowed",
+		"バリアント%dが重複しています":             "variant %d is duplicated",
+		"種%qは既に存在します":                 "dinosaur %q already exists",
+		"種%dは存在しません":                  "dinosaur %d does not exist",
+		"種はユニークから参照されています":            "dinosaur is referenced by uniques",
+		"ユニークの種か付与したバリアントがゴミ箱にあります":   "dinosaur or variants of the unique are in the trash",
+		"バリアントが残らないユニークがあります":         "uniques would have no variants left",
+		"ステータス%qが重複しています":             "stat %q is duplicated",
+
+		// バリアント
+		"説明文が空です":                           "description is empty",
+		"説明文は500文字以内にしてください":                "description must be at most 500 characters",
+		"%qは不明な規則です":                        "unknown rule %q",
+		"グループの規則はグループのみを指定してください":           "group rules must specify only a group",
+		"バリアントの規則は2つのバリアントのみを指定してください":      "variant rules must specify exactly two variants",
+		"異なるバリアントを指定してください":                 "specify two different variants",
+		"規則%d: グループ%dのバリアントは1つまでです":         "rule %d: group %d allows only one variant",
+		"規則%d: バリアント%dはバリアント%dと併用できません":     "rule %d: variant %d is incompatible with variant %d",
+		"規則%d: バリアント%dにはバリアント%dが必要です":       "rule %d: variant %d requires variant %d",
+		"バリアント%dは存在しません":                    "variant %d does not exist",
+		"バリアントはユニークから参照されています":              "variant is referenced by uniques",
+		"バリアントグループ%dは存在しません":                "variant group %d does not exist",
+		"バリアントグループはバリアントから参照されています":         "variant group is referenced by variants",
+		"バリアントグループがゴミ箱にあります":                "variant group is in the trash",
+		"cascadeとreassign_toは同時に指定できません":    "cascade and reassign_to cannot be specified together",
+		"reassign_toには別のバリアントグループを指定してください": "reassign_to must be another variant group",
+
+		// 検索
+		"検索語が空です":            "search keyword is empty",
+		"検索語は100文字以内にしてください": "search keyword must be at most 100 characters",
+		"不正な検索対象の種類です":       "invalid search target type",
+
+		// 表示名
+		"%qは対応していない言語です":    "unsupported locale %q",
+		"%qは不明な表示名の対象です":    "unknown display name target %q",
+		"表示名を指定してください":      "display name is required",
+		"表示名は%d文字以下にしてください": "display name must be at most %d characters",
+
+		// 認証
+		"%qは不明な権限です":                             "unknown role %q",
+		"トークンの名前を指定してください":                       "token name is required",
+		"トークンの名前は%d文字以下にしてください":                  "token name must be at most %d characters",
+		"認証が必要です":                                "authentication required",
+		"%sの権限が必要です":                             "%s role required",
+		"APIトークンが不正です":                           "invalid token",
+		"AuthorizationヘッダーにはBearerトークンを指定してください": "authorization header must be a bearer token",
+
+		// 変更の提案
+		"%qは不明な提案の対象です":      "unknown proposal target %q",
+		"%qは不明な提案の状態です":      "unknown proposal status %q",
+		"変更はオブジェクトで指定してください": "changes must be an object",
+		"コメントは%d文字以下にしてください": "comment must be at most %d characters",
+		"%s %dは存在しません":       "%s %d does not exist",
+		"%s %dは削除されています":     "%s %d has been deleted",
+		"%sは提案できません":         "%s cannot be proposed",
+		"%qは不明な項目です":         "unknown field %q",
+		"提案に変更がありません":        "proposal does not change anything",
+		"変更の値が不正です":          "invalid changes",
+		"提案の提出後に項目が変更されています": "fields were changed after the proposal was submitted",
+		"提案%dは審査済みです":        "proposal %d has already been reviewed",
+
+		// 一括の取り込み
+		"%qは不明な取り込みの対象です":                      "unknown import type %q",
+		"バリアントは「グループ名/バリアント名」の形式で指定してください: %q": "variants must be in the form \"group/variant\": %q",
+		"CSVが不正です": "invalid csv",
+		"取り込む内容に競合があります。ドライランで内容を確認してください": "import contains conflicts; run it as a dry run to see the report",
+
+		// ゴミ箱
+		"%qは不明な種類です": "unknown type %q",
+
+		// 一覧と更新のリクエスト
+		"リクエストが不正です":                 "invalid request",
+		"カーソルが不正です":                  "invalid cursor",
+		"カーソルは並び替えのキー%qで発行されたものです":   "cursor was issued for sort key %q",
+		"件数は%d以下にしてください":             "limit must be less than or equal to %d",
+		"%qは不明な並び順です":                "unknown order %q",
+		"%qは不明な並び替えのキーです":            "unknown sort key %q",
+		"ETagを指定したIf-Matchヘッダーが必要です": "If-Match header with the ETag is required",
+		"If-Match %sが一致しません":         "If-Match %s does not match",
+		"バージョン%dは不正です":               "invalid version %d",
+
+		// 画面
+		"ユニーク・バリアント・グループを検索": "Search uniques, variants and groups",
+		"検索":        "Search",
+		"ユニーク生物":    "Unique creatures",
+		"ユニーク":      "Unique",
+		"バリアント":     "Variants",
+		"グループ":      "Group",
+		"バリアントグループ": "Variant group",
+		"すべて":       "All",
+		"並び替え":      "Sort",
+		"名前順":       "Name",
+		"体力倍率順":     "Health multiplier",
+		"攻撃倍率順":     "Damage multiplier",
+		"更新順":       "Recently updated",
+		"絞り込みを解除":   "Clear filters",
+		"この一覧を絞り込む": "Filter this list",
+		"ユニーク名":     "Unique name",
+		"元の生物":      "Base creature",
+		"体力倍率":      "Health",
+		"攻撃倍率":      "Damage",
+		"該当するユニーク生物はありません": "No unique creatures found",
+		"次のページ":  "Next page",
+		"ステータス":  "Stats",
+		"基礎値":    "Base",
+		"倍率":     "Multiplier",
+		"ユニークの値": "Unique",
+		"体力":     "Health",
+		"スタミナ":   "Stamina",
+		"酸素":     "Oxygen",
+		"食料":     "Food",
+		"重量":     "Weight",
+		"近接攻撃":   "Melee damage",
+		"移動速度":   "Movement speed",
+		"気絶値":    "Torpidity",
+		"防御":     "Armor",
+		"このバリアントを持つユニーク生物": "Unique creatures with this variant",
+		"すべて表示":         "Show all",
+		"このグループのユニーク生物": "Unique creatures in this group",
+		"バリアントはありません":   "No variants",
+		"一致するものはありません":  "No results",
+		"ユニーク生物の一覧へ戻る":  "Back to unique creatures",
+	},
+}
@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// sourceRoot go.modのあるディレクトリ。cmdも含めて全てのソースを対象にする
+const sourceRoot = "../../../.."
+
+// templateText テンプレートの{{t .Locale "..."}}で翻訳する文言
+var templateText = regexp.MustCompile(`\{\{t \$?\.Locale "([^"]+)"`)
+
+// keyArgs 翻訳のキーを渡す関数と、キーの引数の位置
+var keyArgs = map[string]int{"Errorf": 0, "Wrapf": 1, "Text": 1}
+
+// literalKeys Errorf、Wrapf、Textに文字列リテラルで渡した翻訳のキーを位置と共に集める
+func literalKeys(t *testing.T) map[string]string {
+	t.Helper()
+	keys := map[string]string{}
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(sourceRoot, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != sourceRoot && strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case strings.HasSuffix(path, ".html"):
+			body, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			for _, m := range templateText.FindAllStringSubmatch(string(body), -1) {
+				keys[m[1]] = path
+			}
+		case strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go"):
+			file, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				var name string
+				switch fn := call.Fun.(type) {
+				case *ast.SelectorExpr:
+					if x, ok := fn.X.(*ast.Ident); ok && x.Name == "i18n" {
+						name = fn.Sel.Name
+					}
+				case *ast.Ident:
+					if file.Name.Name == "i18n" {
+						name = fn.Name
+					}
+				}
+				index, ok := keyArgs[name]
+				if !ok || len(call.Args) <= index {
+					return true
+				}
+				lit, ok := call.Args[index].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				key, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				keys[key] = fset.Position(lit.Pos()).String()
+				return true
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func Test_Catalog(t *testing.T) {
+	t.Run("ソースとテンプレートで翻訳する全ての文言が登録されているかのテスト", func(t *testing.T) {
+		keys := literalKeys(t)
+		if len(keys) == 0 {
+			t.Fatal("翻訳する文言が見つかりません")
+		}
+		for key, pos := range keys {
+			for locale, catalog := range messages {
+				if _, ok := catalog[key]; !ok {
+					t.Errorf("%s: %qの%sの翻訳が登録されていません", pos, key, locale)
+				}
+			}
+		}
+	})
+}
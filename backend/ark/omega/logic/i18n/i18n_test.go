@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func Test_MatchAcceptLanguage(t *testing.T) {
+	t.Run("Accept-Languageの言語の選択テスト", func(t *testing.T) {
+		cases := map[string]Locale{
+			"":                        DefaultLocale,
+			"en":                      English,
+			"en-US,en;q=0.9":          English,
+			"ja-JP":                   Japanese,
+			"fr-FR,en;q=0.8,ja;q=0.5": English,
+			"de":                      DefaultLocale,
+			"invalid;;q=language-tag": DefaultLocale,
+		}
+		for header, want := range cases {
+			if got := MatchAcceptLanguage(header); got != want {
+				t.Errorf("%qの言語が%sになっていません %s", header, want, got)
+			}
+		}
+	})
+
+	t.Run("contextの言語のテスト", func(t *testing.T) {
+		if got := LocaleOf(context.Background()); got != DefaultLocale {
+			t.Errorf("言語が無いcontextで既定の言語になっていません %s", got)
+		}
+		if got := LocaleOf(SetLocale(context.Background(), English)); got != English {
+			t.Errorf("contextに設定した言語になっていません %s", got)
+		}
+	})
+}
+
+func Test_Localize(t *testing.T) {
+	t.Run("エラーメッセージの翻訳テスト", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", Errorf("バリアントは%d個以下にしてください", 2))
+		if got, ok := Localize(English, err); !ok || got != "at most 2 variants are allowed" {
+			t.Errorf("英語に翻訳されていません %s", got)
+		}
+		if got, ok := Localize(Japanese, err); !ok || got != "バリアントは2個以下にしてください" {
+			t.Errorf("日本語のメッセージになっていません %s", got)
+		}
+	})
+
+	t.Run("まとめたエラーの翻訳テスト", func(t *testing.T) {
+		err := errors.Join(Errorf("体力0は許容されない不正な値です"), errors.New("other"))
+		if got, ok := Localize(English, err); !ok || got != "health must be greater than 0\nother" {
+			t.Errorf("まとめたエラーが翻訳されていません %s", got)
+		}
+	})
+
+	t.Run("翻訳できないエラーのテスト", func(t *testing.T) {
+		if _, ok := Localize(English, errors.New("other")); ok {
+			t.Error("翻訳できないエラーが翻訳されています")
+		}
+	})
+}
+
+func Test_Text(t *testing.T) {
+	t.Run("画面の文言の翻訳テスト", func(t *testing.T) {
+		if got := Text(English, "ユニーク生物"); got != "Unique creatures" {
+			t.Errorf("英語に翻訳されていません %s", got)
+		}
+		if got := Text(Japanese, "ユニーク生物"); got != "ユニーク生物" {
+			t.Errorf("日本語の文言になっていません %s", got)
+		}
+		if got := Text(English, "未登録の文言"); got != "未登録の文言" {
+			t.Errorf("登録されていない文言がそのまま返されていません %s", got)
+		}
+	})
+}
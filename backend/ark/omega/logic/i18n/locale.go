@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// Locale エラーメッセージと表示名の言語。元のメッセージは日本語で記述しているため日本語を既定とする
+type Locale string
+
+const (
+	Japanese Locale = "ja"
+	English  Locale = "en"
+
+	DefaultLocale = Japanese
+)
+
+// SupportedLocales Accept-Languageとの照合に用いるため、既定の言語を先頭にする
+var SupportedLocales = []Locale{Japanese, English}
+
+var matcher = language.NewMatcher([]language.Tag{language.Japanese, language.English})
+
+func (l Locale) Value() string { return string(l) }
+
+func NewLocale(value string) (Locale, error) {
+	for _, l := range SupportedLocales {
+		if Locale(value) == l {
+			return l, nil
+		}
+	}
+	return "", Errorf("%qは対応していない言語です", value)
+}
+
+// MatchAcceptLanguage Accept-Languageの優先順位に従って対応する言語を選ぶ。一致しなければ既定の言語にする
+func MatchAcceptLanguage(header string) Locale {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return SupportedLocales[index]
+}
+
+type localeKey struct{}
+
+func SetLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, l)
+}
+
+// LocaleOf リクエスト以外から呼ばれた場合は既定の言語を返す
+func LocaleOf(ctx context.Context) Locale {
+	if l, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return l
+	}
+	return DefaultLocale
+}
@@ -0,0 +1,66 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error ドメインモデルの検証エラー。元の日本語の書式を翻訳のキーとして保持し、要求された言語で組み立て直す
+type Error struct {
+	format string
+	args   []any
+	cause  error
+}
+
+func Errorf(format string, args ...any) error {
+	return &Error{format: format, args: args}
+}
+
+// Wrapf 参照元や項目毎のエラーなど、レスポンスに含める詳細をcauseとして保持する。翻訳するのはformatのみで、causeはerrors.Asで取り出す
+func Wrapf(cause error, format string, args ...any) error {
+	return &Error{format: format, args: args, cause: cause}
+}
+
+func (e *Error) Error() string { return fmt.Sprintf(e.format, e.args...) }
+
+func (e *Error) Unwrap() error { return e.cause }
+
+func (e *Error) Localize(l Locale) string {
+	if format, ok := messages[l][e.format]; ok {
+		return fmt.Sprintf(format, e.args...)
+	}
+	return e.Error()
+}
+
+// Text 画面の見出しなど書式を持たない文言を翻訳する。登録されていない文言は日本語のまま返す
+func Text(l Locale, text string) string {
+	if translated, ok := messages[l][text]; ok {
+		return translated
+	}
+	return text
+}
+
+// Localize エラーの連鎖から翻訳できるエラーを探す。errors.Joinでまとめたエラーはそれぞれを翻訳して改行で繋げる
+func Localize(l Locale, err error) (string, bool) {
+	for err != nil {
+		switch e := err.(type) {
+		case *Error:
+			return e.Localize(l), true
+		case interface{ Unwrap() []error }:
+			var localized bool
+			lines := make([]string, 0, len(e.Unwrap()))
+			for _, inner := range e.Unwrap() {
+				line, ok := Localize(l, inner)
+				if !ok {
+					line = inner.Error()
+				}
+				localized = localized || ok
+				lines = append(lines, line)
+			}
+			return strings.Join(lines, "\n"), localized
+		}
+		err = errors.Unwrap(err)
+	}
+	return "", false
+}
@@ -0,0 +1,136 @@
+package logic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+const (
+	DefaultPageLimit uint = 50
+	MaxPageLimit     uint = 200
+)
+
+type SortOrder string
+
+const (
+	Asc  SortOrder = "asc"
+	Desc SortOrder = "desc"
+)
+
+// Cursor キーセットページネーションの位置。クライアントには不透明な文字列として扱わせる
+type Cursor string
+
+func (c Cursor) Value() string { return string(c) }
+
+// CursorPosition 最後に返したレコードのソートキーの値とid。idは同値のソートキーを区別するために用いる
+type CursorPosition struct {
+	Key   string `json:"k"`
+	Value any    `json:"v"`
+	ID    int    `json:"id"`
+}
+
+func NewCursor(key string, value any, id int) (Cursor, error) {
+	b, err := json.Marshal(CursorPosition{Key: key, Value: value, ID: id})
+	if err != nil {
+		return "", err
+	}
+	return Cursor(base64.RawURLEncoding.EncodeToString(b)), nil
+}
+
+// Position 空のカーソルは先頭ページとしてnilを返す。異なるソートキーで発行されたカーソルは不正とする
+func (c Cursor) Position(key string) (*CursorPosition, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return nil, failure.Translate(i18n.Errorf("カーソルが不正です"), InvalidArgument)
+	}
+	var position CursorPosition
+	if err = json.Unmarshal(b, &position); err != nil {
+		return nil, failure.Translate(i18n.Errorf("カーソルが不正です"), InvalidArgument)
+	}
+	if position.Key != key {
+		return nil, failure.Translate(i18n.Errorf("カーソルは並び替えのキー%qで発行されたものです", position.Key), InvalidArgument)
+	}
+	return &position, nil
+}
+
+type PageRequest struct {
+	limit  uint
+	cursor Cursor
+	order  SortOrder
+}
+
+// NewPageRequest limitが0の場合はデフォルト値、orderが空の場合は昇順とする
+func NewPageRequest(limit uint, cursor Cursor, order SortOrder) (PageRequest, error) {
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return PageRequest{}, failure.Translate(i18n.Errorf("件数は%d以下にしてください", MaxPageLimit), InvalidArgument)
+	}
+
+	switch order {
+	case "":
+		order = Asc
+	case Asc, Desc:
+	default:
+		return PageRequest{}, failure.Translate(i18n.Errorf("%qは不明な並び順です", order), InvalidArgument)
+	}
+
+	return PageRequest{limit: limit, cursor: cursor, order: order}, nil
+}
+
+func (p PageRequest) Limit() uint      { return p.limit }
+func (p PageRequest) Cursor() Cursor   { return p.cursor }
+func (p PageRequest) Order() SortOrder { return p.order }
+
+type Page[T any] struct {
+	items      []T
+	nextCursor Cursor
+}
+
+func NewPage[T any](items []T, nextCursor Cursor) Page[T] {
+	return Page[T]{items: items, nextCursor: nextCursor}
+}
+
+func (p Page[T]) Items() []T         { return p.items }
+func (p Page[T]) NextCursor() Cursor { return p.nextCursor }
+
+// MapPage ページの情報を保ったまま要素の型を変換する
+func MapPage[T, R any](p Page[T], fn func(T) R) Page[R] {
+	items := make([]R, 0, len(p.items))
+	for _, item := range p.items {
+		items = append(items, fn(item))
+	}
+	return NewPage(items, p.nextCursor)
+}
+
+// ListAll 最大の件数で昇順のページを取得し、最終ページまでnext_cursorを辿る
+func ListAll[T any](list func(PageRequest) (*Page[T], error)) ([]T, error) {
+	var (
+		items  []T
+		cursor Cursor
+	)
+	for {
+		page, err := NewPageRequest(MaxPageLimit, cursor, Asc)
+		if err != nil {
+			return nil, err
+		}
+		result, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items()...)
+		if result.NextCursor() == "" {
+			return items, nil
+		}
+		cursor = result.NextCursor()
+	}
+}
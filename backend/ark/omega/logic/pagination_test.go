@@ -0,0 +1,94 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/morikuni/failure"
+)
+
+func Test_Cursor(t *testing.T) {
+	t.Run("カーソルの生成と復元ができるか", func(t *testing.T) {
+		cursor, err := NewCursor("name", "Kenny", 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		position, err := cursor.Position("name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if position.Value != "Kenny" || position.ID != 3 {
+			t.Errorf("復元したカーソルの位置が異なります %+v", position)
+		}
+	})
+
+	t.Run("空のカーソルは先頭ページとして扱われるか", func(t *testing.T) {
+		position, err := Cursor("").Position("name")
+		if err != nil || position != nil {
+			t.Errorf("空のカーソルで位置が返されました %+v %v", position, err)
+		}
+	})
+
+	t.Run("異なるソートキーのカーソルはエラーになるか", func(t *testing.T) {
+		cursor, err := NewCursor("name", "Kenny", 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = cursor.Position("health"); !failure.Is(err, InvalidArgument) {
+			t.Errorf("異なるソートキーのカーソルでエラーになっていません %v", err)
+		}
+	})
+
+	t.Run("不正な文字列のカーソルはエラーになるか", func(t *testing.T) {
+		if _, err := Cursor("!!!").Position("name"); !failure.Is(err, InvalidArgument) {
+			t.Errorf("不正なカーソルでエラーになっていません %v", err)
+		}
+	})
+}
+
+func Test_PageRequest(t *testing.T) {
+	t.Run("省略時にデフォルト値が用いられるか", func(t *testing.T) {
+		page, err := NewPageRequest(0, "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page.Limit() != DefaultPageLimit || page.Order() != Asc {
+			t.Errorf("デフォルト値になっていません %+v", page)
+		}
+	})
+
+	t.Run("上限を超えるlimitはエラーになるか", func(t *testing.T) {
+		if _, err := NewPageRequest(MaxPageLimit+1, "", Asc); !failure.Is(err, InvalidArgument) {
+			t.Errorf("上限を超えるlimitでエラーになっていません %v", err)
+		}
+	})
+
+	t.Run("不正な並び順はエラーになるか", func(t *testing.T) {
+		if _, err := NewPageRequest(10, "", "random"); !failure.Is(err, InvalidArgument) {
+			t.Errorf("不正な並び順でエラーになっていません %v", err)
+		}
+	})
+}
+
+func Test_ListAll(t *testing.T) {
+	t.Run("最終ページまでカーソルを辿って全件を返すか", func(t *testing.T) {
+		pages := map[Cursor]Page[int]{
+			"":       NewPage([]int{1, 2}, "second"),
+			"second": NewPage([]int{3}, ""),
+		}
+		var requested []Cursor
+		items, err := ListAll(func(page PageRequest) (*Page[int], error) {
+			requested = append(requested, page.Cursor())
+			if page.Limit() != MaxPageLimit {
+				t.Errorf("最大の件数で取得していません %d", page.Limit())
+			}
+			p := pages[page.Cursor()]
+			return &p, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 3 || len(requested) != 2 || requested[1] != "second" {
+			t.Errorf("全件を返していません %v %v", items, requested)
+		}
+	})
+}
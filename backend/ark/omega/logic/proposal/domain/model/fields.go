@@ -0,0 +1,66 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// Fields 対象の状態を更新のリクエストと同じ項目名で保持する。値はJSONのまま扱う
+type Fields map[string]json.RawMessage
+
+func NewFields(raw json.RawMessage) (Fields, error) {
+	var fields Fields
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return nil, i18n.Errorf("変更はオブジェクトで指定してください")
+	}
+	return fields, nil
+}
+
+// Keys 差分の表示順を一定にするため、項目名の順で返す
+func (f Fields) Keys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Merge changesの項目で上書きした状態を返す。元の状態は変更しない
+func (f Fields) Merge(changes Fields) Fields {
+	merged := make(Fields, len(f)+len(changes))
+	for k, v := range f {
+		merged[k] = v
+	}
+	for k, v := range changes {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Diff otherのうち、値が異なる項目のみを返す
+func (f Fields) Diff(other Fields) Fields {
+	diff := Fields{}
+	for k, v := range other {
+		if !f.Equal(k, v) {
+			diff[k] = v
+		}
+	}
+	return diff
+}
+
+// Equal 空白やキーの順序の違いを無視するため、デコードした値で比較する
+func (f Fields) Equal(key string, value json.RawMessage) bool {
+	current, ok := f[key]
+	if !ok {
+		return false
+	}
+	var a, b any
+	if json.Unmarshal(current, &a) != nil || json.Unmarshal(value, &b) != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+type ProposalID int
+
+func (id ProposalID) Value() int { return int(id) }
+
+// Target 変更を提案できるカタログの対象
+type Target string
+
+const (
+	TargetUnique  Target = "unique"
+	TargetVariant Target = "variant"
+	TargetGroup   Target = "group"
+)
+
+func (t Target) Value() string { return string(t) }
+
+func NewTarget(value string) (Target, error) {
+	switch t := Target(value); t {
+	case TargetUnique, TargetVariant, TargetGroup:
+		return t, nil
+	default:
+		return "", i18n.Errorf("%qは不明な提案の対象です", value)
+	}
+}
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+func (s Status) Value() string { return string(s) }
+
+func NewStatus(value string) (Status, error) {
+	switch s := Status(value); s {
+	case StatusPending, StatusApproved, StatusRejected:
+		return s, nil
+	default:
+		return "", i18n.Errorf("%qは不明な提案の状態です", value)
+	}
+}
+
+const maxCommentLength = 500
+
+// Comment 提案の根拠や、却下の理由などのレビューの所感
+type Comment string
+
+func (c Comment) Value() string { return string(c) }
+
+func NewComment(value string) (Comment, error) {
+	value = strings.TrimSpace(value)
+	if utf8.RuneCountInString(value) > maxCommentLength {
+		return "", i18n.Errorf("コメントは%d文字以下にしてください", maxCommentLength)
+	}
+	return Comment(value), nil
+}
+
+// Snapshot 対象の現在の状態と、更新時の競合の検出に用いるバージョン
+type Snapshot struct {
+	fields  Fields
+	version logic.Version
+}
+
+func NewSnapshot(fields Fields, version logic.Version) Snapshot {
+	return Snapshot{fields: fields, version: version}
+}
+
+func (s Snapshot) Fields() Fields         { return s.fields }
+func (s Snapshot) Version() logic.Version { return s.version }
+
+// Review 承認または却下した利用者と日時。審査待ちの提案ではnil
+type Review struct {
+	reviewer   logic.Actor
+	comment    Comment
+	reviewedAt time.Time
+}
+
+func NewReview(reviewer logic.Actor, comment Comment, reviewedAt time.Time) *Review {
+	return &Review{reviewer: reviewer, comment: comment, reviewedAt: reviewedAt}
+}
+
+func (r Review) Reviewer() logic.Actor { return r.reviewer }
+func (r Review) Comment() Comment      { return r.comment }
+func (r Review) ReviewedAt() time.Time { return r.reviewedAt }
+
+// Proposal 提出時の対象の状態(before)に対する変更の提案。changesには値の異なる項目のみを持つ
+type Proposal struct {
+	id          ProposalID
+	target      Target
+	targetID    int
+	baseVersion logic.Version
+	before      Fields
+	changes     Fields
+	comment     Comment
+	status      Status
+	proposer    logic.Actor
+	createdAt   time.Time
+	review      *Review
+}
+
+func NewProposal(
+	id ProposalID,
+	target Target,
+	targetID int,
+	baseVersion logic.Version,
+	before, changes Fields,
+	comment Comment,
+	status Status,
+	proposer logic.Actor,
+	createdAt time.Time,
+	review *Review,
+) Proposal {
+	return Proposal{
+		id:          id,
+		target:      target,
+		targetID:    targetID,
+		baseVersion: baseVersion,
+		before:      before,
+		changes:     changes,
+		comment:     comment,
+		status:      status,
+		proposer:    proposer,
+		createdAt:   createdAt,
+		review:      review,
+	}
+}
+
+func (p Proposal) ID() ProposalID             { return p.id }
+func (p Proposal) Target() Target             { return p.target }
+func (p Proposal) TargetID() int              { return p.targetID }
+func (p Proposal) BaseVersion() logic.Version { return p.baseVersion }
+func (p Proposal) Before() Fields             { return p.before }
+func (p Proposal) Changes() Fields            { return p.changes }
+func (p Proposal) Comment() Comment           { return p.comment }
+func (p Proposal) Status() Status             { return p.status }
+func (p Proposal) Proposer() logic.Actor      { return p.proposer }
+func (p Proposal) CreatedAt() time.Time       { return p.createdAt }
+func (p Proposal) Review() *Review            { return p.review }
+func (p Proposal) Pending() bool              { return p.status == StatusPending }
+
+// FieldChange 1項目の変更前と変更後の値
+type FieldChange struct {
+	Field  string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+func (p Proposal) Diff() []FieldChange {
+	changes := make([]FieldChange, 0, len(p.changes))
+	for _, k := range p.changes.Keys() {
+		changes = append(changes, FieldChange{Field: k, Before: p.before[k], After: p.changes[k]})
+	}
+	return changes
+}
+
+// Conflicts 提出後に他の更新で変わった項目のうち、提案とも異なる値になっている項目を返す。
+// 提案と無関係な項目の変更や、提案と同じ値への変更は競合としない
+func (p Proposal) Conflicts(current Fields) []string {
+	var conflicts []string
+	for _, k := range p.changes.Keys() {
+		if current.Equal(k, p.before[k]) || current.Equal(k, p.changes[k]) {
+			continue
+		}
+		conflicts = append(conflicts, k)
+	}
+	return conflicts
+}
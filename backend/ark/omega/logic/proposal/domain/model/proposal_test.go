@@ -0,0 +1,34 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_NewComment(t *testing.T) {
+	t.Run("前後の空白を除いたコメントの生成テスト", func(t *testing.T) {
+		comment, err := NewComment("  型を修正しました ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if comment != "型を修正しました" {
+			t.Errorf("空白が除かれていません %q", comment)
+		}
+	})
+
+	t.Run("空白を除いて上限に収まるコメントの生成テスト", func(t *testing.T) {
+		comment, err := NewComment(" " + strings.Repeat("a", maxCommentLength) + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(comment) != maxCommentLength {
+			t.Errorf("コメントの長さが異なります %d", len(comment))
+		}
+	})
+
+	t.Run("長すぎるコメントのエラーテスト", func(t *testing.T) {
+		if _, err := NewComment(strings.Repeat("a", maxCommentLength+1)); err == nil {
+			t.Errorf("長すぎるコメントでエラーになっていません")
+		}
+	})
+}
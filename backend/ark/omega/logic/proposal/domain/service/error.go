@@ -0,0 +1,9 @@
+package service
+
+import "errors"
+
+var (
+	NotFound            = errors.New("not found")
+	AlreadyReviewed     = errors.New("already reviewed")
+	IntervalServerError = errors.New("interval server error")
+)
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+)
+
+// SubmitProposal 提出する変更。changesには変更する項目のみを指定する
+type SubmitProposal struct {
+	target   model.Target
+	targetID int
+	changes  model.Fields
+	comment  model.Comment
+}
+
+func NewSubmitProposal(target model.Target, targetID int, changes model.Fields, comment model.Comment) SubmitProposal {
+	return SubmitProposal{target: target, targetID: targetID, changes: changes, comment: comment}
+}
+
+func (s SubmitProposal) Target() model.Target   { return s.target }
+func (s SubmitProposal) TargetID() int          { return s.targetID }
+func (s SubmitProposal) Changes() model.Fields  { return s.changes }
+func (s SubmitProposal) Comment() model.Comment { return s.comment }
+
+// CreateProposal 提出時の対象の状態と、その状態から値の異なる項目のみの変更を保存する
+type CreateProposal struct {
+	SubmitProposal
+	base     model.Snapshot
+	proposer logic.Actor
+}
+
+func NewCreateProposal(submit SubmitProposal, base model.Snapshot, changes model.Fields, proposer logic.Actor) CreateProposal {
+	submit.changes = changes
+	return CreateProposal{SubmitProposal: submit, base: base, proposer: proposer}
+}
+
+func (c CreateProposal) Base() model.Snapshot  { return c.base }
+func (c CreateProposal) Proposer() logic.Actor { return c.proposer }
+
+// ListProposals 提出順に取得する。statusやtargetが空の場合は絞り込まない
+type ListProposals struct {
+	logic.PageRequest
+	status model.Status
+	target model.Target
+}
+
+func NewListProposals(page logic.PageRequest, status model.Status, target model.Target) ListProposals {
+	return ListProposals{PageRequest: page, status: status, target: target}
+}
+
+func (l ListProposals) Status() model.Status { return l.status }
+func (l ListProposals) Target() model.Target { return l.target }
+
+// ReviewProposal 審査待ちの提案を承認または却下する
+type ReviewProposal struct {
+	id       model.ProposalID
+	status   model.Status
+	reviewer logic.Actor
+	comment  model.Comment
+}
+
+func NewReviewProposal(id model.ProposalID, status model.Status, reviewer logic.Actor, comment model.Comment) ReviewProposal {
+	return ReviewProposal{id: id, status: status, reviewer: reviewer, comment: comment}
+}
+
+func (r ReviewProposal) ID() model.ProposalID   { return r.id }
+func (r ReviewProposal) Status() model.Status   { return r.status }
+func (r ReviewProposal) Reviewer() logic.Actor  { return r.reviewer }
+func (r ReviewProposal) Comment() model.Comment { return r.comment }
+
+type ProposalRepository interface {
+	Select(context.Context, model.ProposalID) (*model.Proposal, error)
+	List(context.Context, ListProposals) (*logic.Page[model.Proposal], error)
+	Insert(context.Context, CreateProposal) (*model.Proposal, error)
+	// Review 審査待ちでない提案はAlreadyReviewedを返す
+	Review(context.Context, ReviewProposal) (*model.Proposal, error)
+}
+
+// Editor 提案の対象の状態を取得し、承認した変更を適用する。対象毎のユースケースを用いて実装する
+type Editor interface {
+	// Current 対象の現在の状態を更新のリクエストと同じ項目で返す
+	Current(ctx context.Context, id int) (*model.Snapshot, error)
+	// Validate 変更後の状態が更新のリクエストとして正しいか検証する
+	Validate(ctx context.Context, id int, fields model.Fields) error
+	// Apply 変更後の全ての項目で対象を更新する。versionが古い場合はlogic.PreconditionFailedを返す
+	Apply(ctx context.Context, id int, fields model.Fields, version logic.Version) error
+}
+
+// Editors 提案の対象毎のEditor
+type Editors map[model.Target]Editor
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+var _ service.ProposalRepository = (*mockProposal)(nil)
+
+type mockProposal struct {
+	mock.Mock
+}
+
+func newMockProposal() *mockProposal { return &mockProposal{} }
+
+func (m *mockProposal) Select(ctx context.Context, id model.ProposalID) (*model.Proposal, error) {
+	args := m.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Proposal), args.Error(1)
+}
+
+func (m *mockProposal) List(ctx context.Context, query service.ListProposals) (*logic.Page[model.Proposal], error) {
+	args := m.Called(ctx, query)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*logic.Page[model.Proposal]), args.Error(1)
+}
+
+func (m *mockProposal) Insert(ctx context.Context, create service.CreateProposal) (*model.Proposal, error) {
+	args := m.Called(ctx, create)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Proposal), args.Error(1)
+}
+
+func (m *mockProposal) Review(ctx context.Context, review service.ReviewProposal) (*model.Proposal, error) {
+	args := m.Called(ctx, review)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Proposal), args.Error(1)
+}
+
+var _ service.Editor = (*mockEditor)(nil)
+
+type mockEditor struct {
+	mock.Mock
+}
+
+func newMockEditor() *mockEditor { return &mockEditor{} }
+
+func (m *mockEditor) Current(ctx context.Context, id int) (*model.Snapshot, error) {
+	args := m.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.Snapshot), args.Error(1)
+}
+
+func (m *mockEditor) Validate(ctx context.Context, id int, fields model.Fields) error {
+	args := m.Called(ctx, id, fields)
+
+	return args.Error(0)
+}
+
+func (m *mockEditor) Apply(ctx context.Context, id int, fields model.Fields, version logic.Version) error {
+	args := m.Called(ctx, id, fields, version)
+
+	return args.Error(0)
+}
@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+)
+
+type ProposalUsecase interface {
+	Find(context.Context, model.ProposalID) (*model.Proposal, error)
+	List(context.Context, service.ListProposals) (*logic.Page[model.Proposal], error)
+	Submit(context.Context, service.SubmitProposal) (*model.Proposal, error)
+	Approve(context.Context, model.ProposalID, model.Comment) (*model.Proposal, error)
+	Reject(context.Context, model.ProposalID, model.Comment) (*model.Proposal, error)
+}
+
+type Proposal struct {
+	repository service.ProposalRepository
+	editors    service.Editors
+}
+
+func NewProposal(injector *do.Injector) (ProposalUsecase, error) {
+	return &Proposal{
+		repository: do.MustInvoke[service.ProposalRepository](injector),
+		editors:    do.MustInvoke[service.Editors](injector),
+	}, nil
+}
+
+func (p Proposal) Find(ctx context.Context, id model.ProposalID) (*model.Proposal, error) {
+	proposal, err := p.repository.Select(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.NotFound) {
+			return nil, failure.New(logic.NotFound)
+		}
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return proposal, nil
+}
+
+func (p Proposal) List(ctx context.Context, query service.ListProposals) (*logic.Page[model.Proposal], error) {
+	proposals, err := p.repository.List(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return proposals, nil
+}
+
+// Submit 提出時の対象の状態を保存し、その状態と値が異なる項目のみを変更として残す
+func (p Proposal) Submit(ctx context.Context, submit service.SubmitProposal) (*model.Proposal, error) {
+	editor, err := p.editor(submit.Target())
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := editor.Current(ctx, submit.TargetID())
+	if err != nil {
+		if failure.Is(err, logic.NotFound) {
+			return nil, failure.Translate(
+				i18n.Wrapf(err, "%s %dは存在しません", submit.Target(), submit.TargetID()), logic.InvalidArgument,
+			)
+		}
+		return nil, err
+	}
+	for _, k := range submit.Changes().Keys() {
+		if _, ok := current.Fields()[k]; !ok {
+			return nil, failure.Translate(i18n.Errorf("%qは不明な項目です", k), logic.InvalidArgument)
+		}
+	}
+	changes := current.Fields().Diff(submit.Changes())
+	if len(changes) == 0 {
+		return nil, failure.Translate(i18n.Errorf("提案に変更がありません"), logic.InvalidArgument)
+	}
+	if err = editor.Validate(ctx, submit.TargetID(), current.Fields().Merge(changes)); err != nil {
+		return nil, err
+	}
+
+	proposal, err := p.repository.Insert(ctx, service.NewCreateProposal(submit, *current, changes, logic.ActorOf(ctx)))
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return proposal, nil
+}
+
+// Approve 提出後の他の更新と競合しなければ、現在の状態に変更した項目のみを適用する。
+// 適用と提案の状態の更新は1つのトランザクションで行う
+func (p Proposal) Approve(ctx context.Context, id model.ProposalID, comment model.Comment) (*model.Proposal, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Proposal, error) {
+		proposal, err := p.pending(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		editor, err := p.editor(proposal.Target())
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := editor.Current(ctx, proposal.TargetID())
+		if err != nil {
+			if failure.Is(err, logic.NotFound) {
+				return nil, failure.Translate(
+					i18n.Wrapf(err, "%s %dは削除されています", proposal.Target(), proposal.TargetID()), logic.Conflict,
+				)
+			}
+			return nil, err
+		}
+		if conflicts := proposal.Conflicts(current.Fields()); len(conflicts) != 0 {
+			fields := lo.Map(conflicts, func(field string, _ int) logic.FieldError {
+				return logic.FieldError{Field: field, Rule: "conflict"}
+			})
+			return nil, failure.Translate(
+				i18n.Wrapf(logic.FieldErrors(fields), "提案の提出後に項目が変更されています"), logic.Conflict,
+			)
+		}
+
+		merged := current.Fields().Merge(proposal.Changes())
+		if err = editor.Apply(ctx, proposal.TargetID(), merged, current.Version()); err != nil {
+			return nil, err
+		}
+		return p.review(ctx, service.NewReviewProposal(id, model.StatusApproved, logic.ActorOf(ctx), comment))
+	})
+}
+
+func (p Proposal) Reject(ctx context.Context, id model.ProposalID, comment model.Comment) (*model.Proposal, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Proposal, error) {
+		if _, err := p.pending(ctx, id); err != nil {
+			return nil, err
+		}
+		return p.review(ctx, service.NewReviewProposal(id, model.StatusRejected, logic.ActorOf(ctx), comment))
+	})
+}
+
+func (p Proposal) editor(target model.Target) (service.Editor, error) {
+	editor, ok := p.editors[target]
+	if !ok {
+		return nil, failure.Translate(i18n.Errorf("%sは提案できません", target), logic.InvalidArgument)
+	}
+	return editor, nil
+}
+
+func (p Proposal) pending(ctx context.Context, id model.ProposalID) (*model.Proposal, error) {
+	proposal, err := p.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !proposal.Pending() {
+		return nil, alreadyReviewed(id)
+	}
+	return proposal, nil
+}
+
+func (p Proposal) review(ctx context.Context, review service.ReviewProposal) (*model.Proposal, error) {
+	proposal, err := p.repository.Review(ctx, review)
+	if err != nil {
+		if errors.Is(err, service.AlreadyReviewed) {
+			return nil, alreadyReviewed(review.ID())
+		}
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return proposal, nil
+}
+
+func alreadyReviewed(id model.ProposalID) error {
+	return failure.Translate(i18n.Errorf("提案%dは審査済みです", id), logic.Conflict)
+}
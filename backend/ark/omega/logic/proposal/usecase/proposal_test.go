@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+)
+
+type ProposalTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockProposal
+	editor  *mockEditor
+	usecase ProposalUsecase
+}
+
+func TestProposalSuite(t *testing.T) {
+	suite.Run(t, &ProposalTestSuite{})
+}
+
+const (
+	selectProposal = "Select"
+	insertProposal = "Insert"
+	reviewProposal = "Review"
+	currentState   = "Current"
+	validateFields = "Validate"
+	applyFields    = "Apply"
+)
+
+const uniqueID = 1
+
+var createdAt = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func (s *ProposalTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.mockDB = newMockProposal()
+	s.editor = newMockEditor()
+	do.ProvideValue[service.ProposalRepository](injector, s.mockDB)
+	do.ProvideValue(injector, service.Editors{model.TargetUnique: s.editor})
+	usecase, err := NewProposal(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+func fields(raw string) model.Fields {
+	f, err := model.NewFields(json.RawMessage(raw))
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func snapshot(raw string, version logic.Version) *model.Snapshot {
+	s := model.NewSnapshot(fields(raw), version)
+	return &s
+}
+
+func pendingProposal(id model.ProposalID) *model.Proposal {
+	p := model.NewProposal(
+		id, model.TargetUnique, uniqueID, 3,
+		fields(`{"unique_name":"Meteor Rex","health_multiplier":2,"damage_multiplier":1.5}`),
+		fields(`{"health_multiplier":2.5}`),
+		"", model.StatusPending, logic.Anonymous, createdAt, nil,
+	)
+	return &p
+}
+
+func (s *ProposalTestSuite) TestSubmit() {
+	base := snapshot(`{"unique_name":"Meteor Rex","health_multiplier":2,"damage_multiplier":1.5}`, 3)
+	{
+		s.T().Log("現在の状態と値が異なる項目のみを変更として保存するかテスト")
+		submit := service.NewSubmitProposal(
+			model.TargetUnique, uniqueID, fields(`{"unique_name":"Meteor Rex","health_multiplier":2.5}`), "wikiの値",
+		)
+		changes := fields(`{"health_multiplier":2.5}`)
+		s.editor.On(currentState, ctx, uniqueID).Return(base, nil).Once()
+		s.editor.On(validateFields, ctx, uniqueID, base.Fields().Merge(changes)).Return(nil).Once()
+		s.mockDB.On(insertProposal, ctx, service.NewCreateProposal(submit, *base, changes, logic.Anonymous)).
+			Return(pendingProposal(1), nil).Once()
+
+		r, err := s.usecase.Submit(ctx, submit)
+		s.NoError(err)
+		s.Equal(pendingProposal(1), r)
+	}
+	{
+		s.T().Log("対象に無い項目はInvalidArgumentになるかテスト")
+		s.editor.On(currentState, ctx, uniqueID).Return(base, nil).Once()
+
+		_, err := s.usecase.Submit(ctx, service.NewSubmitProposal(model.TargetUnique, uniqueID, fields(`{"level":10}`), ""))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.T().Log("何も変更しない提案はInvalidArgumentになるかテスト")
+		s.editor.On(currentState, ctx, uniqueID).Return(base, nil).Once()
+
+		_, err := s.usecase.Submit(ctx, service.NewSubmitProposal(model.TargetUnique, uniqueID, fields(`{"health_multiplier":2.0}`), ""))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.T().Log("存在しない対象への提案はInvalidArgumentになるかテスト")
+		s.editor.On(currentState, ctx, 2).Return(nil, failure.New(logic.NotFound)).Once()
+
+		_, err := s.usecase.Submit(ctx, service.NewSubmitProposal(model.TargetUnique, 2, fields(`{"health_multiplier":3}`), ""))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+	{
+		s.T().Log("提案できない対象はInvalidArgumentになるかテスト")
+		_, err := s.usecase.Submit(ctx, service.NewSubmitProposal(model.TargetGroup, 1, fields(`{"name":"cosmic"}`), ""))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+}
+
+func (s *ProposalTestSuite) TestApprove() {
+	approved := func(id model.ProposalID) service.ReviewProposal {
+		return service.NewReviewProposal(id, model.StatusApproved, logic.Anonymous, "ok")
+	}
+	{
+		s.T().Log("提案と無関係な項目の更新は残したまま適用するかテスト")
+		current := snapshot(`{"unique_name":"Meteor Rex","health_multiplier":2,"damage_multiplier":1.8}`, 4)
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(1)).Return(pendingProposal(1), nil).Once()
+		s.editor.On(currentState, ctx, uniqueID).Return(current, nil).Once()
+		s.editor.On(applyFields, ctx, uniqueID,
+			fields(`{"unique_name":"Meteor Rex","health_multiplier":2.5,"damage_multiplier":1.8}`), logic.Version(4),
+		).Return(nil).Once()
+		s.mockDB.On(reviewProposal, ctx, approved(1)).Return(pendingProposal(1), nil).Once()
+
+		_, err := s.usecase.Approve(ctx, 1, "ok")
+		s.NoError(err)
+	}
+	{
+		s.T().Log("提案した項目が他の更新で変わっている場合はConflictになるかテスト")
+		current := snapshot(`{"unique_name":"Meteor Rex","health_multiplier":3,"damage_multiplier":1.5}`, 4)
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(2)).Return(pendingProposal(2), nil).Once()
+		s.editor.On(currentState, ctx, uniqueID).Return(current, nil).Once()
+
+		_, err := s.usecase.Approve(ctx, 2, "ok")
+		s.True(failure.Is(err, logic.Conflict))
+		var conflicts logic.FieldErrors
+		s.True(errors.As(err, &conflicts))
+		s.Equal(logic.FieldErrors{{Field: "health_multiplier", Rule: "conflict"}}, conflicts)
+		s.editor.AssertNumberOfCalls(s.T(), applyFields, 1)
+	}
+	{
+		s.T().Log("審査済みの提案はConflictになるかテスト")
+		reviewed := model.NewProposal(
+			3, model.TargetUnique, uniqueID, 3, fields(`{}`), fields(`{}`), "",
+			model.StatusRejected, logic.Anonymous, createdAt, model.NewReview("moderator", "", createdAt),
+		)
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(3)).Return(&reviewed, nil).Once()
+
+		_, err := s.usecase.Approve(ctx, 3, "ok")
+		s.True(failure.Is(err, logic.Conflict))
+	}
+	{
+		s.T().Log("適用に失敗した場合は提案を承認しないかテスト")
+		current := snapshot(`{"unique_name":"Meteor Rex","health_multiplier":2,"damage_multiplier":1.5}`, 3)
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(4)).Return(pendingProposal(4), nil).Once()
+		s.editor.On(currentState, ctx, uniqueID).Return(current, nil).Once()
+		s.editor.On(applyFields, ctx, uniqueID, mock.Anything, logic.Version(3)).Return(e).Once()
+
+		_, err := s.usecase.Approve(ctx, 4, "ok")
+		s.True(errors.Is(err, e))
+		s.mockDB.AssertNotCalled(s.T(), reviewProposal, ctx, approved(4))
+	}
+}
+
+func (s *ProposalTestSuite) TestReject() {
+	{
+		rejected := service.NewReviewProposal(1, model.StatusRejected, logic.Anonymous, "根拠が不明")
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(1)).Return(pendingProposal(1), nil).Once()
+		s.mockDB.On(reviewProposal, ctx, rejected).Return(pendingProposal(1), nil).Once()
+
+		_, err := s.usecase.Reject(ctx, 1, "根拠が不明")
+		s.NoError(err)
+	}
+	{
+		s.T().Log("同時に審査された場合はConflictになるかテスト")
+		rejected := service.NewReviewProposal(2, model.StatusRejected, logic.Anonymous, "")
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(2)).Return(pendingProposal(2), nil).Once()
+		s.mockDB.On(reviewProposal, ctx, rejected).Return(nil, service.AlreadyReviewed).Once()
+
+		_, err := s.usecase.Reject(ctx, 2, "")
+		s.True(failure.Is(err, logic.Conflict))
+	}
+	{
+		s.mockDB.On(selectProposal, ctx, model.ProposalID(3)).Return(nil, service.NotFound).Once()
+
+		_, err := s.usecase.Reject(ctx, 3, "")
+		s.True(failure.Is(err, logic.NotFound))
+	}
+}
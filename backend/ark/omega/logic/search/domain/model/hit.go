@@ -0,0 +1,86 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+const maxKeywordLength = 100
+
+// Keyword 前後の空白を除いた検索語
+type Keyword string
+
+func (k Keyword) Value() string { return string(k) }
+
+func NewKeyword(value string) (Keyword, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", i18n.Errorf("検索語が空です")
+	}
+	if utf8.RuneCountInString(value) > maxKeywordLength {
+		return "", i18n.Errorf("検索語は100文字以内にしてください")
+	}
+	return Keyword(value), nil
+}
+
+// HitType 検索結果がどの種類のデータに一致したか
+type HitType string
+
+const (
+	HitUnique  HitType = "unique"
+	HitVariant HitType = "variant"
+	HitGroup   HitType = "group"
+)
+
+func (t HitType) Value() string { return string(t) }
+
+func NewHitType(value string) (HitType, error) {
+	switch t := HitType(value); t {
+	case HitUnique, HitVariant, HitGroup:
+		return t, nil
+	default:
+		return "", i18n.Errorf("不正な検索対象の種類です")
+	}
+}
+
+type HitTypes []HitType
+
+// AllHitTypes 検索対象の種類が指定されなかった場合に用いる
+func AllHitTypes() HitTypes { return HitTypes{HitUnique, HitVariant, HitGroup} }
+
+// Snippet 一致した箇所を<mark>で囲んだ文字列
+type Snippet string
+
+func (s Snippet) Value() string { return string(s) }
+
+type Rank float32
+
+func (r Rank) Value() float32 { return float32(r) }
+
+type Hit struct {
+	hitType HitType
+	id      int
+	name    string
+	snippet Snippet
+	rank    Rank
+}
+
+func NewHit(hitType HitType, id int, name string, snippet Snippet, rank Rank) Hit {
+	return Hit{
+		hitType: hitType,
+		id:      id,
+		name:    name,
+		snippet: snippet,
+		rank:    rank,
+	}
+}
+
+func (h Hit) Type() HitType    { return h.hitType }
+func (h Hit) ID() int          { return h.id }
+func (h Hit) Name() string     { return h.name }
+func (h Hit) Snippet() Snippet { return h.snippet }
+func (h Hit) Rank() Rank       { return h.rank }
+
+type Hits []Hit
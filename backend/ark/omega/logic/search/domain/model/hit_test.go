@@ -0,0 +1,30 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_NewKeyword(t *testing.T) {
+	t.Run("前後の空白を除いた検索語の生成テスト", func(t *testing.T) {
+		keyword, err := NewKeyword("  Kenny ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keyword != "Kenny" {
+			t.Errorf("空白が除かれていません %q", keyword)
+		}
+	})
+
+	t.Run("空の検索語のエラーテスト", func(t *testing.T) {
+		if _, err := NewKeyword("   "); err == nil {
+			t.Errorf("空の検索語でエラーになっていません")
+		}
+	})
+
+	t.Run("長すぎる検索語のエラーテスト", func(t *testing.T) {
+		if _, err := NewKeyword(strings.Repeat("a", maxKeywordLength+1)); err == nil {
+			t.Errorf("長すぎる検索語でエラーになっていません")
+		}
+	})
+}
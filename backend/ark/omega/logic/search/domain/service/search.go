@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic/search/domain/model"
+)
+
+const (
+	DefaultSearchLimit uint = 20
+	MaxSearchLimit     uint = 100
+)
+
+type SearchQuery struct {
+	keyword model.Keyword
+	types   model.HitTypes
+	limit   uint
+}
+
+// NewSearchQuery typesが空の場合は全ての種類を、limitが範囲外の場合はデフォルト値・上限値を用いる
+func NewSearchQuery(keyword model.Keyword, types model.HitTypes, limit uint) SearchQuery {
+	if len(types) == 0 {
+		types = model.AllHitTypes()
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+	return SearchQuery{keyword: keyword, types: types, limit: limit}
+}
+
+func (q SearchQuery) Keyword() model.Keyword { return q.keyword }
+func (q SearchQuery) Types() model.HitTypes  { return q.types }
+func (q SearchQuery) Limit() uint            { return q.limit }
+
+// SearchRepository ユニーク・バリアント・グループを横断して関連度順に検索する
+type SearchRepository interface {
+	Search(context.Context, SearchQuery) (model.Hits, error)
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/logic/search/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+var _ service.SearchRepository = (*mockSearchRepo)(nil)
+
+type mockSearchRepo struct {
+	mock.Mock
+}
+
+func newMockSearchRepo() *mockSearchRepo { return &mockSearchRepo{} }
+
+func (r *mockSearchRepo) Search(ctx context.Context, query service.SearchQuery) (model.Hits, error) {
+	args := r.Called(ctx, query)
+
+	h := args.Get(0)
+	if h == nil {
+		return nil, args.Error(1)
+	}
+	return h.(model.Hits), args.Error(1)
+}
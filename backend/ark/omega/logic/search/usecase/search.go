@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/logic/search/domain/service"
+)
+
+type SearchUsecase interface {
+	Search(context.Context, service.SearchQuery) (model.Hits, error)
+}
+
+type Search struct {
+	repository service.SearchRepository
+}
+
+func NewSearch(injector *do.Injector) (SearchUsecase, error) {
+	return &Search{
+		repository: do.MustInvoke[service.SearchRepository](injector),
+	}, nil
+}
+
+func (s Search) Search(ctx context.Context, query service.SearchQuery) (model.Hits, error) {
+	hits, err := s.repository.Search(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return hits, nil
+}
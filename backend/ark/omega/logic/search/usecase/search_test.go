@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/logic/search/domain/service"
+)
+
+type SearchTestSuite struct {
+	suite.Suite
+
+	mockRepo *mockSearchRepo
+	usecase  SearchUsecase
+}
+
+func TestSearchSuite(t *testing.T) {
+	suite.Run(t, &SearchTestSuite{})
+}
+
+const search = "Search"
+
+func (s *SearchTestSuite) SetupSuite() {
+	injector := do.New()
+
+	mockRepo := newMockSearchRepo()
+	do.ProvideValue[service.SearchRepository](injector, mockRepo)
+	s.mockRepo = mockRepo
+	usecase, err := NewSearch(injector)
+	if err != nil {
+		return
+	}
+
+	s.usecase = usecase
+}
+
+func (s *SearchTestSuite) TestSearch() {
+	keyword, err := model.NewKeyword("kenny")
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query := service.NewSearchQuery(keyword, nil, 0)
+	hits := model.Hits{
+		model.NewHit(model.HitUnique, 1, "Kenny", "<mark>Kenny</mark> (Dodo)", 0.6),
+	}
+
+	{
+		s.mockRepo.On(search, ctx, query).Return(hits, nil).Once()
+		r, err := s.usecase.Search(ctx, query)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+
+		s.Equal(hits, r)
+	}
+	{
+		s.mockRepo.On(search, ctx, query).Return(nil, service.IntervalServerError).Once()
+		_, err := s.usecase.Search(ctx, query)
+		s.True(failure.Is(err, logic.IntervalServerError))
+	}
+	{
+		s.mockRepo.On(search, ctx, query).Return(nil, failure.Wrap(e)).Once()
+		_, err := s.usecase.Search(ctx, query)
+		s.True(errors.Is(err, e))
+	}
+}
@@ -0,0 +1,227 @@
+package model
+
+import (
+	"strings"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+// Kind 一括で入出力するカタログの対象
+type Kind string
+
+const (
+	KindGroup       Kind = "group"
+	KindVariant     Kind = "variant"
+	KindDinosaur    Kind = "dinosaur"
+	KindUnique      Kind = "unique"
+	KindRule        Kind = "variant_rule"
+	KindDisplayName Kind = "display_name"
+)
+
+func (k Kind) Value() string { return string(k) }
+
+// Kinds 取り込みで処理する順に並べる
+func Kinds() []Kind {
+	return []Kind{KindGroup, KindVariant, KindDinosaur, KindUnique, KindRule, KindDisplayName}
+}
+
+func NewKind(value string) (Kind, error) {
+	for _, k := range Kinds() {
+		if k.Value() == value {
+			return k, nil
+		}
+	}
+	return "", i18n.Errorf("%qは不明な取り込みの対象です", value)
+}
+
+// refSeparator バリアントの参照を1つの文字列で表す際の、グループ名とバリアント名の区切り
+const refSeparator = "/"
+
+// VariantRef バリアントの名前はグループ内でのみ一意のため、グループの名前と組で参照する
+type VariantRef struct {
+	Group variantModel.VariantGroupName
+	Name  variantModel.Name
+}
+
+// ParseVariantRef "グループ名/バリアント名"の形式を読む。バリアント名には区切りを含められる
+func ParseVariantRef(value string) (VariantRef, error) {
+	group, name, ok := strings.Cut(value, refSeparator)
+	group, name = strings.TrimSpace(group), strings.TrimSpace(name)
+	if !ok || group == "" || name == "" {
+		return VariantRef{}, i18n.Errorf("バリアントは「グループ名/バリアント名」の形式で指定してください: %q", value)
+	}
+	return VariantRef{Group: variantModel.VariantGroupName(group), Name: variantModel.Name(name)}, nil
+}
+
+func (r VariantRef) String() string { return r.Group.Value() + refSeparator + r.Name.Value() }
+
+type Group struct {
+	Name variantModel.VariantGroupName
+}
+
+// Variant 説明文は表示順に並べる
+type Variant struct {
+	Group        variantModel.VariantGroupName
+	Name         variantModel.Name
+	Descriptions []variantModel.DescriptionText
+}
+
+func (v Variant) Ref() VariantRef { return VariantRef{Group: v.Group, Name: v.Name} }
+
+// Dinosaur StatGrowthが空の場合、取り込みでは登録済みの成長値を変えない
+type Dinosaur struct {
+	Name       creatureModel.DinosaurName
+	Stats      creatureModel.DinosaurStats
+	StatGrowth creatureModel.SpeciesStatGrowth
+}
+
+// Unique 基となる種とバリアントは名前で参照する。バリアントは付与する順に並べる
+type Unique struct {
+	Name        creatureModel.UniqueName
+	Dinosaur    creatureModel.DinosaurName
+	Multipliers creatureModel.UniqueMultipliers
+	Variants    []VariantRef
+}
+
+// Rule 規則にはIDの他に識別するものが無いため、種類と参照の組が同じ規則を同じものとして扱う。
+// グループの規則はGroupのみ、バリアントの規則はVariantとOtherのみを持つ
+type Rule struct {
+	Kind    variantModel.VariantRuleKind
+	Group   variantModel.VariantGroupName
+	Variant VariantRef
+	Other   VariantRef
+}
+
+// NewRule 規則の種類に応じた参照のみを指定する。検証はバリアントの規則と同じ
+func NewRule(
+	kind variantModel.VariantRuleKind, group *variantModel.VariantGroupName, variant, other *VariantRef,
+) (Rule, error) {
+	switch kind {
+	case variantModel.RuleGroupExclusive:
+		if group == nil || variant != nil || other != nil {
+			return Rule{}, i18n.Errorf("グループの規則はグループのみを指定してください")
+		}
+		return Rule{Kind: kind, Group: *group}, nil
+	case variantModel.RuleIncompatible, variantModel.RuleRequires:
+		if group != nil || variant == nil || other == nil {
+			return Rule{}, i18n.Errorf("バリアントの規則は2つのバリアントのみを指定してください")
+		}
+		if *variant == *other {
+			return Rule{}, i18n.Errorf("異なるバリアントを指定してください")
+		}
+		return Rule{Kind: kind, Variant: *variant, Other: *other}, nil
+	default:
+		return Rule{}, i18n.Errorf("%qは不明な規則です", kind)
+	}
+}
+
+// String 取り込みの結果で規則を示す"種類 参照"の形式
+func (r Rule) String() string {
+	if r.Kind == variantModel.RuleGroupExclusive {
+		return r.Kind.Value() + " " + r.Group.Value()
+	}
+	return r.Kind.Value() + " " + r.Variant.String() + " " + r.Other.String()
+}
+
+// DisplayName 対象は保存された名前で参照する。バリアントは「グループ名/バリアント名」の形式で参照する
+type DisplayName struct {
+	Target translationModel.Target
+	Name   string
+	Locale i18n.Locale
+	Value  translationModel.Name
+}
+
+// NewDisplayName バリアントの参照は区切りの前後の空白を除いた形にそろえる
+func NewDisplayName(
+	target translationModel.Target, name string, locale i18n.Locale, value translationModel.Name,
+) (DisplayName, error) {
+	if target == translationModel.TargetVariant {
+		ref, err := ParseVariantRef(name)
+		if err != nil {
+			return DisplayName{}, err
+		}
+		name = ref.String()
+	}
+	return DisplayName{Target: target, Name: name, Locale: locale, Value: value}, nil
+}
+
+// String 取り込みの結果で表示名を示す"対象 名前 言語"の形式
+func (d DisplayName) String() string {
+	return d.Target.Value() + " " + d.Name + " " + d.Locale.Value()
+}
+
+// Bundle 参照を全て名前で表したカタログ。取り込みでは参照される対象から順に処理する
+type Bundle struct {
+	Groups       []Group
+	Variants     []Variant
+	Dinosaurs    []Dinosaur
+	Uniques      []Unique
+	Rules        []Rule
+	DisplayNames []DisplayName
+}
+
+// Action 取り込みで対象に行う操作
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+	ActionConflict  Action = "conflict"
+)
+
+func (a Action) Value() string { return string(a) }
+
+// Reason 取り込めない対象の理由
+type Reason string
+
+const (
+	// ReasonDuplicate 同じ名前の対象を複数取り込もうとした
+	ReasonDuplicate Reason = "duplicate"
+	// ReasonAmbiguous 同じ名前のユニークが既に複数あり、更新する対象を決められない
+	ReasonAmbiguous       Reason = "ambiguous"
+	ReasonUnknownGroup    Reason = "unknown_group"
+	ReasonUnknownDinosaur Reason = "unknown_dinosaur"
+	ReasonUnknownVariant  Reason = "unknown_variant"
+	ReasonUnknownUnique   Reason = "unknown_unique"
+)
+
+func (r Reason) Value() string { return string(r) }
+
+// Change 対象毎の取り込みの結果。Paramには解決できなかった参照などの理由の詳細が入る
+type Change struct {
+	Kind   Kind
+	Name   string
+	Action Action
+	Reason Reason
+	Param  string
+}
+
+// Report 取り込みの計画。dry-runでは適用せずにこの計画のみを返す
+type Report struct {
+	DryRun  bool
+	Changes []Change
+}
+
+func (r Report) Count(action Action) int {
+	count := 0
+	for _, c := range r.Changes {
+		if c.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+func (r Report) Conflicts() []Change {
+	var conflicts []Change
+	for _, c := range r.Changes {
+		if c.Action == ActionConflict {
+			conflicts = append(conflicts, c)
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// Catalog 一括の取り込みと書き出しに用いるカタログの読み書き。対象毎のユースケースを用いて実装する。
+// 読み込みはゴミ箱にない全件をID順に返す。書き出しと照合はリクエストの言語に依らないよう、表示名ではなく保存された名前を用いる
+type Catalog interface {
+	Groups(context.Context) ([]variantModel.VariantGroup, error)
+	// Variants 説明文を含めて返す
+	Variants(context.Context) ([]variantModel.Variant, error)
+	// Dinosaurs 成長値を含めて返す
+	Dinosaurs(context.Context) ([]creatureModel.Dinosaur, error)
+	Uniques(context.Context) ([]creatureModel.UniqueDinosaur, error)
+	Rules(context.Context) ([]variantModel.VariantRule, error)
+	// DisplayNames グループ、バリアント、種、ユニークの順に全ての表示名を返す
+	DisplayNames(context.Context) ([]translationModel.DisplayName, error)
+
+	CreateGroup(context.Context, variantSvc.CreateVariantGroup) (variantModel.VariantGroupID, error)
+	// CreateVariant 続けて説明文を変更できるよう、バージョンを含めて返す
+	CreateVariant(context.Context, variantSvc.CreateVariant) (*variantModel.Variant, error)
+	ReplaceDescriptions(context.Context, variantSvc.ReplaceDescriptions) error
+	CreateDinosaur(context.Context, creatureSvc.CreateDinosaur) (creatureModel.DinosaurID, error)
+	UpdateDinosaur(context.Context, creatureSvc.UpdateDinosaur) error
+	// CreateUnique 続けて表示名を登録できるよう、作成したユニークのIDを返す
+	CreateUnique(context.Context, creatureSvc.CreateCreature) (creatureModel.UniqueDinosaurID, error)
+	UpdateUnique(context.Context, creatureSvc.UpdateCreature) error
+	CreateRule(context.Context, variantSvc.CreateVariantRule) error
+	PutDisplayName(context.Context, translationModel.DisplayName) error
+}
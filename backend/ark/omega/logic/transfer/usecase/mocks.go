@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/transfer/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+var _ service.Catalog = (*mockCatalog)(nil)
+
+type mockCatalog struct {
+	mock.Mock
+}
+
+func newMockCatalog() *mockCatalog { return &mockCatalog{} }
+
+func (m *mockCatalog) Groups(ctx context.Context) ([]variantModel.VariantGroup, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]variantModel.VariantGroup), args.Error(1)
+}
+
+func (m *mockCatalog) Variants(ctx context.Context) ([]variantModel.Variant, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]variantModel.Variant), args.Error(1)
+}
+
+func (m *mockCatalog) Dinosaurs(ctx context.Context) ([]creatureModel.Dinosaur, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]creatureModel.Dinosaur), args.Error(1)
+}
+
+func (m *mockCatalog) Uniques(ctx context.Context) ([]creatureModel.UniqueDinosaur, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]creatureModel.UniqueDinosaur), args.Error(1)
+}
+
+func (m *mockCatalog) Rules(ctx context.Context) ([]variantModel.VariantRule, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]variantModel.VariantRule), args.Error(1)
+}
+
+func (m *mockCatalog) DisplayNames(ctx context.Context) ([]translationModel.DisplayName, error) {
+	args := m.Called(ctx)
+
+	return args.Get(0).([]translationModel.DisplayName), args.Error(1)
+}
+
+func (m *mockCatalog) CreateGroup(ctx context.Context, create variantSvc.CreateVariantGroup) (variantModel.VariantGroupID, error) {
+	args := m.Called(ctx, create)
+
+	return args.Get(0).(variantModel.VariantGroupID), args.Error(1)
+}
+
+func (m *mockCatalog) CreateVariant(ctx context.Context, create variantSvc.CreateVariant) (*variantModel.Variant, error) {
+	args := m.Called(ctx, create)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*variantModel.Variant), args.Error(1)
+}
+
+func (m *mockCatalog) ReplaceDescriptions(ctx context.Context, replace variantSvc.ReplaceDescriptions) error {
+	args := m.Called(ctx, replace)
+
+	return args.Error(0)
+}
+
+func (m *mockCatalog) CreateDinosaur(ctx context.Context, create creatureSvc.CreateDinosaur) (creatureModel.DinosaurID, error) {
+	args := m.Called(ctx, create)
+
+	return args.Get(0).(creatureModel.DinosaurID), args.Error(1)
+}
+
+func (m *mockCatalog) UpdateDinosaur(ctx context.Context, update creatureSvc.UpdateDinosaur) error {
+	args := m.Called(ctx, update)
+
+	return args.Error(0)
+}
+
+func (m *mockCatalog) CreateUnique(
+	ctx context.Context, create creatureSvc.CreateCreature,
+) (creatureModel.UniqueDinosaurID, error) {
+	args := m.Called(ctx, create)
+
+	return args.Get(0).(creatureModel.UniqueDinosaurID), args.Error(1)
+}
+
+func (m *mockCatalog) UpdateUnique(ctx context.Context, update creatureSvc.UpdateCreature) error {
+	args := m.Called(ctx, update)
+
+	return args.Error(0)
+}
+
+func (m *mockCatalog) CreateRule(ctx context.Context, create variantSvc.CreateVariantRule) error {
+	args := m.Called(ctx, create)
+
+	return args.Error(0)
+}
+
+func (m *mockCatalog) PutDisplayName(ctx context.Context, name translationModel.DisplayName) error {
+	args := m.Called(ctx, name)
+
+	return args.Error(0)
+}
@@ -0,0 +1,426 @@
+package usecase
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+	"mods-explore/ark/omega/logic/transfer/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// ids 名前からIDへの対応。既存の対象で初期化し、適用中に作成した対象を加える
+type ids struct {
+	groups    map[variantModel.VariantGroupName]variantModel.VariantGroupID
+	variants  map[model.VariantRef]variantModel.VariantID
+	dinosaurs map[string]creatureModel.DinosaurID
+	// uniques 名前が重複するユニークは参照できないため含めない
+	uniques map[creatureModel.UniqueName]creatureModel.UniqueDinosaurID
+}
+
+// dinosaurKey 種の名前は大文字小文字を区別せずに一意のため、小文字にして照合する
+func dinosaurKey(name creatureModel.DinosaurName) string { return strings.ToLower(name.Value()) }
+
+type step func(context.Context, service.Catalog, *ids) error
+
+// plan 取り込む対象毎の結果と、参照される対象から順に並べた適用の手順
+type plan struct {
+	changes []model.Change
+	steps   []step
+	ids     ids
+
+	// 既存か取り込みで作成し、後の対象から参照できる名前
+	groups    map[variantModel.VariantGroupName]bool
+	variants  map[model.VariantRef]bool
+	dinosaurs map[string]bool
+	uniques   map[creatureModel.UniqueName]bool
+	// ambiguous 同じ名前で既に複数あるユニーク
+	ambiguous map[creatureModel.UniqueName]bool
+}
+
+func newPlan(c current, bundle model.Bundle) plan {
+	p := plan{ids: ids{
+		groups:    make(map[variantModel.VariantGroupName]variantModel.VariantGroupID, len(c.groups)),
+		variants:  make(map[model.VariantRef]variantModel.VariantID, len(c.variants)),
+		dinosaurs: make(map[string]creatureModel.DinosaurID, len(c.dinosaurs)),
+		uniques:   make(map[creatureModel.UniqueName]creatureModel.UniqueDinosaurID, len(c.uniques)),
+	}, ambiguous: map[creatureModel.UniqueName]bool{}}
+	for _, g := range c.groups {
+		p.ids.groups[g.Name()] = g.ID()
+	}
+	for _, v := range c.variants {
+		p.ids.variants[model.VariantRef{Group: v.Group(), Name: v.Name()}] = v.ID()
+	}
+	for _, d := range c.dinosaurs {
+		p.ids.dinosaurs[dinosaurKey(d.BaseName())] = d.BaseID()
+	}
+	for name, uniques := range lo.GroupBy(c.uniques, func(u creatureModel.UniqueDinosaur) creatureModel.UniqueName {
+		return u.UniqueName()
+	}) {
+		if len(uniques) > 1 {
+			p.ambiguous[name] = true
+			continue
+		}
+		p.ids.uniques[name] = uniques[0].UniqueID()
+	}
+
+	n := c.names()
+	p.planGroups(bundle.Groups)
+	p.planVariants(c.variants, bundle.Variants)
+	p.planDinosaurs(c.dinosaurs, bundle.Dinosaurs)
+	p.planUniques(c.uniques, bundle.Uniques)
+	// 既存のユニークが規則に違反していても取り込めるよう、規則はユニークの後に作成する
+	p.planRules(n.rules(c.rules), bundle.Rules)
+	p.planDisplayNames(n.displayNames(c.displayNames), bundle.DisplayNames)
+	return p
+}
+
+func (p *plan) add(change model.Change, s step) {
+	p.changes = append(p.changes, change)
+	if s != nil {
+		p.steps = append(p.steps, s)
+	}
+}
+
+func (p *plan) planGroups(groups []model.Group) {
+	p.groups = resolvable(p.ids.groups)
+	seen := map[variantModel.VariantGroupName]bool{}
+	for _, g := range groups {
+		g := g
+		change := model.Change{Kind: model.KindGroup, Name: g.Name.Value()}
+		switch {
+		case seen[g.Name]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case p.groups[g.Name]:
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		default:
+			change.Action = model.ActionCreate
+			p.groups[g.Name] = true
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				id, err := catalog.CreateGroup(ctx, variantSvc.NewCreateVariantGroup(g.Name))
+				if err != nil {
+					return err
+				}
+				ids.groups[g.Name] = id
+				return nil
+			})
+		}
+		seen[g.Name] = true
+	}
+}
+
+func (p *plan) planVariants(existing []variantModel.Variant, variants []model.Variant) {
+	byRef := lo.KeyBy(existing, func(v variantModel.Variant) model.VariantRef {
+		return model.VariantRef{Group: v.Group(), Name: v.Name()}
+	})
+	p.variants = resolvable(p.ids.variants)
+	seen := map[model.VariantRef]bool{}
+	for _, v := range variants {
+		v := v
+		ref := v.Ref()
+		change := model.Change{Kind: model.KindVariant, Name: ref.String()}
+		current, exists := byRef[ref]
+		switch {
+		case seen[ref]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case !p.groups[v.Group]:
+			change.Action, change.Reason, change.Param = model.ActionConflict, model.ReasonUnknownGroup, v.Group.Value()
+			p.add(change, nil)
+		case exists && slices.Equal(descriptionTexts(current), v.Descriptions):
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		case exists:
+			change.Action = model.ActionUpdate
+			p.add(change, func(ctx context.Context, catalog service.Catalog, _ *ids) error {
+				return catalog.ReplaceDescriptions(
+					ctx, variantSvc.NewReplaceDescriptions(current.ID(), current.Version(), v.Descriptions),
+				)
+			})
+		default:
+			change.Action = model.ActionCreate
+			p.variants[ref] = true
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				created, err := catalog.CreateVariant(ctx, variantSvc.NewCreateVariant(ids.groups[v.Group], v.Name))
+				if err != nil {
+					return err
+				}
+				ids.variants[ref] = created.ID()
+				if len(v.Descriptions) == 0 {
+					return nil
+				}
+				return catalog.ReplaceDescriptions(
+					ctx, variantSvc.NewReplaceDescriptions(created.ID(), created.Version(), v.Descriptions),
+				)
+			})
+		}
+		seen[ref] = true
+	}
+}
+
+func (p *plan) planDinosaurs(existing []creatureModel.Dinosaur, dinosaurs []model.Dinosaur) {
+	byName := lo.KeyBy(existing, func(d creatureModel.Dinosaur) string { return dinosaurKey(d.BaseName()) })
+	p.dinosaurs = resolvable(p.ids.dinosaurs)
+	seen := map[string]bool{}
+	for _, d := range dinosaurs {
+		d := d
+		key := dinosaurKey(d.Name)
+		change := model.Change{Kind: model.KindDinosaur, Name: d.Name.Value()}
+		current, exists := byName[key]
+		// 成長値を含まない場合は登録済みの成長値を変えない
+		growth := d.StatGrowth
+		if len(growth) == 0 {
+			growth = nil
+		}
+		switch {
+		case seen[key]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case exists && current.Stats() == d.Stats && (growth == nil || maps.Equal(current.StatGrowth(), growth)):
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		case exists:
+			change.Action = model.ActionUpdate
+			p.add(change, func(ctx context.Context, catalog service.Catalog, _ *ids) error {
+				return catalog.UpdateDinosaur(ctx, creatureSvc.NewUpdateDinosaur(
+					current.BaseID(), current.BaseName(), d.Stats, current.Version(),
+				).WithStatGrowth(growth))
+			})
+		default:
+			change.Action = model.ActionCreate
+			p.dinosaurs[key] = true
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				id, err := catalog.CreateDinosaur(ctx, creatureSvc.NewCreateDinosaur(d.Name, d.Stats).WithStatGrowth(growth))
+				if err != nil {
+					return err
+				}
+				ids.dinosaurs[key] = id
+				return nil
+			})
+		}
+		seen[key] = true
+	}
+}
+
+func (p *plan) planUniques(existing []creatureModel.UniqueDinosaur, uniques []model.Unique) {
+	byName := lo.GroupBy(existing, func(u creatureModel.UniqueDinosaur) creatureModel.UniqueName { return u.UniqueName() })
+	p.uniques = resolvable(p.ids.uniques)
+
+	seen := map[creatureModel.UniqueName]bool{}
+	for _, u := range uniques {
+		u := u
+		change := model.Change{Kind: model.KindUnique, Name: u.Name.Value()}
+		unknown := lo.Filter(u.Variants, func(ref model.VariantRef, _ int) bool { return !p.variants[ref] })
+		currents := byName[u.Name]
+		switch {
+		case seen[u.Name]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case len(currents) > 1:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonAmbiguous
+			p.add(change, nil)
+		case !p.dinosaurs[dinosaurKey(u.Dinosaur)]:
+			change.Action, change.Reason, change.Param = model.ActionConflict, model.ReasonUnknownDinosaur, u.Dinosaur.Value()
+			p.add(change, nil)
+		case len(unknown) != 0:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonUnknownVariant
+			change.Param = strings.Join(lo.Map(unknown, func(ref model.VariantRef, _ int) string { return ref.String() }), ", ")
+			p.add(change, nil)
+		case len(currents) == 1 && sameUnique(currents[0], u):
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		case len(currents) == 1:
+			current := currents[0]
+			change.Action = model.ActionUpdate
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				return catalog.UpdateUnique(ctx, creatureSvc.NewUpdateCreature(
+					ids.dinosaurs[dinosaurKey(u.Dinosaur)], current.UniqueID(), u.Name, u.Multipliers,
+					ids.variantIDs(u.Variants), current.Version(),
+				))
+			})
+		default:
+			change.Action = model.ActionCreate
+			p.uniques[u.Name] = true
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				id, err := catalog.CreateUnique(ctx, creatureSvc.NewCreateCreature(
+					ids.dinosaurs[dinosaurKey(u.Dinosaur)], u.Name, u.Multipliers, ids.variantIDs(u.Variants),
+				))
+				if err != nil {
+					return err
+				}
+				ids.uniques[u.Name] = id
+				return nil
+			})
+		}
+		seen[u.Name] = true
+	}
+}
+
+// planRules 規則は更新せず、同じ種類と参照の組の規則が無ければ作成する
+func (p *plan) planRules(existing []model.Rule, rules []model.Rule) {
+	exists := lo.SliceToMap(existing, func(r model.Rule) (model.Rule, bool) { return r, true })
+	seen := map[model.Rule]bool{}
+	for _, r := range rules {
+		r := r
+		change := model.Change{Kind: model.KindRule, Name: r.String()}
+		group := r.Kind == variantModel.RuleGroupExclusive
+		var unknown []model.VariantRef
+		if !group {
+			unknown = lo.Filter([]model.VariantRef{r.Variant, r.Other}, func(ref model.VariantRef, _ int) bool {
+				return !p.variants[ref]
+			})
+		}
+		switch {
+		case seen[r]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case group && !p.groups[r.Group]:
+			change.Action, change.Reason, change.Param = model.ActionConflict, model.ReasonUnknownGroup, r.Group.Value()
+			p.add(change, nil)
+		case len(unknown) != 0:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonUnknownVariant
+			change.Param = strings.Join(lo.Map(unknown, func(ref model.VariantRef, _ int) string { return ref.String() }), ", ")
+			p.add(change, nil)
+		case exists[r]:
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		default:
+			change.Action = model.ActionCreate
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				rule, err := ids.rule(r)
+				if err != nil {
+					return err
+				}
+				return catalog.CreateRule(ctx, variantSvc.NewCreateVariantRule(rule))
+			})
+		}
+		seen[r] = true
+	}
+}
+
+// displayNameKey 表示名は対象と言語の組で一意のため、対象の名前の照合の規則にそろえて照合する
+type displayNameKey struct {
+	target translationModel.Target
+	name   string
+	locale i18n.Locale
+}
+
+func newDisplayNameKey(d model.DisplayName) displayNameKey {
+	name := d.Name
+	if d.Target == translationModel.TargetDinosaur {
+		name = dinosaurKey(creatureModel.DinosaurName(name))
+	}
+	return displayNameKey{target: d.Target, name: name, locale: d.Locale}
+}
+
+// planDisplayNames 表示名は参照する対象を全て作成した後に登録する
+func (p *plan) planDisplayNames(existing []model.DisplayName, displayNames []model.DisplayName) {
+	byKey := lo.SliceToMap(existing, func(d model.DisplayName) (displayNameKey, translationModel.Name) {
+		return newDisplayNameKey(d), d.Value
+	})
+	seen := map[displayNameKey]bool{}
+	for _, d := range displayNames {
+		d := d
+		key := newDisplayNameKey(d)
+		change := model.Change{Kind: model.KindDisplayName, Name: d.String()}
+		current, exists := byKey[key]
+		switch reason := p.unresolved(key); {
+		case seen[key]:
+			change.Action, change.Reason = model.ActionConflict, model.ReasonDuplicate
+			p.add(change, nil)
+		case reason != "":
+			change.Action, change.Reason, change.Param = model.ActionConflict, reason, d.Name
+			p.add(change, nil)
+		case exists && current == d.Value:
+			change.Action = model.ActionUnchanged
+			p.add(change, nil)
+		default:
+			change.Action = lo.Ternary(exists, model.ActionUpdate, model.ActionCreate)
+			p.add(change, func(ctx context.Context, catalog service.Catalog, ids *ids) error {
+				return catalog.PutDisplayName(
+					ctx, translationModel.NewDisplayName(d.Target, ids.targetID(key), d.Locale, d.Value),
+				)
+			})
+		}
+		seen[key] = true
+	}
+}
+
+// unresolved 表示名の対象を参照できない理由を返す。参照できる場合は空
+func (p *plan) unresolved(key displayNameKey) model.Reason {
+	switch key.target {
+	case translationModel.TargetGroup:
+		return lo.Ternary(p.groups[variantModel.VariantGroupName(key.name)], "", model.ReasonUnknownGroup)
+	case translationModel.TargetVariant:
+		ref, err := model.ParseVariantRef(key.name)
+		return lo.Ternary(err == nil && p.variants[ref], "", model.ReasonUnknownVariant)
+	case translationModel.TargetDinosaur:
+		return lo.Ternary(p.dinosaurs[key.name], "", model.ReasonUnknownDinosaur)
+	default:
+		if p.ambiguous[creatureModel.UniqueName(key.name)] {
+			return model.ReasonAmbiguous
+		}
+		return lo.Ternary(p.uniques[creatureModel.UniqueName(key.name)], "", model.ReasonUnknownUnique)
+	}
+}
+
+// sameUnique バリアントは付与した順序も含めて比較する
+func sameUnique(current creatureModel.UniqueDinosaur, u model.Unique) bool {
+	return dinosaurKey(current.Dinosaur.BaseName()) == dinosaurKey(u.Dinosaur) &&
+		current.Multipliers() == u.Multipliers &&
+		slices.Equal(uniqueVariantRefs(current), u.Variants)
+}
+
+func resolvable[K comparable, V any](ids map[K]V) map[K]bool {
+	return lo.MapValues(ids, func(V, K) bool { return true })
+}
+
+func (i ids) variantIDs(refs []model.VariantRef) []variantModel.VariantID {
+	return lo.Map(refs, func(ref model.VariantRef, _ int) variantModel.VariantID { return i.variants[ref] })
+}
+
+func (i ids) rule(r model.Rule) (variantModel.VariantRule, error) {
+	if r.Kind == variantModel.RuleGroupExclusive {
+		return variantModel.NewVariantRule(0, r.Kind, lo.ToPtr(i.groups[r.Group]), nil, nil)
+	}
+	return variantModel.NewVariantRule(
+		0, r.Kind, nil, lo.ToPtr(i.variants[r.Variant]), lo.ToPtr(i.variants[r.Other]),
+	)
+}
+
+// targetID 参照を解決できることを確かめた表示名の対象のIDを返す
+func (i ids) targetID(key displayNameKey) int {
+	switch key.target {
+	case translationModel.TargetGroup:
+		return int(i.groups[variantModel.VariantGroupName(key.name)])
+	case translationModel.TargetVariant:
+		ref, _ := model.ParseVariantRef(key.name)
+		return i.variants[ref].Value()
+	case translationModel.TargetDinosaur:
+		return i.dinosaurs[key.name].Value()
+	default:
+		return i.uniques[creatureModel.UniqueName(key.name)].Value()
+	}
+}
+
+// apply 参照される対象から順に適用する。作成した対象のIDは後の手順の参照に用いる
+func (p plan) apply(ctx context.Context, catalog service.Catalog) error {
+	ids := p.ids
+	for _, s := range p.steps {
+		if err := s(ctx, catalog, &ids); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+	"mods-explore/ark/omega/logic/transfer/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+type TransferUsecase interface {
+	Export(context.Context) (*model.Bundle, error)
+	Import(ctx context.Context, bundle model.Bundle, dryRun bool) (*model.Report, error)
+}
+
+type Transfer struct {
+	catalog service.Catalog
+}
+
+func NewTransfer(injector *do.Injector) (TransferUsecase, error) {
+	return &Transfer{
+		catalog: do.MustInvoke[service.Catalog](injector),
+	}, nil
+}
+
+// current 取り込み前のカタログ。名前の照合と変更の有無の判定に用いる
+type current struct {
+	groups       []variantModel.VariantGroup
+	variants     []variantModel.Variant
+	dinosaurs    []creatureModel.Dinosaur
+	uniques      []creatureModel.UniqueDinosaur
+	rules        []variantModel.VariantRule
+	displayNames []translationModel.DisplayName
+}
+
+func (t Transfer) load(ctx context.Context) (*current, error) {
+	var (
+		c   current
+		err error
+	)
+	if c.groups, err = t.catalog.Groups(ctx); err != nil {
+		return nil, err
+	}
+	if c.variants, err = t.catalog.Variants(ctx); err != nil {
+		return nil, err
+	}
+	if c.dinosaurs, err = t.catalog.Dinosaurs(ctx); err != nil {
+		return nil, err
+	}
+	if c.uniques, err = t.catalog.Uniques(ctx); err != nil {
+		return nil, err
+	}
+	if c.rules, err = t.catalog.Rules(ctx); err != nil {
+		return nil, err
+	}
+	if c.displayNames, err = t.catalog.DisplayNames(ctx); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// names IDから参照に用いる名前への対応。規則と表示名をIDではなく名前で表すために用いる
+type names struct {
+	groups    map[variantModel.VariantGroupID]variantModel.VariantGroupName
+	variants  map[variantModel.VariantID]model.VariantRef
+	dinosaurs map[creatureModel.DinosaurID]creatureModel.DinosaurName
+	uniques   map[creatureModel.UniqueDinosaurID]creatureModel.UniqueName
+}
+
+func (c current) names() names {
+	n := names{
+		groups:    make(map[variantModel.VariantGroupID]variantModel.VariantGroupName, len(c.groups)),
+		variants:  make(map[variantModel.VariantID]model.VariantRef, len(c.variants)),
+		dinosaurs: make(map[creatureModel.DinosaurID]creatureModel.DinosaurName, len(c.dinosaurs)),
+		uniques:   make(map[creatureModel.UniqueDinosaurID]creatureModel.UniqueName, len(c.uniques)),
+	}
+	for _, g := range c.groups {
+		n.groups[g.ID()] = g.Name()
+	}
+	for _, v := range c.variants {
+		n.variants[v.ID()] = model.VariantRef{Group: v.Group(), Name: v.Name()}
+	}
+	for _, d := range c.dinosaurs {
+		n.dinosaurs[d.BaseID()] = d.BaseName()
+	}
+	for _, u := range c.uniques {
+		n.uniques[u.UniqueID()] = u.UniqueName()
+	}
+	return n
+}
+
+// rules ゴミ箱にあるバリアントやグループを対象とする規則は含めない
+func (n names) rules(rules []variantModel.VariantRule) []model.Rule {
+	return lo.FilterMap(rules, func(r variantModel.VariantRule, _ int) (model.Rule, bool) {
+		if r.Kind() == variantModel.RuleGroupExclusive {
+			group, ok := n.groups[*r.GroupID()]
+			return model.Rule{Kind: r.Kind(), Group: group}, ok
+		}
+		variant, ok := n.variants[*r.VariantID()]
+		other, otherOK := n.variants[*r.OtherVariantID()]
+		return model.Rule{Kind: r.Kind(), Variant: variant, Other: other}, ok && otherOK
+	})
+}
+
+// displayNames 対象をIDではなく保存された名前で表す
+func (n names) displayNames(displayNames []translationModel.DisplayName) []model.DisplayName {
+	return lo.FilterMap(displayNames, func(d translationModel.DisplayName, _ int) (model.DisplayName, bool) {
+		var (
+			name string
+			ok   bool
+		)
+		switch d.Target() {
+		case translationModel.TargetGroup:
+			var group variantModel.VariantGroupName
+			group, ok = n.groups[variantModel.VariantGroupID(d.TargetID())]
+			name = group.Value()
+		case translationModel.TargetVariant:
+			var ref model.VariantRef
+			ref, ok = n.variants[variantModel.VariantID(d.TargetID())]
+			name = ref.String()
+		case translationModel.TargetDinosaur:
+			var dinosaur creatureModel.DinosaurName
+			dinosaur, ok = n.dinosaurs[creatureModel.DinosaurID(d.TargetID())]
+			name = dinosaur.Value()
+		case translationModel.TargetUnique:
+			var unique creatureModel.UniqueName
+			unique, ok = n.uniques[creatureModel.UniqueDinosaurID(d.TargetID())]
+			name = unique.Value()
+		}
+		return model.DisplayName{Target: d.Target(), Name: name, Locale: d.Locale(), Value: d.Name()}, ok
+	})
+}
+
+// Export 参照をIDではなく名前で表したカタログ全体を返す
+func (t Transfer) Export(ctx context.Context) (*model.Bundle, error) {
+	c, err := t.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := c.names()
+	return &model.Bundle{
+		Groups: lo.Map(c.groups, func(g variantModel.VariantGroup, _ int) model.Group {
+			return model.Group{Name: g.Name()}
+		}),
+		Variants: lo.Map(c.variants, func(v variantModel.Variant, _ int) model.Variant {
+			return model.Variant{Group: v.Group(), Name: v.Name(), Descriptions: descriptionTexts(v)}
+		}),
+		Dinosaurs: lo.Map(c.dinosaurs, func(d creatureModel.Dinosaur, _ int) model.Dinosaur {
+			return model.Dinosaur{Name: d.BaseName(), Stats: d.Stats(), StatGrowth: d.StatGrowth()}
+		}),
+		Uniques: lo.Map(c.uniques, func(u creatureModel.UniqueDinosaur, _ int) model.Unique {
+			return model.Unique{
+				Name:        u.UniqueName(),
+				Dinosaur:    u.Dinosaur.BaseName(),
+				Multipliers: u.Multipliers(),
+				Variants:    uniqueVariantRefs(u),
+			}
+		}),
+		Rules:        n.rules(c.rules),
+		DisplayNames: n.displayNames(c.displayNames),
+	}, nil
+}
+
+// Import 名前で既存の対象と照合し、無ければ作成、内容が異なれば更新する。全ての適用を1つのトランザクションで行い、
+// 1つでも取り込めない対象があれば何も適用しない。dryRunでは適用せずに計画のみを返す
+func (t Transfer) Import(ctx context.Context, bundle model.Bundle, dryRun bool) (*model.Report, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Report, error) {
+		c, err := t.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		p := newPlan(*c, bundle)
+		report := model.Report{DryRun: dryRun, Changes: p.changes}
+		if dryRun {
+			return &report, nil
+		}
+		if conflicts := report.Conflicts(); len(conflicts) != 0 {
+			fields := lo.Map(conflicts, func(c model.Change, _ int) logic.FieldError {
+				return logic.FieldError{Field: fmt.Sprintf("%s %s", c.Kind, c.Name), Rule: c.Reason.Value(), Param: c.Param}
+			})
+			return nil, failure.Translate(
+				i18n.Wrapf(logic.FieldErrors(fields), "取り込む内容に競合があります。ドライランで内容を確認してください"),
+				logic.UnprocessableEntity,
+			)
+		}
+
+		if err = p.apply(ctx, t.catalog); err != nil {
+			return nil, err
+		}
+		return &report, nil
+	})
+}
+
+func descriptionTexts(v variantModel.Variant) []variantModel.DescriptionText {
+	return lo.Map(v.Descriptions(), func(d variantModel.Description, _ int) variantModel.DescriptionText {
+		return d.Text()
+	})
+}
+
+func uniqueVariantRefs(u creatureModel.UniqueDinosaur) []model.VariantRef {
+	return lo.Map(u.UniqueVariant(), func(v creatureModel.DinosaurVariant, _ int) model.VariantRef {
+		return model.VariantRef{Group: v.Group(), Name: v.Name()}
+	})
+}
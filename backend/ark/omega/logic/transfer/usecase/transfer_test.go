@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+	"mods-explore/ark/omega/logic/transfer/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+type TransferTestSuite struct {
+	suite.Suite
+
+	catalog *mockCatalog
+	usecase TransferUsecase
+}
+
+func TestTransferSuite(t *testing.T) {
+	suite.Run(t, &TransferTestSuite{})
+}
+
+const (
+	listGroups          = "Groups"
+	listVariants        = "Variants"
+	listDinosaurs       = "Dinosaurs"
+	listUniques         = "Uniques"
+	createGroup         = "CreateGroup"
+	createVariant       = "CreateVariant"
+	replaceDescriptions = "ReplaceDescriptions"
+	createDinosaur      = "CreateDinosaur"
+	updateDinosaur      = "UpdateDinosaur"
+	createUnique        = "CreateUnique"
+	updateUnique        = "UpdateUnique"
+	listRules           = "Rules"
+	listDisplayNames    = "DisplayNames"
+	createRule          = "CreateRule"
+	putDisplayName      = "PutDisplayName"
+)
+
+var (
+	cosmic      = model.VariantRef{Group: "Cosmic", Name: "Singularity"}
+	nature      = model.VariantRef{Group: "Nature", Name: "Thunderstorm"}
+	rexStats    = creatureModel.NewDinosaurStats(1000, 300, 150, 3000, 500, 60, 100, 1500, 0)
+	buffedStats = creatureModel.NewDinosaurStats(1200, 300, 150, 3000, 500, 60, 100, 1500, 0)
+	multipliers = creatureModel.NewUniqueMultipliers(
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Health](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Stamina](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Oxygen](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Food](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Weight](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Melee](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.MovementSpeed](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Torpidity](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Armor](),
+	)
+	rexGrowth    = healthGrowth(0.2)
+	buffedGrowth = healthGrowth(0.25)
+	cosmicRule   = model.Rule{Kind: variantModel.RuleGroupExclusive, Group: cosmic.Group}
+)
+
+func healthGrowth(wildIncrease float32) creatureModel.SpeciesStatGrowth {
+	g, err := creatureModel.NewStatGrowth(wildIncrease, 0.054, 0.35, 0)
+	if err != nil {
+		panic(err)
+	}
+	return creatureModel.SpeciesStatGrowth{creatureModel.StatHealth: g}
+}
+
+func (s *TransferTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.catalog = newMockCatalog()
+	do.ProvideValue[service.Catalog](injector, s.catalog)
+	usecase, err := NewTransfer(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+// expectCurrent Cosmicグループのバリアントを1つ持つRexのユニークのみが登録されたカタログを返す。
+// 書き出しと照合がリクエストの言語に依らないことを確かめるため、全ての対象に表示名を持たせる。
+// ゴミ箱にあるバリアントを対象とする規則と表示名は書き出さない
+func (s *TransferTestSuite) expectCurrent() {
+	singularity := variantModel.NewVariant(1, cosmic.Group, cosmic.Name).
+		WithGroupID(1).
+		WithDisplayName("特異点", "宇宙").
+		WithDescriptions(variantModel.Descriptions{variantModel.NewDescription(1, "Destroys corpses.")})
+	rex := creatureModel.NewDinosaur(1, "Rex", rexStats).
+		WithBaseDisplayName("ティラノサウルス").
+		WithStatGrowth(rexGrowth).
+		WithVersion(2)
+	kenny := creatureModel.NewUniqueDinosaur(rex, 1, "Kenny", multipliers, creatureModel.UniqueVariant{
+		creatureModel.NewDinosaurVariant(singularity, creatureModel.VariantDescriptions{"Destroys corpses."}),
+	}).WithUniqueDisplayName("ケニー").WithVersion(3)
+	group := variantModel.NewVariantGroup(1, cosmic.Group).WithDisplayName("宇宙")
+
+	s.catalog.On(listGroups, ctx).Return([]variantModel.VariantGroup{group}, nil).Once()
+	s.catalog.On(listVariants, ctx).Return([]variantModel.Variant{singularity}, nil).Once()
+	s.catalog.On(listDinosaurs, ctx).Return([]creatureModel.Dinosaur{rex}, nil).Once()
+	s.catalog.On(listUniques, ctx).Return([]creatureModel.UniqueDinosaur{kenny}, nil).Once()
+
+	rules := []variantModel.VariantRule{
+		lo.Must(variantModel.NewVariantRule(1, variantModel.RuleGroupExclusive, lo.ToPtr[variantModel.VariantGroupID](1), nil, nil)),
+		lo.Must(variantModel.NewVariantRule(
+			2, variantModel.RuleIncompatible, nil, lo.ToPtr[variantModel.VariantID](1), lo.ToPtr[variantModel.VariantID](99),
+		)),
+	}
+	s.catalog.On(listRules, ctx).Return(rules, nil).Once()
+	s.catalog.On(listDisplayNames, ctx).Return([]translationModel.DisplayName{
+		translationModel.NewDisplayName(translationModel.TargetGroup, 1, i18n.English, "Cosmos"),
+		translationModel.NewDisplayName(translationModel.TargetVariant, 1, i18n.English, "Black hole"),
+		translationModel.NewDisplayName(translationModel.TargetVariant, 99, i18n.English, "Deleted"),
+		translationModel.NewDisplayName(translationModel.TargetDinosaur, 1, i18n.English, "T-Rex"),
+		translationModel.NewDisplayName(translationModel.TargetUnique, 1, i18n.English, "Kenny the Rex"),
+	}, nil).Once()
+}
+
+// importing 既存のグループとバリアントは変えず、新しいグループのバリアントを作成してユニークに付与する。
+// 作成したバリアントとユニークを参照する規則と表示名も作成する
+var importing = model.Bundle{
+	Groups: []model.Group{{Name: cosmic.Group}, {Name: nature.Group}},
+	Variants: []model.Variant{
+		{Group: cosmic.Group, Name: cosmic.Name, Descriptions: []variantModel.DescriptionText{"Destroys corpses."}},
+		{Group: nature.Group, Name: nature.Name, Descriptions: []variantModel.DescriptionText{"Summons lightning."}},
+	},
+	Dinosaurs: []model.Dinosaur{{Name: "rex", Stats: buffedStats, StatGrowth: buffedGrowth}},
+	Uniques: []model.Unique{
+		{Name: "Kenny", Dinosaur: "Rex", Multipliers: multipliers, Variants: []model.VariantRef{cosmic, nature}},
+		{Name: "Lenny", Dinosaur: "Rex", Multipliers: multipliers, Variants: []model.VariantRef{nature}},
+	},
+	Rules: []model.Rule{
+		cosmicRule,
+		{Kind: variantModel.RuleIncompatible, Variant: cosmic, Other: nature},
+	},
+	DisplayNames: []model.DisplayName{
+		{Target: translationModel.TargetGroup, Name: "Cosmic", Locale: i18n.English, Value: "Cosmos"},
+		{Target: translationModel.TargetDinosaur, Name: "rex", Locale: i18n.English, Value: "Tyrant"},
+		{Target: translationModel.TargetUnique, Name: "Lenny", Locale: i18n.English, Value: "Lenny the Rex"},
+	},
+}
+
+var importingChanges = []model.Change{
+	{Kind: model.KindGroup, Name: "Cosmic", Action: model.ActionUnchanged},
+	{Kind: model.KindGroup, Name: "Nature", Action: model.ActionCreate},
+	{Kind: model.KindVariant, Name: "Cosmic/Singularity", Action: model.ActionUnchanged},
+	{Kind: model.KindVariant, Name: "Nature/Thunderstorm", Action: model.ActionCreate},
+	{Kind: model.KindDinosaur, Name: "rex", Action: model.ActionUpdate},
+	{Kind: model.KindUnique, Name: "Kenny", Action: model.ActionUpdate},
+	{Kind: model.KindUnique, Name: "Lenny", Action: model.ActionCreate},
+	{Kind: model.KindRule, Name: "group_exclusive Cosmic", Action: model.ActionUnchanged},
+	{Kind: model.KindRule, Name: "incompatible Cosmic/Singularity Nature/Thunderstorm", Action: model.ActionCreate},
+	{Kind: model.KindDisplayName, Name: "group Cosmic en", Action: model.ActionUnchanged},
+	{Kind: model.KindDisplayName, Name: "dinosaur rex en", Action: model.ActionUpdate},
+	{Kind: model.KindDisplayName, Name: "unique Lenny en", Action: model.ActionCreate},
+}
+
+func (s *TransferTestSuite) TestExport() {
+	s.T().Log("参照を表示名ではなく保存された名前で表したカタログを返すかテスト")
+	s.expectCurrent()
+
+	r, err := s.usecase.Export(ctx)
+	s.NoError(err)
+	s.Equal(&model.Bundle{
+		Groups: []model.Group{{Name: cosmic.Group}},
+		Variants: []model.Variant{
+			{Group: cosmic.Group, Name: cosmic.Name, Descriptions: []variantModel.DescriptionText{"Destroys corpses."}},
+		},
+		Dinosaurs: []model.Dinosaur{{Name: "Rex", Stats: rexStats, StatGrowth: rexGrowth}},
+		Uniques: []model.Unique{
+			{Name: "Kenny", Dinosaur: "Rex", Multipliers: multipliers, Variants: []model.VariantRef{cosmic}},
+		},
+		Rules: []model.Rule{cosmicRule},
+		DisplayNames: []model.DisplayName{
+			{Target: translationModel.TargetGroup, Name: "Cosmic", Locale: i18n.English, Value: "Cosmos"},
+			{Target: translationModel.TargetVariant, Name: "Cosmic/Singularity", Locale: i18n.English, Value: "Black hole"},
+			{Target: translationModel.TargetDinosaur, Name: "Rex", Locale: i18n.English, Value: "T-Rex"},
+			{Target: translationModel.TargetUnique, Name: "Kenny", Locale: i18n.English, Value: "Kenny the Rex"},
+		},
+	}, r)
+}
+
+func (s *TransferTestSuite) TestImport() {
+	{
+		s.T().Log("dry-runでは適用せずに作成と更新の計画のみを返すかテスト")
+		s.expectCurrent()
+
+		r, err := s.usecase.Import(ctx, importing, true)
+		s.NoError(err)
+		s.Equal(&model.Report{DryRun: true, Changes: importingChanges}, r)
+		s.catalog.AssertNotCalled(s.T(), createGroup, mock.Anything, mock.Anything)
+		s.catalog.AssertNotCalled(s.T(), updateUnique, mock.Anything, mock.Anything)
+	}
+	{
+		s.T().Log("作成した対象のIDで参照を解決しながら順に適用するかテスト")
+		s.expectCurrent()
+		s.catalog.On(createGroup, ctx, variantSvc.NewCreateVariantGroup(nature.Group)).
+			Return(variantModel.VariantGroupID(2), nil).Once()
+		created := variantModel.NewVariant(2, nature.Group, nature.Name).WithVersion(1)
+		s.catalog.On(createVariant, ctx, variantSvc.NewCreateVariant(2, nature.Name)).
+			Return(&created, nil).Once()
+		s.catalog.On(replaceDescriptions, ctx,
+			variantSvc.NewReplaceDescriptions(2, 1, []variantModel.DescriptionText{"Summons lightning."}),
+		).Return(nil).Once()
+		s.catalog.On(updateDinosaur, ctx,
+			creatureSvc.NewUpdateDinosaur(1, "Rex", buffedStats, 2).WithStatGrowth(buffedGrowth),
+		).Return(nil).Once()
+		s.catalog.On(updateUnique, ctx,
+			creatureSvc.NewUpdateCreature(1, 1, "Kenny", multipliers, []variantModel.VariantID{1, 2}, 3),
+		).Return(nil).Once()
+		s.catalog.On(createUnique, ctx,
+			creatureSvc.NewCreateCreature(1, "Lenny", multipliers, []variantModel.VariantID{2}),
+		).Return(creatureModel.UniqueDinosaurID(2), nil).Once()
+		s.catalog.On(createRule, ctx, variantSvc.NewCreateVariantRule(lo.Must(variantModel.NewVariantRule(
+			0, variantModel.RuleIncompatible, nil, lo.ToPtr[variantModel.VariantID](1), lo.ToPtr[variantModel.VariantID](2),
+		)))).Return(nil).Once()
+		s.catalog.On(putDisplayName, ctx,
+			translationModel.NewDisplayName(translationModel.TargetDinosaur, 1, i18n.English, "Tyrant"),
+		).Return(nil).Once()
+		s.catalog.On(putDisplayName, ctx,
+			translationModel.NewDisplayName(translationModel.TargetUnique, 2, i18n.English, "Lenny the Rex"),
+		).Return(nil).Once()
+
+		r, err := s.usecase.Import(ctx, importing, false)
+		s.NoError(err)
+		s.Equal(&model.Report{Changes: importingChanges}, r)
+		s.catalog.AssertExpectations(s.T())
+	}
+	{
+		s.T().Log("解決できない参照や重複がある場合は何も適用せずにUnprocessableEntityになるかテスト")
+		s.expectCurrent()
+		conflicting := model.Bundle{
+			Groups:   []model.Group{{Name: nature.Group}, {Name: nature.Group}},
+			Variants: []model.Variant{{Group: "Fire", Name: "Ember"}},
+			Uniques: []model.Unique{
+				{Name: "Benny", Dinosaur: "Dodo", Multipliers: multipliers, Variants: []model.VariantRef{cosmic}},
+				{Name: "Lenny", Dinosaur: "Rex", Multipliers: multipliers, Variants: []model.VariantRef{{Group: "Fire", Name: "Ember"}}},
+			},
+			Rules: []model.Rule{
+				{Kind: variantModel.RuleRequires, Variant: cosmic, Other: model.VariantRef{Group: "Fire", Name: "Ember"}},
+			},
+			DisplayNames: []model.DisplayName{
+				{Target: translationModel.TargetUnique, Name: "Benny", Locale: i18n.English, Value: "Benny"},
+			},
+		}
+
+		_, err := s.usecase.Import(ctx, conflicting, false)
+		s.True(failure.Is(err, logic.UnprocessableEntity))
+		var conflicts logic.FieldErrors
+		s.True(errors.As(err, &conflicts))
+		s.Equal(logic.FieldErrors{
+			{Field: "group Nature", Rule: "duplicate"},
+			{Field: "variant Fire/Ember", Rule: "unknown_group", Param: "Fire"},
+			{Field: "unique Benny", Rule: "unknown_dinosaur", Param: "Dodo"},
+			{Field: "unique Lenny", Rule: "unknown_variant", Param: "Fire/Ember"},
+			{Field: "variant_rule requires Cosmic/Singularity Fire/Ember", Rule: "unknown_variant", Param: "Fire/Ember"},
+			{Field: "display_name unique Benny en", Rule: "unknown_unique", Param: "Benny"},
+		}, conflicts)
+		s.catalog.AssertNumberOfCalls(s.T(), createGroup, 1)
+	}
+	{
+		s.T().Log("成長値を含まない種は登録済みの成長値を変えず、ステータスが同じなら変更なしになるかテスト")
+		s.expectCurrent()
+
+		r, err := s.usecase.Import(ctx, model.Bundle{Dinosaurs: []model.Dinosaur{{Name: "Rex", Stats: rexStats}}}, false)
+		s.NoError(err)
+		s.Equal(&model.Report{Changes: []model.Change{
+			{Kind: model.KindDinosaur, Name: "Rex", Action: model.ActionUnchanged},
+		}}, r)
+	}
+	{
+		s.T().Log("適用に失敗した場合はエラーを返すかテスト")
+		s.expectCurrent()
+		s.catalog.On(createGroup, ctx, variantSvc.NewCreateVariantGroup(nature.Group)).
+			Return(variantModel.VariantGroupID(0), e).Once()
+
+		_, err := s.usecase.Import(ctx, model.Bundle{Groups: []model.Group{{Name: nature.Group}}}, false)
+		s.True(errors.Is(err, e))
+	}
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// Target 表示名を翻訳する対象の種類
+type Target string
+
+const (
+	TargetDinosaur Target = "dinosaur"
+	TargetUnique   Target = "unique"
+	TargetVariant  Target = "variant"
+	TargetGroup    Target = "group"
+)
+
+func (t Target) Value() string { return string(t) }
+
+func NewTarget(value string) (Target, error) {
+	switch t := Target(value); t {
+	case TargetDinosaur, TargetUnique, TargetVariant, TargetGroup:
+		return t, nil
+	default:
+		return "", i18n.Errorf("%qは不明な表示名の対象です", value)
+	}
+}
+
+const maxNameLength = 100
+
+type Name string
+
+func (n Name) Value() string { return string(n) }
+
+func NewName(value string) (Name, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", i18n.Errorf("表示名を指定してください")
+	}
+	if utf8.RuneCountInString(value) > maxNameLength {
+		return "", i18n.Errorf("表示名は%d文字以下にしてください", maxNameLength)
+	}
+	return Name(value), nil
+}
+
+// DisplayName 生物やバリアントの言語毎の表示名。登録されていない言語では元の名前を表示する
+type DisplayName struct {
+	target   Target
+	targetID int
+	locale   i18n.Locale
+	name     Name
+}
+
+func NewDisplayName(target Target, targetID int, locale i18n.Locale, name Name) DisplayName {
+	return DisplayName{target: target, targetID: targetID, locale: locale, name: name}
+}
+
+func (d DisplayName) Target() Target      { return d.target }
+func (d DisplayName) TargetID() int       { return d.targetID }
+func (d DisplayName) Locale() i18n.Locale { return d.locale }
+func (d DisplayName) Name() Name          { return d.name }
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+)
+
+type DisplayNameRepository interface {
+	// Exists 表示名を登録する対象が存在するか
+	Exists(context.Context, model.Target, int) (bool, error)
+	List(context.Context, model.Target, int) ([]model.DisplayName, error)
+	// ListAll ゴミ箱にない対象の表示名を、対象のID順に全て取得する
+	ListAll(context.Context, model.Target) ([]model.DisplayName, error)
+	Upsert(context.Context, model.DisplayName) (*model.DisplayName, error)
+	Delete(context.Context, model.Target, int, i18n.Locale) error
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/translation/domain/service"
+)
+
+type DisplayNameUsecase interface {
+	List(context.Context, model.Target, int) ([]model.DisplayName, error)
+	ListAll(context.Context, model.Target) ([]model.DisplayName, error)
+	Put(context.Context, model.DisplayName) (*model.DisplayName, error)
+	Delete(context.Context, model.Target, int, i18n.Locale) error
+}
+
+type DisplayName struct {
+	repository service.DisplayNameRepository
+}
+
+func NewDisplayName(injector *do.Injector) (DisplayNameUsecase, error) {
+	return &DisplayName{
+		repository: do.MustInvoke[service.DisplayNameRepository](injector),
+	}, nil
+}
+
+func (d DisplayName) exists(ctx context.Context, target model.Target, id int) error {
+	ok, err := d.repository.Exists(ctx, target, id)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	if !ok {
+		return failure.New(logic.NotFound)
+	}
+	return nil
+}
+
+func (d DisplayName) List(ctx context.Context, target model.Target, id int) ([]model.DisplayName, error) {
+	if err := d.exists(ctx, target, id); err != nil {
+		return nil, err
+	}
+
+	names, err := d.repository.List(ctx, target, id)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return names, nil
+}
+
+// ListAll 一括の書き出しのため、対象の種類毎に全ての表示名をまとめて返す
+func (d DisplayName) ListAll(ctx context.Context, target model.Target) ([]model.DisplayName, error) {
+	names, err := d.repository.ListAll(ctx, target)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return names, nil
+}
+
+// Put 対象の言語の表示名が無ければ登録し、あれば置き換える
+func (d DisplayName) Put(ctx context.Context, name model.DisplayName) (*model.DisplayName, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.DisplayName, error) {
+		if err := d.exists(ctx, name.Target(), name.TargetID()); err != nil {
+			return nil, err
+		}
+
+		stored, err := d.repository.Upsert(ctx, name)
+		if err != nil {
+			return nil, failure.Wrap(err)
+		}
+		return stored, nil
+	})
+}
+
+func (d DisplayName) Delete(ctx context.Context, target model.Target, id int, locale i18n.Locale) error {
+	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
+		if err := d.repository.Delete(ctx, target, id, locale); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return failure.New(logic.NotFound)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return failure.New(logic.IntervalServerError)
+			}
+			return failure.Wrap(err)
+		}
+		return nil
+	})
+}
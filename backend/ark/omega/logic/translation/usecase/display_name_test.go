@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/translation/domain/service"
+)
+
+type DisplayNameTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockDisplayName
+	usecase DisplayNameUsecase
+}
+
+func TestDisplayNameSuite(t *testing.T) {
+	suite.Run(t, &DisplayNameTestSuite{})
+}
+
+const (
+	existsDisplayName = "Exists"
+	listDisplayName   = "List"
+	upsertDisplayName = "Upsert"
+	deleteDisplayName = "Delete"
+)
+
+func (s *DisplayNameTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.mockDB = newMockDisplayName()
+	do.ProvideValue[service.DisplayNameRepository](injector, s.mockDB)
+	usecase, err := NewDisplayName(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+func (s *DisplayNameTestSuite) TestList() {
+	names := []model.DisplayName{model.NewDisplayName(model.TargetDinosaur, id, i18n.Japanese, "ティラノサウルス")}
+	{
+		s.T().Log("登録された表示名の一覧を取得するテスト")
+		s.mockDB.On(existsDisplayName, ctx, model.TargetDinosaur, id).Return(true, nil).Once()
+		s.mockDB.On(listDisplayName, ctx, model.TargetDinosaur, id).Return(names, nil).Once()
+
+		r, err := s.usecase.List(ctx, model.TargetDinosaur, id)
+		s.NoError(err)
+		s.Equal(names, r)
+	}
+	{
+		s.T().Log("存在しない対象の表示名を取得するテスト")
+		s.mockDB.On(existsDisplayName, ctx, model.TargetDinosaur, notExistID).Return(false, nil).Once()
+
+		_, err := s.usecase.List(ctx, model.TargetDinosaur, notExistID)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+	{
+		s.mockDB.On(existsDisplayName, ctx, model.TargetDinosaur, errID).Return(false, service.IntervalServerError).Once()
+
+		_, err := s.usecase.List(ctx, model.TargetDinosaur, errID)
+		s.True(failure.Is(err, logic.IntervalServerError))
+	}
+}
+
+func (s *DisplayNameTestSuite) TestPut() {
+	name := model.NewDisplayName(model.TargetVariant, id, i18n.English, "Cosmic")
+	{
+		s.T().Log("表示名を登録するテスト")
+		s.mockDB.On(existsDisplayName, ctx, model.TargetVariant, id).Return(true, nil).Once()
+		s.mockDB.On(upsertDisplayName, ctx, name).Return(&name, nil).Once()
+
+		r, err := s.usecase.Put(ctx, name)
+		s.NoError(err)
+		s.Equal(&name, r)
+	}
+	{
+		s.T().Log("存在しない対象に表示名を登録するテスト")
+		missing := model.NewDisplayName(model.TargetVariant, notExistID, i18n.English, "Cosmic")
+		s.mockDB.On(existsDisplayName, ctx, model.TargetVariant, notExistID).Return(false, nil).Once()
+
+		_, err := s.usecase.Put(ctx, missing)
+		s.True(failure.Is(err, logic.NotFound))
+		s.mockDB.AssertNotCalled(s.T(), upsertDisplayName, ctx, missing)
+	}
+	{
+		s.mockDB.On(existsDisplayName, ctx, model.TargetVariant, id).Return(true, nil).Once()
+		s.mockDB.On(upsertDisplayName, ctx, name).Return(nil, e).Once()
+
+		_, err := s.usecase.Put(ctx, name)
+		s.Error(err)
+	}
+}
+
+func (s *DisplayNameTestSuite) TestDelete() {
+	{
+		s.T().Log("表示名を削除するテスト")
+		s.mockDB.On(deleteDisplayName, ctx, model.TargetGroup, id, i18n.English).Return(nil).Once()
+		s.NoError(s.usecase.Delete(ctx, model.TargetGroup, id, i18n.English))
+	}
+	{
+		s.T().Log("登録されていない表示名を削除するテスト")
+		s.mockDB.On(deleteDisplayName, ctx, model.TargetGroup, notExistID, i18n.English).Return(service.NotFound).Once()
+		s.True(failure.Is(s.usecase.Delete(ctx, model.TargetGroup, notExistID, i18n.English), logic.NotFound))
+	}
+}
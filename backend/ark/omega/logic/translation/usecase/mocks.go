@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/translation/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+const (
+	id = iota + 1
+	notExistID
+	errID
+)
+
+var _ service.DisplayNameRepository = (*mockDisplayName)(nil)
+
+type mockDisplayName struct {
+	mock.Mock
+}
+
+func newMockDisplayName() *mockDisplayName { return &mockDisplayName{} }
+
+func (m *mockDisplayName) Exists(ctx context.Context, target model.Target, id int) (bool, error) {
+	args := m.Called(ctx, target, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockDisplayName) List(ctx context.Context, target model.Target, id int) ([]model.DisplayName, error) {
+	args := m.Called(ctx, target, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.([]model.DisplayName), args.Error(1)
+}
+
+func (m *mockDisplayName) ListAll(ctx context.Context, target model.Target) ([]model.DisplayName, error) {
+	args := m.Called(ctx, target)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.([]model.DisplayName), args.Error(1)
+}
+
+func (m *mockDisplayName) Upsert(ctx context.Context, name model.DisplayName) (*model.DisplayName, error) {
+	args := m.Called(ctx, name)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*model.DisplayName), args.Error(1)
+}
+
+func (m *mockDisplayName) Delete(ctx context.Context, target model.Target, id int, locale i18n.Locale) error {
+	args := m.Called(ctx, target, id, locale)
+	return args.Error(0)
+}
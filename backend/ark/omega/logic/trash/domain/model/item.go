@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// ItemType ゴミ箱に入るカタログの対象
+type ItemType string
+
+const (
+	ItemDinosaur ItemType = "dinosaur"
+	ItemUnique   ItemType = "unique"
+	ItemVariant  ItemType = "variant"
+	ItemGroup    ItemType = "group"
+)
+
+func (t ItemType) Value() string { return string(t) }
+
+func NewItemType(value string) (ItemType, error) {
+	switch t := ItemType(value); t {
+	case ItemDinosaur, ItemUnique, ItemVariant, ItemGroup:
+		return t, nil
+	default:
+		return "", i18n.Errorf("%qは不明な種類です", value)
+	}
+}
+
+// Item ゴミ箱にある1件の対象。種類毎にIDは重複する
+type Item struct {
+	itemType  ItemType
+	id        int
+	name      string
+	deletedAt time.Time
+}
+
+func NewItem(itemType ItemType, id int, name string, deletedAt time.Time) Item {
+	return Item{itemType: itemType, id: id, name: name, deletedAt: deletedAt}
+}
+
+func (i Item) Type() ItemType       { return i.itemType }
+func (i Item) ID() int              { return i.id }
+func (i Item) Name() string         { return i.name }
+func (i Item) DeletedAt() time.Time { return i.deletedAt }
+
+// Purged 完全に削除した件数を種類毎に保持する
+type Purged map[ItemType]int
+
+func (p Purged) Total() int {
+	var n int
+	for _, count := range p {
+		n += count
+	}
+	return n
+}
@@ -0,0 +1,7 @@
+package service
+
+import "errors"
+
+var (
+	IntervalServerError = errors.New("interval server error")
+)
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+)
+
+// ListTrash 削除日時の順に取得する。typesが空の場合は全ての種類を対象とする
+type ListTrash struct {
+	logic.PageRequest
+	types []model.ItemType
+}
+
+func NewListTrash(page logic.PageRequest, types []model.ItemType) ListTrash {
+	return ListTrash{PageRequest: page, types: types}
+}
+
+func (l ListTrash) Types() []model.ItemType { return l.types }
+
+type TrashRepository interface {
+	List(context.Context, ListTrash) (*logic.Page[model.Item], error)
+	// Purge beforeより前に削除した行を完全に削除する。ゴミ箱にない行から参照されている行は残す
+	Purge(context.Context, time.Time) (model.Purged, error)
+}
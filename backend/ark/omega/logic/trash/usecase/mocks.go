@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+)
+
+var (
+	ctx = context.Background()
+	e   = errors.New("test")
+)
+
+var _ service.TrashRepository = (*mockTrash)(nil)
+
+type mockTrash struct {
+	mock.Mock
+}
+
+func newMockTrash() *mockTrash { return &mockTrash{} }
+
+func (m *mockTrash) List(ctx context.Context, query service.ListTrash) (*logic.Page[model.Item], error) {
+	args := m.Called(ctx, query)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(*logic.Page[model.Item]), args.Error(1)
+}
+
+func (m *mockTrash) Purge(ctx context.Context, before time.Time) (model.Purged, error) {
+	args := m.Called(ctx, before)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(model.Purged), args.Error(1)
+}
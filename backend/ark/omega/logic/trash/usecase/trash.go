@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+)
+
+type TrashUsecase interface {
+	List(context.Context, service.ListTrash) (*logic.Page[model.Item], error)
+	Purge(context.Context, time.Time) (model.Purged, error)
+}
+
+type Trash struct {
+	repository service.TrashRepository
+}
+
+func NewTrash(injector *do.Injector) (TrashUsecase, error) {
+	return &Trash{
+		repository: do.MustInvoke[service.TrashRepository](injector),
+	}, nil
+}
+
+func (t Trash) List(ctx context.Context, query service.ListTrash) (*logic.Page[model.Item], error) {
+	items, err := t.repository.List(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return items, nil
+}
+
+// Purge 参照する側から順に削除するため、全ての種類を1つのトランザクションで削除する
+func (t Trash) Purge(ctx context.Context, before time.Time) (model.Purged, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (model.Purged, error) {
+		purged, err := t.repository.Purge(ctx, before)
+		if err != nil {
+			if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+		return purged, nil
+	})
+}
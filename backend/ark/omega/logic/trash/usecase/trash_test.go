@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+)
+
+type TrashTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockTrash
+	usecase TrashUsecase
+}
+
+func TestTrashSuite(t *testing.T) {
+	suite.Run(t, &TrashTestSuite{})
+}
+
+const (
+	listTrash  = "List"
+	purgeTrash = "Purge"
+)
+
+func (s *TrashTestSuite) SetupTest() {
+	injector := do.New()
+
+	s.mockDB = newMockTrash()
+	do.ProvideValue[service.TrashRepository](injector, s.mockDB)
+	usecase, err := NewTrash(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+}
+
+func (s *TrashTestSuite) TestList() {
+	page, err := logic.NewPageRequest(0, "", "")
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query := service.NewListTrash(page, []model.ItemType{model.ItemVariant})
+	{
+		s.T().Log("ゴミ箱の一覧を取得できるかテスト")
+		items := logic.NewPage([]model.Item{
+			model.NewItem(model.ItemVariant, 1, "cosmic", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)),
+		}, "")
+		s.mockDB.On(listTrash, ctx, query).Return(&items, nil).Once()
+
+		r, err := s.usecase.List(ctx, query)
+		s.NoError(err)
+		s.Equal(&items, r)
+	}
+	{
+		s.mockDB.On(listTrash, ctx, query).Return(nil, service.IntervalServerError).Once()
+
+		_, err := s.usecase.List(ctx, query)
+		s.True(failure.Is(err, logic.IntervalServerError))
+	}
+}
+
+func (s *TrashTestSuite) TestPurge() {
+	before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	{
+		s.T().Log("削除した件数を返すかテスト")
+		purged := model.Purged{model.ItemUnique: 2, model.ItemDinosaur: 1}
+		s.mockDB.On(purgeTrash, ctx, before).Return(purged, nil).Once()
+
+		r, err := s.usecase.Purge(ctx, before)
+		s.NoError(err)
+		s.Equal(purged, r)
+		s.Equal(3, r.Total())
+	}
+	{
+		s.mockDB.On(purgeTrash, ctx, before).Return(nil, e).Once()
+
+		_, err := s.usecase.Purge(ctx, before)
+		s.True(errors.Is(err, e))
+	}
+}
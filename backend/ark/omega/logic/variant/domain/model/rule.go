@@ -0,0 +1,140 @@
+package model
+
+import (
+	"errors"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+type VariantRuleID int
+
+func (i VariantRuleID) Value() int { return int(i) }
+
+// VariantRuleKind ユニークに付与するバリアントの組み合わせの規則の種類
+type VariantRuleKind string
+
+const (
+	// RuleGroupExclusive 同じグループのバリアントは1つまでしか付与できない
+	RuleGroupExclusive VariantRuleKind = "group_exclusive"
+	// RuleIncompatible 2つのバリアントを同時に付与できない
+	RuleIncompatible VariantRuleKind = "incompatible"
+	// RuleRequires バリアントを付与する場合はもう一方のバリアントも付与する
+	RuleRequires VariantRuleKind = "requires"
+)
+
+func (k VariantRuleKind) Value() string { return string(k) }
+
+func NewVariantRuleKind(value string) (VariantRuleKind, error) {
+	switch k := VariantRuleKind(value); k {
+	case RuleGroupExclusive, RuleIncompatible, RuleRequires:
+		return k, nil
+	default:
+		return "", i18n.Errorf("%qは不明な規則です", value)
+	}
+}
+
+// VariantRule 規則の種類によってグループか2つのバリアントのいずれかを対象とする
+type VariantRule struct {
+	id             VariantRuleID
+	kind           VariantRuleKind
+	groupID        *VariantGroupID
+	variantID      *VariantID
+	otherVariantID *VariantID
+}
+
+func NewVariantRule(
+	id VariantRuleID, kind VariantRuleKind, groupID *VariantGroupID, variantID, otherVariantID *VariantID,
+) (VariantRule, error) {
+	switch kind {
+	case RuleGroupExclusive:
+		if groupID == nil || variantID != nil || otherVariantID != nil {
+			return VariantRule{}, i18n.Errorf("グループの規則はグループのみを指定してください")
+		}
+	case RuleIncompatible, RuleRequires:
+		if groupID != nil || variantID == nil || otherVariantID == nil {
+			return VariantRule{}, i18n.Errorf("バリアントの規則は2つのバリアントのみを指定してください")
+		}
+		if *variantID == *otherVariantID {
+			return VariantRule{}, i18n.Errorf("異なるバリアントを指定してください")
+		}
+	default:
+		return VariantRule{}, i18n.Errorf("%qは不明な規則です", kind)
+	}
+	return VariantRule{
+		id:             id,
+		kind:           kind,
+		groupID:        groupID,
+		variantID:      variantID,
+		otherVariantID: otherVariantID,
+	}, nil
+}
+
+func (r VariantRule) ID() VariantRuleID          { return r.id }
+func (r VariantRule) Kind() VariantRuleKind      { return r.kind }
+func (r VariantRule) GroupID() *VariantGroupID   { return r.groupID }
+func (r VariantRule) VariantID() *VariantID      { return r.variantID }
+func (r VariantRule) OtherVariantID() *VariantID { return r.otherVariantID }
+
+// ComposedVariant 規則の判定に用いる、ユニークに付与するバリアントとそのグループ
+type ComposedVariant struct {
+	id      VariantID
+	groupID VariantGroupID
+}
+
+func NewComposedVariant(id VariantID, groupID VariantGroupID) ComposedVariant {
+	return ComposedVariant{id: id, groupID: groupID}
+}
+
+func (v ComposedVariant) ID() VariantID           { return v.id }
+func (v ComposedVariant) GroupID() VariantGroupID { return v.groupID }
+
+type ComposedVariants []ComposedVariant
+
+func (vs ComposedVariants) contains(id VariantID) bool {
+	for _, v := range vs {
+		if v.id == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (vs ComposedVariants) countGroup(id VariantGroupID) int {
+	var n int
+	for _, v := range vs {
+		if v.groupID == id {
+			n++
+		}
+	}
+	return n
+}
+
+// Check 規則に違反する場合は違反した規則を示すエラーを返す
+func (r VariantRule) Check(variants ComposedVariants) error {
+	switch r.kind {
+	case RuleGroupExclusive:
+		if variants.countGroup(*r.groupID) > 1 {
+			return i18n.Errorf("規則%d: グループ%dのバリアントは1つまでです", r.id, *r.groupID)
+		}
+	case RuleIncompatible:
+		if variants.contains(*r.variantID) && variants.contains(*r.otherVariantID) {
+			return i18n.Errorf("規則%d: バリアント%dはバリアント%dと併用できません", r.id, *r.variantID, *r.otherVariantID)
+		}
+	case RuleRequires:
+		if variants.contains(*r.variantID) && !variants.contains(*r.otherVariantID) {
+			return i18n.Errorf("規則%d: バリアント%dにはバリアント%dが必要です", r.id, *r.variantID, *r.otherVariantID)
+		}
+	}
+	return nil
+}
+
+type VariantRules []VariantRule
+
+// Check 違反した全ての規則をまとめて返す
+func (rs VariantRules) Check(variants ComposedVariants) error {
+	errs := make([]error, 0, len(rs))
+	for _, r := range rs {
+		errs = append(errs, r.Check(variants))
+	}
+	return errors.Join(errs...)
+}
@@ -1,5 +1,13 @@
 package model
 
+import (
+	"strings"
+	"unicode/utf8"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
 type VariantID int
 
 func (i VariantID) Value() int { return int(i) }
@@ -9,9 +17,15 @@ type Name string
 func (n Name) Value() string { return string(n) }
 
 type Variant struct {
-	id    VariantID
-	group VariantGroupName
-	name  Name
+	id           VariantID
+	groupID      VariantGroupID
+	group        VariantGroupName
+	name         Name
+	descriptions Descriptions
+	version      logic.Version
+
+	displayName      Name
+	groupDisplayName VariantGroupName
 }
 
 type Variants []Variant
@@ -28,13 +42,102 @@ func (v Variant) ID() VariantID           { return v.id }
 func (v Variant) Group() VariantGroupName { return v.group }
 func (v Variant) Name() Name              { return v.name }
 
+// GroupID グループのIDを読み込んでいない場合は0
+func (v Variant) GroupID() VariantGroupID { return v.groupID }
+
+// WithGroupID 更新のリクエストと同じ項目で扱うため、属するグループのIDを読み込んだバリアントを返す
+func (v Variant) WithGroupID(id VariantGroupID) Variant {
+	v.groupID = id
+	return v
+}
+
+// DisplayName リクエストの言語の表示名。表示名が無い場合は元の名前
+func (v Variant) DisplayName() Name {
+	if v.displayName == "" {
+		return v.name
+	}
+	return v.displayName
+}
+
+// GroupDisplayName リクエストの言語のグループの表示名。表示名が無い場合は元の名前
+func (v Variant) GroupDisplayName() VariantGroupName {
+	if v.groupDisplayName == "" {
+		return v.group
+	}
+	return v.groupDisplayName
+}
+
+// WithDisplayName 表示名を読み込んだバリアントを返す。Name、Groupは保存された名前のまま変えない
+func (v Variant) WithDisplayName(name Name, group VariantGroupName) Variant {
+	v.displayName = name
+	v.groupDisplayName = group
+	return v
+}
+
+// Descriptions 説明文を読み込んでいない場合は空
+func (v Variant) Descriptions() Descriptions { return v.descriptions }
+
+// WithDescriptions 説明文を読み込んだバリアントを返す
+func (v Variant) WithDescriptions(descriptions Descriptions) Variant {
+	v.descriptions = descriptions
+	return v
+}
+
+// Version 更新の度に増える行のバージョン。ETagとしてクライアントに渡す
+func (v Variant) Version() logic.Version { return v.version }
+
+// WithVersion 更新時の競合の検出に用いるバージョンを読み込んだバリアントを返す
+func (v Variant) WithVersion(version logic.Version) Variant {
+	v.version = version
+	return v
+}
+
+const maxDescriptionLength = 500
+
+type DescriptionID int
+
+func (i DescriptionID) Value() int { return int(i) }
+
+// DescriptionText "AoE explosive tick damage, traps dinos in center."のようなバリアントの効果の1行分の説明
+type DescriptionText string
+
+func (t DescriptionText) Value() string { return string(t) }
+
+func NewDescriptionText(value string) (DescriptionText, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", i18n.Errorf("説明文が空です")
+	}
+	if utf8.RuneCountInString(value) > maxDescriptionLength {
+		return "", i18n.Errorf("説明文は500文字以内にしてください")
+	}
+	return DescriptionText(value), nil
+}
+
+type Description struct {
+	id   DescriptionID
+	text DescriptionText
+}
+
+func NewDescription(id DescriptionID, text DescriptionText) Description {
+	return Description{id: id, text: text}
+}
+
+func (d Description) ID() DescriptionID     { return d.id }
+func (d Description) Text() DescriptionText { return d.text }
+
+// Descriptions 表示順に並んだ説明文
+type Descriptions []Description
+
 type VariantGroup struct {
-	id   VariantGroupID
-	name VariantGroupName
+	id          VariantGroupID
+	name        VariantGroupName
+	displayName VariantGroupName
+	version     logic.Version
 }
 
 func NewVariantGroup(id VariantGroupID, name VariantGroupName) VariantGroup {
-	return VariantGroup{id, name}
+	return VariantGroup{id: id, name: name}
 }
 
 type VariantGroupID uint
@@ -45,4 +148,27 @@ func (n VariantGroupName) Value() string { return string(n) }
 func (g VariantGroup) ID() VariantGroupID     { return g.id }
 func (g VariantGroup) Name() VariantGroupName { return g.name }
 
+// DisplayName リクエストの言語の表示名。表示名が無い場合は元の名前
+func (g VariantGroup) DisplayName() VariantGroupName {
+	if g.displayName == "" {
+		return g.name
+	}
+	return g.displayName
+}
+
+// WithDisplayName 表示名を読み込んだグループを返す。Nameは保存された名前のまま変えない
+func (g VariantGroup) WithDisplayName(name VariantGroupName) VariantGroup {
+	g.displayName = name
+	return g
+}
+
+// Version 更新の度に増える行のバージョン。ETagとしてクライアントに渡す
+func (g VariantGroup) Version() logic.Version { return g.version }
+
+// WithVersion 更新時の競合の検出に用いるバージョンを読み込んだグループを返す
+func (g VariantGroup) WithVersion(version logic.Version) VariantGroup {
+	g.version = version
+	return g
+}
+
 type VariantGroups []VariantGroup
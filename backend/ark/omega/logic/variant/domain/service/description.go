@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+// 説明文の変更はバリアントの更新として扱うため、いずれもバリアントのバージョンを指定する
+
+type CreateDescription struct {
+	variantID model.VariantID
+	version   logic.Version
+	text      model.DescriptionText
+}
+
+// NewCreateDescription 説明文は既存の説明文の末尾に追加する
+func NewCreateDescription(
+	variantID model.VariantID, version logic.Version, text model.DescriptionText,
+) CreateDescription {
+	return CreateDescription{variantID, version, text}
+}
+
+func (d CreateDescription) VariantID() model.VariantID  { return d.variantID }
+func (d CreateDescription) Version() logic.Version      { return d.version }
+func (d CreateDescription) Text() model.DescriptionText { return d.text }
+
+type UpdateDescription struct {
+	variantID model.VariantID
+	version   logic.Version
+	id        model.DescriptionID
+	text      model.DescriptionText
+}
+
+func NewUpdateDescription(
+	variantID model.VariantID, version logic.Version, id model.DescriptionID, text model.DescriptionText,
+) UpdateDescription {
+	return UpdateDescription{variantID, version, id, text}
+}
+
+func (d UpdateDescription) VariantID() model.VariantID  { return d.variantID }
+func (d UpdateDescription) Version() logic.Version      { return d.version }
+func (d UpdateDescription) ID() model.DescriptionID     { return d.id }
+func (d UpdateDescription) Text() model.DescriptionText { return d.text }
+
+type DeleteDescription struct {
+	variantID model.VariantID
+	version   logic.Version
+	id        model.DescriptionID
+}
+
+func NewDeleteDescription(variantID model.VariantID, version logic.Version, id model.DescriptionID) DeleteDescription {
+	return DeleteDescription{variantID, version, id}
+}
+
+func (d DeleteDescription) VariantID() model.VariantID { return d.variantID }
+func (d DeleteDescription) Version() logic.Version     { return d.version }
+func (d DeleteDescription) ID() model.DescriptionID    { return d.id }
+
+type ReplaceDescriptions struct {
+	variantID model.VariantID
+	version   logic.Version
+	texts     []model.DescriptionText
+}
+
+// NewReplaceDescriptions textsの順序がそのまま表示順になる
+func NewReplaceDescriptions(
+	variantID model.VariantID, version logic.Version, texts []model.DescriptionText,
+) ReplaceDescriptions {
+	return ReplaceDescriptions{variantID, version, texts}
+}
+
+func (d ReplaceDescriptions) VariantID() model.VariantID     { return d.variantID }
+func (d ReplaceDescriptions) Version() logic.Version         { return d.version }
+func (d ReplaceDescriptions) Texts() []model.DescriptionText { return d.texts }
+
+// VariantDescriptionRepository 説明文の読み込みはVariantRepository.FindVariantで行う
+type VariantDescriptionRepository interface {
+	// TouchVariant 指定したバージョンの場合のみバリアントのバージョンを上げる。一致しない場合はVersionMismatchを返す
+	TouchVariant(context.Context, model.VariantID, logic.Version) error
+	CreateDescription(context.Context, CreateDescription) (model.DescriptionID, error)
+	UpdateDescription(context.Context, UpdateDescription) error
+	DeleteDescription(context.Context, DeleteDescription) error
+	ReplaceDescriptions(context.Context, ReplaceDescriptions) error
+}
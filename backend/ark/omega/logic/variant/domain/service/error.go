@@ -5,4 +5,8 @@ import "errors"
 var (
 	NotFound            = errors.New("not found")
 	IntervalServerError = errors.New("interval server error")
+	// VersionMismatch 条件付きの更新で、指定したバージョンの行が無かった
+	VersionMismatch = errors.New("version mismatch")
+	// ParentDeleted 復元しようとした行が参照する行がゴミ箱にある
+	ParentDeleted = errors.New("parent deleted")
 )
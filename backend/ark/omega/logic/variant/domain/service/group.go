@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 )
 
@@ -15,22 +19,89 @@ func NewCreateVariantGroup(name model.VariantGroupName) CreateVariantGroup {
 }
 func (v CreateVariantGroup) Name() model.VariantGroupName { return v.name }
 
+// UpdateVariantGroup versionはクライアントが取得した時点のバージョンで、一致する場合のみ更新する
 type UpdateVariantGroup struct {
-	id   model.VariantGroupID
-	name model.VariantGroupName
+	id      model.VariantGroupID
+	name    model.VariantGroupName
+	version logic.Version
 }
 
-func NewUpdateVariantGroup(id model.VariantGroupID, name model.VariantGroupName) UpdateVariantGroup {
-	return UpdateVariantGroup{id, name}
+func NewUpdateVariantGroup(id model.VariantGroupID, name model.VariantGroupName, version logic.Version) UpdateVariantGroup {
+	return UpdateVariantGroup{id, name, version}
 }
 
 func (v UpdateVariantGroup) ID() model.VariantGroupID     { return v.id }
 func (v UpdateVariantGroup) Name() model.VariantGroupName { return v.name }
+func (v UpdateVariantGroup) Version() logic.Version       { return v.version }
+
+// DeleteVariantGroup グループに属するバリアントがある場合、cascadeでは一緒に削除し、reassignToでは指定したグループへ移す。
+// どちらも指定しない場合は削除しない
+type DeleteVariantGroup struct {
+	id         model.VariantGroupID
+	version    logic.Version
+	cascade    bool
+	reassignTo *model.VariantGroupID
+}
+
+func NewDeleteVariantGroup(
+	id model.VariantGroupID, version logic.Version, cascade bool, reassignTo *model.VariantGroupID,
+) (DeleteVariantGroup, error) {
+	if cascade && reassignTo != nil {
+		return DeleteVariantGroup{}, failure.Translate(
+			i18n.Errorf("cascadeとreassign_toは同時に指定できません"), logic.InvalidArgument,
+		)
+	}
+	if reassignTo != nil && *reassignTo == id {
+		return DeleteVariantGroup{}, failure.Translate(
+			i18n.Errorf("reassign_toには別のバリアントグループを指定してください"), logic.InvalidArgument,
+		)
+	}
+	return DeleteVariantGroup{id: id, version: version, cascade: cascade, reassignTo: reassignTo}, nil
+}
+
+func (d DeleteVariantGroup) ID() model.VariantGroupID          { return d.id }
+func (d DeleteVariantGroup) Version() logic.Version            { return d.version }
+func (d DeleteVariantGroup) Cascade() bool                     { return d.cascade }
+func (d DeleteVariantGroup) ReassignTo() *model.VariantGroupID { return d.reassignTo }
 
 type VariantGroupRepository interface {
 	Select(context.Context, model.VariantGroupID) (*model.VariantGroup, error)
-	List(context.Context) (model.VariantGroups, error)
+	List(context.Context, ListVariantGroups) (*logic.Page[model.VariantGroup], error)
 	Insert(context.Context, CreateVariantGroup) (*model.VariantGroup, error)
 	Update(context.Context, UpdateVariantGroup) (*model.VariantGroup, error)
-	Delete(context.Context, model.VariantGroupID) error
+	Delete(context.Context, model.VariantGroupID, logic.Version) error
+	// Restore ゴミ箱にないグループはNotFoundを返す
+	Restore(context.Context, model.VariantGroupID) error
+	// SelectDependents グループに属するゴミ箱にないバリアントを返す
+	SelectDependents(context.Context, model.VariantGroupID) (logic.Dependents, error)
+	// MoveVariants グループに属するゴミ箱にないバリアントを別のグループへ移す
+	MoveVariants(ctx context.Context, from, to model.VariantGroupID) error
+}
+
+type VariantGroupSortKey string
+
+const (
+	VariantGroupSortByID        VariantGroupSortKey = "id"
+	VariantGroupSortByName      VariantGroupSortKey = "name"
+	VariantGroupSortByUpdatedAt VariantGroupSortKey = "updated_at"
+)
+
+func (k VariantGroupSortKey) Value() string { return string(k) }
+
+type ListVariantGroups struct {
+	logic.PageRequest
+	sortKey VariantGroupSortKey
+}
+
+func NewListVariantGroups(page logic.PageRequest, sortKey VariantGroupSortKey) (ListVariantGroups, error) {
+	switch sortKey {
+	case "":
+		sortKey = VariantGroupSortByID
+	case VariantGroupSortByID, VariantGroupSortByName, VariantGroupSortByUpdatedAt:
+	default:
+		return ListVariantGroups{}, failure.Translate(i18n.Errorf("%qは不明な並び替えのキーです", sortKey), logic.InvalidArgument)
+	}
+	return ListVariantGroups{PageRequest: page, sortKey: sortKey}, nil
 }
+
+func (l ListVariantGroups) SortKey() VariantGroupSortKey { return l.sortKey }
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+type CreateVariantRule struct {
+	rule model.VariantRule
+}
+
+// NewCreateVariantRule IDは採番前のため0のままの規則を受け取る
+func NewCreateVariantRule(rule model.VariantRule) CreateVariantRule {
+	return CreateVariantRule{rule}
+}
+
+func (r CreateVariantRule) Rule() model.VariantRule { return r.rule }
+
+type UpdateVariantRule struct {
+	rule model.VariantRule
+}
+
+func NewUpdateVariantRule(rule model.VariantRule) UpdateVariantRule {
+	return UpdateVariantRule{rule}
+}
+
+func (r UpdateVariantRule) ID() model.VariantRuleID { return r.rule.ID() }
+func (r UpdateVariantRule) Rule() model.VariantRule { return r.rule }
+
+type VariantRuleRepository interface {
+	Select(context.Context, model.VariantRuleID) (*model.VariantRule, error)
+	List(context.Context, ListVariantRules) (*logic.Page[model.VariantRule], error)
+	Insert(context.Context, CreateVariantRule) (*model.VariantRule, error)
+	Update(context.Context, UpdateVariantRule) (*model.VariantRule, error)
+	Delete(context.Context, model.VariantRuleID) error
+	// SelectApplicable 指定したバリアントかそのグループを対象とする規則を取得する
+	SelectApplicable(context.Context, model.ComposedVariants) (model.VariantRules, error)
+	// SelectComposed 存在しないバリアントは結果に含めない
+	SelectComposed(context.Context, []model.VariantID) (model.ComposedVariants, error)
+}
+
+type VariantRuleSortKey string
+
+const (
+	VariantRuleSortByID        VariantRuleSortKey = "id"
+	VariantRuleSortByKind      VariantRuleSortKey = "kind"
+	VariantRuleSortByUpdatedAt VariantRuleSortKey = "updated_at"
+)
+
+func (k VariantRuleSortKey) Value() string { return string(k) }
+
+type ListVariantRules struct {
+	logic.PageRequest
+	sortKey VariantRuleSortKey
+}
+
+func NewListVariantRules(page logic.PageRequest, sortKey VariantRuleSortKey) (ListVariantRules, error) {
+	switch sortKey {
+	case "":
+		sortKey = VariantRuleSortByID
+	case VariantRuleSortByID, VariantRuleSortByKind, VariantRuleSortByUpdatedAt:
+	default:
+		return ListVariantRules{}, failure.Translate(i18n.Errorf("%qは不明な並び替えのキーです", sortKey), logic.InvalidArgument)
+	}
+	return ListVariantRules{PageRequest: page, sortKey: sortKey}, nil
+}
+
+func (l ListVariantRules) SortKey() VariantRuleSortKey { return l.sortKey }
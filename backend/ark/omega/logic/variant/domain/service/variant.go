@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 )
 
@@ -17,24 +21,86 @@ func NewCreateVariant(groupID model.VariantGroupID, name model.Name) CreateVaria
 func (v CreateVariant) GroupID() model.VariantGroupID { return v.groupID }
 func (v CreateVariant) Name() model.Name              { return v.name }
 
+// UpdateVariant versionはクライアントが取得した時点のバージョンで、一致する場合のみ更新する
 type UpdateVariant struct {
 	id      model.VariantID
 	groupID model.VariantGroupID
 	name    model.Name
+	version logic.Version
 }
 
-func NewUpdateVariant(id model.VariantID, groupID model.VariantGroupID, name model.Name) UpdateVariant {
-	return UpdateVariant{id, groupID, name}
+func NewUpdateVariant(
+	id model.VariantID, groupID model.VariantGroupID, name model.Name, version logic.Version,
+) UpdateVariant {
+	return UpdateVariant{id, groupID, name, version}
 }
 
 func (v UpdateVariant) ID() model.VariantID           { return v.id }
 func (v UpdateVariant) GroupID() model.VariantGroupID { return v.groupID }
 func (v UpdateVariant) Name() model.Name              { return v.name }
+func (v UpdateVariant) Version() logic.Version        { return v.version }
+
+// DeleteVariant ユニークに付与されている場合、cascadeではユニークから外してから削除し、指定しない場合は削除しない
+type DeleteVariant struct {
+	id      model.VariantID
+	version logic.Version
+	cascade bool
+}
+
+func NewDeleteVariant(id model.VariantID, version logic.Version, cascade bool) DeleteVariant {
+	return DeleteVariant{id: id, version: version, cascade: cascade}
+}
+
+func (d DeleteVariant) ID() model.VariantID    { return d.id }
+func (d DeleteVariant) Version() logic.Version { return d.version }
+func (d DeleteVariant) Cascade() bool          { return d.cascade }
 
 type VariantRepository interface {
 	FindVariant(context.Context, model.VariantID) (*model.Variant, error)
-	ListVariants(context.Context) (model.Variants, error)
+	ListVariants(context.Context, ListVariants) (*logic.Page[model.Variant], error)
+	// SelectDescriptions 一覧では読み込まない説明文をまとめて取得する。説明文の無いバリアントは結果に含めない
+	SelectDescriptions(context.Context, []model.VariantID) (map[model.VariantID]model.Descriptions, error)
 	CreateVariant(context.Context, CreateVariant) (*model.Variant, error)
 	UpdateVariant(context.Context, UpdateVariant) (*model.Variant, error)
-	DeleteVariant(context.Context, model.VariantID) error
+	DeleteVariant(context.Context, model.VariantID, logic.Version) error
+	// RestoreVariant ゴミ箱にないバリアントはNotFoundを返す
+	RestoreVariant(context.Context, model.VariantID) error
+	// SelectDependents バリアントを付与したゴミ箱にないユニークを返す
+	SelectDependents(context.Context, model.VariantID) (logic.Dependents, error)
+}
+
+// UniqueVariantDetacher ゴミ箱に移すバリアントをユニークから外す。ユニークは生物のモジュールで管理する
+type UniqueVariantDetacher interface {
+	DetachVariants(context.Context, []model.VariantID) error
 }
+
+type VariantSortKey string
+
+const (
+	VariantSortByID        VariantSortKey = "id"
+	VariantSortByName      VariantSortKey = "name"
+	VariantSortByUpdatedAt VariantSortKey = "updated_at"
+)
+
+func (k VariantSortKey) Value() string { return string(k) }
+
+type ListVariants struct {
+	logic.PageRequest
+	sortKey VariantSortKey
+	groupID *model.VariantGroupID
+}
+
+// NewListVariants groupIDがnilの場合は全グループのバリアントを対象とする
+func NewListVariants(page logic.PageRequest, sortKey VariantSortKey, groupID *model.VariantGroupID) (ListVariants, error) {
+	switch sortKey {
+	case "":
+		sortKey = VariantSortByID
+	case VariantSortByID, VariantSortByName, VariantSortByUpdatedAt:
+	default:
+		return ListVariants{}, failure.Translate(i18n.Errorf("%qは不明な並び替えのキーです", sortKey), logic.InvalidArgument)
+	}
+	return ListVariants{PageRequest: page, sortKey: sortKey, groupID: groupID}, nil
+}
+
+func (l ListVariants) SortKey() VariantSortKey        { return l.sortKey }
+func (l ListVariants) GroupID() *model.VariantGroupID { return l.groupID }
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+// variantAudit 変更履歴に記録するバリアントの状態
+type variantAudit struct {
+	ID           int      `json:"id"`
+	Group        string   `json:"group"`
+	Name         string   `json:"name"`
+	Descriptions []string `json:"descriptions"`
+	Version      int      `json:"version"`
+}
+
+func newVariantAudit(v model.Variant) variantAudit {
+	return variantAudit{
+		ID:    v.ID().Value(),
+		Group: v.Group().Value(),
+		Name:  v.Name().Value(),
+		Descriptions: lo.Map(v.Descriptions(), func(d model.Description, _ int) string {
+			return d.Text().Value()
+		}),
+		Version: v.Version().Value(),
+	}
+}
+
+type variantGroupAudit struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+func newVariantGroupAudit(g model.VariantGroup) variantGroupAudit {
+	return variantGroupAudit{ID: int(g.ID()), Name: g.Name().Value(), Version: g.Version().Value()}
+}
+
+type variantRuleAudit struct {
+	ID             int    `json:"id"`
+	Kind           string `json:"kind"`
+	GroupID        *int   `json:"group_id,omitempty"`
+	VariantID      *int   `json:"variant_id,omitempty"`
+	OtherVariantID *int   `json:"other_variant_id,omitempty"`
+}
+
+func newVariantRuleAudit(r model.VariantRule) variantRuleAudit {
+	audit := variantRuleAudit{ID: r.ID().Value(), Kind: r.Kind().Value()}
+	if id := r.GroupID(); id != nil {
+		audit.GroupID = lo.ToPtr(int(*id))
+	}
+	if id := r.VariantID(); id != nil {
+		audit.VariantID = lo.ToPtr(id.Value())
+	}
+	if id := r.OtherVariantID(); id != nil {
+		audit.OtherVariantID = lo.ToPtr(id.Value())
+	}
+	return audit
+}
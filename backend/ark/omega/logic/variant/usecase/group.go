@@ -6,27 +6,36 @@ import (
 
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
+	"github.com/samber/lo"
 
 	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 )
 
 type VariantGroupUsecase interface {
 	Find(context.Context, model.VariantGroupID) (*model.VariantGroup, error)
-	List(context.Context) (model.VariantGroups, error)
+	List(context.Context, service.ListVariantGroups) (*logic.Page[model.VariantGroup], error)
 	Create(context.Context, service.CreateVariantGroup) (*model.VariantGroup, error)
 	Update(context.Context, service.UpdateVariantGroup) (*model.VariantGroup, error)
-	Delete(context.Context, model.VariantGroupID) error
+	Delete(context.Context, service.DeleteVariantGroup) error
+	Restore(context.Context, model.VariantGroupID) (*model.VariantGroup, error)
 }
 
 type VariantGroup struct {
 	repository service.VariantGroupRepository
+	variants   service.VariantRepository
+	uniques    service.UniqueVariantDetacher
+	recorder   logic.AuditRecorder
 }
 
 func NewVariantGroup(injector *do.Injector) (VariantGroupUsecase, error) {
 	return &VariantGroup{
 		repository: do.MustInvoke[service.VariantGroupRepository](injector),
+		variants:   do.MustInvoke[service.VariantRepository](injector),
+		uniques:    do.MustInvoke[service.UniqueVariantDetacher](injector),
+		recorder:   do.MustInvoke[logic.AuditRecorder](injector),
 	}, nil
 }
 
@@ -44,8 +53,8 @@ func (v VariantGroup) Find(ctx context.Context, id model.VariantGroupID) (*model
 	return variant, nil
 }
 
-func (v VariantGroup) List(ctx context.Context) (model.VariantGroups, error) {
-	variants, err := v.repository.List(ctx)
+func (v VariantGroup) List(ctx context.Context, query service.ListVariantGroups) (*logic.Page[model.VariantGroup], error) {
+	variants, err := v.repository.List(ctx, query)
 	if err != nil {
 		if errors.Is(err, service.IntervalServerError) {
 			return nil, failure.New(logic.IntervalServerError)
@@ -61,13 +70,19 @@ func (v VariantGroup) Create(ctx context.Context, item service.CreateVariantGrou
 		if err != nil {
 			return nil, failure.Wrap(err)
 		}
+		if err = v.recorder.Record(
+			ctx, logic.Created(logic.AuditGroup, int(variant.ID()), newVariantGroupAudit(*variant)),
+		); err != nil {
+			return nil, err
+		}
 		return variant, nil
 	})
 }
 
 func (v VariantGroup) Update(ctx context.Context, item service.UpdateVariantGroup) (*model.VariantGroup, error) {
 	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.VariantGroup, error) {
-		if _, err := v.repository.Select(ctx, item.ID()); err != nil {
+		before, err := v.repository.Select(ctx, item.ID())
+		if err != nil {
 			if errors.Is(err, service.NotFound) {
 				return nil, failure.New(logic.NotFound)
 			}
@@ -76,15 +91,24 @@ func (v VariantGroup) Update(ctx context.Context, item service.UpdateVariantGrou
 
 		variant, err := v.repository.Update(ctx, item)
 		if err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return nil, failure.New(logic.PreconditionFailed)
+			}
 			return nil, failure.Wrap(err)
 		}
+		if err = v.recorder.Record(ctx, logic.Updated(
+			logic.AuditGroup, int(variant.ID()), newVariantGroupAudit(*before), newVariantGroupAudit(*variant),
+		)); err != nil {
+			return nil, err
+		}
 		return variant, nil
 	})
 }
 
-func (v VariantGroup) Delete(ctx context.Context, id model.VariantGroupID) error {
+// Delete 属するバリアントがある場合は、cascadeかreassignToを指定しない限り属するバリアントを示すConflictとする
+func (v VariantGroup) Delete(ctx context.Context, item service.DeleteVariantGroup) error {
 	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
-		_, err := v.repository.Select(ctx, id)
+		before, err := v.repository.Select(ctx, item.ID())
 		if errors.Is(err, service.NotFound) {
 			return failure.New(logic.NotFound)
 		}
@@ -92,15 +116,146 @@ func (v VariantGroup) Delete(ctx context.Context, id model.VariantGroupID) error
 			return err
 		}
 
-		err = v.repository.Delete(ctx, id)
+		dependents, err := v.repository.SelectDependents(ctx, item.ID())
+		if err != nil {
+			if errors.Is(err, service.IntervalServerError) {
+				return failure.New(logic.IntervalServerError)
+			}
+			return failure.Wrap(err)
+		}
+		if len(dependents) != 0 {
+			if to := item.ReassignTo(); to != nil {
+				err = v.reassignVariants(ctx, item.ID(), *to, dependents)
+			} else if item.Cascade() {
+				err = v.removeVariants(ctx, dependents)
+			} else {
+				err = failure.Translate(i18n.Wrapf(dependents, "バリアントグループはバリアントから参照されています"), logic.Conflict)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		err = v.repository.Delete(ctx, item.ID(), item.Version())
 		if err != nil {
 			if errors.Is(err, service.NotFound) {
 				return failure.New(logic.NotFound)
+			} else if errors.Is(err, service.VersionMismatch) {
+				return failure.New(logic.PreconditionFailed)
 			} else if errors.Is(err, service.IntervalServerError) {
 				return failure.New(logic.IntervalServerError)
 			}
 			return failure.Wrap(err)
 		}
-		return nil
+		return v.recorder.Record(ctx, logic.Deleted(logic.AuditGroup, int(item.ID()), newVariantGroupAudit(*before)))
+	})
+}
+
+// reassignVariants 移したバリアントはそれぞれ更新として記録する。削除するグループ自身へは移せない
+func (v VariantGroup) reassignVariants(
+	ctx context.Context, from, to model.VariantGroupID, dependents logic.Dependents,
+) error {
+	if from == to {
+		return failure.Translate(i18n.Errorf("reassign_toには別のバリアントグループを指定してください"), logic.InvalidArgument)
+	}
+	if _, err := v.repository.Select(ctx, to); err != nil {
+		if errors.Is(err, service.NotFound) {
+			return failure.Translate(i18n.Errorf("バリアントグループ%dは存在しません", to), logic.InvalidArgument)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+
+	befores := make([]model.Variant, 0, len(dependents))
+	for _, d := range dependents {
+		variant, err := v.findVariant(ctx, model.VariantID(d.ID))
+		if err != nil {
+			return err
+		}
+		befores = append(befores, *variant)
+	}
+
+	if err := v.repository.MoveVariants(ctx, from, to); err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+
+	for _, before := range befores {
+		after, err := v.findVariant(ctx, before.ID())
+		if err != nil {
+			return err
+		}
+		if err = v.recorder.Record(ctx, logic.Updated(
+			logic.AuditVariant, before.ID().Value(), newVariantAudit(before), newVariantAudit(*after),
+		)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeVariants バリアントはユニークから外してからグループと一緒にゴミ箱へ移す。
+// 複数のバリアントを付与したユニークでバリアントが無くならないかを確かめるため、まとめて外す
+func (v VariantGroup) removeVariants(ctx context.Context, dependents logic.Dependents) error {
+	variants := make([]model.Variant, 0, len(dependents))
+	for _, d := range dependents {
+		variant, err := v.findVariant(ctx, model.VariantID(d.ID))
+		if err != nil {
+			return err
+		}
+		variants = append(variants, *variant)
+	}
+
+	if err := v.uniques.DetachVariants(ctx, lo.Map(variants, func(variant model.Variant, _ int) model.VariantID {
+		return variant.ID()
+	})); err != nil {
+		return err
+	}
+	for _, variant := range variants {
+		if err := removeVariant(ctx, v.variants, v.recorder, variant, variant.Version()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v VariantGroup) findVariant(ctx context.Context, id model.VariantID) (*model.Variant, error) {
+	variant, err := v.variants.FindVariant(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.NotFound) {
+			return nil, failure.New(logic.NotFound)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return variant, nil
+}
+
+// Restore ゴミ箱から戻したグループを返す
+func (v VariantGroup) Restore(ctx context.Context, id model.VariantGroupID) (*model.VariantGroup, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.VariantGroup, error) {
+		if err := v.repository.Restore(ctx, id); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		group, err := v.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = v.recorder.Record(
+			ctx, logic.Restored(logic.AuditGroup, int(id), newVariantGroupAudit(*group)),
+		); err != nil {
+			return nil, err
+		}
+		return group, nil
 	})
 }
@@ -28,11 +28,13 @@ const (
 	notExistGroupID
 	internalServerErrGroupID
 	errGroupID
+	otherGroupID
 )
 
 // mockTransactionがインターフェースを満たしているか
 var _ logic.Transactioner = (*mockDBClient)(nil)
 var _ service.VariantRepository = (*mockDBClient)(nil)
+var _ service.VariantDescriptionRepository = (*mockDBClient)(nil)
 
 type mockDBClient struct {
 	mock.Mock
@@ -54,14 +56,25 @@ func (c *mockDBClient) FindVariant(ctx context.Context, id model.VariantID) (*mo
 	return args.Get(0).(*model.Variant), args.Error(1)
 }
 
-func (c *mockDBClient) ListVariants(ctx context.Context) (model.Variants, error) {
-	args := c.Called(ctx)
+func (c *mockDBClient) ListVariants(ctx context.Context, query service.ListVariants) (*logic.Page[model.Variant], error) {
+	args := c.Called(ctx, query)
 
 	r := args.Get(0)
 	if r == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(model.Variants), nil
+	return args.Get(0).(*logic.Page[model.Variant]), nil
+}
+func (c *mockDBClient) SelectDescriptions(
+	ctx context.Context, ids []model.VariantID,
+) (map[model.VariantID]model.Descriptions, error) {
+	args := c.Called(ctx, ids)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(map[model.VariantID]model.Descriptions), args.Error(1)
 }
 func (c *mockDBClient) CreateVariant(ctx context.Context, create service.CreateVariant) (*model.Variant, error) {
 	args := c.Called(ctx, create)
@@ -81,8 +94,8 @@ func (c *mockDBClient) UpdateVariant(ctx context.Context, update service.UpdateV
 	}
 	return args.Get(0).(*model.Variant), args.Error(1)
 }
-func (c *mockDBClient) DeleteVariant(ctx context.Context, id model.VariantID) error {
-	args := c.Called(ctx, id)
+func (c *mockDBClient) DeleteVariant(ctx context.Context, id model.VariantID, version logic.Version) error {
+	args := c.Called(ctx, id, version)
 
 	r := args.Get(0)
 	if r == nil {
@@ -90,6 +103,50 @@ func (c *mockDBClient) DeleteVariant(ctx context.Context, id model.VariantID) er
 	}
 	return args.Error(0)
 }
+func (c *mockDBClient) RestoreVariant(ctx context.Context, id model.VariantID) error {
+	args := c.Called(ctx, id)
+
+	return args.Error(0)
+}
+func (c *mockDBClient) SelectDependents(ctx context.Context, id model.VariantID) (logic.Dependents, error) {
+	args := c.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(logic.Dependents), args.Error(1)
+}
+
+func (c *mockDBClient) TouchVariant(ctx context.Context, id model.VariantID, version logic.Version) error {
+	args := c.Called(ctx, id, version)
+
+	return args.Error(0)
+}
+func (c *mockDBClient) CreateDescription(ctx context.Context, create service.CreateDescription) (model.DescriptionID, error) {
+	args := c.Called(ctx, create)
+
+	r := args.Get(0)
+	if r == nil {
+		return 0, args.Error(1)
+	}
+	return args.Get(0).(model.DescriptionID), args.Error(1)
+}
+func (c *mockDBClient) UpdateDescription(ctx context.Context, update service.UpdateDescription) error {
+	args := c.Called(ctx, update)
+
+	return args.Error(0)
+}
+func (c *mockDBClient) DeleteDescription(ctx context.Context, remove service.DeleteDescription) error {
+	args := c.Called(ctx, remove)
+
+	return args.Error(0)
+}
+func (c *mockDBClient) ReplaceDescriptions(ctx context.Context, replace service.ReplaceDescriptions) error {
+	args := c.Called(ctx, replace)
+
+	return args.Error(0)
+}
 
 var _ logic.Transactioner = (*mockVariantGroup)(nil)
 var _ service.VariantGroupRepository = (*mockVariantGroup)(nil)
@@ -114,14 +171,14 @@ func (g *mockVariantGroup) Select(ctx context.Context, id model.VariantGroupID)
 	return args.Get(0).(*model.VariantGroup), args.Error(1)
 }
 
-func (g *mockVariantGroup) List(ctx context.Context) (model.VariantGroups, error) {
-	args := g.Called(ctx)
+func (g *mockVariantGroup) List(ctx context.Context, query service.ListVariantGroups) (*logic.Page[model.VariantGroup], error) {
+	args := g.Called(ctx, query)
 
 	r := args.Get(0)
 	if r == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(model.VariantGroups), nil
+	return args.Get(0).(*logic.Page[model.VariantGroup]), nil
 }
 
 func (g *mockVariantGroup) Insert(ctx context.Context, create service.CreateVariantGroup) (*model.VariantGroup, error) {
@@ -144,8 +201,8 @@ func (g *mockVariantGroup) Update(ctx context.Context, update service.UpdateVari
 	return args.Get(0).(*model.VariantGroup), args.Error(1)
 }
 
-func (g *mockVariantGroup) Delete(ctx context.Context, id model.VariantGroupID) error {
-	args := g.Called(ctx, id)
+func (g *mockVariantGroup) Delete(ctx context.Context, id model.VariantGroupID, version logic.Version) error {
+	args := g.Called(ctx, id, version)
 
 	r := args.Get(0)
 	if r == nil {
@@ -153,3 +210,126 @@ func (g *mockVariantGroup) Delete(ctx context.Context, id model.VariantGroupID)
 	}
 	return args.Error(0)
 }
+
+func (g *mockVariantGroup) Restore(ctx context.Context, id model.VariantGroupID) error {
+	args := g.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (g *mockVariantGroup) SelectDependents(ctx context.Context, id model.VariantGroupID) (logic.Dependents, error) {
+	args := g.Called(ctx, id)
+
+	r := args.Get(0)
+	if r == nil {
+		return nil, args.Error(1)
+	}
+	return r.(logic.Dependents), args.Error(1)
+}
+
+func (g *mockVariantGroup) MoveVariants(ctx context.Context, from, to model.VariantGroupID) error {
+	args := g.Called(ctx, from, to)
+
+	return args.Error(0)
+}
+
+var _ service.VariantRuleRepository = (*mockVariantRule)(nil)
+
+type mockVariantRule struct {
+	mock.Mock
+}
+
+func newMockVariantRule() *mockVariantRule { return &mockVariantRule{} }
+
+func (r *mockVariantRule) Select(ctx context.Context, id model.VariantRuleID) (*model.VariantRule, error) {
+	args := r.Called(ctx, id)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.VariantRule), args.Error(1)
+}
+
+func (r *mockVariantRule) List(ctx context.Context, query service.ListVariantRules) (*logic.Page[model.VariantRule], error) {
+	args := r.Called(ctx, query)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*logic.Page[model.VariantRule]), nil
+}
+
+func (r *mockVariantRule) Insert(ctx context.Context, create service.CreateVariantRule) (*model.VariantRule, error) {
+	args := r.Called(ctx, create)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.VariantRule), args.Error(1)
+}
+
+func (r *mockVariantRule) Update(ctx context.Context, update service.UpdateVariantRule) (*model.VariantRule, error) {
+	args := r.Called(ctx, update)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.VariantRule), args.Error(1)
+}
+
+func (r *mockVariantRule) Delete(ctx context.Context, id model.VariantRuleID) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *mockVariantRule) SelectApplicable(
+	ctx context.Context, variants model.ComposedVariants,
+) (model.VariantRules, error) {
+	args := r.Called(ctx, variants)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(model.VariantRules), args.Error(1)
+}
+
+func (r *mockVariantRule) SelectComposed(ctx context.Context, ids []model.VariantID) (model.ComposedVariants, error) {
+	args := r.Called(ctx, ids)
+
+	v := args.Get(0)
+	if v == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(model.ComposedVariants), args.Error(1)
+}
+
+var _ logic.AuditRecorder = (*mockAuditRecorder)(nil)
+
+type mockAuditRecorder struct {
+	mock.Mock
+}
+
+func newMockAuditRecorder() *mockAuditRecorder { return &mockAuditRecorder{} }
+
+func (r *mockAuditRecorder) Record(ctx context.Context, change logic.Change) error {
+	args := r.Called(ctx, change)
+	return args.Error(0)
+}
+
+var _ service.UniqueVariantDetacher = (*mockUniqueVariantDetacher)(nil)
+
+type mockUniqueVariantDetacher struct {
+	mock.Mock
+}
+
+func newMockUniqueVariantDetacher() *mockUniqueVariantDetacher { return &mockUniqueVariantDetacher{} }
+
+func (d *mockUniqueVariantDetacher) DetachVariants(ctx context.Context, ids []model.VariantID) error {
+	args := d.Called(ctx, ids)
+	return args.Error(0)
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+type VariantRuleUsecase interface {
+	Find(context.Context, model.VariantRuleID) (*model.VariantRule, error)
+	List(context.Context, service.ListVariantRules) (*logic.Page[model.VariantRule], error)
+	Create(context.Context, service.CreateVariantRule) (*model.VariantRule, error)
+	Update(context.Context, service.UpdateVariantRule) (*model.VariantRule, error)
+	Delete(context.Context, model.VariantRuleID) error
+	Validate(context.Context, []model.VariantID) error
+}
+
+type VariantRule struct {
+	repository service.VariantRuleRepository
+	recorder   logic.AuditRecorder
+}
+
+func NewVariantRule(injector *do.Injector) (VariantRuleUsecase, error) {
+	return &VariantRule{
+		repository: do.MustInvoke[service.VariantRuleRepository](injector),
+		recorder:   do.MustInvoke[logic.AuditRecorder](injector),
+	}, nil
+}
+
+func (v VariantRule) Find(ctx context.Context, id model.VariantRuleID) (*model.VariantRule, error) {
+	rule, err := v.repository.Select(ctx, id)
+	if err != nil {
+		if errors.Is(err, service.NotFound) {
+			return nil, failure.New(logic.NotFound)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return rule, nil
+}
+
+func (v VariantRule) List(ctx context.Context, query service.ListVariantRules) (*logic.Page[model.VariantRule], error) {
+	rules, err := v.repository.List(ctx, query)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return rules, nil
+}
+
+func (v VariantRule) Create(ctx context.Context, item service.CreateVariantRule) (*model.VariantRule, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.VariantRule, error) {
+		rule, err := v.repository.Insert(ctx, item)
+		if err != nil {
+			return nil, failure.Wrap(err)
+		}
+		if err = v.recorder.Record(
+			ctx, logic.Created(logic.AuditRule, rule.ID().Value(), newVariantRuleAudit(*rule)),
+		); err != nil {
+			return nil, err
+		}
+		return rule, nil
+	})
+}
+
+func (v VariantRule) Update(ctx context.Context, item service.UpdateVariantRule) (*model.VariantRule, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.VariantRule, error) {
+		before, err := v.Find(ctx, item.ID())
+		if err != nil {
+			return nil, err
+		}
+
+		rule, err := v.repository.Update(ctx, item)
+		if err != nil {
+			return nil, failure.Wrap(err)
+		}
+		if err = v.recorder.Record(ctx, logic.Updated(
+			logic.AuditRule, rule.ID().Value(), newVariantRuleAudit(*before), newVariantRuleAudit(*rule),
+		)); err != nil {
+			return nil, err
+		}
+		return rule, nil
+	})
+}
+
+func (v VariantRule) Delete(ctx context.Context, id model.VariantRuleID) error {
+	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
+		before, err := v.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = v.repository.Delete(ctx, id); err != nil {
+			if errors.Is(err, service.IntervalServerError) {
+				return failure.New(logic.IntervalServerError)
+			}
+			return failure.Wrap(err)
+		}
+		return v.recorder.Record(ctx, logic.Deleted(logic.AuditRule, id.Value(), newVariantRuleAudit(*before)))
+	})
+}
+
+// Validate ユニークに付与するバリアントが全て存在し、適用される規則を全て満たすか判定する
+func (v VariantRule) Validate(ctx context.Context, ids []model.VariantID) error {
+	composed, err := v.repository.SelectComposed(ctx, ids)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	exists := make(map[model.VariantID]struct{}, len(composed))
+	for _, c := range composed {
+		exists[c.ID()] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := exists[id]; !ok {
+			return failure.Translate(i18n.Errorf("バリアント%dは存在しません", id), logic.InvalidArgument)
+		}
+	}
+
+	rules, err := v.repository.SelectApplicable(ctx, composed)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	if err = rules.Check(composed); err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+	return nil
+}
@@ -8,25 +8,38 @@ import (
 	"github.com/samber/do"
 
 	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 )
 
 type VariantUsecase interface {
 	Find(context.Context, model.VariantID) (*model.Variant, error)
-	List(context.Context) (model.Variants, error)
+	List(context.Context, service.ListVariants) (*logic.Page[model.Variant], error)
+	ListDescriptions(context.Context, []model.VariantID) (map[model.VariantID]model.Descriptions, error)
 	Create(context.Context, service.CreateVariant) (*model.Variant, error)
 	Update(context.Context, service.UpdateVariant) (*model.Variant, error)
-	Delete(context.Context, model.VariantID) error
+	Delete(context.Context, service.DeleteVariant) error
+	Restore(context.Context, model.VariantID) (*model.Variant, error)
+	CreateDescription(context.Context, service.CreateDescription) (*model.Variant, error)
+	UpdateDescription(context.Context, service.UpdateDescription) (*model.Variant, error)
+	DeleteDescription(context.Context, service.DeleteDescription) (*model.Variant, error)
+	ReplaceDescriptions(context.Context, service.ReplaceDescriptions) (*model.Variant, error)
 }
 
 type Variant struct {
-	repository service.VariantRepository
+	repository   service.VariantRepository
+	descriptions service.VariantDescriptionRepository
+	uniques      service.UniqueVariantDetacher
+	recorder     logic.AuditRecorder
 }
 
 func NewVariant(injector *do.Injector) (VariantUsecase, error) {
 	return Variant{
-		repository: do.MustInvoke[service.VariantRepository](injector),
+		repository:   do.MustInvoke[service.VariantRepository](injector),
+		descriptions: do.MustInvoke[service.VariantDescriptionRepository](injector),
+		uniques:      do.MustInvoke[service.UniqueVariantDetacher](injector),
+		recorder:     do.MustInvoke[logic.AuditRecorder](injector),
 	}, nil
 }
 
@@ -44,8 +57,8 @@ func (v Variant) Find(ctx context.Context, id model.VariantID) (*model.Variant,
 	return variant, nil
 }
 
-func (v Variant) List(ctx context.Context) (model.Variants, error) {
-	variants, err := v.repository.ListVariants(ctx)
+func (v Variant) List(ctx context.Context, query service.ListVariants) (*logic.Page[model.Variant], error) {
+	variants, err := v.repository.ListVariants(ctx, query)
 	if err != nil {
 		if errors.Is(err, service.IntervalServerError) {
 			return nil, failure.New(logic.IntervalServerError)
@@ -55,19 +68,39 @@ func (v Variant) List(ctx context.Context) (model.Variants, error) {
 	return variants, nil
 }
 
+// ListDescriptions 一覧のバリアントの説明文を1度に読み込む。説明文の無いバリアントは結果に含めない
+func (v Variant) ListDescriptions(
+	ctx context.Context, ids []model.VariantID,
+) (map[model.VariantID]model.Descriptions, error) {
+	descriptions, err := v.repository.SelectDescriptions(ctx, ids)
+	if err != nil {
+		if errors.Is(err, service.IntervalServerError) {
+			return nil, failure.New(logic.IntervalServerError)
+		}
+		return nil, failure.Wrap(err)
+	}
+	return descriptions, nil
+}
+
 func (v Variant) Create(ctx context.Context, item service.CreateVariant) (*model.Variant, error) {
 	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Variant, error) {
 		variant, err := v.repository.CreateVariant(ctx, item)
 		if err != nil {
 			return nil, failure.Wrap(err)
 		}
+		if err = v.recorder.Record(
+			ctx, logic.Created(logic.AuditVariant, variant.ID().Value(), newVariantAudit(*variant)),
+		); err != nil {
+			return nil, err
+		}
 		return variant, nil
 	})
 }
 
 func (v Variant) Update(ctx context.Context, item service.UpdateVariant) (*model.Variant, error) {
 	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Variant, error) {
-		if _, err := v.repository.FindVariant(ctx, item.ID()); err != nil {
+		before, err := v.repository.FindVariant(ctx, item.ID())
+		if err != nil {
 			if errors.Is(err, service.NotFound) {
 				return nil, failure.New(logic.NotFound)
 			}
@@ -76,15 +109,24 @@ func (v Variant) Update(ctx context.Context, item service.UpdateVariant) (*model
 
 		variant, err := v.repository.UpdateVariant(ctx, item)
 		if err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return nil, failure.New(logic.PreconditionFailed)
+			}
 			return nil, failure.Wrap(err)
 		}
+		if err = v.recorder.Record(
+			ctx, logic.Updated(logic.AuditVariant, variant.ID().Value(), newVariantAudit(*before), newVariantAudit(*variant)),
+		); err != nil {
+			return nil, err
+		}
 		return variant, nil
 	})
 }
 
-func (v Variant) Delete(ctx context.Context, id model.VariantID) error {
+// Delete ユニークに付与されている場合は、cascadeを指定しない限り付与したユニークを示すConflictとする
+func (v Variant) Delete(ctx context.Context, item service.DeleteVariant) error {
 	return logic.UseTransactioner0(ctx, func(ctx context.Context) error {
-		_, err := v.repository.FindVariant(ctx, id)
+		before, err := v.repository.FindVariant(ctx, item.ID())
 		if errors.Is(err, service.NotFound) {
 			return failure.New(logic.NotFound)
 		}
@@ -92,15 +134,139 @@ func (v Variant) Delete(ctx context.Context, id model.VariantID) error {
 			return err
 		}
 
-		err = v.repository.DeleteVariant(ctx, id)
+		dependents, err := v.repository.SelectDependents(ctx, item.ID())
 		if err != nil {
-			if errors.Is(err, service.NotFound) {
-				return failure.New(logic.NotFound)
-			} else if errors.Is(err, service.IntervalServerError) {
+			if errors.Is(err, service.IntervalServerError) {
 				return failure.New(logic.IntervalServerError)
 			}
 			return failure.Wrap(err)
 		}
-		return nil
+		if len(dependents) != 0 && !item.Cascade() {
+			return failure.Translate(i18n.Wrapf(dependents, "バリアントはユニークから参照されています"), logic.Conflict)
+		}
+		if len(dependents) != 0 {
+			if err = v.uniques.DetachVariants(ctx, []model.VariantID{item.ID()}); err != nil {
+				return err
+			}
+		}
+		return removeVariant(ctx, v.repository, v.recorder, *before, item.Version())
+	})
+}
+
+// removeVariant ゴミ箱へ移し、削除として記録する。付与したユニークからは先に外しておく
+func removeVariant(
+	ctx context.Context,
+	repository service.VariantRepository,
+	recorder logic.AuditRecorder,
+	variant model.Variant,
+	version logic.Version,
+) error {
+	if err := repository.DeleteVariant(ctx, variant.ID(), version); err != nil {
+		if errors.Is(err, service.NotFound) {
+			return failure.New(logic.NotFound)
+		} else if errors.Is(err, service.VersionMismatch) {
+			return failure.New(logic.PreconditionFailed)
+		} else if errors.Is(err, service.IntervalServerError) {
+			return failure.New(logic.IntervalServerError)
+		}
+		return failure.Wrap(err)
+	}
+	return recorder.Record(ctx, logic.Deleted(logic.AuditVariant, variant.ID().Value(), newVariantAudit(variant)))
+}
+
+// Restore ゴミ箱から戻したバリアントを返す。グループがゴミ箱にある場合は先にグループを戻す必要がある
+func (v Variant) Restore(ctx context.Context, id model.VariantID) (*model.Variant, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Variant, error) {
+		if err := v.repository.RestoreVariant(ctx, id); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			} else if errors.Is(err, service.ParentDeleted) {
+				return nil, failure.Translate(i18n.Errorf("バリアントグループがゴミ箱にあります"), logic.Conflict)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		variant, err := v.Find(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err = v.recorder.Record(
+			ctx, logic.Restored(logic.AuditVariant, id.Value(), newVariantAudit(*variant)),
+		); err != nil {
+			return nil, err
+		}
+		return variant, nil
+	})
+}
+
+// updateDescriptions バリアントの存在確認後に説明文を変更し、変更後のバリアントを返す。
+// 説明文の変更はバリアントの更新として記録し、同時の変更で上書きしないようバリアントのバージョンを上げる
+func (v Variant) updateDescriptions(
+	ctx context.Context, id model.VariantID, version logic.Version, fn func(context.Context) error,
+) (*model.Variant, error) {
+	return logic.UseTransactioner(ctx, func(ctx context.Context) (*model.Variant, error) {
+		before, err := v.repository.FindVariant(ctx, id)
+		if err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		if err = v.descriptions.TouchVariant(ctx, id, version); err != nil {
+			if errors.Is(err, service.VersionMismatch) {
+				return nil, failure.New(logic.PreconditionFailed)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		if err = fn(ctx); err != nil {
+			if errors.Is(err, service.NotFound) {
+				return nil, failure.New(logic.NotFound)
+			} else if errors.Is(err, service.IntervalServerError) {
+				return nil, failure.New(logic.IntervalServerError)
+			}
+			return nil, failure.Wrap(err)
+		}
+
+		variant, err := v.repository.FindVariant(ctx, id)
+		if err != nil {
+			return nil, failure.Wrap(err)
+		}
+		if err = v.recorder.Record(
+			ctx, logic.Updated(logic.AuditVariant, id.Value(), newVariantAudit(*before), newVariantAudit(*variant)),
+		); err != nil {
+			return nil, err
+		}
+		return variant, nil
+	})
+}
+
+func (v Variant) CreateDescription(ctx context.Context, item service.CreateDescription) (*model.Variant, error) {
+	return v.updateDescriptions(ctx, item.VariantID(), item.Version(), func(ctx context.Context) error {
+		_, err := v.descriptions.CreateDescription(ctx, item)
+		return err
+	})
+}
+
+func (v Variant) UpdateDescription(ctx context.Context, item service.UpdateDescription) (*model.Variant, error) {
+	return v.updateDescriptions(ctx, item.VariantID(), item.Version(), func(ctx context.Context) error {
+		return v.descriptions.UpdateDescription(ctx, item)
+	})
+}
+
+func (v Variant) DeleteDescription(ctx context.Context, item service.DeleteDescription) (*model.Variant, error) {
+	return v.updateDescriptions(ctx, item.VariantID(), item.Version(), func(ctx context.Context) error {
+		return v.descriptions.DeleteDescription(ctx, item)
+	})
+}
+
+func (v Variant) ReplaceDescriptions(ctx context.Context, item service.ReplaceDescriptions) (*model.Variant, error) {
+	return v.updateDescriptions(ctx, item.VariantID(), item.Version(), func(ctx context.Context) error {
+		return v.descriptions.ReplaceDescriptions(ctx, item)
 	})
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/suite"
 
 	"mods-explore/ark/omega/logic"
@@ -16,8 +17,11 @@ import (
 type VariantGroupTestSuite struct {
 	suite.Suite
 
-	mockDB  *mockVariantGroup
-	usecase VariantGroupUsecase
+	mockDB   *mockVariantGroup
+	variants *mockDBClient
+	uniques  *mockUniqueVariantDetacher
+	recorder *mockAuditRecorder
+	usecase  VariantGroupUsecase
 }
 
 func newTestVariantGroupSuite() *VariantGroupTestSuite { return &VariantGroupTestSuite{} }
@@ -27,11 +31,14 @@ func TestVariantGroupSuite(t *testing.T) {
 }
 
 const (
-	findVariantGroup   = "Select"
-	listVariantGroup   = "List"
-	createVariantGroup = "Insert"
-	updateVariantGroup = "Update"
-	deleteVariantGroup = "Delete"
+	findVariantGroup    = "Select"
+	listVariantGroup    = "List"
+	createVariantGroup  = "Insert"
+	updateVariantGroup  = "Update"
+	deleteVariantGroup  = "Delete"
+	restoreVariantGroup = "Restore"
+	moveVariants        = "MoveVariants"
+	recordChange        = "Record"
 )
 
 func (s *VariantGroupTestSuite) SetupSuite() {
@@ -39,6 +46,12 @@ func (s *VariantGroupTestSuite) SetupSuite() {
 
 	mockDB := newMockVariantGroup()
 	do.ProvideValue[service.VariantGroupRepository](injector, mockDB)
+	s.variants = newMockDBClient()
+	do.ProvideValue[service.VariantRepository](injector, s.variants)
+	s.uniques = newMockUniqueVariantDetacher()
+	do.ProvideValue[service.UniqueVariantDetacher](injector, s.uniques)
+	s.recorder = newMockAuditRecorder()
+	do.ProvideValue[logic.AuditRecorder](injector, s.recorder)
 	s.mockDB = mockDB
 	usecase, err := NewVariantGroup(injector)
 	if err != nil {
@@ -101,40 +114,53 @@ func (s *VariantGroupTestSuite) TestList() {
 	variantGroups := model.VariantGroups{
 		model.NewVariantGroup(model.VariantGroupID(groupID), "cosmic"),
 	}
+	page := logic.NewPage(variantGroups, "")
+
+	pageRequest, err := logic.NewPageRequest(0, "", logic.Asc)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query, err := service.NewListVariantGroups(pageRequest, service.VariantGroupSortByName)
+	if err != nil {
+		s.T().Fatal(err)
+	}
 
 	{
 		s.mockDB.On(
 			listVariantGroup,
 			ctx,
+			query,
 		).
-			Return(variantGroups, nil).
+			Return(&page, nil).
 			Once()
-		r, err := s.usecase.List(ctx)
+		r, err := s.usecase.List(ctx, query)
 		if err != nil {
 			s.T().Error(err)
 			return
 		}
 
-		s.Equal(variantGroups, r)
+		s.Equal(&page, r)
 	}
 	{
 		s.mockDB.On(
 			listVariantGroup,
 			ctx,
+			query,
 		).
 			Return(nil, service.IntervalServerError).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 	{
 		s.mockDB.On(
 			listVariantGroup,
 			ctx,
+			query,
 		).
 			Return(nil, failure.Wrap(e)).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(errors.Is(err, e))
 	}
 }
@@ -150,6 +176,13 @@ func (s *VariantGroupTestSuite) TestCreate() {
 		).
 			Return(&variantGroup, nil).
 			Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Created(logic.AuditGroup, groupID, newVariantGroupAudit(variantGroup)),
+		).
+			Return(nil).
+			Once()
 		r, err := s.usecase.Create(ctx, item)
 		if err != nil {
 			s.T().Error(err)
@@ -158,6 +191,24 @@ func (s *VariantGroupTestSuite) TestCreate() {
 
 		s.Equal(&variantGroup, r)
 	}
+	{ // 変更履歴の記録に失敗した場合は作成も失敗させる
+		s.mockDB.On(
+			createVariantGroup,
+			ctx,
+			item,
+		).
+			Return(&variantGroup, nil).
+			Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Created(logic.AuditGroup, groupID, newVariantGroupAudit(variantGroup)),
+		).
+			Return(e).
+			Once()
+		_, err := s.usecase.Create(ctx, item)
+		s.True(errors.Is(err, e))
+	}
 	{
 		s.mockDB.On(
 			createVariantGroup,
@@ -174,15 +225,23 @@ func (s *VariantGroupTestSuite) TestCreate() {
 // TestUpdate レコードの存在確認はFindと同じなのチェックしない
 func (s *VariantGroupTestSuite) TestUpdate() {
 	{
-		item := service.NewUpdateVariantGroup(groupID, "cosmic")
+		item := service.NewUpdateVariantGroup(groupID, "nature", version)
 		variantGroup := model.NewVariantGroup(groupID, "cosmic")
+		updated := model.NewVariantGroup(groupID, "nature").WithVersion(version + 1)
 		s.mockDB.On(findVariantGroup, model.VariantGroupID(groupID)).Return(&variantGroup, nil).Once()
 		s.mockDB.On(
 			updateVariantGroup,
 			ctx,
 			item,
 		).
-			Return(&variantGroup, nil).
+			Return(&updated, nil).
+			Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Updated(logic.AuditGroup, groupID, newVariantGroupAudit(variantGroup), newVariantGroupAudit(updated)),
+		).
+			Return(nil).
 			Once()
 		r, err := s.usecase.Update(ctx, item)
 		if err != nil {
@@ -190,11 +249,11 @@ func (s *VariantGroupTestSuite) TestUpdate() {
 			return
 		}
 
-		s.Equal(&variantGroup, r)
+		s.Equal(&updated, r)
 	}
 
 	{ // updateVariant error case
-		item := service.NewUpdateVariantGroup(groupID, "cosmic")
+		item := service.NewUpdateVariantGroup(groupID, "cosmic", version)
 		variantGroup := model.NewVariantGroup(groupID, "cosmic")
 		s.mockDB.On(findVariantGroup, model.VariantGroupID(groupID)).Return(&variantGroup, nil).Once()
 		s.mockDB.On(
@@ -207,33 +266,206 @@ func (s *VariantGroupTestSuite) TestUpdate() {
 		_, err := s.usecase.Update(ctx, item)
 		s.True(errors.Is(err, e))
 	}
+
+	{ // 他の更新でバージョンが変わっている場合
+		item := service.NewUpdateVariantGroup(groupID, "cosmic", version)
+		variantGroup := model.NewVariantGroup(groupID, "cosmic")
+		s.mockDB.On(findVariantGroup, model.VariantGroupID(groupID)).Return(&variantGroup, nil).Once()
+		s.mockDB.On(
+			updateVariantGroup,
+			ctx,
+			item,
+		).
+			Return(nil, service.VersionMismatch).
+			Once()
+		_, err := s.usecase.Update(ctx, item)
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
 }
 
 func (s *VariantGroupTestSuite) TestDelete() {
 	{
 		variant := model.NewVariantGroup(groupID, "cosmic")
 		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(logic.Dependents{}, nil).Once()
 		s.mockDB.On(
 			deleteVariantGroup,
 			ctx,
 			model.VariantGroupID(groupID),
+			version,
 		).
 			Return(nil).
 			Once()
-		s.Nil(s.usecase.Delete(ctx, model.VariantGroupID(groupID)))
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Deleted(logic.AuditGroup, groupID, newVariantGroupAudit(variant)),
+		).
+			Return(nil).
+			Once()
+		s.Nil(s.usecase.Delete(ctx, s.deleteGroup(false, nil)))
 	}
 
 	{ // updateVariant error case
 		variant := model.NewVariantGroup(groupID, "cosmic")
 		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(logic.Dependents{}, nil).Once()
+		s.mockDB.On(
+			deleteVariantGroup,
+			ctx,
+			model.VariantGroupID(groupID),
+			version,
+		).
+			Return(e).
+			Once()
+		err := s.usecase.Delete(ctx, s.deleteGroup(false, nil))
+		s.True(errors.Is(err, e))
+	}
+
+	{ // 他の更新でバージョンが変わっている場合
+		variant := model.NewVariantGroup(groupID, "cosmic")
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(logic.Dependents{}, nil).Once()
 		s.mockDB.On(
 			deleteVariantGroup,
 			ctx,
 			model.VariantGroupID(groupID),
+			version,
+		).
+			Return(service.VersionMismatch).
+			Once()
+		err := s.usecase.Delete(ctx, s.deleteGroup(false, nil))
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
+
+	group := model.NewVariantGroup(groupID, "cosmic")
+	variant := model.NewVariant(id, "cosmic", "meteor")
+	variants := logic.Dependents{{Entity: logic.AuditVariant, ID: id, Name: "meteor"}}
+	{ // 属するバリアントがある場合は属するバリアントを示して削除しない
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(variants, nil).Once()
+		err := s.usecase.Delete(ctx, s.deleteGroup(false, nil))
+		s.True(failure.Is(err, logic.Conflict))
+
+		var dependents logic.Dependents
+		s.True(errors.As(err, &dependents))
+		s.Equal(variants, dependents)
+	}
+
+	{ // reassignToでは属するバリアントを移してから削除する
+		other := model.NewVariantGroup(otherGroupID, "shiny")
+		moved := model.NewVariant(id, "shiny", "meteor")
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(variants, nil).Once()
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(otherGroupID)).Return(&other, nil).Once()
+		s.variants.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(moveVariants, ctx, model.VariantGroupID(groupID), model.VariantGroupID(otherGroupID)).Return(nil).Once()
+		s.variants.On(findVariant, ctx, model.VariantID(id)).Return(&moved, nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Updated(logic.AuditVariant, id, newVariantAudit(variant), newVariantAudit(moved)),
+		).
+			Return(nil).
+			Once()
+		s.mockDB.On(deleteVariantGroup, ctx, model.VariantGroupID(groupID), version).Return(nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Deleted(logic.AuditGroup, groupID, newVariantGroupAudit(group)),
+		).
+			Return(nil).
+			Once()
+		s.Nil(s.usecase.Delete(ctx, s.deleteGroup(false, lo.ToPtr(model.VariantGroupID(otherGroupID)))))
+	}
+
+	{ // 移す先のグループが無い場合
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(variants, nil).Once()
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(otherGroupID)).Return(nil, service.NotFound).Once()
+		err := s.usecase.Delete(ctx, s.deleteGroup(false, lo.ToPtr(model.VariantGroupID(otherGroupID))))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+
+	{ // cascadeでは属するバリアントをユニークから外して一緒に削除する
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantGroupID(groupID)).Return(variants, nil).Once()
+		s.variants.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.uniques.On(detachVariants, ctx, []model.VariantID{id}).Return(nil).Once()
+		s.variants.On(deleteVariant, ctx, model.VariantID(id), variant.Version()).Return(nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Deleted(logic.AuditVariant, id, newVariantAudit(variant)),
+		).
+			Return(nil).
+			Once()
+		s.mockDB.On(deleteVariantGroup, ctx, model.VariantGroupID(groupID), version).Return(nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Deleted(logic.AuditGroup, groupID, newVariantGroupAudit(group)),
+		).
+			Return(nil).
+			Once()
+		s.Nil(s.usecase.Delete(ctx, s.deleteGroup(true, nil)))
+	}
+
+	{ // 削除するグループ自身へは移せない
+		_, err := service.NewDeleteVariantGroup(groupID, version, false, lo.ToPtr(model.VariantGroupID(groupID)))
+		s.True(failure.Is(err, logic.InvalidArgument))
+
+		err = s.usecase.(*VariantGroup).reassignVariants(ctx, groupID, groupID, variants)
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+
+	{ // cascadeとreassignToは同時に指定できない
+		_, err := service.NewDeleteVariantGroup(groupID, version, true, lo.ToPtr(model.VariantGroupID(otherGroupID)))
+		s.True(failure.Is(err, logic.InvalidArgument))
+	}
+}
+
+func (s *VariantGroupTestSuite) deleteGroup(cascade bool, reassignTo *model.VariantGroupID) service.DeleteVariantGroup {
+	item, err := service.NewDeleteVariantGroup(groupID, version, cascade, reassignTo)
+	s.Require().NoError(err)
+	return item
+}
+
+func (s *VariantGroupTestSuite) TestRestore() {
+	{
+		group := model.NewVariantGroup(groupID, "cosmic")
+		s.mockDB.On(restoreVariantGroup, ctx, model.VariantGroupID(groupID)).Return(nil).Once()
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Restored(logic.AuditGroup, groupID, newVariantGroupAudit(group)),
+		).
+			Return(nil).
+			Once()
+		r, err := s.usecase.Restore(ctx, model.VariantGroupID(groupID))
+		s.Nil(err)
+		s.Equal(&group, r)
+	}
+
+	{ // ゴミ箱に無い場合
+		s.mockDB.On(restoreVariantGroup, ctx, model.VariantGroupID(groupID)).Return(service.NotFound).Once()
+		_, err := s.usecase.Restore(ctx, model.VariantGroupID(groupID))
+		s.True(failure.Is(err, logic.NotFound))
+	}
+
+	{ // 変更履歴の記録に失敗した場合
+		group := model.NewVariantGroup(groupID, "cosmic")
+		s.mockDB.On(restoreVariantGroup, ctx, model.VariantGroupID(groupID)).Return(nil).Once()
+		s.mockDB.On(findVariantGroup, ctx, model.VariantGroupID(groupID)).Return(&group, nil).Once()
+		s.recorder.On(
+			recordChange,
+			ctx,
+			logic.Restored(logic.AuditGroup, groupID, newVariantGroupAudit(group)),
 		).
 			Return(e).
 			Once()
-		err := s.usecase.Delete(ctx, model.VariantGroupID(groupID))
+		_, err := s.usecase.Restore(ctx, model.VariantGroupID(groupID))
 		s.True(errors.Is(err, e))
 	}
 }
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+type VariantRuleTestSuite struct {
+	suite.Suite
+
+	mockDB  *mockVariantRule
+	usecase VariantRuleUsecase
+
+	composed model.ComposedVariants
+}
+
+func TestVariantRuleSuite(t *testing.T) {
+	suite.Run(t, &VariantRuleTestSuite{})
+}
+
+const (
+	findVariantRule       = "Select"
+	deleteVariantRule     = "Delete"
+	selectApplicableRules = "SelectApplicable"
+	selectComposed        = "SelectComposed"
+)
+
+const (
+	cosmicVariantID = iota + 1
+	natureVariantID
+	otherVariantID
+	elementGroupID = 1
+)
+
+func (s *VariantRuleTestSuite) SetupSuite() {
+	injector := do.New()
+
+	s.mockDB = newMockVariantRule()
+	do.ProvideValue[service.VariantRuleRepository](injector, s.mockDB)
+	recorder := newMockAuditRecorder()
+	recorder.On(recordChange, mock.Anything, mock.Anything).Return(nil)
+	do.ProvideValue[logic.AuditRecorder](injector, recorder)
+	usecase, err := NewVariantRule(injector)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.usecase = usecase
+
+	s.composed = model.ComposedVariants{
+		model.NewComposedVariant(cosmicVariantID, elementGroupID),
+		model.NewComposedVariant(natureVariantID, elementGroupID),
+	}
+}
+
+func (s *VariantRuleTestSuite) rule(
+	kind model.VariantRuleKind, groupID *model.VariantGroupID, variantID, otherID *model.VariantID,
+) model.VariantRule {
+	rule, err := model.NewVariantRule(id, kind, groupID, variantID, otherID)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	return rule
+}
+
+func (s *VariantRuleTestSuite) TestValidate() {
+	ids := []model.VariantID{cosmicVariantID, natureVariantID}
+	{
+		s.T().Log("規則を満たす組み合わせを許容するかテスト")
+		s.mockDB.On(selectComposed, ctx, ids).Return(s.composed, nil).Once()
+		rules := model.VariantRules{
+			s.rule(model.RuleRequires, nil, lo.ToPtr[model.VariantID](cosmicVariantID), lo.ToPtr[model.VariantID](natureVariantID)),
+		}
+		s.mockDB.On(selectApplicableRules, ctx, s.composed).Return(rules, nil).Once()
+
+		s.Nil(s.usecase.Validate(ctx, ids))
+	}
+	{
+		s.T().Log("同じグループのバリアントを排他にする規則に違反するかテスト")
+		s.mockDB.On(selectComposed, ctx, ids).Return(s.composed, nil).Once()
+		rules := model.VariantRules{
+			s.rule(model.RuleGroupExclusive, lo.ToPtr[model.VariantGroupID](elementGroupID), nil, nil),
+		}
+		s.mockDB.On(selectApplicableRules, ctx, s.composed).Return(rules, nil).Once()
+
+		s.True(failure.Is(s.usecase.Validate(ctx, ids), logic.InvalidArgument))
+	}
+	{
+		s.T().Log("同時に付与できないバリアントの規則に違反するかテスト")
+		s.mockDB.On(selectComposed, ctx, ids).Return(s.composed, nil).Once()
+		rules := model.VariantRules{
+			s.rule(model.RuleIncompatible, nil, lo.ToPtr[model.VariantID](natureVariantID), lo.ToPtr[model.VariantID](cosmicVariantID)),
+		}
+		s.mockDB.On(selectApplicableRules, ctx, s.composed).Return(rules, nil).Once()
+
+		err := s.usecase.Validate(ctx, ids)
+		s.True(failure.Is(err, logic.InvalidArgument))
+		msg, ok := i18n.Localize(i18n.English, err)
+		s.True(ok)
+		s.Contains(msg, "incompatible")
+	}
+	{
+		s.T().Log("必要なバリアントが付与されていない場合に違反するかテスト")
+		s.mockDB.On(selectComposed, ctx, ids).Return(s.composed, nil).Once()
+		rules := model.VariantRules{
+			s.rule(model.RuleRequires, nil, lo.ToPtr[model.VariantID](cosmicVariantID), lo.ToPtr[model.VariantID](otherVariantID)),
+		}
+		s.mockDB.On(selectApplicableRules, ctx, s.composed).Return(rules, nil).Once()
+
+		s.True(failure.Is(s.usecase.Validate(ctx, ids), logic.InvalidArgument))
+	}
+	{
+		s.T().Log("存在しないバリアントを指定した場合のテスト")
+		unknown := []model.VariantID{cosmicVariantID, otherVariantID}
+		s.mockDB.On(selectComposed, ctx, unknown).Return(s.composed[:1], nil).Once()
+
+		s.True(failure.Is(s.usecase.Validate(ctx, unknown), logic.InvalidArgument))
+	}
+	{
+		s.mockDB.On(selectComposed, ctx, ids).Return(nil, e).Once()
+
+		s.True(errors.Is(s.usecase.Validate(ctx, ids), e))
+	}
+}
+
+func (s *VariantRuleTestSuite) TestDelete() {
+	rule := s.rule(model.RuleGroupExclusive, lo.ToPtr[model.VariantGroupID](elementGroupID), nil, nil)
+	{
+		s.mockDB.On(findVariantRule, ctx, model.VariantRuleID(id)).Return(&rule, nil).Once()
+		s.mockDB.On(deleteVariantRule, ctx, model.VariantRuleID(id)).Return(nil).Once()
+
+		s.Nil(s.usecase.Delete(ctx, id))
+	}
+	{
+		s.mockDB.On(findVariantRule, ctx, model.VariantRuleID(notExistID)).Return(nil, service.NotFound).Once()
+
+		s.True(failure.Is(s.usecase.Delete(ctx, notExistID), logic.NotFound))
+	}
+}
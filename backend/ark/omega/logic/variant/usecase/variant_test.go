@@ -6,6 +6,7 @@ import (
 
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"mods-explore/ark/omega/logic"
@@ -17,6 +18,7 @@ type VariantTestSuite struct {
 	suite.Suite
 
 	mockDB  *mockDBClient
+	uniques *mockUniqueVariantDetacher
 	usecase VariantUsecase
 }
 
@@ -27,11 +29,23 @@ func TestVariantSuite(t *testing.T) {
 }
 
 const (
-	findVariant   = "FindVariant"
-	listVariant   = "ListVariants"
-	createVariant = "CreateVariant"
-	updateVariant = "UpdateVariant"
-	deleteVariant = "DeleteVariant"
+	findVariant    = "FindVariant"
+	listVariant    = "ListVariants"
+	createVariant  = "CreateVariant"
+	updateVariant  = "UpdateVariant"
+	deleteVariant  = "DeleteVariant"
+	restoreVariant = "RestoreVariant"
+
+	selectDependents = "SelectDependents"
+	detachVariants   = "DetachVariants"
+
+	createDescription   = "CreateDescription"
+	updateDescription   = "UpdateDescription"
+	deleteDescription   = "DeleteDescription"
+	replaceDescriptions = "ReplaceDescriptions"
+	touchVariant        = "TouchVariant"
+
+	version = logic.Version(1)
 )
 
 func (s *VariantTestSuite) SetupSuite() {
@@ -39,6 +53,12 @@ func (s *VariantTestSuite) SetupSuite() {
 
 	mockDB := newMockDBClient()
 	do.ProvideValue[service.VariantRepository](injector, mockDB)
+	do.ProvideValue[service.VariantDescriptionRepository](injector, mockDB)
+	s.uniques = newMockUniqueVariantDetacher()
+	do.ProvideValue[service.UniqueVariantDetacher](injector, s.uniques)
+	recorder := newMockAuditRecorder()
+	recorder.On(recordChange, mock.Anything, mock.Anything).Return(nil)
+	do.ProvideValue[logic.AuditRecorder](injector, recorder)
 	s.mockDB = mockDB
 	usecase, err := NewVariant(injector)
 	if err != nil {
@@ -101,40 +121,53 @@ func (s *VariantTestSuite) TestList() {
 	variants := model.Variants{
 		model.NewVariant(model.VariantID(id), "cosmic", "meteor"),
 	}
+	page := logic.NewPage(variants, "")
+
+	pageRequest, err := logic.NewPageRequest(0, "", logic.Asc)
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	query, err := service.NewListVariants(pageRequest, service.VariantSortByName, nil)
+	if err != nil {
+		s.T().Fatal(err)
+	}
 
 	{
 		s.mockDB.On(
 			listVariant,
 			ctx,
+			query,
 		).
-			Return(variants, nil).
+			Return(&page, nil).
 			Once()
-		r, err := s.usecase.List(ctx)
+		r, err := s.usecase.List(ctx, query)
 		if err != nil {
 			s.T().Error(err)
 			return
 		}
 
-		s.Equal(variants, r)
+		s.Equal(&page, r)
 	}
 	{
 		s.mockDB.On(
 			listVariant,
 			ctx,
+			query,
 		).
 			Return(nil, service.IntervalServerError).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(failure.Is(err, logic.IntervalServerError))
 	}
 	{
 		s.mockDB.On(
 			listVariant,
 			ctx,
+			query,
 		).
 			Return(nil, failure.Wrap(e)).
 			Once()
-		_, err := s.usecase.List(ctx)
+		_, err := s.usecase.List(ctx, query)
 		s.True(errors.Is(err, e))
 	}
 }
@@ -174,7 +207,7 @@ func (s *VariantTestSuite) TestCreate() {
 // TestUpdate レコードの存在確認はFindと同じなのチェックしない
 func (s *VariantTestSuite) TestUpdate() {
 	{
-		item := service.NewUpdateVariant(id, groupID, "meteor")
+		item := service.NewUpdateVariant(id, groupID, "meteor", version)
 		variant := model.NewVariant(id, "cosmic", "meteor")
 		s.mockDB.On(findVariant, model.VariantID(id)).Return(&variant, nil).Once()
 		s.mockDB.On(
@@ -194,7 +227,7 @@ func (s *VariantTestSuite) TestUpdate() {
 	}
 
 	{ // updateVariant error case
-		item := service.NewUpdateVariant(id, groupID, "meteor")
+		item := service.NewUpdateVariant(id, groupID, "meteor", version)
 		variant := model.NewVariant(id, "cosmic", "meteor")
 		s.mockDB.On(findVariant, model.VariantID(id)).Return(&variant, nil).Once()
 		s.mockDB.On(
@@ -207,33 +240,191 @@ func (s *VariantTestSuite) TestUpdate() {
 		_, err := s.usecase.Update(ctx, item)
 		s.True(errors.Is(err, e))
 	}
+
+	{ // 他の更新でバージョンが変わっている場合
+		item := service.NewUpdateVariant(id, groupID, "meteor", version)
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(findVariant, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(
+			updateVariant,
+			ctx,
+			item,
+		).
+			Return(nil, service.VersionMismatch).
+			Once()
+		_, err := s.usecase.Update(ctx, item)
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
 }
 
 func (s *VariantTestSuite) TestDelete() {
 	{
 		variant := model.NewVariant(id, "cosmic", "meteor")
 		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(logic.Dependents{}, nil).Once()
 		s.mockDB.On(
 			deleteVariant,
 			ctx,
 			model.VariantID(id),
+			version,
 		).
 			Return(nil).
 			Once()
-		s.Nil(s.usecase.Delete(ctx, model.VariantID(id)))
+		s.Nil(s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, false)))
 	}
 
 	{ // updateVariant error case
 		variant := model.NewVariant(id, "cosmic", "meteor")
 		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(logic.Dependents{}, nil).Once()
 		s.mockDB.On(
 			deleteVariant,
 			ctx,
 			model.VariantID(id),
+			version,
 		).
 			Return(e).
 			Once()
-		err := s.usecase.Delete(ctx, model.VariantID(id))
+		err := s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, false))
+		s.True(errors.Is(err, e))
+	}
+
+	{ // 他の更新でバージョンが変わっている場合
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(logic.Dependents{}, nil).Once()
+		s.mockDB.On(
+			deleteVariant,
+			ctx,
+			model.VariantID(id),
+			version,
+		).
+			Return(service.VersionMismatch).
+			Once()
+		err := s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, false))
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
+
+	uniques := logic.Dependents{{Entity: logic.AuditUnique, ID: 1, Name: "Alpha Rex"}}
+	{ // ユニークに付与されている場合は付与したユニークを示して削除しない
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(uniques, nil).Once()
+		err := s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, false))
+		s.True(failure.Is(err, logic.Conflict))
+
+		var dependents logic.Dependents
+		s.True(errors.As(err, &dependents))
+		s.Equal(uniques, dependents)
+	}
+
+	{ // cascadeではユニークから外してから削除する
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(uniques, nil).Once()
+		s.uniques.On(detachVariants, ctx, []model.VariantID{id}).Return(nil).Once()
+		s.mockDB.On(deleteVariant, ctx, model.VariantID(id), version).Return(nil).Once()
+		s.Nil(s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, true)))
+	}
+
+	{ // cascadeでもバリアントが無くなるユニークがある場合は削除しない
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(selectDependents, ctx, model.VariantID(id)).Return(uniques, nil).Once()
+		s.uniques.On(detachVariants, ctx, []model.VariantID{id}).
+			Return(failure.Translate(uniques, logic.Conflict)).
+			Once()
+		err := s.usecase.Delete(ctx, service.NewDeleteVariant(model.VariantID(id), version, true))
+		s.True(failure.Is(err, logic.Conflict))
+	}
+}
+
+func (s *VariantTestSuite) TestRestore() {
+	{
+		variant := model.NewVariant(id, "cosmic", "meteor")
+		s.mockDB.On(restoreVariant, ctx, model.VariantID(id)).Return(nil).Once()
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		r, err := s.usecase.Restore(ctx, model.VariantID(id))
+		s.Nil(err)
+		s.Equal(&variant, r)
+	}
+
+	{ // ゴミ箱に無い場合
+		s.mockDB.On(restoreVariant, ctx, model.VariantID(id)).Return(service.NotFound).Once()
+		_, err := s.usecase.Restore(ctx, model.VariantID(id))
+		s.True(failure.Is(err, logic.NotFound))
+	}
+
+	{ // グループがゴミ箱にある場合
+		s.mockDB.On(restoreVariant, ctx, model.VariantID(id)).Return(service.ParentDeleted).Once()
+		_, err := s.usecase.Restore(ctx, model.VariantID(id))
+		s.True(failure.Is(err, logic.Conflict))
+	}
+}
+
+func (s *VariantTestSuite) TestDescriptions() {
+	text, err := model.NewDescriptionText("AoE explosive tick damage, traps dinos in center.")
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	variant := model.NewVariant(id, "cosmic", "singularity")
+	described := variant.WithDescriptions(model.Descriptions{model.NewDescription(1, text)})
+
+	{
+		item := service.NewCreateDescription(id, version, text)
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(touchVariant, ctx, model.VariantID(id), version).Return(nil).Once()
+		s.mockDB.On(createDescription, ctx, item).Return(model.DescriptionID(1), nil).Once()
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&described, nil).Once()
+		r, err := s.usecase.CreateDescription(ctx, item)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+
+		s.Equal(&described, r)
+	}
+	{ // 存在しないバリアントへの追加
+		item := service.NewCreateDescription(notExistID, version, text)
+		s.mockDB.On(findVariant, ctx, model.VariantID(notExistID)).Return(nil, service.NotFound).Once()
+		_, err := s.usecase.CreateDescription(ctx, item)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+	{ // 他の変更で古くなったバージョンでの追加
+		item := service.NewCreateDescription(id, version, text)
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(touchVariant, ctx, model.VariantID(id), version).Return(service.VersionMismatch).Once()
+		_, err := s.usecase.CreateDescription(ctx, item)
+		s.True(failure.Is(err, logic.PreconditionFailed))
+	}
+	{ // 存在しない説明文の更新
+		item := service.NewUpdateDescription(id, version, 2, text)
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(touchVariant, ctx, model.VariantID(id), version).Return(nil).Once()
+		s.mockDB.On(updateDescription, ctx, item).Return(service.NotFound).Once()
+		_, err := s.usecase.UpdateDescription(ctx, item)
+		s.True(failure.Is(err, logic.NotFound))
+	}
+	{
+		item := service.NewReplaceDescriptions(id, version, []model.DescriptionText{text})
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&variant, nil).Once()
+		s.mockDB.On(touchVariant, ctx, model.VariantID(id), version).Return(nil).Once()
+		s.mockDB.On(replaceDescriptions, ctx, item).Return(nil).Once()
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&described, nil).Once()
+		r, err := s.usecase.ReplaceDescriptions(ctx, item)
+		if err != nil {
+			s.T().Error(err)
+			return
+		}
+
+		s.Equal(&described, r)
+	}
+	{
+		item := service.NewDeleteDescription(id, version, 1)
+		s.mockDB.On(findVariant, ctx, model.VariantID(id)).Return(&described, nil).Once()
+		s.mockDB.On(touchVariant, ctx, model.VariantID(id), version).Return(nil).Once()
+		s.mockDB.On(deleteDescription, ctx, item).Return(e).Once()
+		_, err := s.usecase.DeleteDescription(ctx, item)
 		s.True(errors.Is(err, e))
 	}
 }
@@ -0,0 +1,19 @@
+package logic
+
+import (
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// Version 楽観的排他制御に用いる行のバージョン。作成時は1で更新の度に1つ増える
+type Version int
+
+func (v Version) Value() int { return int(v) }
+
+func NewVersion(value int) (Version, error) {
+	if value < 1 {
+		return 0, failure.Translate(i18n.Errorf("バージョン%dは不正です", value), PreconditionFailed)
+	}
+	return Version(value), nil
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+	"mods-explore/ark/omega/logic/audit/usecase"
+)
+
+type AuditHandler interface {
+	// History entityの:idの変更履歴を返すハンドラー
+	History(logic.AuditEntity) echo.HandlerFunc
+	List(echo.Context) error
+}
+
+type Audit struct {
+	usecase.AuditUsecase
+}
+
+func NewAudit(injector *do.Injector) (AuditHandler, error) {
+	return &Audit{
+		AuditUsecase: do.MustInvoke[usecase.AuditUsecase](injector),
+	}, nil
+}
+
+// AuditEntryValue beforeとafterは記録時点の対象の状態をそのまま返す
+type AuditEntryValue struct {
+	ID        int             `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Action    string          `json:"action"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	Actor     string          `json:"actor"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func NewAuditEntryValue(entry model.Entry) AuditEntryValue {
+	return AuditEntryValue{
+		ID:        entry.ID().Value(),
+		Entity:    entry.Entity().Value(),
+		EntityID:  entry.EntityID(),
+		Action:    entry.Action().Value(),
+		Before:    entry.Before(),
+		After:     entry.After(),
+		Actor:     entry.Actor().Value(),
+		CreatedAt: entry.CreatedAt(),
+	}
+}
+
+// auditPageParams 変更履歴は記録順のみで並べるため、ソートキーは受け付けない
+type auditPageParams struct {
+	Limit  uint   `query:"limit"`
+	Cursor string `query:"cursor"`
+	Order  string `query:"order"`
+}
+
+func (p auditPageParams) pageRequest() (logic.PageRequest, error) {
+	return logic.NewPageRequest(p.Limit, logic.Cursor(p.Cursor), logic.SortOrder(p.Order))
+}
+
+type auditHistoryParams struct {
+	auditPageParams
+
+	ID int `param:"id" validate:"required"`
+}
+
+func (a Audit) History(entity logic.AuditEntity) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var params auditHistoryParams
+		if err := c.Bind(&params); err != nil {
+			return err
+		}
+		if err := c.Validate(&params); err != nil {
+			return err
+		}
+		page, err := params.pageRequest()
+		if err != nil {
+			return err
+		}
+
+		entries, err := a.AuditUsecase.History(
+			c.Request().Context(), service.NewListHistory(page, entity, params.ID),
+		)
+		if err != nil {
+			return err
+		}
+		if err = c.JSON(http.StatusOK, NewPageValue(*entries, NewAuditEntryValue)); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+type auditListParams struct {
+	auditPageParams
+
+	Since string `query:"since" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// since 指定が無い場合はゼロ値を返し、全ての変更履歴を対象とする
+func (p auditListParams) since() (time.Time, error) {
+	if p.Since == "" {
+		return time.Time{}, nil
+	}
+	since, err := time.Parse(time.RFC3339, p.Since)
+	if err != nil {
+		return time.Time{}, logic.WrapInvalidArgument(err)
+	}
+	return since, nil
+}
+
+func (a Audit) List(c echo.Context) error {
+	var params auditListParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	since, err := params.since()
+	if err != nil {
+		return err
+	}
+
+	entries, err := a.AuditUsecase.List(c.Request().Context(), service.NewListEntries(page, since))
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewPageValue(*entries, NewAuditEntryValue)); err != nil {
+		return err
+	}
+	return nil
+}
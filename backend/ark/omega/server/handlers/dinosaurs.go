@@ -0,0 +1,365 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/creature/usecase"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/server/view"
+)
+
+type DinosaurHandler interface {
+	Read(echo.Context) error
+	List(echo.Context) error
+	Create(echo.Context) error
+	Update(echo.Context) error
+	Delete(echo.Context) error
+	Restore(echo.Context) error
+	ListUniques(echo.Context) error
+}
+
+type Dinosaur struct {
+	usecase.DinosaurUsecase
+}
+
+func NewDinosaur(injector *do.Injector) (DinosaurHandler, error) {
+	return &Dinosaur{
+		DinosaurUsecase: do.MustInvoke[usecase.DinosaurUsecase](injector),
+	}, nil
+}
+
+type dinosaurParams struct {
+	ID int `param:"id" validate:"required"`
+}
+
+// StatGrowthValue レベル毎の成長値。加算値以外は基礎値に対する割合
+type StatGrowthValue struct {
+	Stat          string  `json:"stat"`
+	WildIncrease  float32 `json:"wild_increase"`
+	TamedIncrease float32 `json:"tamed_increase"`
+	TamedAdd      float32 `json:"tamed_add"`
+	TamedAffinity float32 `json:"tamed_affinity"`
+}
+
+// newStatGrowthValues ステータスの順に並べる
+func newStatGrowthValues(growth creatureModel.SpeciesStatGrowth) []StatGrowthValue {
+	values := make([]StatGrowthValue, 0, len(growth))
+	for _, kind := range creatureModel.StatKinds() {
+		g, ok := growth[kind]
+		if !ok {
+			continue
+		}
+		values = append(values, StatGrowthValue{
+			Stat:          kind.Value(),
+			WildIncrease:  g.WildIncrease(),
+			TamedIncrease: g.TamedIncrease(),
+			TamedAdd:      g.TamedAdd(),
+			TamedAffinity: g.TamedAffinity(),
+		})
+	}
+	return values
+}
+
+// DinosaurValue nameは保存された名前で、表示にはdisplay_nameを用いる。
+// stat_growthsは登録された成長値で、一覧では読み込まない。登録されていないステータスは代表的な値を用いる
+type DinosaurValue struct {
+	ID                int               `json:"id"`
+	Name              string            `json:"name"`
+	DisplayName       string            `json:"display_name"`
+	BaseHealth        uint              `json:"base_health"`
+	BaseStamina       uint              `json:"base_stamina"`
+	BaseOxygen        uint              `json:"base_oxygen"`
+	BaseFood          uint              `json:"base_food"`
+	BaseWeight        uint              `json:"base_weight"`
+	BaseMelee         uint              `json:"base_melee"`
+	BaseMovementSpeed uint              `json:"base_movement_speed"`
+	BaseTorpidity     uint              `json:"base_torpidity"`
+	BaseArmor         uint              `json:"base_armor"`
+	StatGrowths       []StatGrowthValue `json:"stat_growths"`
+}
+
+func NewDinosaurValue(dino creatureModel.Dinosaur) DinosaurValue {
+	stats := dino.Stats()
+	return DinosaurValue{
+		ID:                dino.BaseID().Value(),
+		Name:              dino.BaseName().Value(),
+		DisplayName:       dino.BaseDisplayName().Value(),
+		BaseHealth:        stats.Health().Value(),
+		BaseStamina:       stats.Stamina().Value(),
+		BaseOxygen:        stats.Oxygen().Value(),
+		BaseFood:          stats.Food().Value(),
+		BaseWeight:        stats.Weight().Value(),
+		BaseMelee:         stats.Melee().Value(),
+		BaseMovementSpeed: stats.MovementSpeed().Value(),
+		BaseTorpidity:     stats.Torpidity().Value(),
+		BaseArmor:         stats.Armor().Value(),
+		StatGrowths:       newStatGrowthValues(dino.StatGrowth()),
+	}
+}
+
+func (d Dinosaur) Read(c echo.Context) error {
+	var params dinosaurParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	dino, err := d.DinosaurUsecase.Find(c.Request().Context(), creatureModel.DinosaurID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	setETag(c, dino.Version())
+	if err = c.JSON(http.StatusOK, NewDinosaurValue(*dino)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d Dinosaur) List(c echo.Context) error {
+	var params pageQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	query, err := creatureSvc.NewListDinosaurs(page, creatureSvc.DinosaurSortKey(params.Sort))
+	if err != nil {
+		return err
+	}
+
+	dinos, err := d.DinosaurUsecase.List(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewPageValue(*dinos, NewDinosaurValue)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type statGrowthParams struct {
+	Stat          string  `json:"stat" validate:"required,oneof=health stamina oxygen food weight melee movement_speed torpidity armor"`
+	WildIncrease  float32 `json:"wild_increase" validate:"gte=0"`
+	TamedIncrease float32 `json:"tamed_increase" validate:"gte=0"`
+	TamedAdd      float32 `json:"tamed_add"`
+	TamedAffinity float32 `json:"tamed_affinity"`
+}
+
+// dinosaurBody stat_growthsを省略した場合、作成では登録せず、更新では登録済みの成長値を変えない
+type dinosaurBody struct {
+	dinosaurStatsParams
+
+	Name        string             `json:"name" validate:"required,max=100"`
+	StatGrowths []statGrowthParams `json:"stat_growths" validate:"omitempty,dive"`
+}
+
+func (b dinosaurBody) name() (creatureModel.DinosaurName, error) {
+	name, err := creatureModel.NewDinosaurName(b.Name)
+	if err != nil {
+		return "", logic.WrapInvalidArgument(err)
+	}
+	return name, nil
+}
+
+// statGrowth stat_growthsを省略した場合はnil、空の配列の場合は空の成長値を返す
+func (b dinosaurBody) statGrowth() (creatureModel.SpeciesStatGrowth, error) {
+	return newSpeciesStatGrowth(b.StatGrowths)
+}
+
+func newSpeciesStatGrowth(params []statGrowthParams) (creatureModel.SpeciesStatGrowth, error) {
+	if params == nil {
+		return nil, nil
+	}
+	growth := make(creatureModel.SpeciesStatGrowth, len(params))
+	for _, p := range params {
+		kind, err := creatureModel.NewStatKind(p.Stat)
+		if err != nil {
+			return nil, logic.WrapInvalidArgument(err)
+		}
+		if _, ok := growth[kind]; ok {
+			return nil, failure.Translate(i18n.Errorf("ステータス%qが重複しています", p.Stat), logic.InvalidArgument)
+		}
+		g, err := creatureModel.NewStatGrowth(p.WildIncrease, p.TamedIncrease, p.TamedAdd, p.TamedAffinity)
+		if err != nil {
+			return nil, logic.WrapInvalidArgument(err)
+		}
+		growth[kind] = g
+	}
+	return growth, nil
+}
+
+func (d Dinosaur) Create(c echo.Context) error {
+	var body dinosaurBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	name, err := body.name()
+	if err != nil {
+		return err
+	}
+	stats, err := body.stats()
+	if err != nil {
+		return err
+	}
+	growth, err := body.statGrowth()
+	if err != nil {
+		return err
+	}
+
+	dino, err := d.DinosaurUsecase.Create(
+		c.Request().Context(),
+		creatureSvc.NewCreateDinosaur(name, stats).WithStatGrowth(growth),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewDinosaurValue(*dino)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type updateDinosaurBody struct {
+	dinosaurBody
+
+	ID int `param:"id" validate:"required"`
+}
+
+func (d Dinosaur) Update(c echo.Context) error {
+	var body updateDinosaurBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	name, err := body.name()
+	if err != nil {
+		return err
+	}
+	stats, err := body.stats()
+	if err != nil {
+		return err
+	}
+	growth, err := body.statGrowth()
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	dino, err := d.DinosaurUsecase.Update(
+		c.Request().Context(),
+		creatureSvc.NewUpdateDinosaur(creatureModel.DinosaurID(body.ID), name, stats, version).WithStatGrowth(growth),
+	)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, dino.Version())
+	if err = c.JSON(http.StatusOK, NewDinosaurValue(*dino)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d Dinosaur) Delete(c echo.Context) error {
+	var params dinosaurParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	if err = d.DinosaurUsecase.Delete(c.Request().Context(), creatureModel.DinosaurID(params.ID), version); err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, map[string]any{}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d Dinosaur) Restore(c echo.Context) error {
+	var params dinosaurParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	dino, err := d.DinosaurUsecase.Restore(c.Request().Context(), creatureModel.DinosaurID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	setETag(c, dino.Version())
+	if err = c.JSON(http.StatusOK, NewDinosaurValue(*dino)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type dinosaurUniquesParams struct {
+	uniqueListParams
+
+	ID int `param:"id" validate:"required"`
+}
+
+func (d Dinosaur) ListUniques(c echo.Context) error {
+	var params dinosaurUniquesParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortKey(params.Sort), params.filter())
+	if err != nil {
+		return err
+	}
+
+	uniques, err := d.DinosaurUsecase.ListUniques(
+		c.Request().Context(), creatureModel.DinosaurID(params.ID), query,
+	)
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewPageValue(*uniques, view.NewUniqueValue)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+)
+
+func Test_dinosaurBody_statGrowth(t *testing.T) {
+	t.Run("省略した場合は登録済みの成長値を変えないようnilを返すかのテスト", func(t *testing.T) {
+		growth, err := dinosaurBody{}.statGrowth()
+		if err != nil || growth != nil {
+			t.Errorf("nilになっていません %v %v", growth, err)
+		}
+		growth, err = dinosaurBody{StatGrowths: []statGrowthParams{}}.statGrowth()
+		if err != nil || growth == nil || len(growth) != 0 {
+			t.Errorf("空の配列で成長値を全て削除できません %v %v", growth, err)
+		}
+	})
+
+	t.Run("ステータス毎の成長値に変換できるかのテスト", func(t *testing.T) {
+		growth, err := dinosaurBody{StatGrowths: []statGrowthParams{
+			{Stat: "health", WildIncrease: 0.2, TamedIncrease: 0.27},
+			{Stat: "melee", WildIncrease: 0.05, TamedIncrease: 0.1, TamedAdd: 0.07},
+		}}.statGrowth()
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := newStatGrowthValues(growth)
+		want := []StatGrowthValue{
+			{Stat: "health", WildIncrease: 0.2, TamedIncrease: 0.27},
+			{Stat: "melee", WildIncrease: 0.05, TamedIncrease: 0.1, TamedAdd: 0.07},
+		}
+		if len(values) != len(want) || values[0] != want[0] || values[1] != want[1] {
+			t.Errorf("成長値が一致しません\nwant: %v\ngot:  %v", want, values)
+		}
+		if _, ok := growth[creatureModel.StatStamina]; ok {
+			t.Error("指定していないステータスの成長値が登録されています")
+		}
+	})
+
+	t.Run("不明なステータスや負の成長値を検証できるかのテスト", func(t *testing.T) {
+		body := dinosaurBody{
+			dinosaurStatsParams: dinosaurStatsParams{
+				BaseHealth: 1, BaseStamina: 1, BaseFood: 1, BaseWeight: 1, BaseMovementSpeed: 100, BaseTorpidity: 1,
+			},
+			Name:        "Rex",
+			StatGrowths: []statGrowthParams{{Stat: "luck"}, {Stat: "health", WildIncrease: -1}},
+		}
+		var fields logic.FieldErrors
+		if err := NewValidator().Validate(&body); !errors.As(err, &fields) {
+			t.Fatalf("項目毎のエラーが含まれていません %v", err)
+		}
+		rules := make([]string, 0, len(fields))
+		for _, f := range fields {
+			rules = append(rules, f.Rule)
+		}
+		if len(rules) != 2 || rules[0] != "oneof" || rules[1] != "gte" {
+			t.Errorf("成長値の検証エラーになっていません %v", fields)
+		}
+	})
+
+	t.Run("同じステータスを重複して指定した場合のテスト", func(t *testing.T) {
+		_, err := dinosaurBody{StatGrowths: []statGrowthParams{{Stat: "health"}, {Stat: "health"}}}.statGrowth()
+		if !failure.Is(err, logic.InvalidArgument) {
+			t.Errorf("InvalidArgumentになっていません %v", err)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/translation/usecase"
+)
+
+type DisplayNameHandler interface {
+	List(echo.Context) error
+	Put(echo.Context) error
+	Delete(echo.Context) error
+}
+
+type DisplayName struct {
+	usecase.DisplayNameUsecase
+}
+
+func NewDisplayName(injector *do.Injector) (DisplayNameHandler, error) {
+	return &DisplayName{
+		DisplayNameUsecase: do.MustInvoke[usecase.DisplayNameUsecase](injector),
+	}, nil
+}
+
+// displayNameTargetParams targetはdinosaur、unique、variant、groupのいずれか
+type displayNameTargetParams struct {
+	Target string `param:"target" validate:"required"`
+	ID     int    `param:"id" validate:"required"`
+}
+
+func (p displayNameTargetParams) target() (model.Target, error) {
+	target, err := model.NewTarget(p.Target)
+	if err != nil {
+		return "", logic.WrapInvalidArgument(err)
+	}
+	return target, nil
+}
+
+type displayNameLocaleParams struct {
+	displayNameTargetParams
+
+	Locale string `param:"locale" validate:"required"`
+}
+
+func (p displayNameLocaleParams) locale() (i18n.Locale, error) {
+	locale, err := i18n.NewLocale(p.Locale)
+	if err != nil {
+		return "", logic.WrapInvalidArgument(err)
+	}
+	return locale, nil
+}
+
+type DisplayNameValue struct {
+	Target string `json:"target"`
+	ID     int    `json:"id"`
+	Locale string `json:"locale"`
+	Name   string `json:"name"`
+}
+
+func NewDisplayNameValue(name model.DisplayName) DisplayNameValue {
+	return DisplayNameValue{
+		Target: name.Target().Value(),
+		ID:     name.TargetID(),
+		Locale: name.Locale().Value(),
+		Name:   name.Name().Value(),
+	}
+}
+
+func (d DisplayName) List(c echo.Context) error {
+	var params displayNameTargetParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	target, err := params.target()
+	if err != nil {
+		return err
+	}
+
+	names, err := d.DisplayNameUsecase.List(c.Request().Context(), target, params.ID)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, lo.Map(names, func(n model.DisplayName, _ int) DisplayNameValue {
+		return NewDisplayNameValue(n)
+	})); err != nil {
+		return err
+	}
+	return nil
+}
+
+type putDisplayNameBody struct {
+	displayNameLocaleParams
+
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (d DisplayName) Put(c echo.Context) error {
+	var body putDisplayNameBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	target, err := body.target()
+	if err != nil {
+		return err
+	}
+	locale, err := body.locale()
+	if err != nil {
+		return err
+	}
+	name, err := model.NewName(body.Name)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+
+	stored, err := d.DisplayNameUsecase.Put(
+		c.Request().Context(),
+		model.NewDisplayName(target, body.ID, locale, name),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewDisplayNameValue(*stored)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d DisplayName) Delete(c echo.Context) error {
+	var params displayNameLocaleParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	target, err := params.target()
+	if err != nil {
+		return err
+	}
+	locale, err := params.locale()
+	if err != nil {
+		return err
+	}
+
+	if err = d.DisplayNameUsecase.Delete(c.Request().Context(), target, params.ID, locale); err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, map[string]any{}); err != nil {
+		return err
+	}
+	return nil
+}
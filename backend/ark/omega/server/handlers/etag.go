@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// setETag 行のバージョンを強いETagとして返す。更新、削除の際はこの値をIf-Matchに指定させる
+func setETag(c echo.Context, version logic.Version) {
+	c.Response().Header().Set(HeaderETag, strconv.Quote(strconv.Itoa(version.Value())))
+}
+
+// ifMatch 更新と削除では上書きを防ぐためIf-Matchを必須とする。"*"は競合を検出できないため受け付けない
+func ifMatch(c echo.Context) (logic.Version, error) {
+	value := strings.TrimSpace(c.Request().Header.Get(HeaderIfMatch))
+	if value == "" || value == "*" {
+		return 0, failure.Translate(i18n.Errorf("ETagを指定したIf-Matchヘッダーが必要です"), logic.PreconditionRequired)
+	}
+
+	// 弱いETagや形式の異なる値は、現在のETagと一致しないものとして扱う
+	tag, err := strconv.Unquote(value)
+	if err != nil || !strings.HasPrefix(value, `"`) {
+		return 0, failure.Translate(i18n.Errorf("If-Match %sが一致しません", value), logic.PreconditionFailed)
+	}
+	n, err := strconv.Atoi(tag)
+	if err != nil {
+		return 0, failure.Translate(i18n.Errorf("If-Match %sが一致しません", value), logic.PreconditionFailed)
+	}
+	return logic.NewVersion(n)
+}
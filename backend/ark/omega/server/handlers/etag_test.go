@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+)
+
+func Test_ETag(t *testing.T) {
+	newContext := func(ifMatch string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/uniques/1", nil)
+		if ifMatch != "" {
+			req.Header.Set(HeaderIfMatch, ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		return echo.New().NewContext(req, rec), rec
+	}
+
+	t.Run("バージョンをETagとして返すかのテスト", func(t *testing.T) {
+		c, rec := newContext("")
+		setETag(c, logic.Version(3))
+		if got := rec.Header().Get(HeaderETag); got != `"3"` {
+			t.Errorf("ETagが正しくありません %s", got)
+		}
+	})
+
+	t.Run("If-Matchのバージョンの読み込みテスト", func(t *testing.T) {
+		c, _ := newContext(`"3"`)
+		version, err := ifMatch(c)
+		if err != nil || version != logic.Version(3) {
+			t.Errorf("バージョンを読み込めていません %d %v", version, err)
+		}
+	})
+
+	t.Run("If-Matchが無い場合のテスト", func(t *testing.T) {
+		for _, value := range []string{"", "*"} {
+			c, _ := newContext(value)
+			if _, err := ifMatch(c); !failure.Is(err, logic.PreconditionRequired) {
+				t.Errorf("%qがPreconditionRequiredになっていません %v", value, err)
+			}
+		}
+	})
+
+	t.Run("一致しないIf-Matchのテスト", func(t *testing.T) {
+		for _, value := range []string{`W/"3"`, "3", `"abc"`, `"0"`} {
+			c, _ := newContext(value)
+			if _, err := ifMatch(c); !failure.Is(err, logic.PreconditionFailed) {
+				t.Errorf("%qがPreconditionFailedになっていません %v", value, err)
+			}
+		}
+	})
+
+	t.Run("古いバージョンのレスポンステスト", func(t *testing.T) {
+		rec, problem := serveProblem(t, failure.New(logic.PreconditionFailed))
+		if rec.Code != http.StatusPreconditionFailed || problem.Type != "/problems/precondition-failed" {
+			t.Errorf("412になっていません %d %+v", rec.Code, problem)
+		}
+	})
+}
@@ -2,50 +2,37 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/morikuni/failure"
 	"github.com/samber/do"
 
 	"mods-explore/ark/omega/logic"
+	authModel "mods-explore/ark/omega/logic/auth/domain/model"
+	authUsecase "mods-explore/ark/omega/logic/auth/usecase"
+	"mods-explore/ark/omega/logic/i18n"
 	"mods-explore/ark/omega/storage"
 )
 
+const (
+	HeaderAcceptLanguage  = "Accept-Language"
+	HeaderContentLanguage = "Content-Language"
+)
+
+// NewErrorHandler 全てのエラーをRFC 7807のapplication/problem+jsonで返す
 func NewErrorHandler(s *echo.Echo) func(err error, c echo.Context) {
 	return func(err error, c echo.Context) {
-		code, ok := failure.CodeOf(err)
-		if !ok {
-			s.DefaultHTTPErrorHandler(err, c)
+		if c.Response().Committed {
 			return
 		}
 
-		switch code {
-		case logic.InvalidArgument:
-			if err := c.JSON(http.StatusBadRequest, map[string]any{
-				"message": "bad request",
-			}); err != nil {
-				c.Logger().Error(err)
-			}
-			return
-		case logic.NotFound:
-			if err := c.JSON(http.StatusNotFound, map[string]any{
-				"message": "not found",
-			}); err != nil {
-				c.Logger().Error(err)
-			}
-			return
-		case logic.Forbidden:
-			if err := c.NoContent(http.StatusForbidden); err != nil {
-				if err != nil {
-					c.Logger().Error(err)
-				}
-			}
-		default:
-			if err := c.NoContent(http.StatusInternalServerError); err != nil {
-				if err != nil {
-					c.Logger().Error(err)
-				}
-			}
+		problem := newProblem(err, c)
+		if problem.Status >= http.StatusInternalServerError {
+			s.Logger.Error(err)
+		}
+		if err := writeProblem(c, problem); err != nil {
+			s.Logger.Error(err)
 		}
 	}
 }
@@ -60,3 +47,60 @@ func Transctioner(injector *do.Injector) echo.MiddlewareFunc {
 		}
 	}
 }
+
+const bearerScheme = "Bearer"
+
+// Authenticator Authorizationヘッダーのトークンを検証し、利用者をcontextに設定する。ヘッダーが無い場合は匿名のまま続ける
+func Authenticator(injector *do.Injector) echo.MiddlewareFunc {
+	tokens := do.MustInvoke[authUsecase.TokenUsecase](injector)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == "" {
+				return next(c)
+			}
+			scheme, secret, ok := strings.Cut(header, " ")
+			if !ok || !strings.EqualFold(scheme, bearerScheme) || secret == "" {
+				return failure.Translate(i18n.Errorf("AuthorizationヘッダーにはBearerトークンを指定してください"), logic.Unauthorized)
+			}
+
+			ctx := c.Request().Context()
+			principal, err := tokens.Authenticate(ctx, authModel.Secret(strings.TrimSpace(secret)))
+			if err != nil {
+				return err
+			}
+			c.SetRequest(c.Request().WithContext(logic.SetPrincipal(ctx, *principal)))
+			return next(c)
+		}
+	}
+}
+
+// Authorizer 参照にはread、それ以外の更新にはwriteの権限を求める。Authenticatorの後に用いる
+func Authorizer(read, write logic.Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role := write
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				role = read
+			}
+			if err := logic.Authorize(c.Request().Context(), role); err != nil {
+				return err
+			}
+			return next(c)
+		}
+	}
+}
+
+// Localizer Accept-Languageから選んだ言語をcontextに設定し、エラーメッセージと表示名に用いる
+func Localizer() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			locale := i18n.MatchAcceptLanguage(c.Request().Header.Get(HeaderAcceptLanguage))
+			c.SetRequest(c.Request().WithContext(i18n.SetLocale(c.Request().Context(), locale)))
+			c.Response().Header().Set(HeaderContentLanguage, locale.Value())
+			c.Response().Header().Add(echo.HeaderVary, HeaderAcceptLanguage)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	authModel "mods-explore/ark/omega/logic/auth/domain/model"
+	authUsecase "mods-explore/ark/omega/logic/auth/usecase"
+)
+
+// stubTokens 平文のトークンをそのまま権限に対応させる
+type stubTokens map[authModel.Secret]logic.Role
+
+func (s stubTokens) Authenticate(_ context.Context, secret authModel.Secret) (*logic.Principal, error) {
+	role, ok := s[secret]
+	if !ok {
+		return nil, failure.New(logic.Unauthorized, failure.Message("invalid token"))
+	}
+	return &logic.Principal{Actor: logic.Actor(role), Role: role}, nil
+}
+
+func (stubTokens) Issue(context.Context, authModel.TokenName, logic.Role) (*authModel.IssuedToken, error) {
+	return nil, nil
+}
+func (stubTokens) List(context.Context) ([]authModel.Token, error) { return nil, nil }
+func (stubTokens) Revoke(context.Context, authModel.TokenID) error { return nil }
+
+func Test_Authorizer(t *testing.T) {
+	injector := do.New()
+	do.ProvideValue[authUsecase.TokenUsecase](injector, stubTokens{"viewer": logic.RoleViewer, "editor": logic.RoleEditor})
+
+	s := echo.New()
+	s.HTTPErrorHandler = NewErrorHandler(s)
+	g := s.Group("/api/v1/variants", Authenticator(injector), Authorizer(logic.RoleAnonymous, logic.RoleEditor))
+	actor := func(c echo.Context) error {
+		return c.String(http.StatusOK, logic.ActorOf(c.Request().Context()).Value())
+	}
+	g.GET("", actor)
+	g.POST("/new", actor)
+
+	serve := func(method, path, authorization string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, nil)
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+		}
+		s.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("参照は匿名で行えるかのテスト", func(t *testing.T) {
+		rec := serve(http.MethodGet, "/api/v1/variants", "")
+		if rec.Code != http.StatusOK || rec.Body.String() != logic.Anonymous.Value() {
+			t.Errorf("匿名で参照できません %d %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("トークンの無い更新が401になるかのテスト", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/variants/new", "")
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("トークンの無い更新が401になっていません %d", rec.Code)
+		}
+		if got := rec.Header().Get(echo.HeaderWWWAuthenticate); got != bearerScheme {
+			t.Errorf("WWW-Authenticateが含まれていません %s", got)
+		}
+	})
+
+	t.Run("不正なトークンが401になるかのテスト", func(t *testing.T) {
+		for _, authorization := range []string{"Bearer unknown", "Basic editor"} {
+			if rec := serve(http.MethodGet, "/api/v1/variants", authorization); rec.Code != http.StatusUnauthorized {
+				t.Errorf("%sが401になっていません %d", authorization, rec.Code)
+			}
+		}
+	})
+
+	t.Run("権限の足りない更新が403になるかのテスト", func(t *testing.T) {
+		if rec := serve(http.MethodPost, "/api/v1/variants/new", "Bearer viewer"); rec.Code != http.StatusForbidden {
+			t.Errorf("viewerの更新が403になっていません %d", rec.Code)
+		}
+	})
+
+	t.Run("権限のある更新で利用者がcontextに設定されるかのテスト", func(t *testing.T) {
+		rec := serve(http.MethodPost, "/api/v1/variants/new", "Bearer editor")
+		if rec.Code != http.StatusOK || rec.Body.String() != "editor" {
+			t.Errorf("editorで更新できません %d %s", rec.Code, rec.Body.String())
+		}
+	})
+}
@@ -0,0 +1,591 @@
+package handlers
+
+import (
+	"embed"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/server/view"
+)
+
+const openAPIVersion = "3.0.3"
+
+// apiOperation ルーティングと、そのハンドラーがバインドするリクエストとレスポンスの型の対応
+type apiOperation struct {
+	Method  string
+	Path    string // echoの形式のパス
+	Tag     string
+	Summary string
+	// Request param、queryタグからパラメータを、jsonタグからリクエストボディを生成する。nilの場合は無し
+	Request any
+	// Response 200のレスポンス。ContentTypeが空の場合はJSONとして扱う
+	Response    any
+	ContentType string
+	// Versioned 削除以外のレスポンスでETagを返し、PUTとDELETEではIf-Matchを必須とする
+	Versioned bool
+	// IfMatch POSTでもIf-Matchを必須とする。バリアントの説明文の追加のように、既存の対象を変更する場合に指定する
+	IfMatch bool
+	// Role server.newServerでルーティングのグループに指定した、必要なAPIトークンの権限
+	Role logic.Role
+}
+
+// emptyValue 削除APIが返す空のオブジェクト
+type emptyValue struct{}
+
+// apiOperations server.newServerで登録する全てのルーティング。ルーティングを変更した場合は合わせて更新する
+var apiOperations = []apiOperation{
+	{Method: http.MethodGet, Path: "/health", Tag: "system", Summary: "死活監視", Response: "", ContentType: echo.MIMETextPlain},
+	{Method: http.MethodGet, Path: "/api/openapi.json", Tag: "system", Summary: "OpenAPIのドキュメント", Response: map[string]any{}},
+	{Method: http.MethodGet, Path: "/api/docs", Tag: "system", Summary: "APIドキュメントの閲覧画面", Response: "", ContentType: echo.MIMETextHTML},
+
+	{Method: http.MethodGet, Path: "/", Tag: "pages", Summary: "ユニークの一覧画面", Request: uniqueListParams{}, Response: "", ContentType: echo.MIMETextHTML},
+	{Method: http.MethodGet, Path: "/uniques/:id", Tag: "pages", Summary: "ユニークの詳細画面", Request: uniqueQueryParams{}, Response: "", ContentType: echo.MIMETextHTML},
+	{Method: http.MethodGet, Path: "/variants/:id", Tag: "pages", Summary: "バリアントの詳細画面", Request: referenceParams{}, Response: "", ContentType: echo.MIMETextHTML},
+	{Method: http.MethodGet, Path: "/groups/:id", Tag: "pages", Summary: "バリアントグループの詳細画面", Request: variantGroupParams{}, Response: "", ContentType: echo.MIMETextHTML},
+	{Method: http.MethodGet, Path: "/search", Tag: "pages", Summary: "横断検索の画面", Request: searchPageParams{}, Response: "", ContentType: echo.MIMETextHTML},
+	{Method: http.MethodGet, Path: "/static/:name", Tag: "pages", Summary: "画面のスタイルシートとスクリプト", Request: staticParams{}, Response: "", ContentType: "*/*"},
+
+	{Method: http.MethodGet, Path: "/api/v1/variants/:id", Tag: "variants", Summary: "バリアントの取得", Request: referenceParams{}, Response: view.VariantValue{}, Versioned: true},
+	{Method: http.MethodGet, Path: "/api/v1/variants", Tag: "variants", Summary: "バリアントの一覧", Request: variantListParams{}, Response: PageValue[view.VariantValue]{}},
+	{Method: http.MethodPost, Path: "/api/v1/variants/new", Tag: "variants", Summary: "バリアントの作成", Request: createBody{}, Response: view.VariantValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/variants/:id", Tag: "variants", Summary: "バリアントの更新", Request: updateBody{}, Response: view.VariantValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/variants/:id", Tag: "variants", Summary: "バリアントの削除", Request: deleteVariantParams{}, Response: emptyValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/variants/:id/restore", Tag: "variants", Summary: "バリアントをゴミ箱から復元", Request: referenceParams{}, Response: view.VariantValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/variants/:id/descriptions", Tag: "variants", Summary: "バリアントの説明の追加", Request: createDescriptionBody{}, Response: view.VariantValue{}, Versioned: true, IfMatch: true, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/variants/:id/descriptions", Tag: "variants", Summary: "バリアントの説明の置き換え", Request: replaceDescriptionsBody{}, Response: view.VariantValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/variants/:id/descriptions/:description_id", Tag: "variants", Summary: "バリアントの説明の更新", Request: updateDescriptionBody{}, Response: view.VariantValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/variants/:id/descriptions/:description_id", Tag: "variants", Summary: "バリアントの説明の削除", Request: descriptionParams{}, Response: view.VariantValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodGet, Path: "/api/v1/variants/:id/history", Tag: "variants", Summary: "バリアントの変更履歴", Request: auditHistoryParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/variant-groups/:id", Tag: "variant-groups", Summary: "バリアントグループの取得", Request: variantGroupParams{}, Response: view.VariantGroupValue{}, Versioned: true},
+	{Method: http.MethodGet, Path: "/api/v1/variant-groups", Tag: "variant-groups", Summary: "バリアントグループの一覧", Request: pageQueryParams{}, Response: PageValue[view.VariantGroupValue]{}},
+	{Method: http.MethodPost, Path: "/api/v1/variant-groups/new", Tag: "variant-groups", Summary: "バリアントグループの作成", Request: createVariantGroup{}, Response: view.VariantGroupValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/variant-groups/:id", Tag: "variant-groups", Summary: "バリアントグループの更新", Request: updateVariantGroup{}, Response: view.VariantGroupValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/variant-groups/:id", Tag: "variant-groups", Summary: "バリアントグループの削除", Request: deleteVariantGroupParams{}, Response: emptyValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/variant-groups/:id/restore", Tag: "variant-groups", Summary: "バリアントグループをゴミ箱から復元", Request: variantGroupParams{}, Response: view.VariantGroupValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodGet, Path: "/api/v1/variant-groups/:id/history", Tag: "variant-groups", Summary: "バリアントグループの変更履歴", Request: auditHistoryParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/variant-rules/:id", Tag: "variant-rules", Summary: "バリアントの規則の取得", Request: variantRuleParams{}, Response: VariantRuleValue{}},
+	{Method: http.MethodGet, Path: "/api/v1/variant-rules", Tag: "variant-rules", Summary: "バリアントの規則の一覧", Request: pageQueryParams{}, Response: PageValue[VariantRuleValue]{}},
+	{Method: http.MethodPost, Path: "/api/v1/variant-rules/new", Tag: "variant-rules", Summary: "バリアントの規則の作成", Request: variantRuleBody{}, Response: VariantRuleValue{}, Role: logic.RoleAdmin},
+	{Method: http.MethodPut, Path: "/api/v1/variant-rules/:id", Tag: "variant-rules", Summary: "バリアントの規則の更新", Request: updateVariantRuleBody{}, Response: VariantRuleValue{}, Role: logic.RoleAdmin},
+	{Method: http.MethodDelete, Path: "/api/v1/variant-rules/:id", Tag: "variant-rules", Summary: "バリアントの規則の削除", Request: variantRuleParams{}, Response: emptyValue{}, Role: logic.RoleAdmin},
+	{Method: http.MethodGet, Path: "/api/v1/variant-rules/:id/history", Tag: "variant-rules", Summary: "バリアントの規則の変更履歴", Request: auditHistoryParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/dinosaurs/:id", Tag: "dinosaurs", Summary: "恐竜の取得", Request: dinosaurParams{}, Response: DinosaurValue{}, Versioned: true},
+	{Method: http.MethodGet, Path: "/api/v1/dinosaurs", Tag: "dinosaurs", Summary: "恐竜の一覧", Request: pageQueryParams{}, Response: PageValue[DinosaurValue]{}},
+	{Method: http.MethodPost, Path: "/api/v1/dinosaurs/new", Tag: "dinosaurs", Summary: "恐竜の作成", Request: dinosaurBody{}, Response: DinosaurValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/dinosaurs/:id", Tag: "dinosaurs", Summary: "恐竜の更新", Request: updateDinosaurBody{}, Response: DinosaurValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/dinosaurs/:id", Tag: "dinosaurs", Summary: "恐竜の削除", Request: dinosaurParams{}, Response: emptyValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/dinosaurs/:id/restore", Tag: "dinosaurs", Summary: "恐竜をゴミ箱から復元", Request: dinosaurParams{}, Response: DinosaurValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodGet, Path: "/api/v1/dinosaurs/:id/uniques", Tag: "dinosaurs", Summary: "恐竜を元にしたユニークの一覧", Request: dinosaurUniquesParams{}, Response: PageValue[view.UniqueValue]{}},
+	{Method: http.MethodGet, Path: "/api/v1/dinosaurs/:id/history", Tag: "dinosaurs", Summary: "恐竜の変更履歴", Request: auditHistoryParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/uniques/:id", Tag: "uniques", Summary: "ユニークの取得", Request: uniqueQueryParams{}, Response: view.UniqueValue{}, Versioned: true},
+	{Method: http.MethodGet, Path: "/api/v1/uniques", Tag: "uniques", Summary: "ユニークの一覧", Request: uniqueListParams{}, Response: PageValue[view.UniqueValue]{}},
+	{Method: http.MethodPost, Path: "/api/v1/uniques/new", Tag: "uniques", Summary: "ユニークの作成", Request: uniqueCreateParams{}, Response: view.UniqueValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPut, Path: "/api/v1/uniques/:id", Tag: "uniques", Summary: "ユニークの更新", Request: uniqueUpdateParams{}, Response: view.UniqueValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/uniques/:id", Tag: "uniques", Summary: "ユニークの削除", Request: uniqueQueryParams{}, Response: emptyValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/uniques/:id/restore", Tag: "uniques", Summary: "ユニークをゴミ箱から復元", Request: uniqueQueryParams{}, Response: view.UniqueValue{}, Versioned: true, Role: logic.RoleEditor},
+	{Method: http.MethodGet, Path: "/api/v1/uniques/:id/stats", Tag: "uniques", Summary: "ユニークのステータスの計算", Request: uniqueStatsParams{}, Response: UniqueStatsValue{}},
+	{Method: http.MethodGet, Path: "/api/v1/uniques/:id/history", Tag: "uniques", Summary: "ユニークの変更履歴", Request: auditHistoryParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/display-names/:target/:id", Tag: "display-names", Summary: "表示名の一覧", Request: displayNameTargetParams{}, Response: []DisplayNameValue{}},
+	{Method: http.MethodPut, Path: "/api/v1/display-names/:target/:id/:locale", Tag: "display-names", Summary: "表示名の登録", Request: putDisplayNameBody{}, Response: DisplayNameValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodDelete, Path: "/api/v1/display-names/:target/:id/:locale", Tag: "display-names", Summary: "表示名の削除", Request: displayNameLocaleParams{}, Response: emptyValue{}, Role: logic.RoleEditor},
+
+	{Method: http.MethodGet, Path: "/api/v1/search", Tag: "search", Summary: "横断検索", Request: searchParams{}, Response: SearchValue{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/audit", Tag: "audit", Summary: "変更履歴の一覧", Request: auditListParams{}, Response: PageValue[AuditEntryValue]{}},
+
+	{Method: http.MethodGet, Path: "/api/v1/trash", Tag: "trash", Summary: "ゴミ箱の一覧", Request: trashListParams{}, Response: PageValue[TrashItemValue]{}, Role: logic.RoleViewer},
+
+	{Method: http.MethodGet, Path: "/api/v1/proposals", Tag: "proposals", Summary: "変更の提案の一覧", Request: proposalListParams{}, Response: PageValue[ProposalValue]{}, Role: logic.RoleEditor},
+	{Method: http.MethodGet, Path: "/api/v1/proposals/:id", Tag: "proposals", Summary: "変更の提案の取得", Request: proposalParams{}, Response: ProposalValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/proposals/new", Tag: "proposals", Summary: "変更の提案の提出", Request: submitProposalBody{}, Response: ProposalValue{}},
+	{Method: http.MethodPost, Path: "/api/v1/proposals/:id/approve", Tag: "proposals", Summary: "変更の提案の承認", Request: reviewProposalBody{}, Response: ProposalValue{}, Role: logic.RoleEditor},
+	{Method: http.MethodPost, Path: "/api/v1/proposals/:id/reject", Tag: "proposals", Summary: "変更の提案の却下", Request: reviewProposalBody{}, Response: ProposalValue{}, Role: logic.RoleEditor},
+
+	{Method: http.MethodGet, Path: "/api/v1/export", Tag: "transfer", Summary: "カタログ全体の書き出し。format=csvではCSVを返す", Request: exportParams{}, Response: BundleValue{}},
+	{Method: http.MethodPost, Path: "/api/v1/import", Tag: "transfer", Summary: "カタログの一括の取り込み。text/csvのボディも受け付ける", Request: importBody{}, Response: ImportReportValue{}, Role: logic.RoleEditor},
+}
+
+type OpenAPIDocument struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       OpenAPIInfo                            `json:"info"`
+	Paths      map[string]map[string]OpenAPIOperation `json:"paths"`
+	Components OpenAPIComponents                      `json:"components"`
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses"`
+}
+
+type OpenAPIParameter struct {
+	Ref         string         `json:"$ref,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	In          string         `json:"in,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema,omitempty"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Ref         string                      `json:"$ref,omitempty"`
+	Description string                      `json:"description,omitempty"`
+	Headers     map[string]OpenAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIHeader struct {
+	Description string         `json:"description,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIComponents struct {
+	Schemas         map[string]*OpenAPISchema        `json:"schemas"`
+	Parameters      map[string]OpenAPIParameter      `json:"parameters"`
+	Responses       map[string]OpenAPIResponse       `json:"responses"`
+	SecuritySchemes map[string]OpenAPISecurityScheme `json:"securitySchemes"`
+}
+
+type OpenAPISecurityScheme struct {
+	Type        string `json:"type"`
+	Scheme      string `json:"scheme"`
+	Description string `json:"description,omitempty"`
+}
+
+// OpenAPISchema OpenAPI 3.0のスキーマのうち、validateタグで表現できる範囲のみ扱う
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	MinLength            *uint64                   `json:"minLength,omitempty"`
+	MaxLength            *uint64                   `json:"maxLength,omitempty"`
+	Minimum              *float64                  `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool                      `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64                  `json:"maximum,omitempty"`
+	ExclusiveMaximum     bool                      `json:"exclusiveMaximum,omitempty"`
+	MinItems             *uint64                   `json:"minItems,omitempty"`
+	MaxItems             *uint64                   `json:"maxItems,omitempty"`
+	UniqueItems          bool                      `json:"uniqueItems,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+const (
+	problemResponseRef      = "#/components/responses/Problem"
+	acceptLanguageParameter = "AcceptLanguage"
+	ifMatchParameter        = "IfMatch"
+	bearerSecurityScheme    = "BearerAuth"
+)
+
+var echoPathParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// OpenAPIPath echoの":id"の形式のパスをOpenAPIの"{id}"の形式にする
+func OpenAPIPath(path string) string {
+	return echoPathParam.ReplaceAllString(path, "{$1}")
+}
+
+// NewOpenAPIDocument apiOperationsのリクエストとレスポンスの型をリフレクションで辿ってドキュメントを生成する
+func NewOpenAPIDocument() OpenAPIDocument {
+	g := schemaGenerator{schemas: map[string]*OpenAPISchema{}}
+	g.component(reflect.TypeOf(Problem{}))
+
+	doc := OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info:    OpenAPIInfo{Title: "mods-explore ARK Omega API", Version: "v1"},
+		Paths:   map[string]map[string]OpenAPIOperation{},
+		Components: OpenAPIComponents{
+			Schemas: g.schemas,
+			Parameters: map[string]OpenAPIParameter{
+				acceptLanguageParameter: {
+					Name:   HeaderAcceptLanguage,
+					In:     "header",
+					Schema: &OpenAPISchema{Type: "string"},
+				},
+				ifMatchParameter: {
+					Name:        HeaderIfMatch,
+					In:          "header",
+					Description: "取得時のETag。他の更新でバージョンが変わっている場合は412を返す",
+					Required:    true,
+					Schema:      &OpenAPISchema{Type: "string"},
+				},
+			},
+			Responses: map[string]OpenAPIResponse{
+				"Problem": {
+					Description: "RFC 7807の形式のエラー",
+					Content: map[string]OpenAPIMediaType{
+						MIMEApplicationProblemJSON: {Schema: &OpenAPISchema{Ref: "#/components/schemas/Problem"}},
+					},
+				},
+			},
+			SecuritySchemes: map[string]OpenAPISecurityScheme{
+				bearerSecurityScheme: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "管理コマンドで発行したAPIトークン",
+				},
+			},
+		},
+	}
+
+	for _, op := range apiOperations {
+		path := OpenAPIPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]OpenAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = g.operation(op)
+	}
+	return doc
+}
+
+var openAPIJSON = sync.OnceValues(func() ([]byte, error) {
+	return json.Marshal(NewOpenAPIDocument())
+})
+
+// OpenAPI ドキュメントは起動後に変わらないため、初回に生成したものを返し続ける
+func OpenAPI(c echo.Context) error {
+	body, err := openAPIJSON()
+	if err != nil {
+		return err
+	}
+	return c.JSONBlob(http.StatusOK, body)
+}
+
+//go:embed docs/index.html
+var docsFS embed.FS
+
+// Docs 外部のCDNに依存せず、/api/openapi.jsonを読み込んで描画する閲覧画面
+func Docs(c echo.Context) error {
+	page, err := docsFS.ReadFile("docs/index.html")
+	if err != nil {
+		return err
+	}
+	return c.HTMLBlob(http.StatusOK, page)
+}
+
+type schemaGenerator struct {
+	schemas map[string]*OpenAPISchema
+}
+
+func (g schemaGenerator) operation(op apiOperation) OpenAPIOperation {
+	operation := OpenAPIOperation{
+		OperationID: operationID(op),
+		Summary:     op.Summary,
+		Tags:        []string{op.Tag},
+		Responses: map[string]OpenAPIResponse{
+			"default": {Ref: problemResponseRef},
+		},
+	}
+	if strings.HasPrefix(op.Path, "/api/v1/") {
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Ref: "#/components/parameters/" + acceptLanguageParameter,
+		})
+	}
+
+	if op.Role != logic.RoleAnonymous {
+		operation.Description = op.Role.Value() + "以上の権限を持つAPIトークンが必要"
+		operation.Security = []map[string][]string{{bearerSecurityScheme: {}}}
+	}
+
+	if op.IfMatch || op.Versioned && (op.Method == http.MethodPut || op.Method == http.MethodDelete) {
+		operation.Parameters = append(operation.Parameters, OpenAPIParameter{
+			Ref: "#/components/parameters/" + ifMatchParameter,
+		})
+	}
+
+	if op.Request != nil {
+		params, body := g.request(reflect.TypeOf(op.Request))
+		operation.Parameters = append(operation.Parameters, params...)
+		if body != nil && (op.Method == http.MethodPost || op.Method == http.MethodPut) {
+			operation.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  map[string]OpenAPIMediaType{echo.MIMEApplicationJSON: {Schema: body}},
+			}
+		}
+	}
+
+	contentType := op.ContentType
+	if contentType == "" {
+		contentType = echo.MIMEApplicationJSON
+	}
+	ok := OpenAPIResponse{
+		Description: "OK",
+		Content:     map[string]OpenAPIMediaType{contentType: {Schema: g.schema(reflect.TypeOf(op.Response))}},
+	}
+	if _, deleted := op.Response.(emptyValue); op.Versioned && !deleted {
+		ok.Headers = map[string]OpenAPIHeader{
+			HeaderETag: {Description: "更新、削除の際にIf-Matchに指定するバージョン", Schema: &OpenAPISchema{Type: "string"}},
+		}
+	}
+	operation.Responses[strconv.Itoa(http.StatusOK)] = ok
+	return operation
+}
+
+// operationID "GET /api/v1/uniques/:id/stats"を"getUniquesIdStats"のようにする
+func operationID(op apiOperation) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(op.Method))
+	for _, word := range strings.FieldsFunc(strings.TrimPrefix(op.Path, "/api/v1"), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+	}) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// request 埋め込みの構造体も含めて、param、queryタグのフィールドをパラメータに、jsonタグのフィールドをボディにする
+func (g schemaGenerator) request(t reflect.Type) ([]OpenAPIParameter, *OpenAPISchema) {
+	var (
+		params []OpenAPIParameter
+		body   = &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	)
+	for _, f := range structFields(t) {
+		rules := validateRules(f)
+		required := lo.Contains(rules, "required")
+		switch {
+		case f.Tag.Get("param") != "":
+			params = append(params, OpenAPIParameter{
+				Name: f.Tag.Get("param"), In: "path", Required: true, Schema: g.field(f, rules),
+			})
+		case f.Tag.Get("query") != "":
+			params = append(params, OpenAPIParameter{
+				Name: f.Tag.Get("query"), In: "query", Required: required, Schema: g.field(f, rules),
+			})
+		case jsonName(f) != "":
+			body.Properties[jsonName(f)] = g.field(f, rules)
+			if required {
+				body.Required = append(body.Required, jsonName(f))
+			}
+		}
+	}
+	if len(body.Properties) == 0 {
+		return params, nil
+	}
+	return params, body
+}
+
+// component 構造体をcomponentsに登録して参照を返す。レスポンスはomitemptyでない項目を必須とする
+func (g schemaGenerator) component(t reflect.Type) *OpenAPISchema {
+	name := componentName(t)
+	ref := &OpenAPISchema{Ref: "#/components/schemas/" + name}
+	if _, ok := g.schemas[name]; ok {
+		return ref
+	}
+
+	schema := &OpenAPISchema{Type: "object", Properties: map[string]*OpenAPISchema{}}
+	g.schemas[name] = schema
+	for _, f := range structFields(t) {
+		name := jsonName(f)
+		if name == "" {
+			continue
+		}
+		schema.Properties[name] = g.field(f, nil)
+		if !strings.Contains(f.Tag.Get("json"), ",omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return ref
+}
+
+// componentName "PageValue[view.UniqueValue]"のような型引数を持つ型は"UniqueValuePage"とする
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	base, arg, ok := strings.Cut(name, "[")
+	if !ok {
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+	arg = strings.TrimSuffix(arg, "]")
+	arg = arg[strings.LastIndex(arg, ".")+1:]
+	return arg + strings.TrimSuffix(base, "Value")
+}
+
+func (g schemaGenerator) field(f reflect.StructField, rules []string) *OpenAPISchema {
+	var itemRules []string
+	for i, rule := range rules {
+		if rule == "dive" {
+			rules, itemRules = rules[:i], rules[i+1:]
+			break
+		}
+	}
+
+	schema := g.schema(f.Type)
+	if schema.Ref != "" {
+		return schema
+	}
+	applyRules(schema, rules)
+	if schema.Items != nil && schema.Items.Ref == "" {
+		applyRules(schema.Items, itemRules)
+	}
+	return schema
+}
+
+func (g schemaGenerator) schema(t reflect.Type) *OpenAPISchema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	}
+	// json.RawMessageは任意のJSONをそのまま返す
+	if t == reflect.TypeOf(json.RawMessage{}) {
+		return &OpenAPISchema{Type: "object", Nullable: true}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := g.schema(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &OpenAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &OpenAPISchema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &OpenAPISchema{Type: "integer", Minimum: lo.ToPtr(float64(0))}
+	case reflect.Float32:
+		return &OpenAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &OpenAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &OpenAPISchema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &OpenAPISchema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		return g.component(t)
+	default:
+		return &OpenAPISchema{}
+	}
+}
+
+// applyRules go-playground/validatorのタグのうち、スキーマで表現できるものを反映する
+func applyRules(schema *OpenAPISchema, rules []string) {
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "unique":
+			schema.UniqueItems = true
+		case "datetime":
+			schema.Format = "date-time"
+		case "min", "max":
+			n, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				continue
+			}
+			switch {
+			case schema.Type == "string" && name == "min":
+				schema.MinLength = lo.ToPtr(uint64(n))
+			case schema.Type == "string":
+				schema.MaxLength = lo.ToPtr(uint64(n))
+			case schema.Type == "array" && name == "min":
+				schema.MinItems = lo.ToPtr(uint64(n))
+			case schema.Type == "array":
+				schema.MaxItems = lo.ToPtr(uint64(n))
+			case name == "min":
+				schema.Minimum = &n
+			default:
+				schema.Maximum = &n
+			}
+		case "gt", "gte":
+			if n, err := strconv.ParseFloat(param, 64); err == nil {
+				schema.Minimum, schema.ExclusiveMinimum = &n, name == "gt"
+			}
+		case "lt", "lte":
+			if n, err := strconv.ParseFloat(param, 64); err == nil {
+				schema.Maximum, schema.ExclusiveMaximum = &n, name == "lt"
+			}
+		}
+	}
+}
+
+// structFields 埋め込みの構造体のフィールドを展開する。echoのBindと同じく非公開のフィールドは対象外
+func structFields(t reflect.Type) []reflect.StructField {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields := make([]reflect.StructField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, structFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+func validateRules(f reflect.StructField) []string {
+	tag := f.Tag.Get("validate")
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// OpenAPIRoutes ドキュメントに含まれる"METHOD /path"の一覧。ルーティングとの突き合わせに使う
+func OpenAPIRoutes(doc OpenAPIDocument) []string {
+	routes := make([]string, 0, len(apiOperations))
+	for path, item := range doc.Paths {
+		for method := range item {
+			routes = append(routes, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
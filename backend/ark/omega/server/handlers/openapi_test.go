@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func Test_OpenAPI(t *testing.T) {
+	doc := NewOpenAPIDocument()
+
+	t.Run("パスパラメータとリクエストのparamタグが一致しているかのテスト", func(t *testing.T) {
+		for _, op := range apiOperations {
+			var want []string
+			for _, m := range echoPathParam.FindAllStringSubmatch(op.Path, -1) {
+				want = append(want, m[1])
+			}
+			var got []string
+			if op.Request != nil {
+				for _, f := range structFields(reflect.TypeOf(op.Request)) {
+					if name := f.Tag.Get("param"); name != "" {
+						got = append(got, name)
+					}
+				}
+			}
+			sort.Strings(want)
+			sort.Strings(got)
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("%s %sのパスパラメータ%vとリクエストの%vが一致しません", op.Method, op.Path, want, got)
+			}
+		}
+	})
+
+	t.Run("レスポンスのスキーマがjsonタグから生成されているかのテスト", func(t *testing.T) {
+		schema, ok := doc.Components.Schemas["UniqueStatsValue"]
+		if !ok {
+			t.Fatalf("UniqueStatsValueのスキーマがありません")
+		}
+		for _, f := range structFields(reflect.TypeOf(UniqueStatsValue{})) {
+			if _, ok := schema.Properties[jsonName(f)]; !ok {
+				t.Errorf("%sのプロパティがありません", jsonName(f))
+			}
+		}
+		if got := schema.Properties["imprint"]; got == nil || !got.Nullable {
+			t.Errorf("ポインタの項目がnullableになっていません %+v", got)
+		}
+		if _, ok := doc.Components.Schemas["UniqueValuePage"]; !ok {
+			t.Errorf("ページングのスキーマの名前が型引数から生成されていません")
+		}
+	})
+
+	t.Run("validateタグがリクエストのスキーマに反映されているかのテスト", func(t *testing.T) {
+		op := doc.Paths["/api/v1/uniques/new"]["post"]
+		body := op.RequestBody.Content[echo.MIMEApplicationJSON].Schema
+
+		variants := body.Properties["unique_variants"]
+		if variants.MinItems == nil || *variants.MinItems != 1 || !variants.UniqueItems {
+			t.Errorf("unique_variantsの制約が反映されていません %+v", variants)
+		}
+		if items := variants.Items; items.Minimum == nil || *items.Minimum != 0 || !items.ExclusiveMinimum {
+			t.Errorf("diveの後の制約が要素に反映されていません %+v", items)
+		}
+		if name := body.Properties["unique_name"]; name.MaxLength == nil || *name.MaxLength != 100 {
+			t.Errorf("unique_nameの最大長が反映されていません %+v", name)
+		}
+		required := map[string]bool{}
+		for _, r := range body.Required {
+			required[r] = true
+		}
+		if !required["health_multiplier"] || required["stamina_multiplier"] {
+			t.Errorf("必須の項目が正しくありません %v", body.Required)
+		}
+	})
+
+	t.Run("権限の必要な操作にのみAPIトークンが指定されているかのテスト", func(t *testing.T) {
+		if op := doc.Paths["/api/v1/uniques/new"]["post"]; len(op.Security) != 1 {
+			t.Errorf("更新の操作にsecurityがありません %+v", op)
+		}
+		if op := doc.Paths["/api/v1/uniques"]["get"]; op.Security != nil {
+			t.Errorf("参照の操作にsecurityがあります %+v", op)
+		}
+		if _, ok := doc.Components.SecuritySchemes[bearerSecurityScheme]; !ok {
+			t.Errorf("securitySchemesにAPIトークンがありません")
+		}
+	})
+
+	t.Run("バージョンを持つ対象の変更にIf-MatchとETagが指定されているかのテスト", func(t *testing.T) {
+		ifMatch := OpenAPIParameter{Ref: "#/components/parameters/" + ifMatchParameter}
+		for _, method := range []string{"post", "put"} {
+			op := doc.Paths["/api/v1/variants/{id}/descriptions"][method]
+			if !slices.Contains(op.Parameters, ifMatch) {
+				t.Errorf("説明文の%sにIf-Matchがありません %+v", method, op.Parameters)
+			}
+		}
+		if op := doc.Paths["/api/v1/variants/{id}/restore"]["post"]; slices.Contains(op.Parameters, ifMatch) {
+			t.Errorf("ゴミ箱から戻す操作にIf-Matchがあります %+v", op.Parameters)
+		}
+		if op := doc.Paths["/api/v1/variants/{id}/descriptions/{description_id}"]["delete"]; op.Responses["200"].Headers[HeaderETag].Schema == nil {
+			t.Errorf("説明文の削除のレスポンスにETagがありません %+v", op.Responses["200"])
+		}
+		if op := doc.Paths["/api/v1/variants/{id}"]["delete"]; op.Responses["200"].Headers != nil {
+			t.Errorf("バリアントの削除のレスポンスにETagがあります %+v", op.Responses["200"])
+		}
+	})
+
+	t.Run("ドキュメントをJSONで返すかのテスト", func(t *testing.T) {
+		e := echo.New()
+		rec := httptest.NewRecorder()
+		if err := OpenAPI(e.NewContext(httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil), rec)); err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got["openapi"] != openAPIVersion {
+			t.Errorf("OpenAPIのバージョンが正しくありません %v", got["openapi"])
+		}
+	})
+}
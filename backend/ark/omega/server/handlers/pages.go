@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	"mods-explore/ark/omega/logic/i18n"
+	searchModel "mods-explore/ark/omega/logic/search/domain/model"
+	searchSvc "mods-explore/ark/omega/logic/search/domain/service"
+	searchUsecase "mods-explore/ark/omega/logic/search/usecase"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+	"mods-explore/ark/omega/server/view"
+)
+
+// PageHandler ブラウザで閲覧する画面。APIと同じユースケースを用いてサーバー側でHTMLを組み立てる
+type PageHandler interface {
+	Uniques(echo.Context) error
+	Unique(echo.Context) error
+	Variant(echo.Context) error
+	VariantGroup(echo.Context) error
+	Search(echo.Context) error
+	Static(echo.Context) error
+	// Errors 画面のエラーをProblemのJSONではなくエラー画面として返すミドルウェア
+	Errors(echo.HandlerFunc) echo.HandlerFunc
+}
+
+type Pages struct {
+	uniques   creatureUsecase.UniqueUsecase
+	variants  variantUsecase.VariantUsecase
+	groups    variantUsecase.VariantGroupUsecase
+	search    searchUsecase.SearchUsecase
+	templates map[string]*template.Template
+}
+
+func NewPages(injector *do.Injector) (PageHandler, error) {
+	templates, err := newPageTemplates()
+	if err != nil {
+		return nil, err
+	}
+	return &Pages{
+		uniques:   do.MustInvoke[creatureUsecase.UniqueUsecase](injector),
+		variants:  do.MustInvoke[variantUsecase.VariantUsecase](injector),
+		groups:    do.MustInvoke[variantUsecase.VariantGroupUsecase](injector),
+		search:    do.MustInvoke[searchUsecase.SearchUsecase](injector),
+		templates: templates,
+	}, nil
+}
+
+// pageTemplates 各画面のテンプレート。cmd/export-siteで書き出すサイトと共通のテンプレートを用いる
+var pageTemplates = []string{"uniques.html", "unique.html", "variant.html", "group.html", "search.html", "error.html"}
+
+func newPageTemplates() (map[string]*template.Template, error) {
+	return view.Templates(pageTemplates...)
+}
+
+func newPageMeta(c echo.Context) view.Meta {
+	return view.Meta{Locale: i18n.LocaleOf(c.Request().Context()), Links: view.ServerLinks}
+}
+
+// render 途中で失敗した画面を返さないよう、全て描画してから書き込む
+func (p Pages) render(c echo.Context, status int, name string, data any) error {
+	var buf bytes.Buffer
+	if err := p.templates[name].ExecuteTemplate(&buf, "layout", data); err != nil {
+		return err
+	}
+	return c.HTMLBlob(status, buf.Bytes())
+}
+
+type errorPage struct {
+	view.Meta
+	Problem Problem
+}
+
+func (p Pages) Errors(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := next(c)
+		if err == nil || c.Response().Committed {
+			return err
+		}
+
+		problem := newProblem(err, c)
+		if problem.Status >= http.StatusInternalServerError {
+			c.Logger().Error(err)
+		}
+		return p.render(c, problem.Status, "error.html", errorPage{Meta: newPageMeta(c), Problem: problem})
+	}
+}
+
+func cloneQuery(query url.Values) url.Values {
+	cloned := make(url.Values, len(query))
+	for k, v := range query {
+		cloned[k] = append([]string(nil), v...)
+	}
+	return cloned
+}
+
+// withQuery 現在の検索条件の一部を置き換えたURL。条件が変わると続きの位置も変わるためcursorは引き継がない
+func withQuery(path string, query url.Values, key, value string) string {
+	q := cloneQuery(query)
+	q.Del("cursor")
+	if value == "" {
+		q.Del(key)
+	} else {
+		q.Set(key, value)
+	}
+	if len(q) == 0 {
+		return path
+	}
+	return path + "?" + q.Encode()
+}
+
+type uniqueSortOption struct {
+	key   creatureSvc.UniqueSortKey
+	order logic.SortOrder
+	label string
+}
+
+// uniqueSortOptions 画面で選べる並び順。倍率は大きい順に並べる
+var uniqueSortOptions = []uniqueSortOption{
+	{creatureSvc.UniqueSortByName, logic.Asc, "名前順"},
+	{creatureSvc.UniqueSortByHealth, logic.Desc, "体力倍率順"},
+	{creatureSvc.UniqueSortByDamage, logic.Desc, "攻撃倍率順"},
+	{creatureSvc.UniqueSortByUpdatedAt, logic.Desc, "更新順"},
+}
+
+// Uniques バリアントグループで絞り込めるユニークの一覧。画面内の絞り込みのため既定では上限まで取得する
+func (p Pages) Uniques(c echo.Context) error {
+	var params uniqueListParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	if params.Limit == 0 {
+		params.Limit = logic.MaxPageLimit
+	}
+	if params.Sort == "" {
+		params.Sort, params.Order = creatureSvc.UniqueSortByName.Value(), string(logic.Asc)
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortKey(params.Sort), params.filter())
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	uniques, err := p.uniques.List(ctx, query)
+	if err != nil {
+		return err
+	}
+	groups, err := p.allGroups(c)
+	if err != nil {
+		return err
+	}
+
+	values := c.QueryParams()
+	data := view.UniquesPage{
+		Meta: newPageMeta(c),
+		Groups: append(
+			[]view.PageLink{{Label: i18n.Text(i18n.LocaleOf(ctx), "すべて"), URL: withQuery("/", values, "group_id", ""), Active: params.VariantGroupID == nil}},
+			lo.Map(groups, func(g variantModel.VariantGroup, _ int) view.PageLink {
+				return view.PageLink{
+					Label:  g.DisplayName().Value(),
+					URL:    withQuery("/", values, "group_id", strconv.Itoa(int(g.ID()))),
+					Active: params.VariantGroupID != nil && *params.VariantGroupID == int(g.ID()),
+				}
+			})...,
+		),
+		Sorts: lo.Map(uniqueSortOptions, func(s uniqueSortOption, _ int) view.PageLink {
+			sorted := cloneQuery(values)
+			sorted.Set("order", string(s.order))
+			return view.PageLink{
+				Label:  i18n.Text(i18n.LocaleOf(ctx), s.label),
+				URL:    withQuery("/", sorted, "sort", s.key.Value()),
+				Active: params.Sort == s.key.Value(),
+			}
+		}),
+		Filtered: params.VariantID != nil || params.BaseName != nil,
+		Uniques: lo.Map(uniques.Items(), func(u creatureModel.UniqueDinosaur, _ int) view.UniqueRow {
+			return view.UniqueRow{UniqueValue: view.NewUniqueValue(u)}
+		}),
+	}
+	if next := uniques.NextCursor().Value(); next != "" {
+		query := cloneQuery(values)
+		query.Set("cursor", next)
+		data.NextURL = "/?" + query.Encode()
+	}
+	return p.render(c, http.StatusOK, "uniques.html", data)
+}
+
+// allGroups 絞り込みの選択肢として表示するため、名前順に上限まで取得する
+func (p Pages) allGroups(c echo.Context) ([]variantModel.VariantGroup, error) {
+	page, err := logic.NewPageRequest(logic.MaxPageLimit, "", logic.Asc)
+	if err != nil {
+		return nil, err
+	}
+	query, err := variantSvc.NewListVariantGroups(page, variantSvc.VariantGroupSortByName)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := p.groups.List(c.Request().Context(), query)
+	if err != nil {
+		return nil, err
+	}
+	return groups.Items(), nil
+}
+
+func (p Pages) Unique(c echo.Context) error {
+	var params uniqueQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	unique, err := p.uniques.Find(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	return p.render(c, http.StatusOK, "unique.html", view.UniquePage{
+		Meta:   newPageMeta(c),
+		Unique: view.NewUniqueValue(*unique),
+		Stats:  view.NewStatRows(*unique),
+	})
+}
+
+// Variant バリアントと、そのバリアントが付与されたユニーク。件数が多い場合は一覧の画面へ誘導する
+func (p Pages) Variant(c echo.Context) error {
+	var params referenceParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	variant, err := p.variants.Find(ctx, variantModel.VariantID(params.VariantID))
+	if err != nil {
+		return err
+	}
+	page, err := logic.NewPageRequest(logic.DefaultPageLimit, "", logic.Asc)
+	if err != nil {
+		return err
+	}
+	query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortByName, creatureSvc.UniqueFilter{
+		VariantID: lo.ToPtr(variant.ID()),
+	})
+	if err != nil {
+		return err
+	}
+	uniques, err := p.uniques.List(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	data := view.VariantPage{
+		Meta:    newPageMeta(c),
+		Variant: view.NewVariantValue(*variant),
+		Uniques: NewPageValue(*uniques, view.NewUniqueValue).Items,
+	}
+	if uniques.NextCursor() != "" {
+		data.MoreURL = withQuery("/", url.Values{}, "variant_id", strconv.Itoa(params.VariantID))
+	}
+	return p.render(c, http.StatusOK, "variant.html", data)
+}
+
+func (p Pages) VariantGroup(c echo.Context) error {
+	var params variantGroupParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	group, err := p.groups.Find(ctx, variantModel.VariantGroupID(params.ID))
+	if err != nil {
+		return err
+	}
+	page, err := logic.NewPageRequest(logic.MaxPageLimit, "", logic.Asc)
+	if err != nil {
+		return err
+	}
+	query, err := variantSvc.NewListVariants(page, variantSvc.VariantSortByName, lo.ToPtr(group.ID()))
+	if err != nil {
+		return err
+	}
+	variants, err := p.variants.List(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return p.render(c, http.StatusOK, "group.html", view.VariantGroupPage{
+		Meta:     newPageMeta(c),
+		Group:    view.NewVariantGroupValue(*group),
+		Variants: NewPageValue(*variants, view.NewVariantValue).Items,
+	})
+}
+
+// searchPageParams 検索語が空の場合は検索せずに入力欄のみを表示する
+type searchPageParams struct {
+	Query string   `query:"q" validate:"max=100"`
+	Types []string `query:"type"`
+}
+
+type hitPage struct {
+	label string
+	link  func(view.Links, any) string
+}
+
+// hitPages 検索結果の種類毎の表示名と詳細画面
+var hitPages = map[searchModel.HitType]hitPage{
+	searchModel.HitUnique:  {label: "ユニーク", link: view.Links.Unique},
+	searchModel.HitVariant: {label: "バリアント", link: view.Links.Variant},
+	searchModel.HitGroup:   {label: "グループ", link: view.Links.Group},
+}
+
+// highlightSnippet 名前や説明文に含まれるタグは無害化し、一致箇所を示す<mark>のみを残す
+func highlightSnippet(snippet searchModel.Snippet) template.HTML {
+	escaped := html.EscapeString(snippet.Value())
+	escaped = strings.NewReplacer("&lt;mark&gt;", "<mark>", "&lt;/mark&gt;", "</mark>").Replace(escaped)
+	return template.HTML(escaped)
+}
+
+func (p Pages) Search(c echo.Context) error {
+	var params searchPageParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	data := view.SearchPage{Meta: newPageMeta(c)}
+	data.Q = params.Query
+	if strings.TrimSpace(params.Query) == "" {
+		return p.render(c, http.StatusOK, "search.html", data)
+	}
+
+	keyword, err := searchModel.NewKeyword(params.Query)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+	types := make(searchModel.HitTypes, 0, len(params.Types))
+	for _, t := range params.Types {
+		hitType, err := searchModel.NewHitType(t)
+		if err != nil {
+			return logic.WrapInvalidArgument(err)
+		}
+		types = append(types, hitType)
+	}
+
+	hits, err := p.search.Search(c.Request().Context(), searchSvc.NewSearchQuery(keyword, lo.Uniq(types), 0))
+	if err != nil {
+		return err
+	}
+
+	data.Searched = true
+	data.Hits = lo.Map(hits, func(h searchModel.Hit, _ int) view.SearchHit {
+		return view.SearchHit{
+			Type:    h.Type().Value(),
+			Label:   hitPages[h.Type()].label,
+			Name:    h.Name(),
+			URL:     hitPages[h.Type()].link(data.Links, h.ID()),
+			Snippet: highlightSnippet(h.Snippet()),
+		}
+	})
+	return p.render(c, http.StatusOK, "search.html", data)
+}
+
+type staticParams struct {
+	Name string `param:"name" validate:"required"`
+}
+
+// Static 画面のスタイルシートとスクリプト。バイナリに埋め込んだものを返す
+func (p Pages) Static(c echo.Context) error {
+	var params staticParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	static, err := view.Static()
+	if err != nil {
+		return err
+	}
+	return echo.StaticFileHandler(params.Name, static)(c)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	searchModel "mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/server/view"
+)
+
+func newTestPages(t *testing.T) Pages {
+	t.Helper()
+	templates, err := newPageTemplates()
+	if err != nil {
+		t.Fatalf("テンプレートを読み込めませんでした %s", err.Error())
+	}
+	return Pages{templates: templates}
+}
+
+func servePage(t *testing.T, handler echo.HandlerFunc, target string, acceptLanguage string) *httptest.ResponseRecorder {
+	t.Helper()
+	s := echo.New()
+	s.Use(Localizer())
+	s.HTTPErrorHandler = NewErrorHandler(s)
+	s.GET("/*", handler, newTestPages(t).Errors)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.Header.Set(HeaderAcceptLanguage, acceptLanguage)
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_Pages(t *testing.T) {
+	t.Run("一覧画面の描画テスト", func(t *testing.T) {
+		pages := newTestPages(t)
+		rec := servePage(t, func(c echo.Context) error {
+			return pages.render(c, http.StatusOK, "uniques.html", view.UniquesPage{
+				Meta:   newPageMeta(c),
+				Groups: []view.PageLink{{Label: "Alpha", URL: "/?group_id=1", Active: true}},
+				Uniques: []view.UniqueRow{{UniqueValue: view.UniqueValue{
+					UniqueID: 1, UniqueName: "Andromeda", UniqueDisplayName: "<Andromeda>", BaseName: "Rex", BaseDisplayName: "Rex",
+					HealthMultiplier: 2.5, DamageMultiplier: 1,
+					UniqueVariants: []view.UniqueVariantsValue{{
+						VariantID: 3, VariantName: "Gamma-Ray", VariantDisplayName: "Gamma-Ray",
+						VariantGroupName: "Cosmic", VariantGroupDisplayName: "Cosmic",
+					}},
+				}}},
+			})
+		}, "/", "en")
+
+		body := rec.Body.String()
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML) {
+			t.Errorf("HTMLとして返されていません %d %s", rec.Code, rec.Header().Get(echo.HeaderContentType))
+		}
+		for _, want := range []string{
+			`<html lang="en">`, "Unique creatures", `href="/uniques/1"`, "&lt;Andromeda&gt;", `href="/variants/3"`,
+			"×2.5", `class="chip active" href="/?group_id=1"`,
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("%qが含まれていません", want)
+			}
+		}
+	})
+
+	t.Run("詳細画面の描画テスト", func(t *testing.T) {
+		pages := newTestPages(t)
+		for name, data := range map[string]func(view.Meta) any{
+			"unique.html": func(m view.Meta) any {
+				return view.UniquePage{Meta: m, Stats: []view.StatRow{{Label: "体力", Base: 1000, Multiplier: 2, Value: 2000}}}
+			},
+			"variant.html": func(m view.Meta) any { return view.VariantPage{Meta: m} },
+			"group.html":   func(m view.Meta) any { return view.VariantGroupPage{Meta: m} },
+			"search.html":  func(m view.Meta) any { return view.SearchPage{Meta: m, Searched: true} },
+		} {
+			rec := servePage(t, func(c echo.Context) error {
+				return pages.render(c, http.StatusOK, name, data(newPageMeta(c)))
+			}, "/", "ja")
+			if rec.Code != http.StatusOK {
+				t.Errorf("%sを描画できませんでした %d %s", name, rec.Code, rec.Body.String())
+			}
+		}
+	})
+
+	t.Run("エラー画面のテスト", func(t *testing.T) {
+		rec := servePage(t, func(echo.Context) error {
+			return failure.New(logic.NotFound, failure.Message("unique 1 not found"))
+		}, "/uniques/1", "ja")
+		if rec.Code != http.StatusNotFound || !strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML) {
+			t.Errorf("404のエラー画面になっていません %d %s", rec.Code, rec.Header().Get(echo.HeaderContentType))
+		}
+		if !strings.Contains(rec.Body.String(), "unique 1 not found") {
+			t.Error("エラーの詳細が表示されていません")
+		}
+
+		rec = servePage(t, func(echo.Context) error { return errors.New("internal") }, "/", "ja")
+		if rec.Code != http.StatusInternalServerError || strings.Contains(rec.Body.String(), `class="detail"`) {
+			t.Errorf("内部のエラーの詳細を含めずに500を返していません %d", rec.Code)
+		}
+	})
+
+	t.Run("静的ファイルのテスト", func(t *testing.T) {
+		pages := newTestPages(t)
+		s := echo.New()
+		s.Validator = NewValidator()
+		s.GET("/static/:name", pages.Static, pages.Errors)
+		for target, code := range map[string]int{
+			"/static/style.css": http.StatusOK,
+			"/static/app.js":    http.StatusOK,
+			"/static/none.css":  http.StatusNotFound,
+		} {
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+			if rec.Code != code {
+				t.Errorf("%sのステータスコードが%dになっていません %d", target, code, rec.Code)
+			}
+		}
+	})
+}
+
+func Test_highlightSnippet(t *testing.T) {
+	got := highlightSnippet(searchModel.Snippet(`<mark>Alpha</mark> <script>alert(1)</script>`))
+	if want := `<mark>Alpha</mark> &lt;script&gt;alert(1)&lt;/script&gt;`; string(got) != want {
+		t.Errorf("一致箇所以外のタグが無害化されていません %s", got)
+	}
+}
+
+func Test_withQuery(t *testing.T) {
+	query := map[string][]string{"group_id": {"1"}, "cursor": {"abc"}, "sort": {"name"}}
+	if got := withQuery("/", query, "group_id", "2"); got != "/?group_id=2&sort=name" {
+		t.Errorf("条件を置き換えたURLになっていません %s", got)
+	}
+	if got := withQuery("/", map[string][]string{"group_id": {"1"}}, "group_id", ""); got != "/" {
+		t.Errorf("条件を外したURLになっていません %s", got)
+	}
+	if _, ok := query["cursor"]; !ok {
+		t.Error("元の条件が変更されています")
+	}
+}
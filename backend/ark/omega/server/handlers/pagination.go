@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"mods-explore/ark/omega/logic"
+)
+
+// pageQueryParams 一覧取得APIで共通のページング用クエリパラメータ
+type pageQueryParams struct {
+	Limit  uint   `query:"limit"`
+	Cursor string `query:"cursor"`
+	Sort   string `query:"sort"`
+	Order  string `query:"order"`
+}
+
+func (p pageQueryParams) pageRequest() (logic.PageRequest, error) {
+	return logic.NewPageRequest(p.Limit, logic.Cursor(p.Cursor), logic.SortOrder(p.Order))
+}
+
+// PageValue next_cursorが空の場合は最終ページ
+type PageValue[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+func NewPageValue[T, V any](page logic.Page[T], fn func(T) V) PageValue[V] {
+	items := make([]V, 0, len(page.Items()))
+	for _, item := range page.Items() {
+		items = append(items, fn(item))
+	}
+	return PageValue[V]{Items: items, NextCursor: page.NextCursor().Value()}
+}
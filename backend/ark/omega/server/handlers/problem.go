@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem RFC 7807の形式のエラーレスポンス。制約違反や項目毎の検証エラー、削除を拒否した行の参照元は拡張メンバーとして返す
+type Problem struct {
+	Type       string              `json:"type"`
+	Title      string              `json:"title"`
+	Status     int                 `json:"status"`
+	Detail     string              `json:"detail,omitempty"`
+	Instance   string              `json:"instance"`
+	RequestID  string              `json:"request_id,omitempty"`
+	Errors     []ProblemFieldValue `json:"errors,omitempty"`
+	Field      string              `json:"field,omitempty"`
+	Constraint string              `json:"constraint,omitempty"`
+	Dependents []ProblemDependent  `json:"dependents,omitempty"`
+}
+
+type ProblemDependent struct {
+	Entity string `json:"entity"`
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+}
+
+type ProblemFieldValue struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+	Param string `json:"param,omitempty"`
+}
+
+type problemType struct {
+	status int
+	slug   string
+}
+
+// problemTypes failure.Codeとステータスコード、typeに用いる識別子の対応
+var problemTypes = map[failure.Code]problemType{
+	logic.InvalidArgument:      {status: http.StatusBadRequest, slug: "invalid-argument"},
+	logic.NotFound:             {status: http.StatusNotFound, slug: "not-found"},
+	logic.Unauthorized:         {status: http.StatusUnauthorized, slug: "unauthorized"},
+	logic.Forbidden:            {status: http.StatusForbidden, slug: "forbidden"},
+	logic.Conflict:             {status: http.StatusConflict, slug: "conflict"},
+	logic.UnprocessableEntity:  {status: http.StatusUnprocessableEntity, slug: "unprocessable-entity"},
+	logic.PreconditionFailed:   {status: http.StatusPreconditionFailed, slug: "precondition-failed"},
+	logic.PreconditionRequired: {status: http.StatusPreconditionRequired, slug: "precondition-required"},
+	logic.IntervalServerError:  {status: http.StatusInternalServerError, slug: "internal-server-error"},
+}
+
+func problemTypeURI(slug string) string { return "/problems/" + slug }
+
+func newProblem(err error, c echo.Context) Problem {
+	problem := Problem{
+		Type:      "about:blank",
+		Status:    http.StatusInternalServerError,
+		Instance:  c.Request().URL.RequestURI(),
+		RequestID: c.Response().Header().Get(echo.HeaderXRequestID),
+	}
+
+	var he *echo.HTTPError
+	if code, ok := failure.CodeOf(err); ok {
+		if t, ok := problemTypes[code]; ok {
+			problem.Type = problemTypeURI(t.slug)
+			problem.Status = t.status
+		}
+		// 内部のエラーの詳細はレスポンスに含めない
+		if message, ok := failure.MessageOf(err); ok && problem.Status < http.StatusInternalServerError {
+			problem.Detail = message
+		}
+	} else if errors.As(err, &he) {
+		// ルーティングやバインドなどecho自体のエラー
+		problem.Status = he.Code
+		if problem.Status < http.StatusInternalServerError {
+			problem.Detail = fmt.Sprint(he.Message)
+		}
+	}
+	problem.Title = http.StatusText(problem.Status)
+	// ドメインモデルの検証エラーはリクエストの言語に翻訳する
+	if detail, ok := i18n.Localize(i18n.LocaleOf(c.Request().Context()), err); ok && problem.Status < http.StatusInternalServerError {
+		problem.Detail = detail
+	}
+
+	var fields logic.FieldErrors
+	if errors.As(err, &fields) {
+		problem.Errors = lo.Map(fields, func(f logic.FieldError, _ int) ProblemFieldValue {
+			return ProblemFieldValue{Field: f.Field, Rule: f.Rule, Param: f.Param}
+		})
+	}
+	var dependents logic.Dependents
+	if errors.As(err, &dependents) {
+		problem.Dependents = lo.Map(dependents, func(d logic.Dependent, _ int) ProblemDependent {
+			return ProblemDependent{Entity: d.Entity.Value(), ID: d.ID, Name: d.Name}
+		})
+	}
+	var violation *logic.ConstraintViolation
+	if errors.As(err, &violation) {
+		problem.Field = violation.Field
+		problem.Constraint = violation.Constraint
+	}
+	return problem
+}
+
+func writeProblem(c echo.Context, problem Problem) error {
+	if problem.Status == http.StatusUnauthorized {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, bearerScheme)
+	}
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(problem.Status)
+	}
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(problem.Status, MIMEApplicationProblemJSON, body)
+}
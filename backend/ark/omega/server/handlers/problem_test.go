@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+func serveProblem(t *testing.T, err error, acceptLanguage ...string) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+	s := echo.New()
+	s.Use(middleware.RequestID())
+	s.Use(Localizer())
+	s.HTTPErrorHandler = NewErrorHandler(s)
+	s.GET("/api/v1/uniques/:id", func(echo.Context) error { return err })
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/uniques/1?level=1", nil)
+	for _, l := range acceptLanguage {
+		req.Header.Add(HeaderAcceptLanguage, l)
+	}
+	s.ServeHTTP(rec, req)
+
+	var problem Problem
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("problem+jsonとして読み込めませんでした %s", err.Error())
+	}
+	return rec, problem
+}
+
+func Test_ErrorHandler(t *testing.T) {
+	t.Run("検証エラーのレスポンステスト", func(t *testing.T) {
+		err := failure.Translate(
+			logic.FieldErrors{{Field: "unique_name", Rule: "max", Param: "100"}},
+			logic.InvalidArgument,
+			failure.Message("invalid request"),
+		)
+		rec, problem := serveProblem(t, err)
+		if rec.Code != http.StatusBadRequest || problem.Status != http.StatusBadRequest {
+			t.Errorf("ステータスコードが400になっていません %d", rec.Code)
+		}
+		if got := rec.Header().Get(echo.HeaderContentType); got != MIMEApplicationProblemJSON {
+			t.Errorf("Content-Typeがproblem+jsonになっていません %s", got)
+		}
+		if problem.Type != "/problems/invalid-argument" || problem.Detail != "invalid request" {
+			t.Errorf("typeかdetailが不正です %+v", problem)
+		}
+		if problem.Instance != "/api/v1/uniques/1?level=1" {
+			t.Errorf("instanceがリクエストのURIになっていません %s", problem.Instance)
+		}
+		if problem.RequestID == "" || problem.RequestID != rec.Header().Get(echo.HeaderXRequestID) {
+			t.Errorf("リクエストIDが含まれていません %+v", problem)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "unique_name" {
+			t.Errorf("項目毎のエラーが含まれていません %+v", problem.Errors)
+		}
+	})
+
+	t.Run("ドメインモデルのエラーのレスポンステスト", func(t *testing.T) {
+		rec, problem := serveProblem(t, logic.WrapInvalidArgument(errors.New("倍率は0より大きくしてください")))
+		if rec.Code != http.StatusBadRequest || problem.Detail != "倍率は0より大きくしてください" {
+			t.Errorf("ドメインモデルのエラーが400になっていません %d %+v", rec.Code, problem)
+		}
+	})
+
+	t.Run("ドメインモデルのエラーの翻訳テスト", func(t *testing.T) {
+		err := logic.WrapInvalidArgument(errors.Join(
+			i18n.Errorf("体力0は許容されない不正な値です"), i18n.Errorf("バリアント%dが重複しています", 3),
+		))
+		rec, problem := serveProblem(t, err, "en-US,en;q=0.9")
+		if problem.Detail != "health must be greater than 0\nvariant 3 is duplicated" {
+			t.Errorf("detailが英語に翻訳されていません %s", problem.Detail)
+		}
+		if got := rec.Header().Get(HeaderContentLanguage); got != "en" {
+			t.Errorf("Content-Languageが英語になっていません %s", got)
+		}
+
+		_, problem = serveProblem(t, err)
+		if problem.Detail != "体力0は許容されない不正な値です\nバリアント3が重複しています" {
+			t.Errorf("既定の言語のdetailになっていません %s", problem.Detail)
+		}
+	})
+
+	t.Run("制約違反のレスポンステスト", func(t *testing.T) {
+		err := failure.Translate(&logic.ConstraintViolation{
+			Kind: logic.ConstraintUnique, Constraint: "dinosaurs_lower_name_key", Field: "name", Err: errors.New("duplicate"),
+		}, logic.Conflict)
+		rec, problem := serveProblem(t, err)
+		if rec.Code != http.StatusConflict || problem.Field != "name" || problem.Constraint != "dinosaurs_lower_name_key" {
+			t.Errorf("制約違反が409になっていません %d %+v", rec.Code, problem)
+		}
+	})
+
+	t.Run("参照元を含む競合のレスポンステスト", func(t *testing.T) {
+		err := failure.Translate(
+			logic.Dependents{{Entity: logic.AuditUnique, ID: 1, Name: "meteor rex"}},
+			logic.Conflict,
+			failure.Message("variant is referenced by uniques"),
+		)
+		rec, problem := serveProblem(t, err)
+		if rec.Code != http.StatusConflict {
+			t.Errorf("参照元のある競合が409になっていません %d", rec.Code)
+		}
+		if len(problem.Dependents) != 1 || problem.Dependents[0].Entity != "unique" || problem.Dependents[0].ID != 1 {
+			t.Errorf("参照元が含まれていません %+v", problem.Dependents)
+		}
+	})
+
+	t.Run("参照元を含む競合の翻訳テスト", func(t *testing.T) {
+		err := failure.Translate(
+			i18n.Wrapf(logic.Dependents{{Entity: logic.AuditUnique, ID: 1, Name: "meteor rex"}}, "バリアントはユニークから参照されています"),
+			logic.Conflict,
+		)
+		rec, problem := serveProblem(t, err, "en")
+		if rec.Code != http.StatusConflict || problem.Detail != "variant is referenced by uniques" {
+			t.Errorf("detailが英語に翻訳されていません %d %+v", rec.Code, problem)
+		}
+		if len(problem.Dependents) != 1 || problem.Dependents[0].Name != "meteor rex" {
+			t.Errorf("翻訳したエラーから参照元が取り出せていません %+v", problem.Dependents)
+		}
+
+		_, problem = serveProblem(t, err)
+		if problem.Detail != "バリアントはユニークから参照されています" {
+			t.Errorf("既定の言語のdetailになっていません %s", problem.Detail)
+		}
+	})
+
+	t.Run("内部エラーのレスポンステスト", func(t *testing.T) {
+		rec, problem := serveProblem(t, errors.New("connection refused"))
+		if rec.Code != http.StatusInternalServerError || problem.Title != "Internal Server Error" {
+			t.Errorf("内部エラーが500になっていません %d %+v", rec.Code, problem)
+		}
+		if problem.Detail != "" {
+			t.Errorf("内部エラーの詳細が含まれています %s", problem.Detail)
+		}
+	})
+
+	t.Run("echoのエラーのレスポンステスト", func(t *testing.T) {
+		rec, problem := serveProblem(t, echo.NewHTTPError(http.StatusUnsupportedMediaType))
+		if rec.Code != http.StatusUnsupportedMediaType || problem.Type != "about:blank" {
+			t.Errorf("echoのエラーがproblem+jsonになっていません %d %+v", rec.Code, problem)
+		}
+	})
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/labstack/echo/v4"
+	"github.com/morikuni/failure"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	"mods-explore/ark/omega/logic/i18n"
+	proposalModel "mods-explore/ark/omega/logic/proposal/domain/model"
+	proposalSvc "mods-explore/ark/omega/logic/proposal/domain/service"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+)
+
+// NewProposalEditors 提案の項目を作成のリクエストと同じ形式で扱うため、リクエストの型と検証を用いて各対象を更新する
+// 現在の状態は承認時にそのまま適用されるため、名前はリクエストの言語の表示名ではなく保存された名前とする
+func NewProposalEditors(injector *do.Injector) (proposalSvc.Editors, error) {
+	validator := NewValidator()
+	return proposalSvc.Editors{
+		proposalModel.TargetUnique: uniqueEditor{
+			usecase: do.MustInvoke[creatureUsecase.UniqueUsecase](injector), validator: validator,
+		},
+		proposalModel.TargetVariant: variantEditor{
+			usecase: do.MustInvoke[variantUsecase.VariantUsecase](injector), validator: validator,
+		},
+		proposalModel.TargetGroup: variantGroupEditor{
+			usecase: do.MustInvoke[variantUsecase.VariantGroupUsecase](injector), validator: validator,
+		},
+	}, nil
+}
+
+func toFields(body any) (proposalModel.Fields, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return proposalModel.NewFields(raw)
+}
+
+// bindFields リクエストのボディと同様にデコードして検証する
+func bindFields(fields proposalModel.Fields, body any, validator echo.Validator) error {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(raw, body); err != nil {
+		return failure.Translate(i18n.Wrapf(err, "変更の値が不正です"), logic.InvalidArgument)
+	}
+	return validator.Validate(body)
+}
+
+type uniqueEditor struct {
+	usecase   creatureUsecase.UniqueUsecase
+	validator echo.Validator
+}
+
+func (e uniqueEditor) Current(ctx context.Context, id int) (*proposalModel.Snapshot, error) {
+	unique, err := e.usecase.Find(ctx, creatureModel.UniqueDinosaurID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := toFields(uniqueCreateParams{
+		uniqueMultipliersParams: newUniqueMultipliersParams(unique.Multipliers()),
+		BaseID:                  unique.Dinosaur.BaseID(),
+		UniqueName:              unique.UniqueName(),
+		VariantIDs: lo.Map(unique.UniqueVariant(), func(v creatureModel.DinosaurVariant, _ int) variantModel.VariantID {
+			return v.ID()
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := proposalModel.NewSnapshot(fields, unique.Version())
+	return &snapshot, nil
+}
+
+func (e uniqueEditor) bind(fields proposalModel.Fields) (uniqueCreateParams, creatureModel.UniqueMultipliers, error) {
+	var params uniqueCreateParams
+	if err := bindFields(fields, &params, e.validator); err != nil {
+		return params, creatureModel.UniqueMultipliers{}, err
+	}
+	multipliers, err := params.multipliers()
+	return params, multipliers, err
+}
+
+func (e uniqueEditor) Validate(_ context.Context, _ int, fields proposalModel.Fields) error {
+	_, _, err := e.bind(fields)
+	return err
+}
+
+func (e uniqueEditor) Apply(ctx context.Context, id int, fields proposalModel.Fields, version logic.Version) error {
+	params, multipliers, err := e.bind(fields)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.usecase.Update(ctx, creatureSvc.NewUpdateCreature(
+		params.BaseID,
+		creatureModel.UniqueDinosaurID(id),
+		params.UniqueName,
+		multipliers,
+		params.VariantIDs,
+		version,
+	))
+	return err
+}
+
+type variantEditor struct {
+	usecase   variantUsecase.VariantUsecase
+	validator echo.Validator
+}
+
+func (e variantEditor) Current(ctx context.Context, id int) (*proposalModel.Snapshot, error) {
+	variant, err := e.usecase.Find(ctx, variantModel.VariantID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := toFields(createBody{GroupID: int(variant.GroupID()), Name: variant.Name().Value()})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := proposalModel.NewSnapshot(fields, variant.Version())
+	return &snapshot, nil
+}
+
+func (e variantEditor) Validate(_ context.Context, _ int, fields proposalModel.Fields) error {
+	var body createBody
+	return bindFields(fields, &body, e.validator)
+}
+
+func (e variantEditor) Apply(ctx context.Context, id int, fields proposalModel.Fields, version logic.Version) error {
+	var body createBody
+	if err := bindFields(fields, &body, e.validator); err != nil {
+		return err
+	}
+
+	_, err := e.usecase.Update(ctx, variantSvc.NewUpdateVariant(
+		variantModel.VariantID(id), variantModel.VariantGroupID(body.GroupID), variantModel.Name(body.Name), version,
+	))
+	return err
+}
+
+type variantGroupEditor struct {
+	usecase   variantUsecase.VariantGroupUsecase
+	validator echo.Validator
+}
+
+func (e variantGroupEditor) Current(ctx context.Context, id int) (*proposalModel.Snapshot, error) {
+	group, err := e.usecase.Find(ctx, variantModel.VariantGroupID(id))
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := toFields(createVariantGroup{Name: group.Name().Value()})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := proposalModel.NewSnapshot(fields, group.Version())
+	return &snapshot, nil
+}
+
+func (e variantGroupEditor) Validate(_ context.Context, _ int, fields proposalModel.Fields) error {
+	var body createVariantGroup
+	return bindFields(fields, &body, e.validator)
+}
+
+func (e variantGroupEditor) Apply(ctx context.Context, id int, fields proposalModel.Fields, version logic.Version) error {
+	var body createVariantGroup
+	if err := bindFields(fields, &body, e.validator); err != nil {
+		return err
+	}
+
+	_, err := e.usecase.Update(ctx, variantSvc.NewUpdateVariantGroup(
+		variantModel.VariantGroupID(id), variantModel.VariantGroupName(body.Name), version,
+	))
+	return err
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"mods-explore/ark/omega/logic"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+)
+
+// stubVariantGroupUsecase 読み込んだグループを返し、更新の内容を記録する
+type stubVariantGroupUsecase struct {
+	variantUsecase.VariantGroupUsecase
+
+	group   variantModel.VariantGroup
+	updated []variantSvc.UpdateVariantGroup
+}
+
+func (s *stubVariantGroupUsecase) Find(context.Context, variantModel.VariantGroupID) (*variantModel.VariantGroup, error) {
+	return &s.group, nil
+}
+
+func (s *stubVariantGroupUsecase) Update(
+	_ context.Context, update variantSvc.UpdateVariantGroup,
+) (*variantModel.VariantGroup, error) {
+	s.updated = append(s.updated, update)
+	return &s.group, nil
+}
+
+func Test_ProposalEditors(t *testing.T) {
+	t.Run("表示名ではなく保存された名前で現在の状態を取得し、そのまま適用できるかのテスト", func(t *testing.T) {
+		usecase := &stubVariantGroupUsecase{
+			group: variantModel.NewVariantGroup(1, "Cosmic").WithDisplayName("宇宙").WithVersion(3),
+		}
+		editor := variantGroupEditor{usecase: usecase, validator: NewValidator()}
+
+		current, err := editor.Current(context.Background(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := toFields(createVariantGroup{Name: "Cosmic"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(want, current.Fields()) {
+			t.Errorf("保存された名前になっていません\nwant: %s\ngot:  %s", want, current.Fields())
+		}
+
+		if err = editor.Apply(context.Background(), 1, current.Fields(), current.Version()); err != nil {
+			t.Fatal(err)
+		}
+		if len(usecase.updated) != 1 || usecase.updated[0].Name() != "Cosmic" || usecase.updated[0].Version() != logic.Version(3) {
+			t.Errorf("保存された名前のまま更新されていません %+v", usecase.updated)
+		}
+	})
+}
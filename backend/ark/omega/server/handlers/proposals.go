@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+	"mods-explore/ark/omega/logic/proposal/usecase"
+)
+
+type ProposalHandler interface {
+	Read(echo.Context) error
+	List(echo.Context) error
+	Submit(echo.Context) error
+	Approve(echo.Context) error
+	Reject(echo.Context) error
+}
+
+type Proposal struct {
+	usecase.ProposalUsecase
+}
+
+func NewProposal(injector *do.Injector) (ProposalHandler, error) {
+	return &Proposal{
+		ProposalUsecase: do.MustInvoke[usecase.ProposalUsecase](injector),
+	}, nil
+}
+
+type FieldChangeValue struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// ProposalValue 審査待ちの提案ではreviewerとreviewed_atがnullになる
+type ProposalValue struct {
+	ID            int                `json:"id"`
+	Target        string             `json:"target"`
+	TargetID      int                `json:"target_id"`
+	Status        string             `json:"status"`
+	BaseVersion   int                `json:"base_version"`
+	Changes       []FieldChangeValue `json:"changes"`
+	Comment       string             `json:"comment"`
+	Proposer      string             `json:"proposer"`
+	CreatedAt     time.Time          `json:"created_at"`
+	Reviewer      *string            `json:"reviewer"`
+	ReviewComment string             `json:"review_comment"`
+	ReviewedAt    *time.Time         `json:"reviewed_at"`
+}
+
+func NewProposalValue(proposal model.Proposal) ProposalValue {
+	changes := make([]FieldChangeValue, 0, len(proposal.Changes()))
+	for _, change := range proposal.Diff() {
+		changes = append(changes, FieldChangeValue{Field: change.Field, Before: change.Before, After: change.After})
+	}
+
+	value := ProposalValue{
+		ID:          proposal.ID().Value(),
+		Target:      proposal.Target().Value(),
+		TargetID:    proposal.TargetID(),
+		Status:      proposal.Status().Value(),
+		BaseVersion: int(proposal.BaseVersion()),
+		Changes:     changes,
+		Comment:     proposal.Comment().Value(),
+		Proposer:    proposal.Proposer().Value(),
+		CreatedAt:   proposal.CreatedAt(),
+	}
+	if review := proposal.Review(); review != nil {
+		reviewer := review.Reviewer().Value()
+		reviewedAt := review.ReviewedAt()
+		value.Reviewer = &reviewer
+		value.ReviewComment = review.Comment().Value()
+		value.ReviewedAt = &reviewedAt
+	}
+	return value
+}
+
+type proposalParams struct {
+	ID int `param:"id" validate:"required"`
+}
+
+func (p Proposal) Read(c echo.Context) error {
+	var params proposalParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	proposal, err := p.ProposalUsecase.Find(c.Request().Context(), model.ProposalID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewProposalValue(*proposal)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// proposalListParams 審査キューとして提出順に並べるため、ソートキーは受け付けない
+type proposalListParams struct {
+	auditPageParams
+
+	Status string `query:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Target string `query:"target" validate:"omitempty,oneof=unique variant group"`
+}
+
+func (p Proposal) List(c echo.Context) error {
+	var params proposalListParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+
+	proposals, err := p.ProposalUsecase.List(
+		c.Request().Context(),
+		service.NewListProposals(page, model.Status(params.Status), model.Target(params.Target)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewPageValue(*proposals, NewProposalValue)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// submitProposalBody changesは対象の作成時のリクエストと同じ項目名で、変更する項目のみを指定する
+type submitProposalBody struct {
+	Target   string          `json:"target" validate:"required,oneof=unique variant group"`
+	TargetID int             `json:"target_id" validate:"required,min=1"`
+	Changes  json.RawMessage `json:"changes" validate:"required"`
+	Comment  string          `json:"comment" validate:"max=500"`
+}
+
+func (p Proposal) Submit(c echo.Context) error {
+	var body submitProposalBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	changes, err := model.NewFields(body.Changes)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+	comment, err := model.NewComment(body.Comment)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+
+	proposal, err := p.ProposalUsecase.Submit(
+		c.Request().Context(),
+		service.NewSubmitProposal(model.Target(body.Target), body.TargetID, changes, comment),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewProposalValue(*proposal)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type reviewProposalBody struct {
+	ID      int    `param:"id" validate:"required"`
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+func (p Proposal) review(
+	c echo.Context,
+	review func(context.Context, model.ProposalID, model.Comment) (*model.Proposal, error),
+) error {
+	var body reviewProposalBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	comment, err := model.NewComment(body.Comment)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+
+	proposal, err := review(c.Request().Context(), model.ProposalID(body.ID), comment)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewProposalValue(*proposal)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Approve 提案の変更を対象に適用し、提案を承認済みにする
+func (p Proposal) Approve(c echo.Context) error {
+	return p.review(c, p.ProposalUsecase.Approve)
+}
+
+func (p Proposal) Reject(c echo.Context) error {
+	return p.review(c, p.ProposalUsecase.Reject)
+}
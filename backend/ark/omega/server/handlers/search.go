@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/logic/search/domain/service"
+	"mods-explore/ark/omega/logic/search/usecase"
+)
+
+type SearchHandler interface {
+	Search(echo.Context) error
+}
+
+type Search struct {
+	usecase.SearchUsecase
+}
+
+func NewSearch(injector *do.Injector) (SearchHandler, error) {
+	return &Search{
+		SearchUsecase: do.MustInvoke[usecase.SearchUsecase](injector),
+	}, nil
+}
+
+type searchParams struct {
+	Query string   `query:"q" validate:"required,max=100"`
+	Types []string `query:"type"`
+	Limit uint     `query:"limit"`
+}
+
+type SearchHitValue struct {
+	Type    string  `json:"type"`
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Snippet string  `json:"snippet"`
+	Rank    float32 `json:"rank"`
+}
+
+func NewSearchHitValue(hit model.Hit) SearchHitValue {
+	return SearchHitValue{
+		Type:    hit.Type().Value(),
+		ID:      hit.ID(),
+		Name:    hit.Name(),
+		Snippet: hit.Snippet().Value(),
+		Rank:    hit.Rank().Value(),
+	}
+}
+
+type SearchValue struct {
+	Query string           `json:"q"`
+	Hits  []SearchHitValue `json:"hits"`
+}
+
+func (s Search) Search(c echo.Context) error {
+	var params searchParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	keyword, err := model.NewKeyword(params.Query)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+	types := make(model.HitTypes, 0, len(params.Types))
+	for _, t := range params.Types {
+		hitType, err := model.NewHitType(t)
+		if err != nil {
+			return logic.WrapInvalidArgument(err)
+		}
+		types = append(types, hitType)
+	}
+
+	hits, err := s.SearchUsecase.Search(
+		c.Request().Context(),
+		service.NewSearchQuery(keyword, lo.Uniq(types), params.Limit),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, SearchValue{
+		Query: keyword.Value(),
+		Hits: lo.Map(hits, func(h model.Hit, _ int) SearchHitValue {
+			return NewSearchHitValue(h)
+		}),
+	}); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+	"mods-explore/ark/omega/logic/transfer/usecase"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+type TransferHandler interface {
+	Export(echo.Context) error
+	Import(echo.Context) error
+}
+
+type Transfer struct {
+	usecase.TransferUsecase
+}
+
+func NewTransfer(injector *do.Injector) (TransferHandler, error) {
+	return &Transfer{
+		TransferUsecase: do.MustInvoke[usecase.TransferUsecase](injector),
+	}, nil
+}
+
+// transferFormatCSV 書き出しの形式。指定しない場合はJSONとする
+const transferFormatCSV = "csv"
+
+type TransferGroupValue struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+// TransferVariantValue 説明文は表示順に並べる
+type TransferVariantValue struct {
+	Group        string   `json:"group" validate:"required,max=100"`
+	Name         string   `json:"name" validate:"required,max=100"`
+	Descriptions []string `json:"descriptions" validate:"dive,required,max=500"`
+}
+
+// TransferDinosaurValue stat_growthsを省略した場合、取り込みでは登録済みの成長値を変えない
+type TransferDinosaurValue struct {
+	dinosaurStatsParams
+
+	Name        string             `json:"name" validate:"required,max=100"`
+	StatGrowths []statGrowthParams `json:"stat_growths,omitempty" validate:"omitempty,dive"`
+}
+
+type TransferVariantRefValue struct {
+	Group string `json:"group" validate:"required,max=100"`
+	Name  string `json:"name" validate:"required,max=100"`
+}
+
+// TransferUniqueValue 基となる種とバリアントはIDではなく名前で参照する
+type TransferUniqueValue struct {
+	uniqueMultipliersParams
+
+	Name     string                    `json:"name" validate:"required,max=100"`
+	Dinosaur string                    `json:"dinosaur" validate:"required,max=100"`
+	Variants []TransferVariantRefValue `json:"variants" validate:"required,min=1,dive"`
+}
+
+// TransferRuleValue 規則の種類によってgroupか、variantとother_variantのいずれかを指定する
+type TransferRuleValue struct {
+	Kind         string                   `json:"kind" validate:"required,oneof=group_exclusive incompatible requires"`
+	Group        *string                  `json:"group,omitempty" validate:"omitempty,max=100"`
+	Variant      *TransferVariantRefValue `json:"variant,omitempty"`
+	OtherVariant *TransferVariantRefValue `json:"other_variant,omitempty"`
+}
+
+// TransferDisplayNameValue nameは対象の保存された名前で、バリアントは「グループ名/バリアント名」の形式で指定する
+type TransferDisplayNameValue struct {
+	Target      string `json:"target" validate:"required,oneof=dinosaur unique variant group"`
+	Name        string `json:"name" validate:"required,max=201"`
+	Locale      string `json:"locale" validate:"required"`
+	DisplayName string `json:"display_name" validate:"required,max=100"`
+}
+
+// BundleValue 書き出したものをそのまま取り込める形式のカタログ全体
+type BundleValue struct {
+	Groups       []TransferGroupValue       `json:"groups" validate:"dive"`
+	Variants     []TransferVariantValue     `json:"variants" validate:"dive"`
+	Dinosaurs    []TransferDinosaurValue    `json:"dinosaurs" validate:"dive"`
+	Uniques      []TransferUniqueValue      `json:"uniques" validate:"dive"`
+	VariantRules []TransferRuleValue        `json:"variant_rules" validate:"dive"`
+	DisplayNames []TransferDisplayNameValue `json:"display_names" validate:"dive"`
+}
+
+func newTransferVariantRefValue(ref model.VariantRef) TransferVariantRefValue {
+	return TransferVariantRefValue{Group: ref.Group.Value(), Name: ref.Name.Value()}
+}
+
+func (v TransferVariantRefValue) ref() model.VariantRef {
+	return model.VariantRef{Group: variantModel.VariantGroupName(v.Group), Name: variantModel.Name(v.Name)}
+}
+
+// newTransferStatGrowths 成長値が登録されていない場合は省略する
+func newTransferStatGrowths(growth creatureModel.SpeciesStatGrowth) []statGrowthParams {
+	if len(growth) == 0 {
+		return nil
+	}
+	return lo.Map(newStatGrowthValues(growth), func(v StatGrowthValue, _ int) statGrowthParams {
+		return statGrowthParams(v)
+	})
+}
+
+func newTransferRuleValue(r model.Rule) TransferRuleValue {
+	value := TransferRuleValue{Kind: r.Kind.Value()}
+	if r.Kind == variantModel.RuleGroupExclusive {
+		value.Group = lo.ToPtr(r.Group.Value())
+		return value
+	}
+	value.Variant = lo.ToPtr(newTransferVariantRefValue(r.Variant))
+	value.OtherVariant = lo.ToPtr(newTransferVariantRefValue(r.Other))
+	return value
+}
+
+func (v TransferRuleValue) rule() (model.Rule, error) {
+	kind, err := variantModel.NewVariantRuleKind(v.Kind)
+	if err != nil {
+		return model.Rule{}, logic.WrapInvalidArgument(err)
+	}
+	var group *variantModel.VariantGroupName
+	if v.Group != nil {
+		group = lo.ToPtr(variantModel.VariantGroupName(*v.Group))
+	}
+	var variant, other *model.VariantRef
+	if v.Variant != nil {
+		variant = lo.ToPtr(v.Variant.ref())
+	}
+	if v.OtherVariant != nil {
+		other = lo.ToPtr(v.OtherVariant.ref())
+	}
+	rule, err := model.NewRule(kind, group, variant, other)
+	if err != nil {
+		return model.Rule{}, logic.WrapInvalidArgument(err)
+	}
+	return rule, nil
+}
+
+func (v TransferDisplayNameValue) displayName() (model.DisplayName, error) {
+	target, err := translationModel.NewTarget(v.Target)
+	if err != nil {
+		return model.DisplayName{}, logic.WrapInvalidArgument(err)
+	}
+	locale, err := i18n.NewLocale(v.Locale)
+	if err != nil {
+		return model.DisplayName{}, logic.WrapInvalidArgument(err)
+	}
+	name, err := translationModel.NewName(v.DisplayName)
+	if err != nil {
+		return model.DisplayName{}, logic.WrapInvalidArgument(err)
+	}
+	displayName, err := model.NewDisplayName(target, v.Name, locale, name)
+	if err != nil {
+		return model.DisplayName{}, logic.WrapInvalidArgument(err)
+	}
+	return displayName, nil
+}
+
+func NewBundleValue(bundle model.Bundle) BundleValue {
+	return BundleValue{
+		Groups: lo.Map(bundle.Groups, func(g model.Group, _ int) TransferGroupValue {
+			return TransferGroupValue{Name: g.Name.Value()}
+		}),
+		Variants: lo.Map(bundle.Variants, func(v model.Variant, _ int) TransferVariantValue {
+			return TransferVariantValue{
+				Group: v.Group.Value(),
+				Name:  v.Name.Value(),
+				Descriptions: lo.Map(v.Descriptions, func(d variantModel.DescriptionText, _ int) string {
+					return d.Value()
+				}),
+			}
+		}),
+		Dinosaurs: lo.Map(bundle.Dinosaurs, func(d model.Dinosaur, _ int) TransferDinosaurValue {
+			return TransferDinosaurValue{
+				dinosaurStatsParams: newDinosaurStatsParams(d.Stats),
+				Name:                d.Name.Value(),
+				StatGrowths:         newTransferStatGrowths(d.StatGrowth),
+			}
+		}),
+		Uniques: lo.Map(bundle.Uniques, func(u model.Unique, _ int) TransferUniqueValue {
+			return TransferUniqueValue{
+				uniqueMultipliersParams: newUniqueMultipliersParams(u.Multipliers),
+				Name:                    u.Name.Value(),
+				Dinosaur:                u.Dinosaur.Value(),
+				Variants: lo.Map(u.Variants, func(ref model.VariantRef, _ int) TransferVariantRefValue {
+					return newTransferVariantRefValue(ref)
+				}),
+			}
+		}),
+		VariantRules: lo.Map(bundle.Rules, func(r model.Rule, _ int) TransferRuleValue { return newTransferRuleValue(r) }),
+		DisplayNames: lo.Map(bundle.DisplayNames, func(d model.DisplayName, _ int) TransferDisplayNameValue {
+			return TransferDisplayNameValue{
+				Target: d.Target.Value(), Name: d.Name, Locale: d.Locale.Value(), DisplayName: d.Value.Value(),
+			}
+		}),
+	}
+}
+
+func (b BundleValue) bundle() (model.Bundle, error) {
+	bundle := model.Bundle{
+		Groups: lo.Map(b.Groups, func(g TransferGroupValue, _ int) model.Group {
+			return model.Group{Name: variantModel.VariantGroupName(g.Name)}
+		}),
+	}
+	for _, v := range b.Variants {
+		texts := make([]variantModel.DescriptionText, 0, len(v.Descriptions))
+		for _, d := range v.Descriptions {
+			text, err := variantModel.NewDescriptionText(d)
+			if err != nil {
+				return model.Bundle{}, logic.WrapInvalidArgument(err)
+			}
+			texts = append(texts, text)
+		}
+		bundle.Variants = append(bundle.Variants, model.Variant{
+			Group: variantModel.VariantGroupName(v.Group), Name: variantModel.Name(v.Name), Descriptions: texts,
+		})
+	}
+	for _, d := range b.Dinosaurs {
+		name, err := creatureModel.NewDinosaurName(d.Name)
+		if err != nil {
+			return model.Bundle{}, logic.WrapInvalidArgument(err)
+		}
+		stats, err := d.stats()
+		if err != nil {
+			return model.Bundle{}, err
+		}
+		growth, err := newSpeciesStatGrowth(d.StatGrowths)
+		if err != nil {
+			return model.Bundle{}, err
+		}
+		bundle.Dinosaurs = append(bundle.Dinosaurs, model.Dinosaur{Name: name, Stats: stats, StatGrowth: growth})
+	}
+	for _, u := range b.Uniques {
+		dinosaur, err := creatureModel.NewDinosaurName(u.Dinosaur)
+		if err != nil {
+			return model.Bundle{}, logic.WrapInvalidArgument(err)
+		}
+		multipliers, err := u.multipliers()
+		if err != nil {
+			return model.Bundle{}, err
+		}
+		bundle.Uniques = append(bundle.Uniques, model.Unique{
+			Name:        creatureModel.UniqueName(u.Name),
+			Dinosaur:    dinosaur,
+			Multipliers: multipliers,
+			Variants:    lo.Map(u.Variants, func(ref TransferVariantRefValue, _ int) model.VariantRef { return ref.ref() }),
+		})
+	}
+	for _, r := range b.VariantRules {
+		rule, err := r.rule()
+		if err != nil {
+			return model.Bundle{}, err
+		}
+		bundle.Rules = append(bundle.Rules, rule)
+	}
+	for _, d := range b.DisplayNames {
+		displayName, err := d.displayName()
+		if err != nil {
+			return model.Bundle{}, err
+		}
+		bundle.DisplayNames = append(bundle.DisplayNames, displayName)
+	}
+	return bundle, nil
+}
+
+type ImportChangeValue struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+	Param  string `json:"param,omitempty"`
+}
+
+// ImportReportValue dry-runでは適用せずに、適用した場合の結果を返す
+type ImportReportValue struct {
+	DryRun    bool                `json:"dry_run"`
+	Created   int                 `json:"created"`
+	Updated   int                 `json:"updated"`
+	Unchanged int                 `json:"unchanged"`
+	Conflicts int                 `json:"conflicts"`
+	Changes   []ImportChangeValue `json:"changes"`
+}
+
+func NewImportReportValue(report model.Report) ImportReportValue {
+	return ImportReportValue{
+		DryRun:    report.DryRun,
+		Created:   report.Count(model.ActionCreate),
+		Updated:   report.Count(model.ActionUpdate),
+		Unchanged: report.Count(model.ActionUnchanged),
+		Conflicts: report.Count(model.ActionConflict),
+		Changes: lo.Map(report.Changes, func(c model.Change, _ int) ImportChangeValue {
+			return ImportChangeValue{
+				Type: c.Kind.Value(), Name: c.Name, Action: c.Action.Value(), Reason: c.Reason.Value(), Param: c.Param,
+			}
+		}),
+	}
+}
+
+type exportParams struct {
+	Format string `query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+func (t Transfer) Export(c echo.Context) error {
+	var params exportParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	bundle, err := t.TransferUsecase.Export(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	value := NewBundleValue(*bundle)
+
+	if params.Format == transferFormatCSV {
+		body, err := value.csv()
+		if err != nil {
+			return err
+		}
+		c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="catalog.csv"`)
+		return c.Blob(http.StatusOK, MIMETextCSVCharsetUTF8, body)
+	}
+	if err = c.JSON(http.StatusOK, value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// importBody ボディはContent-Typeがtext/csvの場合はCSVとして、それ以外はJSONとして読む
+type importBody struct {
+	BundleValue
+
+	DryRun bool `query:"dry_run"`
+}
+
+func (t Transfer) Import(c echo.Context) error {
+	var body importBody
+	// echoのBindはPOSTのクエリパラメータをバインドしないため、個別にバインドする
+	binder := &echo.DefaultBinder{}
+	if err := binder.BindQueryParams(c, &body); err != nil {
+		return err
+	}
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), MIMETextCSV) {
+		bundle, err := readBundleCSV(c.Request().Body)
+		if err != nil {
+			return err
+		}
+		body.BundleValue = *bundle
+	} else if err := binder.BindBody(c, &body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	bundle, err := body.bundle()
+	if err != nil {
+		return err
+	}
+
+	report, err := t.TransferUsecase.Import(c.Request().Context(), bundle, body.DryRun)
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewImportReportValue(*report)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	transferSvc "mods-explore/ark/omega/logic/transfer/domain/service"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	translationUsecase "mods-explore/ark/omega/logic/translation/usecase"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+)
+
+// NewTransferCatalog 一括の取り込みでも変更履歴や検証が個別の操作と同じになるよう、対象毎のユースケースを用いる
+func NewTransferCatalog(injector *do.Injector) (transferSvc.Catalog, error) {
+	return transferCatalog{
+		uniques:      do.MustInvoke[creatureUsecase.UniqueUsecase](injector),
+		dinosaurs:    do.MustInvoke[creatureUsecase.DinosaurUsecase](injector),
+		variants:     do.MustInvoke[variantUsecase.VariantUsecase](injector),
+		groups:       do.MustInvoke[variantUsecase.VariantGroupUsecase](injector),
+		rules:        do.MustInvoke[variantUsecase.VariantRuleUsecase](injector),
+		displayNames: do.MustInvoke[translationUsecase.DisplayNameUsecase](injector),
+	}, nil
+}
+
+type transferCatalog struct {
+	uniques      creatureUsecase.UniqueUsecase
+	dinosaurs    creatureUsecase.DinosaurUsecase
+	variants     variantUsecase.VariantUsecase
+	groups       variantUsecase.VariantGroupUsecase
+	rules        variantUsecase.VariantRuleUsecase
+	displayNames translationUsecase.DisplayNameUsecase
+}
+
+// displayNameTargets DisplayNamesで返す表示名の対象の順序
+var displayNameTargets = []translationModel.Target{
+	translationModel.TargetGroup,
+	translationModel.TargetVariant,
+	translationModel.TargetDinosaur,
+	translationModel.TargetUnique,
+}
+
+func (t transferCatalog) Groups(ctx context.Context) ([]variantModel.VariantGroup, error) {
+	return logic.ListAll(func(page logic.PageRequest) (*logic.Page[variantModel.VariantGroup], error) {
+		query, err := variantSvc.NewListVariantGroups(page, variantSvc.VariantGroupSortByID)
+		if err != nil {
+			return nil, err
+		}
+		return t.groups.List(ctx, query)
+	})
+}
+
+func (t transferCatalog) Variants(ctx context.Context) ([]variantModel.Variant, error) {
+	variants, err := logic.ListAll(func(page logic.PageRequest) (*logic.Page[variantModel.Variant], error) {
+		query, err := variantSvc.NewListVariants(page, variantSvc.VariantSortByID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return t.variants.List(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 一覧では説明文を読み込まないため、全てのバリアントの説明文をまとめて読み込む
+	descriptions, err := t.variants.ListDescriptions(
+		ctx, lo.Map(variants, func(v variantModel.Variant, _ int) variantModel.VariantID { return v.ID() }),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(variants, func(v variantModel.Variant, _ int) variantModel.Variant {
+		return v.WithDescriptions(descriptions[v.ID()])
+	}), nil
+}
+
+func (t transferCatalog) Dinosaurs(ctx context.Context) ([]creatureModel.Dinosaur, error) {
+	dinosaurs, err := logic.ListAll(func(page logic.PageRequest) (*logic.Page[creatureModel.Dinosaur], error) {
+		query, err := creatureSvc.NewListDinosaurs(page, creatureSvc.DinosaurSortByID)
+		if err != nil {
+			return nil, err
+		}
+		return t.dinosaurs.List(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 一覧では成長値を読み込まないため、全ての種の成長値をまとめて読み込む
+	growths, err := t.dinosaurs.ListStatGrowths(
+		ctx, lo.Map(dinosaurs, func(d creatureModel.Dinosaur, _ int) creatureModel.DinosaurID { return d.BaseID() }),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(dinosaurs, func(d creatureModel.Dinosaur, _ int) creatureModel.Dinosaur {
+		return d.WithStatGrowth(growths[d.BaseID()])
+	}), nil
+}
+
+func (t transferCatalog) Uniques(ctx context.Context) ([]creatureModel.UniqueDinosaur, error) {
+	return logic.ListAll(func(page logic.PageRequest) (*logic.Page[creatureModel.UniqueDinosaur], error) {
+		query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortByID, creatureSvc.UniqueFilter{})
+		if err != nil {
+			return nil, err
+		}
+		return t.uniques.List(ctx, query)
+	})
+}
+
+func (t transferCatalog) Rules(ctx context.Context) ([]variantModel.VariantRule, error) {
+	return logic.ListAll(func(page logic.PageRequest) (*logic.Page[variantModel.VariantRule], error) {
+		query, err := variantSvc.NewListVariantRules(page, variantSvc.VariantRuleSortByID)
+		if err != nil {
+			return nil, err
+		}
+		return t.rules.List(ctx, query)
+	})
+}
+
+func (t transferCatalog) DisplayNames(ctx context.Context) ([]translationModel.DisplayName, error) {
+	var names []translationModel.DisplayName
+	for _, target := range displayNameTargets {
+		targetNames, err := t.displayNames.ListAll(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, targetNames...)
+	}
+	return names, nil
+}
+
+func (t transferCatalog) CreateGroup(
+	ctx context.Context, cmd variantSvc.CreateVariantGroup,
+) (variantModel.VariantGroupID, error) {
+	group, err := t.groups.Create(ctx, cmd)
+	if err != nil {
+		return 0, err
+	}
+	return group.ID(), nil
+}
+
+func (t transferCatalog) CreateVariant(ctx context.Context, cmd variantSvc.CreateVariant) (*variantModel.Variant, error) {
+	return t.variants.Create(ctx, cmd)
+}
+
+func (t transferCatalog) ReplaceDescriptions(ctx context.Context, cmd variantSvc.ReplaceDescriptions) error {
+	_, err := t.variants.ReplaceDescriptions(ctx, cmd)
+	return err
+}
+
+func (t transferCatalog) CreateDinosaur(
+	ctx context.Context, cmd creatureSvc.CreateDinosaur,
+) (creatureModel.DinosaurID, error) {
+	dino, err := t.dinosaurs.Create(ctx, cmd)
+	if err != nil {
+		return 0, err
+	}
+	return dino.BaseID(), nil
+}
+
+func (t transferCatalog) UpdateDinosaur(ctx context.Context, cmd creatureSvc.UpdateDinosaur) error {
+	_, err := t.dinosaurs.Update(ctx, cmd)
+	return err
+}
+
+func (t transferCatalog) CreateUnique(
+	ctx context.Context, cmd creatureSvc.CreateCreature,
+) (creatureModel.UniqueDinosaurID, error) {
+	unique, err := t.uniques.Create(ctx, cmd)
+	if err != nil {
+		return 0, err
+	}
+	return unique.UniqueID(), nil
+}
+
+func (t transferCatalog) UpdateUnique(ctx context.Context, cmd creatureSvc.UpdateCreature) error {
+	_, err := t.uniques.Update(ctx, cmd)
+	return err
+}
+
+func (t transferCatalog) CreateRule(ctx context.Context, cmd variantSvc.CreateVariantRule) error {
+	_, err := t.rules.Create(ctx, cmd)
+	return err
+}
+
+func (t transferCatalog) PutDisplayName(ctx context.Context, name translationModel.DisplayName) error {
+	_, err := t.displayNames.Put(ctx, name)
+	return err
+}
@@ -0,0 +1,341 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+)
+
+const (
+	MIMETextCSV            = "text/csv"
+	MIMETextCSVCharsetUTF8 = "text/csv; charset=UTF-8"
+)
+
+// CSVは1つのファイルに全ての対象を並べ、type列で対象を区別する。対象に関係の無い列は空にする。
+// 規則はgroup列かvariants列に対象を、表示名はname列に対象の名前を書く
+const (
+	csvType         = "type"
+	csvName         = "name"
+	csvGroup        = "group"
+	csvDescriptions = "descriptions"
+	csvDinosaur     = "dinosaur"
+	csvVariants     = "variants"
+	csvStatGrowths  = "stat_growths"
+	csvKind         = "kind"
+	csvTarget       = "target"
+	csvLocale       = "locale"
+	csvDisplayName  = "display_name"
+)
+
+// csvListSeparator 説明文、バリアント、成長値の一覧は1つのセルに改行で区切って並べる
+const csvListSeparator = "\n"
+
+// csvFieldSeparator 成長値は"ステータス 野生 テイム テイム加算 テイム親和性"の形式で1行に空白で区切って並べる
+const csvFieldSeparator = " "
+
+// csvColumns ステータスと倍率の列はJSONの項目と同じ名前にする
+var csvColumns = append(
+	[]string{
+		csvType, csvName, csvGroup, csvDescriptions, csvDinosaur, csvVariants,
+		csvStatGrowths, csvKind, csvTarget, csvLocale, csvDisplayName,
+	},
+	append(numberColumns(dinosaurStatsParams{}), numberColumns(uniqueMultipliersParams{})...)...,
+)
+
+func numberColumns(params any) []string {
+	return lo.Map(structFields(reflect.TypeOf(params)), func(f reflect.StructField, _ int) string { return jsonName(f) })
+}
+
+type csvRow map[string]string
+
+func (r csvRow) record() []string {
+	return lo.Map(csvColumns, func(column string, _ int) string { return r[column] })
+}
+
+func splitCSVList(cell string) []string {
+	if cell == "" {
+		return nil
+	}
+	return strings.Split(cell, csvListSeparator)
+}
+
+// csv 参照される対象から順に、取り込みと同じ順序で書き出す
+func (b BundleValue) csv() ([]byte, error) {
+	rows := make(
+		[]csvRow, 0, len(b.Groups)+len(b.Variants)+len(b.Dinosaurs)+len(b.Uniques)+len(b.VariantRules)+len(b.DisplayNames),
+	)
+	for _, g := range b.Groups {
+		rows = append(rows, csvRow{csvType: model.KindGroup.Value(), csvName: g.Name})
+	}
+	for _, v := range b.Variants {
+		rows = append(rows, csvRow{
+			csvType:         model.KindVariant.Value(),
+			csvName:         v.Name,
+			csvGroup:        v.Group,
+			csvDescriptions: strings.Join(v.Descriptions, csvListSeparator),
+		})
+	}
+	for _, d := range b.Dinosaurs {
+		row := csvRow{csvType: model.KindDinosaur.Value(), csvName: d.Name, csvStatGrowths: writeStatGrowths(d.StatGrowths)}
+		writeNumbers(row, d.dinosaurStatsParams)
+		rows = append(rows, row)
+	}
+	for _, u := range b.Uniques {
+		row := csvRow{
+			csvType:     model.KindUnique.Value(),
+			csvName:     u.Name,
+			csvDinosaur: u.Dinosaur,
+			csvVariants: writeVariantRefs(u.Variants...),
+		}
+		writeNumbers(row, u.uniqueMultipliersParams)
+		rows = append(rows, row)
+	}
+	for _, r := range b.VariantRules {
+		row := csvRow{csvType: model.KindRule.Value(), csvKind: r.Kind, csvGroup: lo.FromPtr(r.Group)}
+		if r.Variant != nil && r.OtherVariant != nil {
+			row[csvVariants] = writeVariantRefs(*r.Variant, *r.OtherVariant)
+		}
+		rows = append(rows, row)
+	}
+	for _, d := range b.DisplayNames {
+		rows = append(rows, csvRow{
+			csvType:        model.KindDisplayName.Value(),
+			csvTarget:      d.Target,
+			csvName:        d.Name,
+			csvLocale:      d.Locale,
+			csvDisplayName: d.DisplayName,
+		})
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(csvColumns); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if err := w.Write(row.record()); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// readBundleCSV 列の順序は問わず、1行目の見出しで列を特定する。見出しの無い列は空として扱う
+func readBundleCSV(body io.Reader) (*BundleValue, error) {
+	r := csv.NewReader(body)
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return &BundleValue{}, nil
+	}
+	if err != nil {
+		return nil, logic.WrapInvalidArgument(err)
+	}
+	if !lo.Contains(header, csvType) {
+		return nil, failure.Translate(
+			i18n.Wrapf(logic.FieldErrors{{Field: csvType, Rule: "required"}}, "CSVが不正です"), logic.InvalidArgument,
+		)
+	}
+
+	var (
+		bundle BundleValue
+		fields logic.FieldErrors
+	)
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, logic.WrapInvalidArgument(err)
+		}
+		line, _ := r.FieldPos(0)
+		row := csvRow{}
+		for n, column := range header {
+			row[column] = record[n]
+		}
+		// 項目名に行番号を含め、どの行の値が不正かを返す
+		invalid := func(column, rule, param string) {
+			fields = append(fields, logic.FieldError{Field: fmt.Sprintf("line %d %s", line, column), Rule: rule, Param: param})
+		}
+
+		kind, err := model.NewKind(row[csvType])
+		if err != nil {
+			invalid(csvType, "oneof", strings.Join(lo.Map(model.Kinds(), func(k model.Kind, _ int) string {
+				return k.Value()
+			}), " "))
+			continue
+		}
+		switch kind {
+		case model.KindGroup:
+			bundle.Groups = append(bundle.Groups, TransferGroupValue{Name: row[csvName]})
+		case model.KindVariant:
+			bundle.Variants = append(bundle.Variants, TransferVariantValue{
+				Group:        row[csvGroup],
+				Name:         row[csvName],
+				Descriptions: splitCSVList(row[csvDescriptions]),
+			})
+		case model.KindDinosaur:
+			d := TransferDinosaurValue{Name: row[csvName], StatGrowths: readStatGrowths(row[csvStatGrowths], invalid)}
+			readNumbers(row, &d.dinosaurStatsParams, invalid)
+			bundle.Dinosaurs = append(bundle.Dinosaurs, d)
+		case model.KindUnique:
+			u := TransferUniqueValue{
+				Name: row[csvName], Dinosaur: row[csvDinosaur], Variants: readVariantRefs(row[csvVariants], invalid),
+			}
+			readNumbers(row, &u.uniqueMultipliersParams, invalid)
+			bundle.Uniques = append(bundle.Uniques, u)
+		case model.KindRule:
+			r := TransferRuleValue{Kind: row[csvKind]}
+			if row[csvGroup] != "" {
+				r.Group = lo.ToPtr(row[csvGroup])
+			}
+			// バリアントの規則は2つのバリアントを、規則の対象とする順に並べる
+			switch refs := readVariantRefs(row[csvVariants], invalid); len(refs) {
+			case 0:
+			case 2:
+				r.Variant, r.OtherVariant = &refs[0], &refs[1]
+			default:
+				invalid(csvVariants, "len", "2")
+			}
+			bundle.VariantRules = append(bundle.VariantRules, r)
+		case model.KindDisplayName:
+			bundle.DisplayNames = append(bundle.DisplayNames, TransferDisplayNameValue{
+				Target:      row[csvTarget],
+				Name:        row[csvName],
+				Locale:      row[csvLocale],
+				DisplayName: row[csvDisplayName],
+			})
+		}
+	}
+	if len(fields) != 0 {
+		return nil, failure.Translate(i18n.Wrapf(fields, "CSVが不正です"), logic.InvalidArgument)
+	}
+	return &bundle, nil
+}
+
+func writeVariantRefs(refs ...TransferVariantRefValue) string {
+	return strings.Join(lo.Map(refs, func(ref TransferVariantRefValue, _ int) string {
+		return ref.ref().String()
+	}), csvListSeparator)
+}
+
+func readVariantRefs(cell string, invalid func(column, rule, param string)) []TransferVariantRefValue {
+	var refs []TransferVariantRefValue
+	for _, line := range splitCSVList(cell) {
+		ref, err := model.ParseVariantRef(line)
+		if err != nil {
+			invalid(csvVariants, "variant_ref", line)
+			continue
+		}
+		refs = append(refs, newTransferVariantRefValue(ref))
+	}
+	return refs
+}
+
+func writeStatGrowths(growths []statGrowthParams) string {
+	return strings.Join(lo.Map(growths, func(g statGrowthParams, _ int) string {
+		return strings.Join([]string{
+			g.Stat,
+			strconv.FormatFloat(float64(g.WildIncrease), 'g', -1, 32),
+			strconv.FormatFloat(float64(g.TamedIncrease), 'g', -1, 32),
+			strconv.FormatFloat(float64(g.TamedAdd), 'g', -1, 32),
+			strconv.FormatFloat(float64(g.TamedAffinity), 'g', -1, 32),
+		}, csvFieldSeparator)
+	}), csvListSeparator)
+}
+
+// readStatGrowths ステータスの名前と成長値の範囲はリクエストの検証に任せる
+func readStatGrowths(cell string, invalid func(column, rule, param string)) []statGrowthParams {
+	var growths []statGrowthParams
+	for _, line := range splitCSVList(cell) {
+		fields := strings.Fields(line)
+		if len(fields) != 5 {
+			invalid(csvStatGrowths, "stat_growth", line)
+			continue
+		}
+		values := make([]float32, 0, 4)
+		for _, field := range fields[1:] {
+			value, err := strconv.ParseFloat(field, 32)
+			if err != nil {
+				invalid(csvStatGrowths, "number", field)
+				break
+			}
+			values = append(values, float32(value))
+		}
+		if len(values) != 4 {
+			continue
+		}
+		growths = append(growths, statGrowthParams{
+			Stat: fields[0], WildIncrease: values[0], TamedIncrease: values[1], TamedAdd: values[2], TamedAffinity: values[3],
+		})
+	}
+	return growths
+}
+
+// writeNumbers ステータスと倍率の構造体の項目を、同じ名前の列に書く。省略した倍率は空にする
+func writeNumbers(row csvRow, params any) {
+	v := reflect.ValueOf(params)
+	for n, f := range structFields(v.Type()) {
+		switch field := v.Field(n); field.Kind() {
+		case reflect.Uint:
+			row[jsonName(f)] = strconv.FormatUint(field.Uint(), 10)
+		case reflect.Float32:
+			row[jsonName(f)] = strconv.FormatFloat(field.Float(), 'g', -1, 32)
+		case reflect.Pointer:
+			if !field.IsNil() {
+				row[jsonName(f)] = strconv.FormatFloat(field.Elem().Float(), 'g', -1, 32)
+			}
+		}
+	}
+}
+
+// readNumbers 空の列は省略したものとして扱い、必須かどうかはリクエストの検証に任せる
+func readNumbers(row csvRow, params any, invalid func(column, rule, param string)) {
+	v := reflect.ValueOf(params).Elem()
+	for n, f := range structFields(v.Type()) {
+		column, cell := jsonName(f), row[jsonName(f)]
+		if cell == "" {
+			continue
+		}
+		switch field := v.Field(n); field.Kind() {
+		case reflect.Uint:
+			value, err := strconv.ParseUint(cell, 10, 0)
+			if err != nil {
+				invalid(column, "number", cell)
+				continue
+			}
+			field.SetUint(value)
+		case reflect.Float32:
+			value, err := strconv.ParseFloat(cell, 32)
+			if err != nil {
+				invalid(column, "number", cell)
+				continue
+			}
+			field.SetFloat(value)
+		case reflect.Pointer:
+			value, err := strconv.ParseFloat(cell, 32)
+			if err != nil {
+				invalid(column, "number", cell)
+				continue
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+			field.Elem().SetFloat(value)
+		}
+	}
+}
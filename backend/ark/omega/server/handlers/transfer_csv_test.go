@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/morikuni/failure"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+)
+
+func Test_BundleCSV(t *testing.T) {
+	t.Run("書き出したCSVを取り込むと同じ内容になるかのテスト", func(t *testing.T) {
+		bundle := BundleValue{
+			Groups: []TransferGroupValue{{Name: "Cosmic"}},
+			Variants: []TransferVariantValue{
+				{Group: "Cosmic", Name: "Singularity", Descriptions: []string{"Destroys corpses.", "Pulls, \"nearby\" enemies."}},
+				{Group: "Cosmic", Name: "Void"},
+			},
+			Dinosaurs: []TransferDinosaurValue{{
+				dinosaurStatsParams: dinosaurStatsParams{
+					BaseHealth: 1100, BaseStamina: 420, BaseOxygen: 150, BaseFood: 3000, BaseWeight: 500,
+					BaseMelee: 100, BaseMovementSpeed: 100, BaseTorpidity: 1550,
+				},
+				Name: "Rex",
+				StatGrowths: []statGrowthParams{
+					{Stat: "health", WildIncrease: 0.2, TamedIncrease: 0.054, TamedAdd: 0.35},
+					{Stat: "melee", WildIncrease: 0.05, TamedIncrease: 0.1, TamedAdd: 0.07, TamedAffinity: 0.5},
+				},
+			}, {
+				dinosaurStatsParams: dinosaurStatsParams{
+					BaseHealth: 40, BaseStamina: 100, BaseOxygen: 150, BaseFood: 450, BaseWeight: 50,
+					BaseMelee: 100, BaseMovementSpeed: 100, BaseTorpidity: 30,
+				},
+				Name: "Dodo",
+			}},
+			Uniques: []TransferUniqueValue{{
+				uniqueMultipliersParams: uniqueMultipliersParams{
+					HealthMultiplier: 1.5, DamageMultiplier: 2, ArmorMultiplier: lo.ToPtr[float32](0.8),
+				},
+				Name:     "Kenny",
+				Dinosaur: "Rex",
+				Variants: []TransferVariantRefValue{{Group: "Cosmic", Name: "Singularity"}, {Group: "Cosmic", Name: "Void"}},
+			}},
+			VariantRules: []TransferRuleValue{
+				{Kind: "group_exclusive", Group: lo.ToPtr("Cosmic")},
+				{
+					Kind:         "incompatible",
+					Variant:      &TransferVariantRefValue{Group: "Cosmic", Name: "Singularity"},
+					OtherVariant: &TransferVariantRefValue{Group: "Cosmic", Name: "Void"},
+				},
+			},
+			DisplayNames: []TransferDisplayNameValue{
+				{Target: "group", Name: "Cosmic", Locale: "en", DisplayName: "Cosmos"},
+				{Target: "variant", Name: "Cosmic/Singularity", Locale: "ja", DisplayName: "特異点, \"大\""},
+				{Target: "unique", Name: "Kenny", Locale: "en", DisplayName: "Kenny the Rex"},
+			},
+		}
+
+		body, err := bundle.csv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := readBundleCSV(bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("書き出したCSVを読めません %v\n%s", err, body)
+		}
+		if !reflect.DeepEqual(&bundle, got) {
+			t.Errorf("取り込んだ内容が一致しません\nwant: %+v\ngot:  %+v", bundle, *got)
+		}
+	})
+
+	t.Run("列の順序が異なり、一部の列が無いCSVを読めるかのテスト", func(t *testing.T) {
+		body := "name,type,variants,health_multiplier,damage_multiplier,dinosaur\n" +
+			"Cosmic,group,,,,\n" +
+			"Kenny,unique,Cosmic/Singularity,1.5,2,Rex\n"
+		got, err := readBundleCSV(strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &BundleValue{
+			Groups: []TransferGroupValue{{Name: "Cosmic"}},
+			Uniques: []TransferUniqueValue{{
+				uniqueMultipliersParams: uniqueMultipliersParams{HealthMultiplier: 1.5, DamageMultiplier: 2},
+				Name:                    "Kenny",
+				Dinosaur:                "Rex",
+				Variants:                []TransferVariantRefValue{{Group: "Cosmic", Name: "Singularity"}},
+			}},
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("読んだ内容が一致しません\nwant: %+v\ngot:  %+v", *want, *got)
+		}
+	})
+
+	t.Run("不正な値は行番号と列を含む項目毎のエラーになるかのテスト", func(t *testing.T) {
+		body := "type,name,variants,base_health,stat_growths\n" +
+			"unknown,Cosmic,,,\n" +
+			"dinosaur,Rex,,many,\"health 0.2 0.054\nmelee 0.05 fast 0.07 0.5\"\n" +
+			"unique,Kenny,Singularity,,\n" +
+			"variant_rule,,\"Cosmic/Singularity\",,\n"
+		_, err := readBundleCSV(strings.NewReader(body))
+		if code, ok := failure.CodeOf(err); !ok || code != logic.InvalidArgument {
+			t.Fatalf("InvalidArgumentになっていません %v", err)
+		}
+
+		var fields logic.FieldErrors
+		if !errors.As(err, &fields) {
+			t.Fatalf("項目毎のエラーが含まれていません %v", err)
+		}
+		want := logic.FieldErrors{
+			{Field: "line 2 type", Rule: "oneof", Param: "group variant dinosaur unique variant_rule display_name"},
+			{Field: "line 3 stat_growths", Rule: "stat_growth", Param: "health 0.2 0.054"},
+			{Field: "line 3 stat_growths", Rule: "number", Param: "fast"},
+			{Field: "line 3 base_health", Rule: "number", Param: "many"},
+			{Field: "line 5 variants", Rule: "variant_ref", Param: "Singularity"},
+			{Field: "line 6 variants", Rule: "len", Param: "2"},
+		}
+		if !reflect.DeepEqual(want, fields) {
+			t.Errorf("項目毎のエラーが一致しません\nwant: %v\ngot:  %v", want, fields)
+		}
+	})
+}
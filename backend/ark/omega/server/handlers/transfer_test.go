@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/transfer/domain/model"
+	translationModel "mods-explore/ark/omega/logic/translation/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+func Test_BundleValue(t *testing.T) {
+	t.Run("書き出したJSONを取り込むと成長値、規則、表示名も含めて同じカタログになるかのテスト", func(t *testing.T) {
+		health, err := creatureModel.NewStatGrowth(0.2, 0.054, 0.35, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		multipliers, err := uniqueMultipliersParams{HealthMultiplier: 1.5, DamageMultiplier: 2}.multipliers()
+		if err != nil {
+			t.Fatal(err)
+		}
+		singularity := model.VariantRef{Group: "Cosmic", Name: "Singularity"}
+		void := model.VariantRef{Group: "Cosmic", Name: "Void"}
+		bundle := model.Bundle{
+			Groups: []model.Group{{Name: "Cosmic"}},
+			Variants: []model.Variant{
+				{Group: "Cosmic", Name: "Singularity", Descriptions: []variantModel.DescriptionText{"Destroys corpses."}},
+				{Group: "Cosmic", Name: "Void", Descriptions: []variantModel.DescriptionText{}},
+			},
+			Dinosaurs: []model.Dinosaur{{
+				Name:       "Rex",
+				Stats:      creatureModel.NewDinosaurStats(1100, 420, 150, 3000, 500, 100, 100, 1550, 0),
+				StatGrowth: creatureModel.SpeciesStatGrowth{creatureModel.StatHealth: health},
+			}},
+			Uniques: []model.Unique{{
+				Name:        "Kenny",
+				Dinosaur:    "Rex",
+				Multipliers: multipliers,
+				Variants:    []model.VariantRef{singularity},
+			}},
+			Rules: []model.Rule{
+				{Kind: variantModel.RuleGroupExclusive, Group: "Cosmic"},
+				{Kind: variantModel.RuleRequires, Variant: singularity, Other: void},
+			},
+			DisplayNames: []model.DisplayName{
+				{Target: translationModel.TargetVariant, Name: "Cosmic/Singularity", Locale: i18n.English, Value: "Black hole"},
+				{Target: translationModel.TargetUnique, Name: "Kenny", Locale: i18n.Japanese, Value: "ケニー"},
+			},
+		}
+
+		body, err := json.Marshal(NewBundleValue(bundle))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var value BundleValue
+		if err = json.Unmarshal(body, &value); err != nil {
+			t.Fatal(err)
+		}
+		if err = NewValidator().Validate(&value); err != nil {
+			t.Fatalf("書き出したJSONが検証を通りません %v\n%s", err, body)
+		}
+		got, err := value.bundle()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(bundle, got) {
+			t.Errorf("取り込んだ内容が一致しません\nwant: %+v\ngot:  %+v", bundle, got)
+		}
+	})
+
+	t.Run("規則の種類に合わない参照や不明な言語はInvalidArgumentになるかのテスト", func(t *testing.T) {
+		ref := &TransferVariantRefValue{Group: "Cosmic", Name: "Singularity"}
+		values := map[string]BundleValue{
+			"グループの規則にバリアントを指定": {VariantRules: []TransferRuleValue{
+				{Kind: "group_exclusive", Variant: ref, OtherVariant: ref},
+			}},
+			"同じバリアントを指定": {VariantRules: []TransferRuleValue{
+				{Kind: "incompatible", Variant: ref, OtherVariant: ref},
+			}},
+			"対応していない言語": {DisplayNames: []TransferDisplayNameValue{
+				{Target: "group", Name: "Cosmic", Locale: "de", DisplayName: "Kosmisch"},
+			}},
+			"バリアントの参照が不正": {DisplayNames: []TransferDisplayNameValue{
+				{Target: "variant", Name: "Singularity", Locale: "en", DisplayName: "Black hole"},
+			}},
+		}
+		for name, value := range values {
+			if _, err := value.bundle(); !failure.Is(err, logic.InvalidArgument) {
+				t.Errorf("%s: InvalidArgumentになっていません %v", name, err)
+			}
+		}
+	})
+}
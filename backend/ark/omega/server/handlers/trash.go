@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+	"mods-explore/ark/omega/logic/trash/usecase"
+)
+
+type TrashHandler interface {
+	List(echo.Context) error
+}
+
+type Trash struct {
+	usecase.TrashUsecase
+}
+
+func NewTrash(injector *do.Injector) (TrashHandler, error) {
+	return &Trash{
+		TrashUsecase: do.MustInvoke[usecase.TrashUsecase](injector),
+	}, nil
+}
+
+// TrashItemValue idは種類毎に採番されるため、typeと組で対象を表す
+type TrashItemValue struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+func NewTrashItemValue(item model.Item) TrashItemValue {
+	return TrashItemValue{
+		Type:      item.Type().Value(),
+		ID:        item.ID(),
+		Name:      item.Name(),
+		DeletedAt: item.DeletedAt(),
+	}
+}
+
+// trashListParams ゴミ箱は削除日時の順のみで並べる。typeは複数指定できる
+type trashListParams struct {
+	auditPageParams
+
+	Types []string `query:"type" validate:"dive,oneof=dinosaur unique variant group"`
+}
+
+func (p trashListParams) types() ([]model.ItemType, error) {
+	types := make([]model.ItemType, 0, len(p.Types))
+	for _, value := range p.Types {
+		t, err := model.NewItemType(value)
+		if err != nil {
+			return nil, logic.WrapInvalidArgument(err)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+func (t Trash) List(c echo.Context) error {
+	var params trashListParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	types, err := params.types()
+	if err != nil {
+		return err
+	}
+
+	items, err := t.TrashUsecase.List(c.Request().Context(), service.NewListTrash(page, types))
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewPageValue(*items, NewTrashItemValue)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,16 +1,19 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
 	"github.com/samber/lo"
 
+	"mods-explore/ark/omega/logic"
 	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
 	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
 	"mods-explore/ark/omega/logic/creature/usecase"
 	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/server/view"
 )
 
 type UniqueHandler interface {
@@ -19,6 +22,8 @@ type UniqueHandler interface {
 	CreateUnique(echo.Context) error
 	UpdateUnique(echo.Context) error
 	DeleteUnique(echo.Context) error
+	RestoreUnique(echo.Context) error
+	UniqueStats(echo.Context) error
 }
 
 type Unique struct {
@@ -35,91 +40,203 @@ type uniqueQueryParams struct {
 	ID int `param:"id" validate:"required"`
 }
 
-type UniqueValue struct {
-	UniqueID         int                    `json:"id" validate:"required"`
-	BaseID           int                    `json:"base_id" validate:"required"`
-	BaseName         string                 `json:"base_name" validate:"required"`
-	BaseHealth       uint                   `json:"base_health" validate:"required"`
-	BaseMelee        uint                   `json:"base_melee" validate:"required"`
-	UniqueName       string                 `json:"unique_name" validate:"required"`
-	HealthMultiplier float32                `json:"health_multiplier" validate:"required"`
-	DamageMultiplier float32                `json:"damage_multiplier" validate:"required"`
-	UniqueVariants   [2]UniqueVariantsValue `json:"unique_variants" validate:"required"`
+func (u Unique) ReadUnique(c echo.Context) error {
+	var params uniqueQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	unique, err := u.UniqueUsecase.Find(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	setETag(c, unique.Version())
+	if err = c.JSON(http.StatusOK, view.NewUniqueValue(*unique)); err != nil {
+		return err
+	}
+	return nil
 }
 
-// UniqueVariantsValue TODO UniqueValueに入れ子で定義できるなら修正する。配列の定義がうまくいかないので現状は別の型とする。
-type UniqueVariantsValue struct {
-	VariantID        int    `json:"variant_id" validate:"required"`
-	VariantName      string `json:"variant_name" validate:"required"`
-	VariantGroupName string `json:"group_name" validate:"required"`
+type uniqueListParams struct {
+	pageQueryParams
+	VariantGroupID      *int     `query:"group_id"`
+	VariantID           *int     `query:"variant_id"`
+	BaseName            *string  `query:"base_name"`
+	MinHealthMultiplier *float32 `query:"health_multiplier_min"`
+	MaxHealthMultiplier *float32 `query:"health_multiplier_max"`
+	MinDamageMultiplier *float32 `query:"damage_multiplier_min"`
+	MaxDamageMultiplier *float32 `query:"damage_multiplier_max"`
 }
 
-func NewUniqueValue(unique creatureModel.UniqueDinosaur) UniqueValue {
-	vs := unique.UniqueVariant()
-	variants := lo.Map(vs[:], func(v creatureModel.DinosaurVariant, _ int) UniqueVariantsValue {
-		return UniqueVariantsValue{
-			VariantID:        v.ID().Value(),
-			VariantName:      v.Name().Value(),
-			VariantGroupName: v.Group().Value(),
-		}
-	})
-	return UniqueValue{
-		unique.UniqueID().Value(),
-		unique.Dinosaur.BaseID().Value(),
-		unique.Dinosaur.BaseName().Value(),
-		unique.Dinosaur.Health().Value(),
-		unique.Dinosaur.Melee().Value(),
-		unique.UniqueName().Value(),
-		unique.HealthMultiplier().Value(),
-		unique.DamageMultiplier().Value(),
-		([2]UniqueVariantsValue)(variants),
+func (p uniqueListParams) filter() creatureSvc.UniqueFilter {
+	var filter creatureSvc.UniqueFilter
+	if p.VariantGroupID != nil {
+		id := variantModel.VariantGroupID(*p.VariantGroupID)
+		filter.VariantGroupID = &id
+	}
+	if p.VariantID != nil {
+		id := variantModel.VariantID(*p.VariantID)
+		filter.VariantID = &id
 	}
+	if p.BaseName != nil {
+		name := creatureModel.DinosaurName(*p.BaseName)
+		filter.BaseName = &name
+	}
+	filter.MinHealthMultiplier = toStatusMultiplier(p.MinHealthMultiplier)
+	filter.MaxHealthMultiplier = toStatusMultiplier(p.MaxHealthMultiplier)
+	filter.MinDamageMultiplier = toStatusMultiplier(p.MinDamageMultiplier)
+	filter.MaxDamageMultiplier = toStatusMultiplier(p.MaxDamageMultiplier)
+	return filter
 }
 
-type UniqueValues []UniqueValue
-
-func NewUniqueValues(uniques creatureModel.UniqueDinosaurs) UniqueValues {
-	return lo.Map(uniques, func(u creatureModel.UniqueDinosaur, _ int) UniqueValue {
-		return NewUniqueValue(u)
-	})
+func toStatusMultiplier(v *float32) *creatureModel.StatusMultiplier {
+	if v == nil {
+		return nil
+	}
+	m := creatureModel.StatusMultiplier(*v)
+	return &m
 }
 
-func (u Unique) ReadUnique(c echo.Context) error {
-	var params uniqueQueryParams
+func (u Unique) ListUniques(c echo.Context) error {
+	var params uniqueListParams
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
-
-	unique, err := u.UniqueUsecase.Find(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID))
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
 	if err != nil {
 		return err
 	}
-
-	if err = c.JSON(http.StatusOK, NewUniqueValue(*unique)); err != nil {
+	query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortKey(params.Sort), params.filter())
+	if err != nil {
 		return err
 	}
-	return nil
-}
 
-func (u Unique) ListUniques(c echo.Context) error {
-	uniques, err := u.UniqueUsecase.List(c.Request().Context())
+	uniques, err := u.UniqueUsecase.List(c.Request().Context(), query)
 	if err != nil {
 		return err
 	}
-	if err = c.JSON(http.StatusOK, NewUniqueValues(uniques)); err != nil {
+	if err = c.JSON(http.StatusOK, NewPageValue(*uniques, view.NewUniqueValue)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// dinosaurStatsParams 移動速度は100を標準とする百分率で指定する
+type dinosaurStatsParams struct {
+	BaseHealth        uint `json:"base_health" validate:"required"`
+	BaseStamina       uint `json:"base_stamina" validate:"required"`
+	BaseOxygen        uint `json:"base_oxygen"`
+	BaseFood          uint `json:"base_food" validate:"required"`
+	BaseWeight        uint `json:"base_weight" validate:"required"`
+	BaseMelee         uint `json:"base_melee"`
+	BaseMovementSpeed uint `json:"base_movement_speed" validate:"required,max=1000"`
+	BaseTorpidity     uint `json:"base_torpidity" validate:"required"`
+	BaseArmor         uint `json:"base_armor"`
+}
+
+func newDinosaurStatsParams(stats creatureModel.DinosaurStats) dinosaurStatsParams {
+	return dinosaurStatsParams{
+		BaseHealth:        stats.Health().Value(),
+		BaseStamina:       stats.Stamina().Value(),
+		BaseOxygen:        stats.Oxygen().Value(),
+		BaseFood:          stats.Food().Value(),
+		BaseWeight:        stats.Weight().Value(),
+		BaseMelee:         stats.Melee().Value(),
+		BaseMovementSpeed: stats.MovementSpeed().Value(),
+		BaseTorpidity:     stats.Torpidity().Value(),
+		BaseArmor:         stats.Armor().Value(),
+	}
+}
+
+func (p dinosaurStatsParams) stats() (creatureModel.DinosaurStats, error) {
+	health, e1 := creatureModel.NewHealth(p.BaseHealth)
+	stamina, e2 := creatureModel.NewStamina(p.BaseStamina)
+	food, e3 := creatureModel.NewFood(p.BaseFood)
+	weight, e4 := creatureModel.NewWeight(p.BaseWeight)
+	speed, e5 := creatureModel.NewMovementSpeed(p.BaseMovementSpeed)
+	torpidity, e6 := creatureModel.NewTorpidity(p.BaseTorpidity)
+	if err := errors.Join(e1, e2, e3, e4, e5, e6); err != nil {
+		return creatureModel.DinosaurStats{}, logic.WrapInvalidArgument(err)
+	}
+
+	return creatureModel.NewDinosaurStats(
+		health, stamina, creatureModel.NewOxygen(p.BaseOxygen), food, weight,
+		creatureModel.NewMelee(p.BaseMelee), speed, torpidity, creatureModel.NewArmor(p.BaseArmor),
+	), nil
+}
+
+// uniqueMultipliersParams 体力と攻撃力以外の倍率は省略すると等倍とする
+type uniqueMultipliersParams struct {
+	HealthMultiplier        float32  `json:"health_multiplier" validate:"required,gt=0"`
+	StaminaMultiplier       *float32 `json:"stamina_multiplier" validate:"omitempty,gt=0"`
+	OxygenMultiplier        *float32 `json:"oxygen_multiplier" validate:"omitempty,gt=0"`
+	FoodMultiplier          *float32 `json:"food_multiplier" validate:"omitempty,gt=0"`
+	WeightMultiplier        *float32 `json:"weight_multiplier" validate:"omitempty,gt=0"`
+	DamageMultiplier        float32  `json:"damage_multiplier" validate:"required,gt=0"`
+	MovementSpeedMultiplier *float32 `json:"movement_speed_multiplier" validate:"omitempty,gt=0"`
+	TorpidityMultiplier     *float32 `json:"torpidity_multiplier" validate:"omitempty,gt=0"`
+	ArmorMultiplier         *float32 `json:"armor_multiplier" validate:"omitempty,gt=0"`
+}
+
+// newUniqueMultipliersParams 等倍の倍率も省略せずに指定する
+func newUniqueMultipliersParams(multipliers creatureModel.UniqueMultipliers) uniqueMultipliersParams {
+	return uniqueMultipliersParams{
+		HealthMultiplier:        multipliers.Health().Value(),
+		StaminaMultiplier:       lo.ToPtr(multipliers.Stamina().Value()),
+		OxygenMultiplier:        lo.ToPtr(multipliers.Oxygen().Value()),
+		FoodMultiplier:          lo.ToPtr(multipliers.Food().Value()),
+		WeightMultiplier:        lo.ToPtr(multipliers.Weight().Value()),
+		DamageMultiplier:        multipliers.Melee().Value(),
+		MovementSpeedMultiplier: lo.ToPtr(multipliers.MovementSpeed().Value()),
+		TorpidityMultiplier:     lo.ToPtr(multipliers.Torpidity().Value()),
+		ArmorMultiplier:         lo.ToPtr(multipliers.Armor().Value()),
+	}
+}
+
+func (p uniqueMultipliersParams) multipliers() (creatureModel.UniqueMultipliers, error) {
+	health, e1 := toUniqueMultiplier[creatureModel.Health](&p.HealthMultiplier)
+	stamina, e2 := toUniqueMultiplier[creatureModel.Stamina](p.StaminaMultiplier)
+	oxygen, e3 := toUniqueMultiplier[creatureModel.Oxygen](p.OxygenMultiplier)
+	food, e4 := toUniqueMultiplier[creatureModel.Food](p.FoodMultiplier)
+	weight, e5 := toUniqueMultiplier[creatureModel.Weight](p.WeightMultiplier)
+	damage, e6 := toUniqueMultiplier[creatureModel.Melee](&p.DamageMultiplier)
+	speed, e7 := toUniqueMultiplier[creatureModel.MovementSpeed](p.MovementSpeedMultiplier)
+	torpidity, e8 := toUniqueMultiplier[creatureModel.Torpidity](p.TorpidityMultiplier)
+	armor, e9 := toUniqueMultiplier[creatureModel.Armor](p.ArmorMultiplier)
+	if err := errors.Join(e1, e2, e3, e4, e5, e6, e7, e8, e9); err != nil {
+		return creatureModel.UniqueMultipliers{}, logic.WrapInvalidArgument(err)
+	}
+
+	return creatureModel.NewUniqueMultipliers(
+		health, stamina, oxygen, food, weight, damage, speed, torpidity, armor,
+	), nil
+}
+
+func toUniqueMultiplier[T creatureModel.DinosaurStatus](v *float32) (creatureModel.UniqueMultiplier[T], error) {
+	if v == nil {
+		return creatureModel.DefaultUniqueMultiplier[T](), nil
+	}
+	m, err := creatureModel.NewUniqueMultiplier[T](creatureModel.StatusMultiplier(*v))
+	if err != nil {
+		return creatureModel.UniqueMultiplier[T]{}, err
+	}
+	return *m, nil
+}
+
+// uniqueCreateParams 基となる種は事前に登録したものをIDで参照する
 type uniqueCreateParams struct {
-	BaseName         creatureModel.DinosaurName `json:"base_name" validate:"required"`
-	BaseHealth       creatureModel.Health       `json:"base_health" validate:"required"`
-	BaseMelee        creatureModel.Melee        `json:"base_melee" validate:"required"`
-	UniqueName       creatureModel.UniqueName   `json:"unique_name" validate:"required"`
-	HealthMultiplier float32                    `json:"health_multiplier" validate:"required"`
-	DamageMultiplier float32                    `json:"damage_multiplier" validate:"required"`
-	VariantIDs       [2]int                     `json:"unique_variants" validate:"required"`
+	uniqueMultipliersParams
+
+	BaseID     creatureModel.DinosaurID `json:"base_id" validate:"required"`
+	UniqueName creatureModel.UniqueName `json:"unique_name" validate:"required,max=100"`
+	VariantIDs []variantModel.VariantID `json:"unique_variants" validate:"required,min=1,unique,dive,gt=0"`
 }
 
 func (u Unique) CreateUnique(c echo.Context) error {
@@ -127,56 +244,38 @@ func (u Unique) CreateUnique(c echo.Context) error {
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
-	healthMultiplier, err := creatureModel.NewUniqueMultiplier[creatureModel.Health](
-		creatureModel.StatusMultiplier(params.HealthMultiplier),
-	)
-	if err != nil {
+	if err := c.Validate(&params); err != nil {
 		return err
 	}
-
-	damageMultiplier, err := creatureModel.NewUniqueMultiplier[creatureModel.Melee](
-		creatureModel.StatusMultiplier(params.DamageMultiplier),
-	)
+	multipliers, err := params.multipliers()
 	if err != nil {
 		return err
 	}
 
-	ids := params.VariantIDs
-	variantIDs := lo.Map(ids[:], func(id int, _ int) variantModel.VariantID { return variantModel.VariantID(id) })
 	unique, err := u.UniqueUsecase.Create(
 		c.Request().Context(),
 		creatureSvc.NewCreateCreature(
-			params.BaseName,
-			params.BaseHealth,
-			params.BaseMelee,
+			params.BaseID,
 			params.UniqueName,
-			*healthMultiplier,
-			*damageMultiplier,
-			([2]variantModel.VariantID)(variantIDs),
+			multipliers,
+			params.VariantIDs,
 		),
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewUniqueValue(*unique)); err != nil {
+	if err = c.JSON(http.StatusOK, view.NewUniqueValue(*unique)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// uniqueUpdateParams 作成時と同じ項目で全体を置き換える
 type uniqueUpdateParams struct {
-	UniqueID creatureModel.UniqueDinosaurID `param:"id" validate:"required"`
+	uniqueCreateParams
 
-	BaseID           creatureModel.DinosaurID                             `json:"base_id" validate:"required"`
-	BaseName         creatureModel.DinosaurName                           `json:"base_name" validate:"required"`
-	BaseHealth       creatureModel.Health                                 `json:"base_health" validate:"required"`
-	BaseMelee        creatureModel.Melee                                  `json:"base_melee" validate:"required"`
-	UniqueName       creatureModel.UniqueName                             `json:"unique_name" validate:"required"`
-	HealthMultiplier creatureModel.UniqueMultiplier[creatureModel.Health] `json:"health_multiplier" validate:"required"`
-	DamageMultiplier creatureModel.UniqueMultiplier[creatureModel.Melee]  `json:"damage_multiplier" validate:"required"`
-	UniqueVariantID  creatureModel.UniqueVariantID                        `json:"unique_variant_id" validate:"required"`
-	VariantIDs       [2]variantModel.VariantID                            `json:"variant_ids" validate:"required"`
+	UniqueID creatureModel.UniqueDinosaurID `param:"id" validate:"required"`
 }
 
 func (u Unique) UpdateUnique(c echo.Context) error {
@@ -184,39 +283,55 @@ func (u Unique) UpdateUnique(c echo.Context) error {
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	multipliers, err := params.multipliers()
+	if err != nil {
+		return err
+	}
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
 	unique, err := u.UniqueUsecase.Update(
 		c.Request().Context(),
 		creatureSvc.NewUpdateCreature(
 			params.BaseID,
-			params.BaseName,
-			params.BaseHealth,
-			params.BaseMelee,
 			params.UniqueID,
 			params.UniqueName,
-			params.HealthMultiplier,
-			params.DamageMultiplier,
-			params.UniqueVariantID,
+			multipliers,
 			params.VariantIDs,
+			version,
 		),
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewUniqueValue(*unique)); err != nil {
+	setETag(c, unique.Version())
+	if err = c.JSON(http.StatusOK, view.NewUniqueValue(*unique)); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (u Unique) DeleteUnique(c echo.Context) error {
-	var params variantGroupParams
+	var params uniqueQueryParams
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
-	err := u.UniqueUsecase.Delete(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID))
+	err = u.UniqueUsecase.Delete(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID), version)
 	if err != nil {
 		return err
 	}
@@ -226,3 +341,114 @@ func (u Unique) DeleteUnique(c echo.Context) error {
 	}
 	return nil
 }
+
+func (u Unique) RestoreUnique(c echo.Context) error {
+	var params uniqueQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	unique, err := u.UniqueUsecase.Restore(c.Request().Context(), creatureModel.UniqueDinosaurID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	setETag(c, unique.Version())
+	if err = c.JSON(http.StatusOK, view.NewUniqueValue(*unique)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// uniqueStatsParams テイム効果、刷り込み、テイム後のレベルのいずれかを指定するとテイム後のステータスを計算する
+type uniqueStatsParams struct {
+	ID                  int      `param:"id" validate:"required"`
+	Level               uint     `query:"level" validate:"required,min=1"`
+	TamingEffectiveness *float32 `query:"taming_effectiveness" validate:"omitempty,gte=0,lte=1"`
+	Imprint             *float32 `query:"imprint" validate:"omitempty,gte=0,lte=1"`
+	TamedLevels         *uint    `query:"tamed_levels"`
+}
+
+func (p uniqueStatsParams) taming() (*creatureModel.TamingInput, error) {
+	if p.TamingEffectiveness == nil && p.Imprint == nil && p.TamedLevels == nil {
+		return nil, nil
+	}
+	taming, err := creatureModel.NewTamingInput(
+		lo.FromPtr(p.TamingEffectiveness), lo.FromPtr(p.Imprint), lo.FromPtr(p.TamedLevels),
+	)
+	if err != nil {
+		return nil, logic.WrapInvalidArgument(err)
+	}
+	return &taming, nil
+}
+
+type UniqueStatsValue struct {
+	UniqueID            int      `json:"id"`
+	Level               uint     `json:"level"`
+	TamingEffectiveness *float32 `json:"taming_effectiveness,omitempty"`
+	Imprint             *float32 `json:"imprint,omitempty"`
+	TamedLevels         *uint    `json:"tamed_levels,omitempty"`
+	Health              float32  `json:"health"`
+	Stamina             float32  `json:"stamina"`
+	Oxygen              float32  `json:"oxygen"`
+	Food                float32  `json:"food"`
+	Weight              float32  `json:"weight"`
+	Melee               float32  `json:"melee"`
+	MovementSpeed       float32  `json:"movement_speed"`
+	Torpidity           float32  `json:"torpidity"`
+	Armor               float32  `json:"armor"`
+}
+
+func NewUniqueStatsValue(id creatureModel.UniqueDinosaurID, stats creatureModel.CalculatedStats) UniqueStatsValue {
+	value := UniqueStatsValue{
+		UniqueID:      id.Value(),
+		Level:         stats.Level().Value(),
+		Health:        stats.Health().Value(),
+		Stamina:       stats.Stamina().Value(),
+		Oxygen:        stats.Oxygen().Value(),
+		Food:          stats.Food().Value(),
+		Weight:        stats.Weight().Value(),
+		Melee:         stats.Melee().Value(),
+		MovementSpeed: stats.MovementSpeed().Value(),
+		Torpidity:     stats.Torpidity().Value(),
+		Armor:         stats.Armor().Value(),
+	}
+	if taming := stats.Taming(); taming != nil {
+		value.TamingEffectiveness = lo.ToPtr(taming.Effectiveness())
+		value.Imprint = lo.ToPtr(taming.Imprint())
+		value.TamedLevels = lo.ToPtr(taming.Levels())
+	}
+	return value
+}
+
+func (u Unique) UniqueStats(c echo.Context) error {
+	var params uniqueStatsParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	level, err := creatureModel.NewLevel(params.Level)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+	taming, err := params.taming()
+	if err != nil {
+		return err
+	}
+
+	id := creatureModel.UniqueDinosaurID(params.ID)
+	stats, err := u.UniqueUsecase.Stats(c.Request().Context(), id, level, taming)
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewUniqueStatsValue(id, *stats)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/morikuni/failure"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+// Validator echo.Validatorの実装。検証エラーはInvalidArgumentとして項目毎のエラーを返す
+type Validator struct {
+	validate *validator.Validate
+}
+
+func NewValidator() *Validator {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	// レスポンスの項目名をリクエストと一致させるため、json、param、queryのタグ名を用いる
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		for _, key := range []string{"json", "param", "query"} {
+			name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return field.Name
+	})
+	return &Validator{validate: validate}
+}
+
+func (v *Validator) Validate(i any) error {
+	err := v.validate.Struct(i)
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	fields := lo.Map(errs, func(e validator.FieldError, _ int) logic.FieldError {
+		return logic.FieldError{Field: e.Field(), Rule: e.Tag(), Param: e.Param()}
+	})
+	return failure.Translate(i18n.Wrapf(logic.FieldErrors(fields), "リクエストが不正です"), logic.InvalidArgument)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+func Test_Validator(t *testing.T) {
+	validator := NewValidator()
+
+	t.Run("正常なリクエストの検証テスト", func(t *testing.T) {
+		body := uniqueCreateParams{
+			uniqueMultipliersParams: uniqueMultipliersParams{HealthMultiplier: 1.5, DamageMultiplier: 1.2},
+			BaseID:                  1,
+			UniqueName:              "unique",
+			VariantIDs:              []variantModel.VariantID{1, 2},
+		}
+		if err := validator.Validate(&body); err != nil {
+			t.Errorf("正常なリクエストでエラーが発生しました %s", err.Error())
+		}
+	})
+
+	t.Run("異常なリクエストの検証テスト", func(t *testing.T) {
+		body := uniqueCreateParams{
+			uniqueMultipliersParams: uniqueMultipliersParams{HealthMultiplier: -1, DamageMultiplier: 1.2},
+			BaseID:                  1,
+			UniqueName:              creatureModel.UniqueName(strings.Repeat("a", 101)),
+			VariantIDs:              []variantModel.VariantID{1, 1},
+		}
+		err := validator.Validate(&body)
+		if code, ok := failure.CodeOf(err); !ok || code != logic.InvalidArgument {
+			t.Fatalf("InvalidArgumentになっていません %v", err)
+		}
+
+		var fields logic.FieldErrors
+		if !errors.As(err, &fields) {
+			t.Fatalf("項目毎のエラーが含まれていません %v", err)
+		}
+		got := make(map[string]string, len(fields))
+		for _, f := range fields {
+			got[f.Field] = f.Rule
+		}
+		want := map[string]string{"health_multiplier": "gt", "unique_name": "max", "unique_variants": "unique"}
+		for field, rule := range want {
+			if got[field] != rule {
+				t.Errorf("%sの検証エラーが%sになっていません %v", field, rule, fields)
+			}
+		}
+	})
+}
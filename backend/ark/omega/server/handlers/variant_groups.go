@@ -5,10 +5,12 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
+	"github.com/samber/lo"
 
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 	"mods-explore/ark/omega/logic/variant/usecase"
+	"mods-explore/ark/omega/server/view"
 )
 
 type VariantGroup struct {
@@ -27,10 +29,11 @@ type VariantGroupHandler interface {
 	Create(echo.Context) error
 	Update(echo.Context) error
 	Delete(echo.Context) error
+	Restore(echo.Context) error
 }
 
 type variantGroupParams struct {
-	ID int `param:"id" validator:"required"`
+	ID int `param:"id" validate:"required"`
 }
 
 func (v VariantGroup) Read(c echo.Context) error {
@@ -38,31 +41,51 @@ func (v VariantGroup) Read(c echo.Context) error {
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
 
 	variantGroup, err := v.VariantGroupUsecase.Find(c.Request().Context(), model.VariantGroupID(params.ID))
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantGroupValue(*variantGroup)); err != nil {
+	setETag(c, variantGroup.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantGroupValue(*variantGroup)); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (v VariantGroup) List(c echo.Context) error {
-	variantGroups, err := v.VariantGroupUsecase.List(c.Request().Context())
+	var params pageQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	query, err := service.NewListVariantGroups(page, service.VariantGroupSortKey(params.Sort))
+	if err != nil {
+		return err
+	}
+
+	variantGroups, err := v.VariantGroupUsecase.List(c.Request().Context(), query)
 	if err != nil {
 		return err
 	}
-	if err = c.JSON(http.StatusOK, NewVariantGroupValues(variantGroups)); err != nil {
+	if err = c.JSON(http.StatusOK, NewPageValue(*variantGroups, view.NewVariantGroupValue)); err != nil {
 		return err
 	}
 	return nil
 }
 
 type createVariantGroup struct {
-	Name string `json:"name" validate:"required"`
+	Name string `json:"name" validate:"required,max=100"`
 }
 
 func (v VariantGroup) Create(c echo.Context) error {
@@ -70,6 +93,9 @@ func (v VariantGroup) Create(c echo.Context) error {
 	if err := c.Bind(&body); err != nil {
 		return err
 	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
 
 	variant, err := v.VariantGroupUsecase.Create(
 		c.Request().Context(),
@@ -79,15 +105,15 @@ func (v VariantGroup) Create(c echo.Context) error {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantGroupValue(*variant)); err != nil {
+	if err = c.JSON(http.StatusOK, view.NewVariantGroupValue(*variant)); err != nil {
 		return err
 	}
 	return nil
 }
 
 type updateVariantGroup struct {
-	ID   int    `param:"id" validator:"required"`
-	Name string `json:"name" validate:"required"`
+	ID   int    `param:"id" validate:"required"`
+	Name string `json:"name" validate:"required,max=100"`
 }
 
 func (v VariantGroup) Update(c echo.Context) error {
@@ -95,61 +121,87 @@ func (v VariantGroup) Update(c echo.Context) error {
 	if err := c.Bind(&body); err != nil {
 		return err
 	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
 	variant, err := v.VariantGroupUsecase.Update(
 		c.Request().Context(),
-		service.NewUpdateVariantGroup(model.VariantGroupID(body.ID), model.VariantGroupName(body.Name)),
+		service.NewUpdateVariantGroup(model.VariantGroupID(body.ID), model.VariantGroupName(body.Name), version),
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantGroupValue(*variant)); err != nil {
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantGroupValue(*variant)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// deleteVariantGroupParams 属するバリアントがある場合、cascadeでは一緒に削除し、reassign_toでは指定したグループへ移す
+type deleteVariantGroupParams struct {
+	variantGroupParams
+
+	Cascade    bool `query:"cascade"`
+	ReassignTo *int `query:"reassign_to" validate:"omitempty,min=1"`
+}
+
 func (v VariantGroup) Delete(c echo.Context) error {
-	var params variantGroupParams
+	var params deleteVariantGroupParams
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
-	err := v.VariantGroupUsecase.Delete(c.Request().Context(), model.VariantGroupID(params.ID))
+	var reassignTo *model.VariantGroupID
+	if params.ReassignTo != nil {
+		reassignTo = lo.ToPtr(model.VariantGroupID(*params.ReassignTo))
+	}
+	item, err := service.NewDeleteVariantGroup(model.VariantGroupID(params.ID), version, params.Cascade, reassignTo)
 	if err != nil {
 		return err
 	}
 
+	if err = v.VariantGroupUsecase.Delete(c.Request().Context(), item); err != nil {
+		return err
+	}
+
 	if err = c.JSON(http.StatusOK, map[string]any{}); err != nil {
 		return err
 	}
 	return nil
 }
 
-type VariantGroupValue struct {
-	ID   model.VariantGroupID   `json:"id" validator:"required"`
-	Name model.VariantGroupName `json:"name" validator:"required"`
-}
-
-func NewVariantGroupValue(v model.VariantGroup) VariantGroupValue {
-	return VariantGroupValue{
-		ID:   v.ID(),
-		Name: v.Name(),
+func (v VariantGroup) Restore(c echo.Context) error {
+	var params variantGroupParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
 	}
-}
 
-type VariantGroupValues []VariantGroupValue
+	group, err := v.VariantGroupUsecase.Restore(c.Request().Context(), model.VariantGroupID(params.ID))
+	if err != nil {
+		return err
+	}
 
-func NewVariantGroupValues(vs []model.VariantGroup) (values VariantGroupValues) {
-	for _, v := range vs {
-		values = append(
-			values,
-			VariantGroupValue{
-				ID:   v.ID(),
-				Name: v.Name(),
-			},
-		)
+	setETag(c, group.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantGroupValue(*group)); err != nil {
+		return err
 	}
-	return values
+	return nil
 }
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/service"
+	"mods-explore/ark/omega/logic/variant/usecase"
+)
+
+type VariantRuleHandler interface {
+	Read(echo.Context) error
+	List(echo.Context) error
+	Create(echo.Context) error
+	Update(echo.Context) error
+	Delete(echo.Context) error
+}
+
+type VariantRule struct {
+	usecase.VariantRuleUsecase
+}
+
+func NewVariantRule(injector *do.Injector) (VariantRuleHandler, error) {
+	return &VariantRule{
+		VariantRuleUsecase: do.MustInvoke[usecase.VariantRuleUsecase](injector),
+	}, nil
+}
+
+type variantRuleParams struct {
+	ID int `param:"id" validate:"required"`
+}
+
+// VariantRuleValue 規則の種類によってgroup_idか、variant_idとother_variant_idのいずれかを返す
+type VariantRuleValue struct {
+	ID             int    `json:"id"`
+	Kind           string `json:"kind"`
+	GroupID        *uint  `json:"group_id,omitempty"`
+	VariantID      *int   `json:"variant_id,omitempty"`
+	OtherVariantID *int   `json:"other_variant_id,omitempty"`
+}
+
+func NewVariantRuleValue(rule model.VariantRule) VariantRuleValue {
+	value := VariantRuleValue{
+		ID:   rule.ID().Value(),
+		Kind: rule.Kind().Value(),
+	}
+	if id := rule.GroupID(); id != nil {
+		value.GroupID = lo.ToPtr(uint(*id))
+	}
+	if id := rule.VariantID(); id != nil {
+		value.VariantID = lo.ToPtr(id.Value())
+	}
+	if id := rule.OtherVariantID(); id != nil {
+		value.OtherVariantID = lo.ToPtr(id.Value())
+	}
+	return value
+}
+
+func (v VariantRule) Read(c echo.Context) error {
+	var params variantRuleParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	rule, err := v.VariantRuleUsecase.Find(c.Request().Context(), model.VariantRuleID(params.ID))
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewVariantRuleValue(*rule)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v VariantRule) List(c echo.Context) error {
+	var params pageQueryParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	query, err := service.NewListVariantRules(page, service.VariantRuleSortKey(params.Sort))
+	if err != nil {
+		return err
+	}
+
+	rules, err := v.VariantRuleUsecase.List(c.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+	if err = c.JSON(http.StatusOK, NewPageValue(*rules, NewVariantRuleValue)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type variantRuleBody struct {
+	Kind           string `json:"kind" validate:"required,oneof=group_exclusive incompatible requires"`
+	GroupID        *uint  `json:"group_id"`
+	VariantID      *int   `json:"variant_id"`
+	OtherVariantID *int   `json:"other_variant_id"`
+}
+
+func (b variantRuleBody) rule(id model.VariantRuleID) (model.VariantRule, error) {
+	kind, err := model.NewVariantRuleKind(b.Kind)
+	if err != nil {
+		return model.VariantRule{}, logic.WrapInvalidArgument(err)
+	}
+
+	var groupID *model.VariantGroupID
+	if b.GroupID != nil {
+		groupID = lo.ToPtr(model.VariantGroupID(*b.GroupID))
+	}
+	var variantID, otherVariantID *model.VariantID
+	if b.VariantID != nil {
+		variantID = lo.ToPtr(model.VariantID(*b.VariantID))
+	}
+	if b.OtherVariantID != nil {
+		otherVariantID = lo.ToPtr(model.VariantID(*b.OtherVariantID))
+	}
+	rule, err := model.NewVariantRule(id, kind, groupID, variantID, otherVariantID)
+	if err != nil {
+		return model.VariantRule{}, logic.WrapInvalidArgument(err)
+	}
+	return rule, nil
+}
+
+func (v VariantRule) Create(c echo.Context) error {
+	var body variantRuleBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	rule, err := body.rule(0)
+	if err != nil {
+		return err
+	}
+
+	created, err := v.VariantRuleUsecase.Create(c.Request().Context(), service.NewCreateVariantRule(rule))
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewVariantRuleValue(*created)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type updateVariantRuleBody struct {
+	variantRuleBody
+
+	ID int `param:"id" validate:"required"`
+}
+
+func (v VariantRule) Update(c echo.Context) error {
+	var body updateVariantRuleBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	rule, err := body.rule(model.VariantRuleID(body.ID))
+	if err != nil {
+		return err
+	}
+
+	updated, err := v.VariantRuleUsecase.Update(c.Request().Context(), service.NewUpdateVariantRule(rule))
+	if err != nil {
+		return err
+	}
+
+	if err = c.JSON(http.StatusOK, NewVariantRuleValue(*updated)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v VariantRule) Delete(c echo.Context) error {
+	var params variantRuleParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	if err := v.VariantRuleUsecase.Delete(c.Request().Context(), model.VariantRuleID(params.ID)); err != nil {
+		return err
+	}
+
+	if err := c.JSON(http.StatusOK, map[string]any{}); err != nil {
+		return err
+	}
+	return nil
+}
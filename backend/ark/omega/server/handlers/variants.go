@@ -6,9 +6,12 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/samber/do"
 
+	"mods-explore/ark/omega/logic"
+
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 	"mods-explore/ark/omega/logic/variant/usecase"
+	"mods-explore/ark/omega/server/view"
 )
 
 type Variant struct {
@@ -27,10 +30,15 @@ type VariantHandler interface {
 	Create(echo.Context) error
 	Update(echo.Context) error
 	Delete(echo.Context) error
+	Restore(echo.Context) error
+	CreateDescription(echo.Context) error
+	ReplaceDescriptions(echo.Context) error
+	UpdateDescription(echo.Context) error
+	DeleteDescription(echo.Context) error
 }
 
 type referenceParams struct {
-	VariantID int `param:"id" validator:"required"`
+	VariantID int `param:"id" validate:"required"`
 }
 
 func (v Variant) Read(c echo.Context) error {
@@ -38,24 +46,54 @@ func (v Variant) Read(c echo.Context) error {
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
 
 	variant, err := v.VariantUsecase.Find(c.Request().Context(), model.VariantID(params.VariantID))
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantValue(*variant)); err != nil {
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
 		return err
 	}
 	return nil
 }
 
+type variantListParams struct {
+	pageQueryParams
+	GroupID *int `query:"group_id"`
+}
+
 func (v Variant) List(c echo.Context) error {
-	variants, err := v.VariantUsecase.List(c.Request().Context())
+	var params variantListParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+	page, err := params.pageRequest()
+	if err != nil {
+		return err
+	}
+	var groupID *model.VariantGroupID
+	if params.GroupID != nil {
+		id := model.VariantGroupID(*params.GroupID)
+		groupID = &id
+	}
+	query, err := service.NewListVariants(page, service.VariantSortKey(params.Sort), groupID)
+	if err != nil {
+		return err
+	}
+
+	variants, err := v.VariantUsecase.List(c.Request().Context(), query)
 	if err != nil {
 		return err
 	}
-	if err = c.JSON(http.StatusOK, NewVariantValues(variants)); err != nil {
+	if err = c.JSON(http.StatusOK, NewPageValue(*variants, view.NewVariantValue)); err != nil {
 		return err
 	}
 	return nil
@@ -63,7 +101,7 @@ func (v Variant) List(c echo.Context) error {
 
 type createBody struct {
 	GroupID int    `json:"group_id" validate:"required"`
-	Name    string `json:"name" validate:"required"`
+	Name    string `json:"name" validate:"required,max=100"`
 }
 
 func (v Variant) Create(c echo.Context) error {
@@ -71,6 +109,9 @@ func (v Variant) Create(c echo.Context) error {
 	if err := c.Bind(&body); err != nil {
 		return err
 	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
 
 	variant, err := v.VariantUsecase.Create(
 		c.Request().Context(),
@@ -83,16 +124,16 @@ func (v Variant) Create(c echo.Context) error {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantValue(*variant)); err != nil {
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
 		return err
 	}
 	return nil
 }
 
 type updateBody struct {
-	VariantID int    `param:"id" validator:"required"`
+	VariantID int    `param:"id" validate:"required"`
 	GroupID   int    `json:"group_id" validate:"required"`
-	Name      string `json:"name" validate:"required"`
+	Name      string `json:"name" validate:"required,max=100"`
 }
 
 func (v Variant) Update(c echo.Context) error {
@@ -100,28 +141,55 @@ func (v Variant) Update(c echo.Context) error {
 	if err := c.Bind(&body); err != nil {
 		return err
 	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
 	variant, err := v.VariantUsecase.Update(
 		c.Request().Context(),
-		service.NewUpdateVariant(model.VariantID(body.VariantID), model.VariantGroupID(body.GroupID), model.Name(body.Name)),
+		service.NewUpdateVariant(
+			model.VariantID(body.VariantID), model.VariantGroupID(body.GroupID), model.Name(body.Name), version,
+		),
 	)
 	if err != nil {
 		return err
 	}
 
-	if err = c.JSON(http.StatusOK, NewVariantValue(*variant)); err != nil {
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
 		return err
 	}
 	return nil
 }
 
+// deleteVariantParams ユニークに付与されている場合、cascadeではユニークから外してから削除する。バリアントが無くなるユニークがある場合はConflictとする
+type deleteVariantParams struct {
+	referenceParams
+
+	Cascade bool `query:"cascade"`
+}
+
 func (v Variant) Delete(c echo.Context) error {
-	var params referenceParams
+	var params deleteVariantParams
 	if err := c.Bind(&params); err != nil {
 		return err
 	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
 
-	err := v.VariantUsecase.Delete(c.Request().Context(), model.VariantID(params.VariantID))
+	err = v.VariantUsecase.Delete(
+		c.Request().Context(), service.NewDeleteVariant(model.VariantID(params.VariantID), version, params.Cascade),
+	)
 	if err != nil {
 		return err
 	}
@@ -132,32 +200,185 @@ func (v Variant) Delete(c echo.Context) error {
 	return nil
 }
 
-type VariantValue struct {
-	ID    model.VariantID        `json:"id" validator:"required"`
-	Name  model.Name             `json:"name" validator:"required"`
-	Group model.VariantGroupName `json:"group" validator:"required"`
+func (v Variant) Restore(c echo.Context) error {
+	var params referenceParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	variant, err := v.VariantUsecase.Restore(c.Request().Context(), model.VariantID(params.VariantID))
+	if err != nil {
+		return err
+	}
+
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type createDescriptionBody struct {
+	VariantID   int    `param:"id" validate:"required"`
+	Description string `json:"description" validate:"required,max=500"`
 }
 
-func NewVariantValue(v model.Variant) VariantValue {
-	return VariantValue{
-		ID:    v.ID(),
-		Name:  v.Name(),
-		Group: v.Group(),
+func (v Variant) CreateDescription(c echo.Context) error {
+	var body createDescriptionBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	text, err := model.NewDescriptionText(body.Description)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	variant, err := v.VariantUsecase.CreateDescription(
+		c.Request().Context(),
+		service.NewCreateDescription(model.VariantID(body.VariantID), version, text),
+	)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
+		return err
 	}
+	return nil
+}
+
+type replaceDescriptionsBody struct {
+	VariantID    int      `param:"id" validate:"required"`
+	Descriptions []string `json:"descriptions"`
+}
+
+func (v Variant) ReplaceDescriptions(c echo.Context) error {
+	var body replaceDescriptionsBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	texts := make([]model.DescriptionText, 0, len(body.Descriptions))
+	for _, d := range body.Descriptions {
+		text, err := model.NewDescriptionText(d)
+		if err != nil {
+			return logic.WrapInvalidArgument(err)
+		}
+		texts = append(texts, text)
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	variant, err := v.VariantUsecase.ReplaceDescriptions(
+		c.Request().Context(),
+		service.NewReplaceDescriptions(model.VariantID(body.VariantID), version, texts),
+	)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type updateDescriptionBody struct {
+	VariantID     int    `param:"id" validate:"required"`
+	DescriptionID int    `param:"description_id" validate:"required"`
+	Description   string `json:"description" validate:"required,max=500"`
 }
 
-type VariantValues []VariantValue
+func (v Variant) UpdateDescription(c echo.Context) error {
+	var body updateDescriptionBody
+	if err := c.Bind(&body); err != nil {
+		return err
+	}
+	if err := c.Validate(&body); err != nil {
+		return err
+	}
+	text, err := model.NewDescriptionText(body.Description)
+	if err != nil {
+		return logic.WrapInvalidArgument(err)
+	}
 
-func NewVariantValues(vs []model.Variant) (values VariantValues) {
-	for _, v := range vs {
-		values = append(
-			values,
-			VariantValue{
-				ID:    v.ID(),
-				Name:  v.Name(),
-				Group: v.Group(),
-			},
-		)
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
 	}
-	return values
+
+	variant, err := v.VariantUsecase.UpdateDescription(
+		c.Request().Context(),
+		service.NewUpdateDescription(
+			model.VariantID(body.VariantID),
+			version,
+			model.DescriptionID(body.DescriptionID),
+			text,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
+		return err
+	}
+	return nil
+}
+
+type descriptionParams struct {
+	VariantID     int `param:"id" validate:"required"`
+	DescriptionID int `param:"description_id" validate:"required"`
+}
+
+func (v Variant) DeleteDescription(c echo.Context) error {
+	var params descriptionParams
+	if err := c.Bind(&params); err != nil {
+		return err
+	}
+	if err := c.Validate(&params); err != nil {
+		return err
+	}
+
+	version, err := ifMatch(c)
+	if err != nil {
+		return err
+	}
+
+	variant, err := v.VariantUsecase.DeleteDescription(
+		c.Request().Context(),
+		service.NewDeleteDescription(
+			model.VariantID(params.VariantID),
+			version,
+			model.DescriptionID(params.DescriptionID),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	setETag(c, variant.Version())
+	if err = c.JSON(http.StatusOK, view.NewVariantValue(*variant)); err != nil {
+		return err
+	}
+	return nil
 }
@@ -1,11 +1,12 @@
 package server
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
 
+	"github.com/golang-migrate/migrate/v4"
 	"github.com/jmoiron/sqlx"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -13,7 +14,18 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"mods-explore/ark/omega"
+	"mods-explore/ark/omega/logic"
+	auditUsecase "mods-explore/ark/omega/logic/audit/usecase"
+	authUsecase "mods-explore/ark/omega/logic/auth/usecase"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
 	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	proposalUsecase "mods-explore/ark/omega/logic/proposal/usecase"
+	searchUsecase "mods-explore/ark/omega/logic/search/usecase"
+	transferUsecase "mods-explore/ark/omega/logic/transfer/usecase"
+	translationUsecase "mods-explore/ark/omega/logic/translation/usecase"
+	trashUsecase "mods-explore/ark/omega/logic/trash/usecase"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
 	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
 	"mods-explore/ark/omega/server/handlers"
 	"mods-explore/ark/omega/storage"
@@ -26,13 +38,20 @@ func Run() {
 		return
 	}
 
+	env := do.MustInvoke[omega.Environments](injector)
+	if env.AutoMigrate {
+		if err = storage.MigrateDatabase(env.DSN(), storage.MigrateUp()); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+			logrus.Fatal(err)
+			return
+		}
+	}
+
 	s, err := newServer(injector)
 	if err != nil {
 		logrus.Fatal(err)
 		return
 	}
 
-	env := do.MustInvoke[omega.Environments](injector)
 	if err = s.Start(env.Address); err != nil {
 		logrus.Fatal(err)
 	}
@@ -41,17 +60,44 @@ func Run() {
 func newServer(injector *do.Injector) (*echo.Echo, error) {
 	s := echo.New()
 	s.HideBanner = true
+	s.Use(middleware.RequestID())
 	s.Use(middleware.Recover())
-	s.Use(middleware.CORS())
+	s.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		// 更新時にIf-Matchへ指定できるよう、ブラウザからETagを読めるようにする
+		ExposeHeaders: []string{handlers.HeaderETag},
+	}))
+	s.Use(handlers.Localizer())
+	s.Validator = handlers.NewValidator()
 	s.HTTPErrorHandler = handlers.NewErrorHandler(s)
 
 	s.GET("/health", func(c echo.Context) error {
 		return c.String(http.StatusOK, "I'm fine!")
 	})
+	s.GET("/api/openapi.json", handlers.OpenAPI)
+	s.GET("/api/docs", handlers.Docs)
+
+	{
+		// ブラウザで閲覧する画面。未登録のパスをAPIのエラーのまま返すため、グループにはしない
+		pages := do.MustInvoke[handlers.PageHandler](injector)
+		web := []echo.MiddlewareFunc{handlers.Transctioner(injector), pages.Errors}
+		s.GET("/", pages.Uniques, web...)
+		s.GET("/uniques/:id", pages.Unique, web...)
+		s.GET("/variants/:id", pages.Variant, web...)
+		s.GET("/groups/:id", pages.VariantGroup, web...)
+		s.GET("/search", pages.Search, web...)
+		s.GET("/static/:name", pages.Static, pages.Errors)
+	}
+
+	audit := do.MustInvoke[handlers.AuditHandler](injector)
+	// 参照は匿名でも行え、更新にはeditor、バリアントの規則の更新にはadmin、ゴミ箱の参照にはviewerのAPIトークンを求める
+	authenticator := handlers.Authenticator(injector)
+	editable := handlers.Authorizer(logic.RoleAnonymous, logic.RoleEditor)
 
 	variantsV1 := s.Group(
 		"/api/v1/variants",
 		handlers.Transctioner(injector),
+		authenticator,
+		editable,
 	)
 	{ // variant
 		handler := do.MustInvoke[handlers.VariantHandler](injector)
@@ -60,11 +106,19 @@ func newServer(injector *do.Injector) (*echo.Echo, error) {
 		variantsV1.POST("/new", handler.Create)
 		variantsV1.PUT("/:id", handler.Update)
 		variantsV1.DELETE("/:id", handler.Delete)
+		variantsV1.POST("/:id/restore", handler.Restore)
+		variantsV1.POST("/:id/descriptions", handler.CreateDescription)
+		variantsV1.PUT("/:id/descriptions", handler.ReplaceDescriptions)
+		variantsV1.PUT("/:id/descriptions/:description_id", handler.UpdateDescription)
+		variantsV1.DELETE("/:id/descriptions/:description_id", handler.DeleteDescription)
+		variantsV1.GET("/:id/history", audit.History(logic.AuditVariant))
 	}
 
 	variantGroupsV1 := s.Group(
 		"/api/v1/variant-groups",
 		handlers.Transctioner(injector),
+		authenticator,
+		editable,
 	)
 	{ // variant group
 		handler := do.MustInvoke[handlers.VariantGroupHandler](injector)
@@ -73,11 +127,47 @@ func newServer(injector *do.Injector) (*echo.Echo, error) {
 		variantGroupsV1.POST("/new", handler.Create)
 		variantGroupsV1.PUT("/:id", handler.Update)
 		variantGroupsV1.DELETE("/:id", handler.Delete)
+		variantGroupsV1.POST("/:id/restore", handler.Restore)
+		variantGroupsV1.GET("/:id/history", audit.History(logic.AuditGroup))
+	}
+	{
+		variantRulesV1 := s.Group(
+			"/api/v1/variant-rules",
+			handlers.Transctioner(injector),
+			authenticator,
+			handlers.Authorizer(logic.RoleAnonymous, logic.RoleAdmin),
+		)
+		handler := do.MustInvoke[handlers.VariantRuleHandler](injector)
+		variantRulesV1.GET("/:id", handler.Read)
+		variantRulesV1.GET("", handler.List)
+		variantRulesV1.POST("/new", handler.Create)
+		variantRulesV1.PUT("/:id", handler.Update)
+		variantRulesV1.DELETE("/:id", handler.Delete)
+		variantRulesV1.GET("/:id/history", audit.History(logic.AuditRule))
+	}
+	{
+		dinosaursV1 := s.Group(
+			"/api/v1/dinosaurs",
+			handlers.Transctioner(injector),
+			authenticator,
+			editable,
+		)
+		handler := do.MustInvoke[handlers.DinosaurHandler](injector)
+		dinosaursV1.GET("/:id", handler.Read)
+		dinosaursV1.GET("", handler.List)
+		dinosaursV1.POST("/new", handler.Create)
+		dinosaursV1.PUT("/:id", handler.Update)
+		dinosaursV1.DELETE("/:id", handler.Delete)
+		dinosaursV1.POST("/:id/restore", handler.Restore)
+		dinosaursV1.GET("/:id/uniques", handler.ListUniques)
+		dinosaursV1.GET("/:id/history", audit.History(logic.AuditDinosaur))
 	}
 	{
 		uniquesV1 := s.Group(
 			"/api/v1/uniques",
 			handlers.Transctioner(injector),
+			authenticator,
+			editable,
 		)
 		handler := do.MustInvoke[handlers.UniqueHandler](injector)
 		uniquesV1.GET("/:id", handler.ReadUnique)
@@ -85,6 +175,74 @@ func newServer(injector *do.Injector) (*echo.Echo, error) {
 		uniquesV1.POST("/new", handler.CreateUnique)
 		uniquesV1.PUT("/:id", handler.UpdateUnique)
 		uniquesV1.DELETE("/:id", handler.DeleteUnique)
+		uniquesV1.POST("/:id/restore", handler.RestoreUnique)
+		uniquesV1.GET("/:id/stats", handler.UniqueStats)
+		uniquesV1.GET("/:id/history", audit.History(logic.AuditUnique))
+	}
+	{
+		displayNamesV1 := s.Group(
+			"/api/v1/display-names",
+			handlers.Transctioner(injector),
+			authenticator,
+			editable,
+		)
+		handler := do.MustInvoke[handlers.DisplayNameHandler](injector)
+		displayNamesV1.GET("/:target/:id", handler.List)
+		displayNamesV1.PUT("/:target/:id/:locale", handler.Put)
+		displayNamesV1.DELETE("/:target/:id/:locale", handler.Delete)
+	}
+	{
+		searchV1 := s.Group(
+			"/api/v1/search",
+			handlers.Transctioner(injector),
+			authenticator,
+			editable,
+		)
+		handler := do.MustInvoke[handlers.SearchHandler](injector)
+		searchV1.GET("", handler.Search)
+	}
+	{
+		auditV1 := s.Group(
+			"/api/v1/audit",
+			handlers.Transctioner(injector),
+			authenticator,
+			editable,
+		)
+		auditV1.GET("", audit.List)
+	}
+	{
+		trashV1 := s.Group(
+			"/api/v1/trash",
+			handlers.Transctioner(injector),
+			authenticator,
+			handlers.Authorizer(logic.RoleViewer, logic.RoleEditor),
+		)
+		handler := do.MustInvoke[handlers.TrashHandler](injector)
+		trashV1.GET("", handler.List)
+	}
+	{
+		// 変更の提案は誰でも提出できる。提出された提案には利用者の投稿内容が含まれるため、
+		// 一覧と取得は承認・却下と同じく編集者のみが行える
+		proposalsV1 := s.Group(
+			"/api/v1/proposals",
+			handlers.Transctioner(injector),
+			authenticator,
+		)
+		// 読み取り(GET)と書き込み(POST)のどちらにも編集者の権限を求める
+		moderator := handlers.Authorizer(logic.RoleEditor, logic.RoleEditor)
+		handler := do.MustInvoke[handlers.ProposalHandler](injector)
+		proposalsV1.GET("", handler.List, moderator)
+		proposalsV1.GET("/:id", handler.Read, moderator)
+		proposalsV1.POST("/new", handler.Submit)
+		proposalsV1.POST("/:id/approve", handler.Approve, moderator)
+		proposalsV1.POST("/:id/reject", handler.Reject, moderator)
+	}
+	{
+		// 一括の書き出しと取り込み。1つのパスのみのため、グループにはしない
+		handler := do.MustInvoke[handlers.TransferHandler](injector)
+		transfer := []echo.MiddlewareFunc{handlers.Transctioner(injector), authenticator, editable}
+		s.GET("/api/v1/export", handler.Export, transfer...)
+		s.POST("/api/v1/import", handler.Import, transfer...)
 	}
 
 	return s, nil
@@ -95,27 +253,33 @@ func Wired() (*do.Injector, error) {
 
 	do.Provide(injector, func(_ *do.Injector) (omega.Environments, error) {
 		conf, err := omega.LoadConfig()
-		return *conf, err
+		if err != nil {
+			return omega.Environments{}, err
+		}
+		return *conf, nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*sqlx.DB, error) {
 		env := do.MustInvoke[omega.Environments](i)
-		dns := fmt.Sprintf(
-			"postgres://%s:%s@%s:%d/%s?sslmode=disable",
-			env.DBUsername,
-			env.DBPassword,
-			env.DatabaseURL,
-			env.Port,
-			env.DatabaseName,
-		)
-		return storage.ConnectPostgres(dns)
+		return storage.ConnectPostgres(env.DSN())
 	})
 
 	do.ProvideValue(injector, slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
 	do.Provide(injector, storage.NewSQLxClient)
 
+	do.Provide(injector, storage.NewAPITokenClient)
+	do.Provide(injector, authUsecase.NewToken)
+
+	do.Provide(injector, storage.NewAuditClient)
+	do.Provide(injector, auditUsecase.NewAudit)
+	do.Provide(injector, func(i *do.Injector) (logic.AuditRecorder, error) {
+		return do.MustInvoke[auditUsecase.AuditUsecase](i), nil
+	})
+	do.Provide(injector, handlers.NewAudit)
+
 	do.Provide(injector, storage.NewVariantClient)
+	do.Provide(injector, storage.NewVariantDescriptionClient)
 	do.Provide(injector, variantUsecase.NewVariant)
 	do.Provide(injector, handlers.NewVariant)
 
@@ -123,12 +287,72 @@ func Wired() (*do.Injector, error) {
 	do.Provide(injector, variantUsecase.NewVariantGroup)
 	do.Provide(injector, handlers.NewVariantGroup)
 
+	do.Provide(injector, storage.NewVariantRuleClient)
+	do.Provide(injector, variantUsecase.NewVariantRule)
+	do.Provide(injector, handlers.NewVariantRule)
+	do.Provide(injector, func(i *do.Injector) (creatureSvc.VariantRuleValidator, error) {
+		return do.MustInvoke[variantUsecase.VariantRuleUsecase](i), nil
+	})
+
 	do.Provide(injector, storage.NewUniqueQueryRepo)
 	do.Provide(injector, storage.NewUniqueCommandRepo)
 	do.Provide(injector, storage.NewUniqueVariantsClient)
 	do.Provide(injector, storage.NewDinosaurClient)
+	do.Provide(injector, storage.NewDinosaurQueryClient)
+	do.Provide(injector, storage.NewStatGrowthClient)
+	do.Provide(injector, newServerStatMultipliers)
+	do.Provide(injector, func(i *do.Injector) (creatureModel.MaxUniqueVariants, error) {
+		return creatureModel.NewMaxUniqueVariants(do.MustInvoke[omega.Environments](i).MaxUniqueVariants)
+	})
 	do.Provide(injector, creatureUsecase.NewUnique)
+	do.Provide(injector, func(i *do.Injector) (variantSvc.UniqueVariantDetacher, error) {
+		return do.MustInvoke[creatureUsecase.UniqueUsecase](i), nil
+	})
 	do.Provide(injector, handlers.NewUnique)
+	do.Provide(injector, creatureUsecase.NewDinosaur)
+	do.Provide(injector, handlers.NewDinosaur)
+
+	do.Provide(injector, storage.NewSearchClient)
+	do.Provide(injector, searchUsecase.NewSearch)
+	do.Provide(injector, handlers.NewSearch)
+
+	do.Provide(injector, storage.NewDisplayNameClient)
+	do.Provide(injector, translationUsecase.NewDisplayName)
+	do.Provide(injector, handlers.NewDisplayName)
+
+	do.Provide(injector, storage.NewTrashClient)
+	do.Provide(injector, trashUsecase.NewTrash)
+	do.Provide(injector, handlers.NewTrash)
+
+	do.Provide(injector, handlers.NewPages)
+
+	do.Provide(injector, storage.NewProposalClient)
+	do.Provide(injector, handlers.NewProposalEditors)
+	do.Provide(injector, proposalUsecase.NewProposal)
+	do.Provide(injector, handlers.NewProposal)
+
+	do.Provide(injector, handlers.NewTransferCatalog)
+	do.Provide(injector, transferUsecase.NewTransfer)
+	do.Provide(injector, handlers.NewTransfer)
 
 	return injector, nil
 }
+
+func newServerStatMultipliers(i *do.Injector) (creatureModel.ServerStatMultipliers, error) {
+	env := do.MustInvoke[omega.Environments](i)
+
+	var multipliers [4]map[creatureModel.StatKind]float32
+	for n, conf := range [4]map[string]float32{
+		env.WildLevelMultipliers, env.TamedLevelMultipliers, env.TamedAddMultipliers, env.TamedAffinityMultipliers,
+	} {
+		multipliers[n] = make(map[creatureModel.StatKind]float32, len(conf))
+		for k, v := range conf {
+			kind, err := creatureModel.NewStatKind(k)
+			if err != nil {
+				return creatureModel.ServerStatMultipliers{}, err
+			}
+			multipliers[n][kind] = v
+		}
+	}
+	return creatureModel.NewServerStatMultipliers(multipliers[0], multipliers[1], multipliers[2], multipliers[3]), nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega"
+	"mods-explore/ark/omega/server/handlers"
+)
+
+// Test_OpenAPIRoutes newServerで登録したルーティングとOpenAPIのドキュメントが一致しているかのテスト
+func Test_OpenAPIRoutes(t *testing.T) {
+	injector, err := Wired()
+	if err != nil {
+		t.Fatal(err)
+	}
+	do.OverrideValue(injector, omega.Environments{UniqueConfig: omega.UniqueConfig{MaxUniqueVariants: 2}})
+	// sqlx.Openは接続しないため、DBが無くてもルーティングを組み立てられる
+	do.Override(injector, func(_ *do.Injector) (*sqlx.DB, error) {
+		return sqlx.Open("postgres", "")
+	})
+
+	s, err := newServer(injector)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registered := map[string]bool{}
+	for _, r := range s.Routes() {
+		if !isHTTPMethod(r.Method) {
+			continue
+		}
+		registered[r.Method+" "+handlers.OpenAPIPath(r.Path)] = true
+	}
+	documented := map[string]bool{}
+	for _, r := range handlers.OpenAPIRoutes(handlers.NewOpenAPIDocument()) {
+		documented[r] = true
+	}
+
+	for _, r := range sortedKeys(registered) {
+		if !documented[r] {
+			t.Errorf("%sがOpenAPIのドキュメントにありません", r)
+		}
+	}
+	for _, r := range sortedKeys(documented) {
+		if !registered[r] {
+			t.Errorf("%sがルーティングに登録されていません", r)
+		}
+	}
+}
+
+// isHTTPMethod グループのミドルウェア用にechoが登録するRouteNotFoundのルートを除く
+func isHTTPMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
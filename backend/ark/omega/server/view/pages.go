@@ -0,0 +1,169 @@
+package view
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strconv"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+)
+
+//go:embed templates/*.html static/*
+var webFS embed.FS
+
+var funcs = template.FuncMap{
+	"t": func(l i18n.Locale, text string) string { return i18n.Text(l, text) },
+	// multiplier 倍率は小数点以下の不要な0を付けずに表示する
+	"multiplier": func(v float32) string { return strconv.FormatFloat(float64(v), 'f', -1, 32) },
+	"stat":       func(v float32) string { return strconv.FormatFloat(float64(v), 'f', 1, 32) },
+}
+
+// Templates 指定した画面のテンプレート。layout.htmlと組み合わせて画面毎に解析する
+func Templates(names ...string) (map[string]*template.Template, error) {
+	templates := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		t, err := template.New(name).Funcs(funcs).ParseFS(webFS, "templates/layout.html", "templates/"+name)
+		if err != nil {
+			return nil, err
+		}
+		templates[name] = t
+	}
+	return templates, nil
+}
+
+// Static 画面のスタイルシートとスクリプト。cmd/export-siteで書き出す静的なサイトでも用いる
+func Static() (fs.FS, error) {
+	return fs.Sub(webFS, "static")
+}
+
+// Links 画面内のリンクの形式。サーバーの画面はルートからの絶対パス、書き出したサイトは任意の場所に置けるよう画面からの相対パスにする
+type Links struct {
+	// Root 画面からルートへのパス。サーバーでは"/"、書き出したサイトでは"../../"のように画面の深さで変わる
+	Root string
+	// Export 静的なサイトとして書き出す場合にtrue。ディレクトリへのリンクにindex.htmlを付け、検索はクライアント側で行う
+	Export bool
+}
+
+// ServerLinks サーバーの画面のリンク
+var ServerLinks = Links{Root: "/"}
+
+func (l Links) dir(path string) string {
+	if l.Export {
+		return l.Root + path + "/index.html"
+	}
+	return l.Root + path
+}
+
+func (l Links) Home() string {
+	if l.Export {
+		return l.Root + "index.html"
+	}
+	return l.Root
+}
+
+func (l Links) Search() string           { return l.dir("search") }
+func (l Links) Unique(id any) string     { return l.dir(fmt.Sprintf("uniques/%v", id)) }
+func (l Links) Variant(id any) string    { return l.dir(fmt.Sprintf("variants/%v", id)) }
+func (l Links) Group(id any) string      { return l.dir(fmt.Sprintf("groups/%v", id)) }
+func (l Links) Asset(name string) string { return l.Root + "static/" + name }
+
+// GroupUniques バリアントグループで絞り込んだユニークの一覧
+func (l Links) GroupUniques(id any) string { return fmt.Sprintf("%s?group_id=%v", l.Home(), id) }
+
+// JSON 書き出したサイトに含める、APIのレスポンスと同じ形式のJSON
+func (l Links) JSON(kind string, id any) string {
+	return fmt.Sprintf("%sapi/%s/%v.json", l.Root, kind, id)
+}
+
+// SearchIndex 書き出したサイトでクライアント側の検索に用いる索引
+func (l Links) SearchIndex() string { return l.Root + "search-index.json" }
+
+// Meta 全ての画面で共通のレイアウトに渡す値
+type Meta struct {
+	Locale i18n.Locale
+	Q      string
+	Links  Links
+}
+
+// PageLink 絞り込みや並び替えのリンク
+type PageLink struct {
+	Label  string
+	URL    string
+	Active bool
+}
+
+type UniqueRow struct {
+	UniqueValue
+	// GroupIDs 書き出したサイトで画面内の絞り込みに用いる、付与されたバリアントのグループの空白区切り
+	GroupIDs string
+}
+
+type UniquesPage struct {
+	Meta
+	Groups   []PageLink
+	Sorts    []PageLink
+	Filtered bool
+	Uniques  []UniqueRow
+	NextURL  string
+}
+
+// StatRow 元の生物のステータスと、倍率を掛けたユニークのステータス
+type StatRow struct {
+	Label      string
+	Base       uint
+	Multiplier float32
+	Value      float32
+}
+
+func NewStatRows(unique creatureModel.UniqueDinosaur) []StatRow {
+	stats := unique.Dinosaur.Stats()
+	multipliers := unique.Multipliers()
+	return []StatRow{
+		{"体力", stats.Health().Value(), multipliers.Health().Value(), unique.Health().Value()},
+		{"スタミナ", stats.Stamina().Value(), multipliers.Stamina().Value(), unique.Stamina().Value()},
+		{"酸素", stats.Oxygen().Value(), multipliers.Oxygen().Value(), unique.Oxygen().Value()},
+		{"食料", stats.Food().Value(), multipliers.Food().Value(), unique.Food().Value()},
+		{"重量", stats.Weight().Value(), multipliers.Weight().Value(), unique.Weight().Value()},
+		{"近接攻撃", stats.Melee().Value(), multipliers.Melee().Value(), unique.Damage().Value()},
+		{"移動速度", stats.MovementSpeed().Value(), multipliers.MovementSpeed().Value(), unique.MovementSpeed().Value()},
+		{"気絶値", stats.Torpidity().Value(), multipliers.Torpidity().Value(), unique.Torpidity().Value()},
+		{"防御", stats.Armor().Value(), multipliers.Armor().Value(), unique.Armor().Value()},
+	}
+}
+
+type UniquePage struct {
+	Meta
+	Unique UniqueValue
+	Stats  []StatRow
+}
+
+type VariantPage struct {
+	Meta
+	Variant VariantValue
+	Uniques []UniqueValue
+	MoreURL string
+}
+
+type VariantGroupPage struct {
+	Meta
+	Group    VariantGroupValue
+	Variants []VariantValue
+}
+
+type SearchHit struct {
+	Type    string
+	Label   string
+	Name    string
+	URL     string
+	Snippet template.HTML
+}
+
+// SearchPage 書き出したサイトでは検索せず、索引を読み込むスクリプトが結果を表示する
+type SearchPage struct {
+	Meta
+	Searched bool
+	Hits     []SearchHit
+}
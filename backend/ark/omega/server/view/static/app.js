@@ -0,0 +1,16 @@
+"use strict";
+
+// data-filterに指定した表の行を、入力した文字列を含むものに絞り込む
+document.querySelectorAll("input[data-filter]").forEach((input) => {
+  const table = document.querySelector(input.dataset.filter);
+  if (!table) {
+    return;
+  }
+  const rows = Array.from(table.tBodies[0].rows);
+  input.addEventListener("input", () => {
+    const keyword = input.value.trim().toLowerCase();
+    rows.forEach((row) => {
+      row.hidden = keyword !== "" && !row.textContent.toLowerCase().includes(keyword);
+    });
+  });
+});
@@ -0,0 +1,98 @@
+package view
+
+import (
+	"github.com/samber/lo"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+)
+
+// UniqueValue 名前は保存された値で、表示にはbase_display_name、unique_display_nameを用いる
+type UniqueValue struct {
+	UniqueID                int                   `json:"id"`
+	BaseID                  int                   `json:"base_id"`
+	BaseName                string                `json:"base_name"`
+	BaseDisplayName         string                `json:"base_display_name"`
+	BaseHealth              uint                  `json:"base_health"`
+	BaseStamina             uint                  `json:"base_stamina"`
+	BaseOxygen              uint                  `json:"base_oxygen"`
+	BaseFood                uint                  `json:"base_food"`
+	BaseWeight              uint                  `json:"base_weight"`
+	BaseMelee               uint                  `json:"base_melee"`
+	BaseMovementSpeed       uint                  `json:"base_movement_speed"`
+	BaseTorpidity           uint                  `json:"base_torpidity"`
+	BaseArmor               uint                  `json:"base_armor"`
+	UniqueName              string                `json:"unique_name"`
+	UniqueDisplayName       string                `json:"unique_display_name"`
+	HealthMultiplier        float32               `json:"health_multiplier"`
+	StaminaMultiplier       float32               `json:"stamina_multiplier"`
+	OxygenMultiplier        float32               `json:"oxygen_multiplier"`
+	FoodMultiplier          float32               `json:"food_multiplier"`
+	WeightMultiplier        float32               `json:"weight_multiplier"`
+	DamageMultiplier        float32               `json:"damage_multiplier"`
+	MovementSpeedMultiplier float32               `json:"movement_speed_multiplier"`
+	TorpidityMultiplier     float32               `json:"torpidity_multiplier"`
+	ArmorMultiplier         float32               `json:"armor_multiplier"`
+	UniqueVariants          []UniqueVariantsValue `json:"unique_variants"`
+}
+
+// UniqueVariantsValue 付与された順序で返す
+type UniqueVariantsValue struct {
+	VariantID               int      `json:"variant_id"`
+	VariantName             string   `json:"variant_name"`
+	VariantDisplayName      string   `json:"variant_display_name"`
+	VariantGroupName        string   `json:"group_name"`
+	VariantGroupDisplayName string   `json:"group_display_name"`
+	Descriptions            []string `json:"descriptions"`
+}
+
+func NewUniqueValue(unique creatureModel.UniqueDinosaur) UniqueValue {
+	variants := lo.Map(unique.UniqueVariant(), func(v creatureModel.DinosaurVariant, _ int) UniqueVariantsValue {
+		return UniqueVariantsValue{
+			VariantID:               v.ID().Value(),
+			VariantName:             v.Name().Value(),
+			VariantDisplayName:      v.DisplayName().Value(),
+			VariantGroupName:        v.Group().Value(),
+			VariantGroupDisplayName: v.GroupDisplayName().Value(),
+			Descriptions: lo.Map(v.Descriptions(), func(d creatureModel.VariantDescription, _ int) string {
+				return string(d)
+			}),
+		}
+	})
+	stats := unique.Dinosaur.Stats()
+	multipliers := unique.Multipliers()
+	return UniqueValue{
+		UniqueID:                unique.UniqueID().Value(),
+		BaseID:                  unique.Dinosaur.BaseID().Value(),
+		BaseName:                unique.Dinosaur.BaseName().Value(),
+		BaseDisplayName:         unique.Dinosaur.BaseDisplayName().Value(),
+		BaseHealth:              stats.Health().Value(),
+		BaseStamina:             stats.Stamina().Value(),
+		BaseOxygen:              stats.Oxygen().Value(),
+		BaseFood:                stats.Food().Value(),
+		BaseWeight:              stats.Weight().Value(),
+		BaseMelee:               stats.Melee().Value(),
+		BaseMovementSpeed:       stats.MovementSpeed().Value(),
+		BaseTorpidity:           stats.Torpidity().Value(),
+		BaseArmor:               stats.Armor().Value(),
+		UniqueName:              unique.UniqueName().Value(),
+		UniqueDisplayName:       unique.UniqueDisplayName().Value(),
+		HealthMultiplier:        multipliers.Health().Value(),
+		StaminaMultiplier:       multipliers.Stamina().Value(),
+		OxygenMultiplier:        multipliers.Oxygen().Value(),
+		FoodMultiplier:          multipliers.Food().Value(),
+		WeightMultiplier:        multipliers.Weight().Value(),
+		DamageMultiplier:        multipliers.Melee().Value(),
+		MovementSpeedMultiplier: multipliers.MovementSpeed().Value(),
+		TorpidityMultiplier:     multipliers.Torpidity().Value(),
+		ArmorMultiplier:         multipliers.Armor().Value(),
+		UniqueVariants:          variants,
+	}
+}
+
+type UniqueValues []UniqueValue
+
+func NewUniqueValues(uniques creatureModel.UniqueDinosaurs) UniqueValues {
+	return lo.Map(uniques, func(u creatureModel.UniqueDinosaur, _ int) UniqueValue {
+		return NewUniqueValue(u)
+	})
+}
@@ -0,0 +1,29 @@
+package view
+
+import (
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+// VariantGroupValue nameは保存された名前で、表示にはdisplay_nameを用いる
+type VariantGroupValue struct {
+	ID          model.VariantGroupID   `json:"id"`
+	Name        model.VariantGroupName `json:"name"`
+	DisplayName model.VariantGroupName `json:"display_name"`
+}
+
+func NewVariantGroupValue(v model.VariantGroup) VariantGroupValue {
+	return VariantGroupValue{
+		ID:          v.ID(),
+		Name:        v.Name(),
+		DisplayName: v.DisplayName(),
+	}
+}
+
+type VariantGroupValues []VariantGroupValue
+
+func NewVariantGroupValues(vs []model.VariantGroup) (values VariantGroupValues) {
+	for _, v := range vs {
+		values = append(values, NewVariantGroupValue(v))
+	}
+	return values
+}
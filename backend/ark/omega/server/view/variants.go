@@ -0,0 +1,46 @@
+package view
+
+import (
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+type VariantDescriptionValue struct {
+	ID   model.DescriptionID   `json:"id"`
+	Text model.DescriptionText `json:"text"`
+}
+
+// VariantValue name、groupは保存された名前で、更新のリクエストにそのまま使える。表示にはdisplay_nameを用いる
+type VariantValue struct {
+	ID               model.VariantID           `json:"id"`
+	Name             model.Name                `json:"name"`
+	DisplayName      model.Name                `json:"display_name"`
+	GroupID          model.VariantGroupID      `json:"group_id"`
+	Group            model.VariantGroupName    `json:"group"`
+	GroupDisplayName model.VariantGroupName    `json:"group_display_name"`
+	Descriptions     []VariantDescriptionValue `json:"descriptions"`
+}
+
+func NewVariantValue(v model.Variant) VariantValue {
+	return VariantValue{
+		ID:               v.ID(),
+		Name:             v.Name(),
+		DisplayName:      v.DisplayName(),
+		GroupID:          v.GroupID(),
+		Group:            v.Group(),
+		GroupDisplayName: v.GroupDisplayName(),
+		Descriptions: lo.Map(v.Descriptions(), func(d model.Description, _ int) VariantDescriptionValue {
+			return VariantDescriptionValue{ID: d.ID(), Text: d.Text()}
+		}),
+	}
+}
+
+type VariantValues []VariantValue
+
+func NewVariantValues(vs []model.Variant) (values VariantValues) {
+	for _, v := range vs {
+		values = append(values, NewVariantValue(v))
+	}
+	return values
+}
@@ -0,0 +1,230 @@
+package site
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/server/view"
+)
+
+//go:embed static/*
+var siteFS embed.FS
+
+// パーマリンク。サーバーの画面と同じパスをディレクトリとして書き出し、静的なホスティングでも同じURLで開けるようにする
+func uniquePath(id int) string  { return fmt.Sprintf("uniques/%d/index.html", id) }
+func variantPath(id int) string { return fmt.Sprintf("variants/%d/index.html", id) }
+func groupPath(id int) string   { return fmt.Sprintf("groups/%d/index.html", id) }
+
+// 個別のJSON。APIのレスポンスと同じ形式で書き出す
+func uniqueJSONPath(id int) string  { return fmt.Sprintf("api/uniques/%d.json", id) }
+func variantJSONPath(id int) string { return fmt.Sprintf("api/variants/%d.json", id) }
+func groupJSONPath(id int) string   { return fmt.Sprintf("api/groups/%d.json", id) }
+
+const (
+	indexPath       = "index.html"
+	searchPath      = "search/index.html"
+	searchIndexPath = "search-index.json"
+)
+
+// siteTemplates サーバーの画面と共通のテンプレート。一覧の画面をトップとして書き出す
+var siteTemplates = []string{"uniques.html", "unique.html", "variant.html", "group.html", "search.html"}
+
+// links 書き出したサイトを任意の場所に置けるよう、リンクは画面の深さに応じた相対パスにする
+func links(path string) view.Links {
+	return view.Links{Root: strings.Repeat("../", strings.Count(path, "/")), Export: true}
+}
+
+// searchEntry クライアント側の検索に用いる索引。textは名前以外に一致させる文字列
+type searchEntry struct {
+	Type  string `json:"type"`
+	Label string `json:"label"`
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Text  string `json:"text"`
+	URL   string `json:"url"`
+}
+
+// renderer 書き出す全てのファイルをパスと内容の組として集める
+type renderer struct {
+	templates map[string]*template.Template
+	locale    i18n.Locale
+	files     map[string][]byte
+}
+
+func render(catalog Catalog, locale i18n.Locale) (map[string][]byte, error) {
+	templates, err := view.Templates(siteTemplates...)
+	if err != nil {
+		return nil, err
+	}
+	r := renderer{templates: templates, locale: locale, files: map[string][]byte{}}
+	// 索引のURLは検索の画面から開くため、検索の画面からの相対パスにする
+	searchLinks := links(searchPath)
+
+	groupOf := make(map[variantModel.VariantID]variantModel.VariantGroupID, len(catalog.Variants))
+	for _, v := range catalog.Variants {
+		groupOf[v.ID()] = v.GroupID()
+	}
+	uniques := lo.Map(catalog.Uniques, func(u creatureModel.UniqueDinosaur, _ int) view.UniqueValue {
+		return view.NewUniqueValue(u)
+	})
+	variants := lo.Map(catalog.Variants, func(v variantModel.Variant, _ int) view.VariantValue {
+		return view.NewVariantValue(v)
+	})
+	groups := lo.Map(catalog.Groups, func(g variantModel.VariantGroup, _ int) view.VariantGroupValue {
+		return view.NewVariantGroupValue(g)
+	})
+
+	home := r.meta(indexPath)
+	if err = r.page(indexPath, "uniques.html", view.UniquesPage{
+		Meta: home,
+		Groups: append(
+			[]view.PageLink{{Label: i18n.Text(locale, "すべて"), URL: home.Links.Home()}},
+			lo.Map(groups, func(g view.VariantGroupValue, _ int) view.PageLink {
+				return view.PageLink{Label: g.DisplayName.Value(), URL: home.Links.GroupUniques(g.ID)}
+			})...,
+		),
+		Uniques: lo.Map(uniques, func(u view.UniqueValue, _ int) view.UniqueRow {
+			ids := lo.Uniq(lo.Map(u.UniqueVariants, func(v view.UniqueVariantsValue, _ int) string {
+				return strconv.Itoa(int(groupOf[variantModel.VariantID(v.VariantID)]))
+			}))
+			return view.UniqueRow{UniqueValue: u, GroupIDs: strings.Join(ids, " ")}
+		}),
+	}); err != nil {
+		return nil, err
+	}
+	if err = r.page(searchPath, "search.html", view.SearchPage{Meta: r.meta(searchPath)}); err != nil {
+		return nil, err
+	}
+
+	index := make([]searchEntry, 0, len(uniques)+len(variants)+len(groups))
+	for n, u := range uniques {
+		path := uniquePath(u.UniqueID)
+		if err = r.page(path, "unique.html", view.UniquePage{
+			Meta:   r.meta(path),
+			Unique: u,
+			Stats:  view.NewStatRows(catalog.Uniques[n]),
+		}); err != nil {
+			return nil, err
+		}
+		if err = r.json(uniqueJSONPath(u.UniqueID), u); err != nil {
+			return nil, err
+		}
+		index = append(index, searchEntry{
+			Type: "unique", Label: i18n.Text(locale, "ユニーク"), ID: u.UniqueID, Name: u.UniqueDisplayName,
+			Text: strings.Join(append(
+				[]string{u.BaseDisplayName},
+				lo.Map(u.UniqueVariants, func(v view.UniqueVariantsValue, _ int) string { return v.VariantDisplayName })...,
+			), " "),
+			URL: searchLinks.Unique(u.UniqueID),
+		})
+	}
+	for _, v := range variants {
+		path := variantPath(int(v.ID))
+		if err = r.page(path, "variant.html", view.VariantPage{
+			Meta:    r.meta(path),
+			Variant: v,
+			Uniques: lo.Filter(uniques, func(u view.UniqueValue, _ int) bool {
+				return lo.ContainsBy(u.UniqueVariants, func(uv view.UniqueVariantsValue) bool {
+					return uv.VariantID == int(v.ID)
+				})
+			}),
+		}); err != nil {
+			return nil, err
+		}
+		if err = r.json(variantJSONPath(int(v.ID)), v); err != nil {
+			return nil, err
+		}
+		index = append(index, searchEntry{
+			Type: "variant", Label: i18n.Text(locale, "バリアント"), ID: int(v.ID), Name: v.DisplayName.Value(),
+			Text: strings.Join(append(
+				[]string{v.GroupDisplayName.Value()},
+				lo.Map(v.Descriptions, func(d view.VariantDescriptionValue, _ int) string { return d.Text.Value() })...,
+			), " "),
+			URL: searchLinks.Variant(v.ID),
+		})
+	}
+	for _, g := range groups {
+		path := groupPath(int(g.ID))
+		if err = r.page(path, "group.html", view.VariantGroupPage{
+			Meta:     r.meta(path),
+			Group:    g,
+			Variants: lo.Filter(variants, func(v view.VariantValue, _ int) bool { return v.GroupID == g.ID }),
+		}); err != nil {
+			return nil, err
+		}
+		if err = r.json(groupJSONPath(int(g.ID)), g); err != nil {
+			return nil, err
+		}
+		index = append(index, searchEntry{
+			Type: "group", Label: i18n.Text(locale, "グループ"), ID: int(g.ID), Name: g.DisplayName.Value(),
+			URL: searchLinks.Group(g.ID),
+		})
+	}
+	if err = r.json(searchIndexPath, index); err != nil {
+		return nil, err
+	}
+
+	if err = r.static(); err != nil {
+		return nil, err
+	}
+	return r.files, nil
+}
+
+func (r renderer) meta(path string) view.Meta {
+	return view.Meta{Locale: r.locale, Links: links(path)}
+}
+
+func (r renderer) page(path, name string, data any) error {
+	var buf bytes.Buffer
+	if err := r.templates[name].ExecuteTemplate(&buf, "layout", data); err != nil {
+		return err
+	}
+	r.files[path] = buf.Bytes()
+	return nil
+}
+
+func (r renderer) json(path string, value any) error {
+	body, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	r.files[path] = body
+	return nil
+}
+
+// static サーバーの画面と共通のスタイルシートとスクリプトに、静的なサイト向けの検索のスクリプトを加える
+func (r renderer) static() error {
+	web, err := view.Static()
+	if err != nil {
+		return err
+	}
+	own, err := fs.Sub(siteFS, "static")
+	if err != nil {
+		return err
+	}
+	for _, fsys := range []fs.FS{web, own} {
+		entries, err := fs.ReadDir(fsys, ".")
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			body, err := fs.ReadFile(fsys, entry.Name())
+			if err != nil {
+				return err
+			}
+			r.files["static/"+entry.Name()] = body
+		}
+	}
+	return nil
+}
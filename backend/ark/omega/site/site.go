@@ -0,0 +1,227 @@
+package site
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	"mods-explore/ark/omega/logic/i18n"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+	variantSvc "mods-explore/ark/omega/logic/variant/domain/service"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+)
+
+// Catalog 静的なサイトとして書き出す全てのデータ
+type Catalog struct {
+	Uniques  []creatureModel.UniqueDinosaur
+	Variants []variantModel.Variant
+	Groups   []variantModel.VariantGroup
+}
+
+// Load ユースケースを通して全件をID順に読み込む。名前はcontextの言語の表示名になる
+func Load(
+	ctx context.Context,
+	uniques creatureUsecase.UniqueUsecase,
+	variants variantUsecase.VariantUsecase,
+	groups variantUsecase.VariantGroupUsecase,
+) (*Catalog, error) {
+	var (
+		catalog Catalog
+		err     error
+	)
+	catalog.Uniques, err = logic.ListAll(func(page logic.PageRequest) (*logic.Page[creatureModel.UniqueDinosaur], error) {
+		query, err := creatureSvc.NewListUniques(page, creatureSvc.UniqueSortByID, creatureSvc.UniqueFilter{})
+		if err != nil {
+			return nil, err
+		}
+		return uniques.List(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	catalog.Variants, err = logic.ListAll(func(page logic.PageRequest) (*logic.Page[variantModel.Variant], error) {
+		query, err := variantSvc.NewListVariants(page, variantSvc.VariantSortByID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return variants.List(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 一覧では説明文を読み込まないため、バリアント毎に読み込み直す
+	for n, v := range catalog.Variants {
+		variant, err := variants.Find(ctx, v.ID())
+		if err != nil {
+			return nil, err
+		}
+		catalog.Variants[n] = *variant
+	}
+	catalog.Groups, err = logic.ListAll(func(page logic.PageRequest) (*logic.Page[variantModel.VariantGroup], error) {
+		query, err := variantSvc.NewListVariantGroups(page, variantSvc.VariantGroupSortByID)
+		if err != nil {
+			return nil, err
+		}
+		return groups.List(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// manifestFile 前回書き出したファイルとその内容のハッシュ。差分のみを書き出すために出力先に残す
+const manifestFile = ".manifest.json"
+
+type manifest struct {
+	Files map[string]string `json:"files"`
+}
+
+// Result 書き出したファイル、内容が変わらず書き出さなかったファイル、データが無くなり削除したファイルの数
+type Result struct {
+	Written   int
+	Unchanged int
+	Removed   int
+}
+
+type Generator struct {
+	out    string
+	locale i18n.Locale
+	force  bool
+}
+
+// NewGenerator localeは画面の文言の言語。forceを指定すると前回から変わっていないファイルも書き出し直す
+func NewGenerator(out string, locale i18n.Locale, force bool) Generator {
+	return Generator{out: out, locale: locale, force: force}
+}
+
+// Generate 全てのファイルを描画し、前回から内容が変わったファイルのみを書き出す
+func (g Generator) Generate(catalog Catalog) (*Result, error) {
+	files, err := render(catalog, g.locale)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := g.readManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var result Result
+	current := manifest{Files: make(map[string]string, len(files))}
+	for _, name := range sortedNames(files) {
+		sum := sha256.Sum256(files[name])
+		hash := hex.EncodeToString(sum[:])
+		current.Files[name] = hash
+		if !g.force && previous.Files[name] == hash && g.exists(name) {
+			result.Unchanged++
+			continue
+		}
+		if err = g.write(name, files[name]); err != nil {
+			return nil, err
+		}
+		result.Written++
+	}
+
+	for name := range previous.Files {
+		if _, ok := files[name]; ok {
+			continue
+		}
+		if err = g.remove(name); err != nil {
+			return nil, err
+		}
+		result.Removed++
+	}
+
+	body, err := json.MarshalIndent(current, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = g.write(manifestFile, body); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (g Generator) path(name string) string {
+	return filepath.Join(g.out, filepath.FromSlash(name))
+}
+
+func (g Generator) readManifest() (manifest, error) {
+	body, err := os.ReadFile(g.path(manifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return manifest{}, nil
+	}
+	if err != nil {
+		return manifest{}, err
+	}
+	var m manifest
+	if err = json.Unmarshal(body, &m); err != nil {
+		return manifest{}, err
+	}
+	return m, nil
+}
+
+func (g Generator) exists(name string) bool {
+	_, err := os.Stat(g.path(name))
+	return err == nil
+}
+
+// write 書き出し途中のファイルを公開しないよう、一時ファイルに書いてから置き換える
+func (g Generator) write(name string, body []byte) error {
+	path := g.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// remove 出力先の外を消さないよう、マニフェストに書き換えられたパスは無視する。空になったディレクトリも消す
+func (g Generator) remove(name string) error {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return nil
+	}
+	path := g.path(name)
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for dir := filepath.Dir(path); dir != filepath.Clean(g.out); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/i18n"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+func newTestCatalog(variantName variantModel.Name) Catalog {
+	variant := variantModel.NewVariant(1, "Cosmic", variantName).
+		WithGroupID(1).
+		WithDescriptions(variantModel.Descriptions{variantModel.NewDescription(1, "Destroys corpses.")})
+	multipliers := creatureModel.NewUniqueMultipliers(
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Health](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Stamina](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Oxygen](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Food](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Weight](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Melee](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.MovementSpeed](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Torpidity](),
+		creatureModel.DefaultUniqueMultiplier[creatureModel.Armor](),
+	)
+	dodo := creatureModel.NewDinosaur(1, "Dodo", creatureModel.DinosaurStats{})
+
+	return Catalog{
+		Uniques: []creatureModel.UniqueDinosaur{
+			creatureModel.NewUniqueDinosaur(dodo, 1, "<Kenny>", multipliers, creatureModel.UniqueVariant{
+				creatureModel.NewDinosaurVariant(variant, creatureModel.VariantDescriptions{"Destroys corpses."}),
+			}),
+			creatureModel.NewUniqueDinosaur(dodo, 2, "Benny", multipliers, nil),
+		},
+		Variants: []variantModel.Variant{variant},
+		Groups: []variantModel.VariantGroup{
+			variantModel.NewVariantGroup(1, "Cosmic"),
+			variantModel.NewVariantGroup(2, "Nature"),
+		},
+	}
+}
+
+func readFile(t *testing.T, out, name string) string {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join(out, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("%sを読み込めませんでした %s", name, err.Error())
+	}
+	return string(body)
+}
+
+func Test_Generate(t *testing.T) {
+	t.Run("書き出したファイルのテスト", func(t *testing.T) {
+		out := t.TempDir()
+		result, err := NewGenerator(out, i18n.English, false).Generate(newTestCatalog("Singularity"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Written == 0 || result.Unchanged != 0 || result.Removed != 0 {
+			t.Errorf("初回に全てのファイルを書き出していません %+v", result)
+		}
+
+		for name, wants := range map[string][]string{
+			"index.html":            {`<html lang="en">`, `href="uniques/1/index.html"`, "&lt;Kenny&gt;", `data-groups="1"`, `href="index.html?group_id=2"`, `href="static/style.css"`},
+			"uniques/1/index.html":  {`href="../../variants/1/index.html"`, "Destroys corpses.", `href="../../api/uniques/1.json"`, `href="../../index.html"`},
+			"variants/1/index.html": {`href="../../groups/1/index.html"`, `href="../../uniques/1/index.html"`},
+			"groups/1/index.html":   {`href="../../index.html?group_id=1"`, `href="../../variants/1/index.html"`},
+			"search/index.html":     {`data-search-results="../search-index.json"`, `src="../static/site.js"`, `action="../search/index.html"`},
+			"api/uniques/1.json":    {`"id":1`, `"unique_name":"\u003cKenny\u003e"`},
+			"api/variants/1.json":   {`"name":"Singularity"`, `"group_id":1`},
+			"api/groups/2.json":     {`"name":"Nature"`},
+			"search-index.json":     {`"type":"unique"`, `"url":"../variants/1/index.html"`, `"label":"Group"`},
+			"static/style.css":      {"group-hidden"},
+			"static/app.js":         {"data-filter"},
+			"static/site.js":        {"data-search-results"},
+			manifestFile:            {`"index.html"`},
+		} {
+			body := readFile(t, out, name)
+			for _, want := range wants {
+				if !strings.Contains(body, want) {
+					t.Errorf("%sに%qが含まれていません", name, want)
+				}
+			}
+		}
+		for _, name := range []string{"index.html", "uniques/1/index.html", "variants/1/index.html", "groups/1/index.html", "search/index.html"} {
+			body := readFile(t, out, name)
+			if strings.Contains(body, `href="/`) || strings.Contains(body, `src="/`) || strings.Contains(body, `action="/`) {
+				t.Errorf("%sにルートからの絶対パスが含まれています", name)
+			}
+		}
+	})
+
+	t.Run("差分のみを書き出すテスト", func(t *testing.T) {
+		out := t.TempDir()
+		first, err := NewGenerator(out, i18n.Japanese, false).Generate(newTestCatalog("Singularity"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+
+		result, err := NewGenerator(out, i18n.Japanese, false).Generate(newTestCatalog("Singularity"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Written != 0 || result.Unchanged != first.Written {
+			t.Errorf("変更が無いのにファイルを書き出しています %+v", result)
+		}
+
+		result, err = NewGenerator(out, i18n.Japanese, false).Generate(newTestCatalog("Gamma-Ray"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Written == 0 || result.Unchanged == 0 {
+			t.Errorf("変更したファイルのみを書き出していません %+v", result)
+		}
+		if !strings.Contains(readFile(t, out, "variants/1/index.html"), "Gamma-Ray") {
+			t.Error("変更したバリアントの画面が書き出し直されていません")
+		}
+
+		if err = os.Remove(filepath.Join(out, "uniques", "2", "index.html")); err != nil {
+			t.Fatal(err)
+		}
+		result, err = NewGenerator(out, i18n.Japanese, false).Generate(newTestCatalog("Gamma-Ray"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Written != 1 {
+			t.Errorf("出力先から消えたファイルが書き出し直されていません %+v", result)
+		}
+
+		result, err = NewGenerator(out, i18n.Japanese, true).Generate(newTestCatalog("Gamma-Ray"))
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Unchanged != 0 {
+			t.Errorf("forceで全てのファイルを書き出し直していません %+v", result)
+		}
+	})
+
+	t.Run("削除したデータのファイルを消すテスト", func(t *testing.T) {
+		out := t.TempDir()
+		catalog := newTestCatalog("Singularity")
+		if _, err := NewGenerator(out, i18n.Japanese, false).Generate(catalog); err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+
+		catalog.Groups = catalog.Groups[:1]
+		result, err := NewGenerator(out, i18n.Japanese, false).Generate(catalog)
+		if err != nil {
+			t.Fatalf("書き出せませんでした %s", err.Error())
+		}
+		if result.Removed != 2 {
+			t.Errorf("削除したグループの画面とJSONが消されていません %+v", result)
+		}
+		for _, name := range []string{"groups/2", "api/groups/2.json"} {
+			if _, err = os.Stat(filepath.Join(out, filepath.FromSlash(name))); !os.IsNotExist(err) {
+				t.Errorf("%sが残っています", name)
+			}
+		}
+		if _, err = os.Stat(filepath.Join(out, "groups", "1", "index.html")); err != nil {
+			t.Error("残っているグループの画面が消されています")
+		}
+	})
+}
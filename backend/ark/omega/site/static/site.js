@@ -0,0 +1,47 @@
+"use strict";
+
+// 一覧の行を?group_id=のバリアントグループで絞り込み、選択したグループのリンクを強調する
+document.querySelectorAll("nav[data-group-filter]").forEach((nav) => {
+  const table = document.querySelector(nav.dataset.groupFilter);
+  const group = new URLSearchParams(location.search).get("group_id") || "";
+  nav.querySelectorAll("a.chip").forEach((chip) => {
+    chip.classList.toggle("active", (new URL(chip.href).searchParams.get("group_id") || "") === group);
+  });
+  if (!table || group === "") {
+    return;
+  }
+  Array.from(table.tBodies[0].rows).forEach((row) => {
+    row.classList.toggle("group-hidden", !(row.dataset.groups || "").split(" ").includes(group));
+  });
+});
+
+// 書き出し時に作成した索引を画面からの相対パスで読み込み、?q=の文字列を名前か説明に含むものを表示する
+const results = document.querySelector("[data-search-results]");
+if (results) {
+  const q = (new URLSearchParams(location.search).get("q") || "").trim();
+  const input = document.querySelector("input[name=q]");
+  if (input) {
+    input.value = q;
+  }
+  if (q !== "") {
+    const keyword = q.toLowerCase();
+    fetch(results.dataset.searchResults)
+      .then((response) => response.json())
+      .then((entries) => {
+        const hits = entries.filter((entry) =>
+          entry.name.toLowerCase().includes(keyword) || entry.text.toLowerCase().includes(keyword));
+        hits.forEach((hit) => {
+          const item = document.createElement("li");
+          const badge = document.createElement("span");
+          badge.className = "badge " + hit.type;
+          badge.textContent = hit.label;
+          const link = document.createElement("a");
+          link.href = hit.url;
+          link.textContent = hit.name;
+          item.append(badge, " ", link);
+          results.append(item);
+        });
+        document.querySelector("[data-search-empty]").hidden = hits.length > 0;
+      });
+  }
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/auth/domain/model"
+	"mods-explore/ark/omega/logic/auth/domain/service"
+	variantService "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// APITokenModel 平文のトークンは保存せず、SHA-256のハッシュのみを持つ
+type APITokenModel struct {
+	ID        int        `db:"id"`
+	Name      string     `db:"name"`
+	Role      string     `db:"role"`
+	CreatedAt time.Time  `db:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+const apiTokenColumns = `id, name, role, created_at, revoked_at`
+
+func (m APITokenModel) toToken() model.Token {
+	return model.NewToken(
+		model.TokenID(m.ID),
+		model.TokenName(m.Name),
+		logic.Role(m.Role),
+		m.CreatedAt,
+		m.RevokedAt,
+	)
+}
+
+// authNotFound NamedGetはバリアントのNotFoundを返すためトークンのNotFoundに置き換える
+func authNotFound(err error) error {
+	if errors.Is(err, variantService.NotFound) {
+		return service.NotFound
+	}
+	return err
+}
+
+type APITokenClient struct {
+	*Client
+}
+
+func NewAPITokenClient(injector *do.Injector) (service.TokenRepository, error) {
+	return APITokenClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (a APITokenClient) SelectByHash(ctx context.Context, hash model.TokenHash) (*model.Token, error) {
+	row, err := NamedGet[APITokenModel](
+		ctx,
+		a.Client,
+		`SELECT `+apiTokenColumns+` FROM api_tokens WHERE token_hash = :token_hash;`,
+		map[string]any{"token_hash": hash.Value()},
+	)
+	if err != nil {
+		return nil, authNotFound(err)
+	}
+
+	token := row.toToken()
+	return &token, nil
+}
+
+func (a APITokenClient) List(ctx context.Context) ([]model.Token, error) {
+	rows, err := Select[APITokenModel](ctx, a.Client, `SELECT `+apiTokenColumns+` FROM api_tokens ORDER BY id;`)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]model.Token, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, row.toToken())
+	}
+	return tokens, nil
+}
+
+func (a APITokenClient) Insert(ctx context.Context, issue service.IssueToken) (*model.Token, error) {
+	row, err := NamedGet[APITokenModel](
+		ctx,
+		a.Client,
+		`INSERT INTO api_tokens (name, token_hash, role) VALUES (:name, :token_hash, :role)
+				RETURNING `+apiTokenColumns+`;`,
+		map[string]any{
+			"name":       issue.Name().Value(),
+			"token_hash": issue.Hash().Value(),
+			"role":       issue.Role().Value(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	token := row.toToken()
+	return &token, nil
+}
+
+func (a APITokenClient) Revoke(ctx context.Context, id model.TokenID) error {
+	_, err := NamedStore[int](
+		ctx,
+		a.Client,
+		`UPDATE api_tokens SET revoked_at = COALESCE(revoked_at, NOW()) WHERE id = :id RETURNING id;`,
+		map[string]any{"id": id},
+	)
+	return authNotFound(err)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"mods-explore/ark/omega/logic/auth/domain/model"
+	"mods-explore/ark/omega/logic/auth/domain/service"
+)
+
+type testAPITokenSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestAPITokenSuite(t *testing.T) {
+	suite.Run(t, &testAPITokenSuite{})
+}
+
+func (s *testAPITokenSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+func (s *testAPITokenSuite) TestSelectByHash() {
+	ctx := context.Background()
+	client := APITokenClient{&s.cli}
+	secret := model.Secret("secret")
+	revokedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	s.T().Log("ハッシュで照合し、失効日時も取得するテスト")
+	s.mock.ExpectQuery(`SELECT id, name, role, created_at, revoked_at FROM api_tokens WHERE token_hash = \?`).
+		WithArgs(secret.Hash().Value()).
+		WillReturnRows(
+			sqlxmock.NewRows([]string{"id", "name", "role", "created_at", "revoked_at"}).
+				AddRow(1, "editor-bot", "editor", revokedAt, revokedAt),
+		)
+
+	token, err := client.SelectByHash(ctx, secret.Hash())
+	s.Require().NoError(err)
+	s.Equal(model.TokenName("editor-bot"), token.Name())
+	s.True(token.Revoked())
+
+	s.T().Log("存在しないトークンはトークンのNotFoundになるテスト")
+	s.mock.ExpectQuery(`FROM api_tokens WHERE token_hash = \?`).
+		WithArgs(secret.Hash().Value()).
+		WillReturnRows(sqlxmock.NewRows([]string{"id", "name", "role", "created_at", "revoked_at"}))
+
+	_, err = client.SelectByHash(ctx, secret.Hash())
+	s.ErrorIs(err, service.NotFound)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testAPITokenSuite) TestRevoke() {
+	ctx := context.Background()
+	client := APITokenClient{&s.cli}
+
+	s.T().Log("失効済みのトークンは最初の失効日時のままにするテスト")
+	s.mock.ExpectPrepare(`UPDATE api_tokens SET revoked_at = COALESCE\(revoked_at, NOW\(\)\)`).
+		ExpectQuery().
+		WithArgs(1).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(1))
+	s.NoError(client.Revoke(ctx, 1))
+
+	s.mock.ExpectPrepare(`UPDATE api_tokens`).
+		ExpectQuery().
+		WithArgs(2).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+	s.ErrorIs(client.Revoke(ctx, 2), service.NotFound)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
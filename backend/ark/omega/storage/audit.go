@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/model"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+)
+
+// AuditLogModel 作成の履歴ではbefore、削除の履歴ではafterがNULLになる
+type AuditLogModel struct {
+	ID         int       `db:"id"`
+	EntityType string    `db:"entity_type"`
+	EntityID   int       `db:"entity_id"`
+	Action     string    `db:"action"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
+	Actor      string    `db:"actor"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+const auditLogColumns = `id, entity_type, entity_id, action, before, after, actor, created_at`
+
+func (m AuditLogModel) toEntry() model.Entry {
+	return model.NewEntry(
+		model.EntryID(m.ID),
+		logic.AuditEntity(m.EntityType),
+		m.EntityID,
+		logic.AuditAction(m.Action),
+		m.Before,
+		m.After,
+		logic.Actor(m.Actor),
+		m.CreatedAt,
+	)
+}
+
+// jsonArg 空の状態はJSONのnullではなくNULLとして保存する
+func jsonArg(raw json.RawMessage) any {
+	if raw == nil {
+		return nil
+	}
+	return string(raw)
+}
+
+// auditLogKeyset 変更履歴は記録順のみで並べるため、idをソート列とする
+func auditLogKeyset(page logic.PageRequest) keyset {
+	return keyset{
+		column: sortColumn{expr: "id", castType: "BIGINT"},
+		idExpr: "id",
+		key:    "id",
+		page:   page,
+	}
+}
+
+type AuditClient struct {
+	*Client
+}
+
+func NewAuditClient(injector *do.Injector) (service.AuditRepository, error) {
+	return AuditClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (a AuditClient) Insert(ctx context.Context, create service.CreateEntry) error {
+	_, err := NamedStore[int](
+		ctx,
+		a.Client,
+		`INSERT INTO audit_logs (entity_type, entity_id, action, before, after, actor)
+				VALUES (:entity_type, :entity_id, :action, CAST(:before AS JSONB), CAST(:after AS JSONB), :actor)
+				RETURNING id;`,
+		map[string]any{
+			"entity_type": create.Entity().Value(),
+			"entity_id":   create.EntityID(),
+			"action":      create.Action().Value(),
+			"before":      jsonArg(create.Before()),
+			"after":       jsonArg(create.After()),
+			"actor":       create.Actor().Value(),
+		},
+	)
+	return err
+}
+
+func (a AuditClient) ListHistory(ctx context.Context, query service.ListHistory) (*logic.Page[model.Entry], error) {
+	return a.list(ctx, auditLogKeyset(query.PageRequest), map[string]any{
+		"entity_type": query.Entity().Value(),
+		"entity_id":   query.EntityID(),
+	}, []string{"entity_type = :entity_type", "entity_id = :entity_id"})
+}
+
+func (a AuditClient) ListEntries(ctx context.Context, query service.ListEntries) (*logic.Page[model.Entry], error) {
+	arg := map[string]any{}
+	var conditions []string
+	if !query.Since().IsZero() {
+		arg["since"] = query.Since()
+		conditions = append(conditions, "created_at >= :since")
+	}
+	return a.list(ctx, auditLogKeyset(query.PageRequest), arg, conditions)
+}
+
+func (a AuditClient) list(
+	ctx context.Context, k keyset, arg map[string]any, conditions []string,
+) (*logic.Page[model.Entry], error) {
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[AuditLogModel](
+		ctx,
+		a.Client,
+		fmt.Sprintf(
+			`SELECT `+auditLogColumns+` FROM audit_logs %s %s;`,
+			whereClause(append(conditions, where)), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r AuditLogModel) (any, int) { return r.ID, r.ID },
+		func(r AuditLogModel) (model.Entry, error) { return r.toEntry(), nil },
+	)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/audit/domain/service"
+)
+
+type testAuditSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestAuditSuite(t *testing.T) {
+	suite.Run(t, &testAuditSuite{})
+}
+
+func (s *testAuditSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+func (s *testAuditSuite) TestInsert() {
+	ctx := context.Background()
+	client := AuditClient{&s.cli}
+	after := json.RawMessage(`{"id":1,"name":"cosmic"}`)
+
+	s.T().Log("作成の履歴では変更前の状態をNULLで保存するテスト")
+	s.mock.ExpectPrepare(`INSERT INTO audit_logs`).
+		ExpectQuery().
+		WithArgs("group", 1, "create", nil, string(after), "editor").
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(1))
+
+	s.Nil(client.Insert(ctx, service.NewCreateEntry(
+		logic.Created(logic.AuditGroup, 1, nil), nil, after, logic.Actor("editor"),
+	)))
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testAuditSuite) TestListEntries() {
+	ctx := context.Background()
+	client := AuditClient{&s.cli}
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	page, err := logic.NewPageRequest(1, "", logic.Asc)
+	s.Require().NoError(err)
+
+	s.T().Log("指定日時以降の履歴を取得し、件数を超える場合は次ページのカーソルを返すテスト")
+	s.mock.ExpectQuery(`FROM audit_logs WHERE created_at >= \? ORDER BY id ASC, id ASC LIMIT \?`).
+		WithArgs(since, 2).
+		WillReturnRows(
+			sqlxmock.NewRows([]string{"id", "entity_type", "entity_id", "action", "before", "after", "actor", "created_at"}).
+				AddRow(1, "unique", 3, "delete", []byte(`{"id":3}`), nil, "anonymous", since).
+				AddRow(2, "unique", 4, "create", nil, []byte(`{"id":4}`), "anonymous", since),
+		)
+
+	entries, err := client.ListEntries(ctx, service.NewListEntries(page, since))
+	s.Require().NoError(err)
+	s.Require().Len(entries.Items(), 1)
+	entry := entries.Items()[0]
+	s.Equal(logic.AuditDelete, entry.Action())
+	s.JSONEq(`{"id":3}`, string(entry.Before()))
+	s.Nil(entry.After())
+	s.NotEmpty(entries.NextCursor())
+	s.Nil(s.mock.ExpectationsWereMet())
+}
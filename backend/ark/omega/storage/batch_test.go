@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+)
+
+// testBatchSuite 一覧では読み込まない説明文と成長値を、対象の数に依らず1度のクエリで読み込むかのテスト
+type testBatchSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, &testBatchSuite{})
+}
+
+func (s *testBatchSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+func (s *testBatchSuite) TestSelectDescriptions() {
+	ctx := context.Background()
+	client := VariantClient{&s.cli}
+
+	s.mock.ExpectQuery(`SELECT variant_id, id, description FROM variant_descriptions\s+WHERE variant_id = ANY\(\?\)`).
+		WithArgs(sqlxmock.AnyArg()).
+		WillReturnRows(sqlxmock.NewRows([]string{"variant_id", "id", "description"}).
+			AddRow(1, 10, "Destroys corpses.").
+			AddRow(1, 11, "Pulls nearby enemies.").
+			AddRow(3, 12, "Summons lightning."))
+
+	descriptions, err := client.SelectDescriptions(ctx, []model.VariantID{1, 2, 3})
+	s.NoError(err)
+	s.Equal(map[model.VariantID]model.Descriptions{
+		1: {model.NewDescription(10, "Destroys corpses."), model.NewDescription(11, "Pulls nearby enemies.")},
+		3: {model.NewDescription(12, "Summons lightning.")},
+	}, descriptions)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testBatchSuite) TestSelectStatGrowths() {
+	ctx := context.Background()
+	client := DinosaurClient{&s.cli}
+
+	s.mock.ExpectQuery(`FROM dinosaur_stat_growths WHERE dinosaur_id = ANY\(\?\)`).
+		WithArgs(sqlxmock.AnyArg()).
+		WillReturnRows(sqlxmock.NewRows(
+			[]string{"dinosaur_id", "stat", "wild_increase", "tamed_increase", "tamed_add", "tamed_affinity"},
+		).
+			AddRow(1, "health", 0.2, 0.054, 0.35, 0).
+			AddRow(1, "melee", 0.05, 0.1, 0.07, 0.5).
+			AddRow(2, "health", 0.2, 0.27, 0.14, 0))
+
+	growths, err := client.SelectStatGrowths(ctx, []creatureModel.DinosaurID{1, 2, 3})
+	s.NoError(err)
+	s.Len(growths, 2)
+	s.Len(growths[1], 2)
+	s.Equal(float32(0.27), growths[2].Of(creatureModel.StatHealth).TamedIncrease())
+	s.Nil(growths[3])
+	s.Nil(s.mock.ExpectationsWereMet())
+}
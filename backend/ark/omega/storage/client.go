@@ -26,17 +26,41 @@ func NewSQLxClient(injector *do.Injector) (*Client, error) {
 	}, nil
 }
 
+// Executor *sqlx.DBと*sqlx.Txの両方が満たすクエリ実行のインターフェース
+type Executor interface {
+	sqlx.ExtContext
+	BindNamed(query string, arg any) (string, []any, error)
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+	PrepareNamedContext(ctx context.Context, query string) (*sqlx.NamedStmt, error)
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+}
+
+var (
+	_ Executor = (*sqlx.DB)(nil)
+	_ Executor = (*sqlx.Tx)(nil)
+)
+
+// Executor contextにトランザクションがあればそれを、なければコネクションプールを返す
+func (c *Client) Executor(ctx context.Context) Executor {
+	if tx, ok := GetTx(ctx); ok {
+		return tx
+	}
+	return c.DB
+}
+
 func NamedGet[T any](ctx context.Context, c *Client, query string, args ...any) (*T, error) {
-	query, args, err := c.BindNamed(query, args)
+	exec := c.Executor(ctx)
+	query, args, err := exec.BindNamed(query, args)
 	if err != nil {
 		return nil, err
 	}
 
 	var row T
-	if err = c.GetContext(ctx, &row, query, args...); errors.Is(err, sql.ErrNoRows) {
+	if err = exec.GetContext(ctx, &row, query, args...); errors.Is(err, sql.ErrNoRows) {
 		return nil, service.NotFound
 	} else if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 
 	return &row, nil
@@ -44,32 +68,57 @@ func NamedGet[T any](ctx context.Context, c *Client, query string, args ...any)
 
 func Select[T any](ctx context.Context, c *Client, query string) ([]T, error) {
 	var rows []T
-	if err := c.SelectContext(ctx, &rows, query); err != nil {
+	if err := c.Executor(ctx).SelectContext(ctx, &rows, query); err != nil {
+		return nil, translateError(err)
+	}
+
+	return rows, nil
+}
+
+// NamedSelect 検索条件などの名前付きパラメータを伴う複数行の取得に用いる
+func NamedSelect[T any](ctx context.Context, c *Client, query string, arg any) ([]T, error) {
+	exec := c.Executor(ctx)
+	query, args, err := exec.BindNamed(query, arg)
+	if err != nil {
 		return nil, err
 	}
 
+	var rows []T
+	if err = exec.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, translateError(err)
+	}
+
 	return rows, nil
 }
 
+// NamedStore RETURNINGで返されたIDを受け取る。UPDATEの対象が存在しない場合はNotFoundを返す
 func NamedStore[ID any](ctx context.Context, c *Client, query string, arg any) (id ID, err error) {
-	stmt, err := c.PrepareNamedContext(ctx, query)
+	stmt, err := c.Executor(ctx).PrepareNamedContext(ctx, query)
 	if err != nil {
 		return id, err
 	}
+	defer stmt.Close()
 
 	err = stmt.QueryRowContext(ctx, arg).Scan(&id)
-	if err != nil {
-		return id, err
+	if errors.Is(err, sql.ErrNoRows) {
+		return id, service.NotFound
+	} else if err != nil {
+		return id, translateError(err)
 	}
 
 	return id, nil
 }
 
-func NamedDelete(ctx context.Context, c *Client, query string, arg any) error {
-	_, err := c.NamedExecContext(
+// NamedExec 戻り値のないINSERTやスライスを渡す一括INSERTに用いる
+func NamedExec(ctx context.Context, c *Client, query string, arg any) error {
+	_, err := c.Executor(ctx).NamedExecContext(
 		ctx,
 		query,
 		arg,
 	)
-	return err
+	return translateError(err)
+}
+
+func NamedDelete(ctx context.Context, c *Client, query string, arg any) error {
+	return NamedExec(ctx, c, query, arg)
 }
@@ -33,26 +33,19 @@ func TestTestClientSuite(t *testing.T) {
 }
 
 func (s *TestClientSuite) SetupSuite() {
+	env, err := omega.LoadConfig()
+	if err != nil {
+		s.T().Skipf("skip database test: %s", err.Error())
+	}
+
 	injector := do.New()
 	{
-
-		do.Provide(injector, func(_ *do.Injector) (omega.Environments, error) {
-			conf, err := omega.LoadConfig()
-			return *conf, err
-		})
+		do.ProvideValue(injector, *env)
 		do.ProvideValue(injector, slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
 		do.Provide(injector, func(i *do.Injector) (*sqlx.DB, error) {
 			env := do.MustInvoke[omega.Environments](i)
-			postgresDSN := fmt.Sprintf(
-				"postgres://%s:%s@%s:%d/%s?sslmode=disable",
-				env.DBUsername,
-				env.DBPassword,
-				env.DatabaseURL,
-				env.Port,
-				env.DatabaseName,
-			)
-			return ConnectPostgres(postgresDSN)
+			return ConnectPostgres(env.DSN())
 		})
 
 		do.Provide(injector, NewSQLxClient)
@@ -78,7 +71,12 @@ func (s *TestClientSuite) SetupSuite() {
 			s.T().Log(fmt.Printf("error migrate up: %s", err.Error()))
 			return
 		}
-		s.T().Log("create table: test")
+		s.T().Log("create tables")
+	}
+
+	if _, err = db.Exec(`INSERT INTO groups (name) VALUES ('test');`); err != nil {
+		s.T().Log(fmt.Printf("error insert test record: %s", err.Error()))
+		return
 	}
 }
 
@@ -93,7 +91,7 @@ func (s *TestClientSuite) TearDownSuite() {
 			s.T().Log(fmt.Printf("error migrate down: %s", err.Error()))
 			return
 		}
-		s.T().Log("delete table: test")
+		s.T().Log("delete tables")
 	}
 
 	if err := s.cli.Close(); err != nil {
@@ -106,7 +104,7 @@ func (s *TestClientSuite) TestNamedGet() {
 	ctx := context.Background()
 	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	r, err := NamedGet[testModel](timeout, s.cli, `SELECT id, name FROM tests WHERE id = :id`, map[string]any{"id": 1})
+	r, err := NamedGet[testModel](timeout, s.cli, `SELECT id, name FROM groups WHERE id = :id`, map[string]any{"id": 1})
 	if err != nil {
 		s.T().Log(fmt.Printf("failed select test record: %s", err.Error()))
 		s.T().Fail()
@@ -119,7 +117,7 @@ func (s *TestClientSuite) TestSelect() {
 	ctx := context.Background()
 	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	rs, err := Select[testModel](timeout, s.cli, `SELECT id, name FROM tests`)
+	rs, err := Select[testModel](timeout, s.cli, `SELECT id, name FROM groups WHERE name = 'test'`)
 	if err != nil {
 		s.T().Log(fmt.Printf("failed select test record: %s", err.Error()))
 		s.T().Fail()
@@ -132,13 +130,13 @@ func (s *TestClientSuite) TestNamedStore() {
 	ctx := context.Background()
 	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	id, err := NamedStore[int](timeout, s.cli, `INSERT INTO tests(name) VALUES(:name) RETURNING id`, map[string]any{"name": "test2"})
+	id, err := NamedStore[int](timeout, s.cli, `INSERT INTO groups(name) VALUES(:name) RETURNING id`, map[string]any{"name": "test2"})
 	if err != nil {
 		s.T().Log(fmt.Printf("insert test record: %s", err.Error()))
 		s.T().Fail()
 	}
 
-	r, err := NamedGet[testModel](timeout, s.cli, `SELECT id, name FROM tests WHERE id = :id`, map[string]any{"id": id})
+	r, err := NamedGet[testModel](timeout, s.cli, `SELECT id, name FROM groups WHERE id = :id`, map[string]any{"id": id})
 	if err != nil {
 		s.T().Log(fmt.Printf("failed select test record: %s", err.Error()))
 		s.T().Fail()
@@ -151,12 +149,12 @@ func (s *TestClientSuite) TestNamedDelete() {
 	ctx := context.Background()
 	timeout, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	id, err := NamedStore[int](timeout, s.cli, `INSERT INTO tests(name) VALUES(:name) RETURNING id`, map[string]any{"name": "test3"})
+	id, err := NamedStore[int](timeout, s.cli, `INSERT INTO groups(name) VALUES(:name) RETURNING id`, map[string]any{"name": "test3"})
 	if err != nil {
 		s.T().Log(fmt.Printf("insert test record: %s", err.Error()))
 		s.T().Fail()
 	}
 
-	err = NamedDelete(timeout, s.cli, `DELETE FROM tests WHERE id = :id`, map[string]any{"id": id})
+	err = NamedDelete(timeout, s.cli, `DELETE FROM groups WHERE id = :id`, map[string]any{"id": id})
 	s.ErrorIs(err, nil)
 }
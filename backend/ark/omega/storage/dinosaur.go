@@ -2,18 +2,80 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/samber/do"
 
+	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
 	"mods-explore/ark/omega/logic/creature/domain/service"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
+	variantService "mods-explore/ark/omega/logic/variant/domain/service"
 )
 
 type DinosaurModel struct {
-	ID         int    `db:"id"`
-	Name       string `db:"name"`
-	BaseHealth int    `db:"health"`
-	BaseMelee  int    `db:"melee"`
+	ID                int    `db:"id"`
+	Name              string `db:"name"`
+	DisplayName       string `db:"display_name"`
+	BaseHealth        int    `db:"health"`
+	BaseStamina       int    `db:"stamina"`
+	BaseOxygen        int    `db:"oxygen"`
+	BaseFood          int    `db:"food"`
+	BaseWeight        int    `db:"weight"`
+	BaseMelee         int    `db:"melee"`
+	BaseMovementSpeed int    `db:"movement_speed"`
+	BaseTorpidity     int    `db:"torpidity"`
+	BaseArmor         int    `db:"armor"`
+	Version           int    `db:"version"`
+}
+
+const dinosaurColumns = `id, name, health, stamina, oxygen, food, weight, melee, movement_speed, torpidity, armor, version`
+
+// localizedDinosaurColumns dinosaurColumnsにリクエストの言語の表示名を加える。名前は保存された値のまま変えない
+var localizedDinosaurColumns = dinosaurColumns + `, ` + localizedName(translation.TargetDinosaur, "dinosaurs") + ` AS display_name`
+
+func (d DinosaurModel) toDinosaur() (model.Dinosaur, error) {
+	health, e1 := model.NewHealth(uint(d.BaseHealth))
+	stamina, e2 := model.NewStamina(uint(d.BaseStamina))
+	food, e3 := model.NewFood(uint(d.BaseFood))
+	weight, e4 := model.NewWeight(uint(d.BaseWeight))
+	speed, e5 := model.NewMovementSpeed(uint(d.BaseMovementSpeed))
+	torpidity, e6 := model.NewTorpidity(uint(d.BaseTorpidity))
+	if err := errors.Join(e1, e2, e3, e4, e5, e6); err != nil {
+		return model.Dinosaur{}, err
+	}
+
+	return model.NewDinosaur(
+		model.DinosaurID(d.ID),
+		model.DinosaurName(d.Name),
+		model.NewDinosaurStats(
+			health, stamina, model.NewOxygen(uint(d.BaseOxygen)), food, weight,
+			model.NewMelee(uint(d.BaseMelee)), speed, torpidity, model.NewArmor(uint(d.BaseArmor)),
+		),
+	).WithBaseDisplayName(model.DinosaurName(d.DisplayName)).WithVersion(logic.Version(d.Version)), nil
+}
+
+// creatureNotFound NamedGetはバリアントのNotFoundを返すため生物のNotFoundに置き換える
+func creatureNotFound(err error) error {
+	if errors.Is(err, variantService.NotFound) {
+		return service.NotFound
+	}
+	return err
+}
+
+// dinosaurStatsArg ステータスをクエリの名前付きパラメータに展開する
+func dinosaurStatsArg(stats model.DinosaurStats, arg map[string]any) map[string]any {
+	arg["health"] = stats.Health()
+	arg["stamina"] = stats.Stamina()
+	arg["oxygen"] = stats.Oxygen()
+	arg["food"] = stats.Food()
+	arg["weight"] = stats.Weight()
+	arg["melee"] = stats.Melee()
+	arg["movement_speed"] = stats.MovementSpeed()
+	arg["torpidity"] = stats.Torpidity()
+	arg["armor"] = stats.Armor()
+	return arg
 }
 
 type DinosaurClient struct {
@@ -26,28 +88,169 @@ func NewDinosaurClient(injector *do.Injector) (service.DinosaurCommandRepository
 	}, nil
 }
 
+func NewDinosaurQueryClient(injector *do.Injector) (service.DinosaurQueryRepository, error) {
+	return DinosaurClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (c DinosaurClient) Select(ctx context.Context, id model.DinosaurID) (*model.Dinosaur, error) {
+	row, err := NamedGet[DinosaurModel](
+		ctx,
+		c.Client,
+		`SELECT `+localizedDinosaurColumns+` FROM dinosaurs WHERE id = :id AND deleted_at IS NULL;`,
+		localeArg(ctx, map[string]any{"id": id}),
+	)
+	if err != nil {
+		return nil, creatureNotFound(err)
+	}
+
+	dino, err := row.toDinosaur()
+	if err != nil {
+		return nil, err
+	}
+	growth, err := selectStatGrowth(ctx, c.Client, dino.BaseID())
+	if err != nil {
+		return nil, err
+	}
+	dino = dino.WithStatGrowth(growth)
+	return &dino, nil
+}
+
+func (c DinosaurClient) SelectByName(ctx context.Context, name model.DinosaurName) (*model.Dinosaur, error) {
+	row, err := NamedGet[DinosaurModel](
+		ctx,
+		c.Client,
+		`SELECT `+dinosaurColumns+` FROM dinosaurs WHERE LOWER(name) = LOWER(:name) AND deleted_at IS NULL;`,
+		map[string]any{"name": name},
+	)
+	if err != nil {
+		return nil, creatureNotFound(err)
+	}
+
+	dino, err := row.toDinosaur()
+	if err != nil {
+		return nil, err
+	}
+	return &dino, nil
+}
+
+var dinosaurSortColumns = map[service.DinosaurSortKey]sortColumn{
+	service.DinosaurSortByID:        {expr: "id", castType: "INTEGER"},
+	service.DinosaurSortByName:      {expr: "dinosaurs.name", castType: "VARCHAR"},
+	service.DinosaurSortByUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+}
+
+// dinosaurListModel ページングのため、ソートキーの値も合わせて取得する
+type dinosaurListModel struct {
+	DinosaurModel
+	SortValue any `db:"sort_value"`
+}
+
+func (c DinosaurClient) List(ctx context.Context, query service.ListDinosaurs) (*logic.Page[model.Dinosaur], error) {
+	k := keyset{
+		column: dinosaurSortColumns[query.SortKey()],
+		idExpr: "id",
+		key:    query.SortKey().Value(),
+		page:   query.PageRequest,
+	}
+	arg := localeArg(ctx, map[string]any{})
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[dinosaurListModel](
+		ctx,
+		c.Client,
+		fmt.Sprintf(
+			`SELECT `+localizedDinosaurColumns+`, %s AS sort_value FROM dinosaurs %s %s;`,
+			k.column.expr, whereClause([]string{"deleted_at IS NULL", where}), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r dinosaurListModel) (any, int) { return r.SortValue, r.ID },
+		func(r dinosaurListModel) (model.Dinosaur, error) { return r.toDinosaur() },
+	)
+}
+
+func (c DinosaurClient) SelectStatGrowths(
+	ctx context.Context, ids []model.DinosaurID,
+) (map[model.DinosaurID]model.SpeciesStatGrowth, error) {
+	return selectStatGrowths(ctx, c.Client, ids)
+}
+
 func (c DinosaurClient) Insert(ctx context.Context, create service.CreateDinosaur) (model.DinosaurID, error) {
 	id, err := NamedStore[int](
 		ctx,
 		c.Client,
-		`INSERT INTO dinosaurs (name, health, melee) VALUES (:name, :health, :melee) RETURNING id;`,
-		map[string]any{"name": create.Name(), "health": create.Health(), "melee": create.Melee()},
+		`INSERT INTO dinosaurs (name, health, stamina, oxygen, food, weight, melee, movement_speed, torpidity, armor)
+			VALUES (:name, :health, :stamina, :oxygen, :food, :weight, :melee, :movement_speed, :torpidity, :armor)
+			RETURNING id;`,
+		dinosaurStatsArg(create.Stats(), map[string]any{"name": create.Name()}),
 	)
 	if err != nil {
 		return 0, err
 	}
+	if growth := create.StatGrowth(); growth != nil {
+		if err = replaceStatGrowth(ctx, c.Client, model.DinosaurID(id), growth); err != nil {
+			return 0, err
+		}
+	}
 	return model.DinosaurID(id), err
 }
 
+// Update 指定したバージョンの場合のみ更新する。対象が無い場合は、存在を確認した上で呼ばれるためVersionMismatchを返す。
+// 成長値が指定されていない場合は登録済みの成長値を変えない
 func (c DinosaurClient) Update(ctx context.Context, update service.UpdateDinosaur) error {
 	_, err := NamedStore[int](
 		ctx,
 		c.Client,
-		`UPDATE dinosaurs SET name = :name, health = :health, melee = :melee, updated_at = NOW() WHERE id = :id;`,
-		map[string]any{"id": update.ID(), "name": update.Name(), "health": update.Health(), "melee": update.Melee()},
+		`UPDATE dinosaurs
+			SET name = :name, health = :health, stamina = :stamina, oxygen = :oxygen, food = :food, weight = :weight,
+			    melee = :melee, movement_speed = :movement_speed, torpidity = :torpidity, armor = :armor,
+			    version = version + 1, updated_at = NOW()
+			WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		dinosaurStatsArg(
+			update.Stats(), map[string]any{"id": update.ID(), "name": update.Name(), "version": update.Version()},
+		),
 	)
-	return err
+	if err != nil {
+		return versionMismatch(err)
+	}
+	if growth := update.StatGrowth(); growth != nil {
+		return replaceStatGrowth(ctx, c.Client, update.ID(), growth)
+	}
+	return nil
+}
+
+// Delete ゴミ箱へ移すのみで、ゴミ箱のユニークからの参照は残す。バージョンが一致しない場合はVersionMismatchを返す
+func (c DinosaurClient) Delete(ctx context.Context, id model.DinosaurID, version logic.Version) error {
+	_, err := NamedStore[int](
+		ctx,
+		c.Client,
+		`UPDATE dinosaurs SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+			WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": id, "version": version},
+	)
+	return versionMismatch(err)
 }
-func (c DinosaurClient) Delete(ctx context.Context, id model.DinosaurID) error {
-	return NamedDelete(ctx, c.Client, `DELETE FROM dinosaurs WHERE id = :id;`, map[string]any{"id": id})
+
+// Restore ゴミ箱に無い場合はNotFoundを返す
+func (c DinosaurClient) Restore(ctx context.Context, id model.DinosaurID) error {
+	_, err := NamedStore[int](
+		ctx,
+		c.Client,
+		`UPDATE dinosaurs SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+			WHERE id = :id AND deleted_at IS NOT NULL RETURNING id;`,
+		map[string]any{"id": id},
+	)
+	return creatureNotFound(err)
 }
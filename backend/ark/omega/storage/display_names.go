@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/translation/domain/service"
+	variantService "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// displayNameTable 対象毎に外部キーで削除を連動させるため、表示名は対象毎のテーブルに保存する
+type displayNameTable struct {
+	table    string
+	idColumn string
+	parent   string
+}
+
+var displayNameTables = map[model.Target]displayNameTable{
+	model.TargetDinosaur: {table: "dinosaur_names", idColumn: "dinosaur_id", parent: "dinosaurs"},
+	model.TargetUnique:   {table: "unique_names", idColumn: "unique_id", parent: "uniques"},
+	model.TargetVariant:  {table: "variant_names", idColumn: "variant_id", parent: "variants"},
+	model.TargetGroup:    {table: "group_names", idColumn: "group_id", parent: "groups"},
+}
+
+// localizedName :localeの表示名が無ければ元の名前を返す式。aliasは対象のテーブルの別名
+func localizedName(target model.Target, alias string) string {
+	t := displayNameTables[target]
+	return fmt.Sprintf(
+		`COALESCE((SELECT dn.name FROM %s AS dn WHERE dn.%s = %s.id AND dn.locale = :locale), %s.name)`,
+		t.table, t.idColumn, alias, alias,
+	)
+}
+
+// localeArg localizedNameを用いるクエリの名前付きパラメータにリクエストの言語を加える
+func localeArg(ctx context.Context, arg map[string]any) map[string]any {
+	arg["locale"] = i18n.LocaleOf(ctx)
+	return arg
+}
+
+// translationNotFound NamedStoreはバリアントのNotFoundを返すため表示名のNotFoundに置き換える
+func translationNotFound(err error) error {
+	if errors.Is(err, variantService.NotFound) {
+		return service.NotFound
+	}
+	return err
+}
+
+type DisplayNameModel struct {
+	TargetID int    `db:"target_id"`
+	Locale   string `db:"locale"`
+	Name     string `db:"name"`
+}
+
+func (m DisplayNameModel) toDisplayName(target model.Target) model.DisplayName {
+	return model.NewDisplayName(target, m.TargetID, i18n.Locale(m.Locale), model.Name(m.Name))
+}
+
+type DisplayNameClient struct {
+	*Client
+}
+
+func NewDisplayNameClient(injector *do.Injector) (service.DisplayNameRepository, error) {
+	return DisplayNameClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (c DisplayNameClient) Exists(ctx context.Context, target model.Target, id int) (bool, error) {
+	t := displayNameTables[target]
+	row, err := NamedGet[bool](
+		ctx,
+		c.Client,
+		fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %s WHERE id = :id AND deleted_at IS NULL);`, t.parent),
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return false, err
+	}
+	return *row, nil
+}
+
+func (c DisplayNameClient) List(ctx context.Context, target model.Target, id int) ([]model.DisplayName, error) {
+	t := displayNameTables[target]
+	rows, err := NamedSelect[DisplayNameModel](
+		ctx,
+		c.Client,
+		fmt.Sprintf(`SELECT %s AS target_id, locale, name FROM %s WHERE %s = :id ORDER BY locale;`,
+			t.idColumn, t.table, t.idColumn),
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(r DisplayNameModel, _ int) model.DisplayName { return r.toDisplayName(target) }), nil
+}
+
+func (c DisplayNameClient) ListAll(ctx context.Context, target model.Target) ([]model.DisplayName, error) {
+	t := displayNameTables[target]
+	rows, err := NamedSelect[DisplayNameModel](
+		ctx,
+		c.Client,
+		fmt.Sprintf(`SELECT dn.%[2]s AS target_id, dn.locale, dn.name FROM %[1]s AS dn
+				INNER JOIN %[3]s ON (dn.%[2]s = %[3]s.id) WHERE %[3]s.deleted_at IS NULL
+				ORDER BY dn.%[2]s, dn.locale;`, t.table, t.idColumn, t.parent),
+		map[string]any{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(r DisplayNameModel, _ int) model.DisplayName { return r.toDisplayName(target) }), nil
+}
+
+func (c DisplayNameClient) Upsert(ctx context.Context, name model.DisplayName) (*model.DisplayName, error) {
+	t := displayNameTables[name.Target()]
+	if _, err := NamedStore[int](
+		ctx,
+		c.Client,
+		fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, locale, name) VALUES (:id, :locale, :name)
+				ON CONFLICT (%[2]s, locale) DO UPDATE SET name = EXCLUDED.name, updated_at = NOW()
+				RETURNING %[2]s;`, t.table, t.idColumn),
+		map[string]any{"id": name.TargetID(), "locale": name.Locale(), "name": name.Name()},
+	); err != nil {
+		return nil, err
+	}
+
+	return &name, nil
+}
+
+func (c DisplayNameClient) Delete(ctx context.Context, target model.Target, id int, locale i18n.Locale) error {
+	t := displayNameTables[target]
+	_, err := NamedStore[int](
+		ctx,
+		c.Client,
+		fmt.Sprintf(`DELETE FROM %[1]s WHERE %[2]s = :id AND locale = :locale RETURNING %[2]s;`, t.table, t.idColumn),
+		map[string]any{"id": id, "locale": locale},
+	)
+	return translationNotFound(err)
+}
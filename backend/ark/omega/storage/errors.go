@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/morikuni/failure"
+
+	"mods-explore/ark/omega/logic"
+)
+
+// translateError PostgreSQLの制約違反をハンドラーでステータスコードに変換できるfailureにする。それ以外のエラーはそのまま返す
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+
+	violation := &logic.ConstraintViolation{
+		Constraint: pqErr.Constraint,
+		Field:      violatedField(pqErr),
+		Err:        err,
+	}
+	switch pqErr.Code.Name() {
+	case "unique_violation":
+		violation.Kind = logic.ConstraintUnique
+		return failure.Translate(violation, logic.Conflict)
+	case "foreign_key_violation":
+		violation.Kind = logic.ConstraintForeignKey
+		// 削除や更新の対象が他のテーブルから参照されている場合と、存在しないレコードを参照した場合を区別する
+		if strings.Contains(pqErr.Detail, "is still referenced") {
+			return failure.Translate(violation, logic.Conflict)
+		}
+		return failure.Translate(violation, logic.UnprocessableEntity)
+	case "check_violation":
+		violation.Kind = logic.ConstraintCheck
+		return failure.Translate(violation, logic.UnprocessableEntity)
+	case "not_null_violation":
+		violation.Kind = logic.ConstraintNotNull
+		return failure.Translate(violation, logic.UnprocessableEntity)
+	default:
+		return err
+	}
+}
+
+// violatedField 列名が返されない制約は"Key (name)=(...)"の詳細か、"<table>_<column>_check"の命名規則から列名を求める
+func violatedField(pqErr *pq.Error) string {
+	if pqErr.Column != "" {
+		return pqErr.Column
+	}
+
+	if rest, ok := strings.CutPrefix(pqErr.Detail, "Key ("); ok {
+		if field, _, ok := strings.Cut(rest, ")="); ok {
+			// LOWER(name)のような式のインデックスは"lower(name::text)"となるため列名のみにする
+			if inner, ok := strings.CutPrefix(field, "lower("); ok {
+				field, _, _ = strings.Cut(strings.TrimSuffix(inner, ")"), "::")
+			}
+			return field
+		}
+	}
+
+	field := strings.TrimPrefix(pqErr.Constraint, pqErr.Table+"_")
+	for _, suffix := range []string{"_check", "_fkey", "_key"} {
+		field = strings.TrimSuffix(field, suffix)
+	}
+	return field
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/morikuni/failure"
+	"github.com/stretchr/testify/suite"
+
+	"mods-explore/ark/omega/logic"
+)
+
+type testTranslateErrorSuite struct {
+	suite.Suite
+}
+
+func TestTranslateError(t *testing.T) {
+	suite.Run(t, &testTranslateErrorSuite{})
+}
+
+func (s *testTranslateErrorSuite) violation(err error) *logic.ConstraintViolation {
+	var violation *logic.ConstraintViolation
+	if !errors.As(err, &violation) {
+		s.T().Fatalf("制約違反に変換されていません: %v", err)
+	}
+	return violation
+}
+
+func (s *testTranslateErrorSuite) TestUniqueViolation() {
+	s.T().Log("一意制約の違反がConflictになり、詳細から列名を求められるかテスト")
+
+	err := translateError(&pq.Error{
+		Code:       "23505",
+		Table:      "groups",
+		Constraint: "groups_name_key",
+		Detail:     "Key (name)=(cosmic) already exists.",
+	})
+	s.True(failure.Is(err, logic.Conflict))
+	violation := s.violation(err)
+	s.Equal(logic.ConstraintUnique, violation.Kind)
+	s.Equal("groups_name_key", violation.Constraint)
+	s.Equal("name", violation.Field)
+
+	err = translateError(&pq.Error{
+		Code:       "23505",
+		Table:      "dinosaurs",
+		Constraint: "dinosaurs_lower_name_key",
+		Detail:     "Key (lower(name::text))=(dodo) already exists.",
+	})
+	s.Equal("name", s.violation(err).Field)
+}
+
+func (s *testTranslateErrorSuite) TestForeignKeyViolation() {
+	s.T().Log("存在しないレコードの参照はUnprocessableEntity、参照されているレコードの削除はConflictになるかテスト")
+
+	err := translateError(&pq.Error{
+		Code:       "23503",
+		Table:      "variants",
+		Constraint: "variants_group_id_fkey",
+		Detail:     `Key (group_id)=(99) is not present in table "groups".`,
+	})
+	s.True(failure.Is(err, logic.UnprocessableEntity))
+	s.Equal("group_id", s.violation(err).Field)
+
+	err = translateError(&pq.Error{
+		Code:       "23503",
+		Table:      "variants",
+		Constraint: "variants_group_id_fkey",
+		Detail:     `Key (id)=(1) is still referenced from table "variants".`,
+	})
+	s.True(failure.Is(err, logic.Conflict))
+}
+
+func (s *testTranslateErrorSuite) TestCheckAndNotNullViolation() {
+	s.T().Log("検査制約とNOT NULL制約の違反がUnprocessableEntityになるかテスト")
+
+	err := translateError(&pq.Error{
+		Code:       "23514",
+		Table:      "uniques",
+		Constraint: "uniques_health_multiplier_check",
+	})
+	s.True(failure.Is(err, logic.UnprocessableEntity))
+	s.Equal("health_multiplier", s.violation(err).Field)
+
+	err = translateError(&pq.Error{Code: "23502", Table: "variants", Column: "name"})
+	s.True(failure.Is(err, logic.UnprocessableEntity))
+	s.Equal(logic.ConstraintNotNull, s.violation(err).Kind)
+	s.Equal("name", s.violation(err).Field)
+}
+
+func (s *testTranslateErrorSuite) TestOtherError() {
+	s.T().Log("制約違反以外のエラーはそのまま返すかテスト")
+
+	e := errors.New("test")
+	s.Equal(e, translateError(e))
+	pqErr := &pq.Error{Code: "40001"}
+	s.Equal(pqErr, translateError(pqErr))
+	s.Nil(translateError(nil))
+}
@@ -1,20 +1,33 @@
 package storage
 
 import (
+	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
-	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
-var migrationVer uint = 20240212020000
+// migrations バイナリの実行ディレクトリに依存しないようにマイグレーションファイルを埋め込む
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+var migrationVer uint = 20240301002000
 
 type MigrateAction func(m *migrate.Migrate) error
 
 func RunMigration(driver database.Driver, action MigrateAction) error {
-	m, err := migrate.NewWithDatabaseInstance("file://./migrations", "postgres", driver)
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
 	if err != nil {
 		return err
 	}
@@ -22,6 +35,50 @@ func RunMigration(driver database.Driver, action MigrateAction) error {
 	return action(m)
 }
 
+// MigrateDatabase マイグレーション専用のコネクションで実行し、終了後にコネクションを閉じる
+func MigrateDatabase(dsn string, action MigrateAction) (err error) {
+	db, err := ConnectPostgres(dsn)
+	if err != nil {
+		return err
+	}
+
+	driver, err := postgres.WithInstance(db.DB, &postgres.Config{})
+	if err != nil {
+		db.Close()
+		return err
+	}
+	defer func() {
+		if e := driver.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	return RunMigration(driver, action)
+}
+
+// LatestMigrationVersion 埋め込まれたマイグレーションファイルの最新バージョンを返す
+func LatestMigrationVersion() (uint, error) {
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		} else if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
+
 func MigrateUp() MigrateAction {
 	return func(m *migrate.Migrate) error {
 		return m.Up()
@@ -34,6 +91,37 @@ func MigrateDown() MigrateAction {
 	}
 }
 
+// MigrateSteps 正の値で指定数だけup、負の値で指定数だけdownする
+func MigrateSteps(n int) MigrateAction {
+	return func(m *migrate.Migrate) error {
+		return m.Steps(n)
+	}
+}
+
+func MigrateGoto(version uint) MigrateAction {
+	return func(m *migrate.Migrate) error {
+		return m.Migrate(version)
+	}
+}
+
+// MigrateForce dirtyになったバージョンを手動で修正した後に用いる
+func MigrateForce(version int) MigrateAction {
+	return func(m *migrate.Migrate) error {
+		return m.Force(version)
+	}
+}
+
+func MigrateVersion(fn func(version uint, dirty bool)) MigrateAction {
+	return func(m *migrate.Migrate) error {
+		version, dirty, err := m.Version()
+		if err != nil {
+			return err
+		}
+		fn(version, dirty)
+		return nil
+	}
+}
+
 func VerifyMigrationVersion(expected uint) MigrateAction {
 	return func(m *migrate.Migrate) error {
 		actual, dirty, err := m.Version()
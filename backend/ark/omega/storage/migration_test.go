@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"testing"
+)
+
+func TestLatestMigrationVersion(t *testing.T) {
+	t.Run("埋め込んだマイグレーションの最新バージョンと検証用バージョンが一致するか", func(t *testing.T) {
+		latest, err := LatestMigrationVersion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if latest != migrationVer {
+			t.Errorf("migrationVerを更新してください (latest: %d, migrationVer: %d)", latest, migrationVer)
+		}
+	})
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS groups
+(
+    id          SERIAL       PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT groups_name_key UNIQUE (name),
+    CONSTRAINT groups_name_check CHECK (name <> '')
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS variants
+(
+    id          SERIAL       PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    group_id    INTEGER      NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT variants_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE RESTRICT,
+    CONSTRAINT variants_group_id_name_key UNIQUE (group_id, name),
+    CONSTRAINT variants_name_check CHECK (name <> '')
+);
+
+CREATE INDEX IF NOT EXISTS variants_group_id_idx ON variants (group_id);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS dinosaurs
+(
+    id          SERIAL       PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    health      INTEGER      NOT NULL,
+    melee       INTEGER      NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT dinosaurs_name_check CHECK (name <> ''),
+    CONSTRAINT dinosaurs_health_check CHECK (health > 0),
+    CONSTRAINT dinosaurs_melee_check CHECK (melee >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS dinosaurs_name_idx ON dinosaurs (name);
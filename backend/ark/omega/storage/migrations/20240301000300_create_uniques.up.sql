@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS uniques
+(
+    id                 SERIAL       PRIMARY KEY,
+    dinosaur_id        INTEGER      NOT NULL,
+    name               VARCHAR(100) NOT NULL,
+    health_multiplier  REAL         NOT NULL,
+    damage_multiplier  REAL         NOT NULL,
+    created_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT uniques_dinosaur_id_fkey FOREIGN KEY (dinosaur_id) REFERENCES dinosaurs (id) ON DELETE RESTRICT,
+    CONSTRAINT uniques_name_check CHECK (name <> ''),
+    CONSTRAINT uniques_health_multiplier_check CHECK (health_multiplier > 0),
+    CONSTRAINT uniques_damage_multiplier_check CHECK (damage_multiplier > 0)
+);
+
+CREATE INDEX IF NOT EXISTS uniques_dinosaur_id_idx ON uniques (dinosaur_id);
+CREATE INDEX IF NOT EXISTS uniques_name_idx ON uniques (name);
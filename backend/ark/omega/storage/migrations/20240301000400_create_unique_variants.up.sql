@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS unique_variants
+(
+    id          SERIAL       PRIMARY KEY,
+    unique_id   INTEGER      NOT NULL,
+    variant_id  INTEGER      NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_variants_unique_id_fkey FOREIGN KEY (unique_id) REFERENCES uniques (id) ON DELETE CASCADE,
+    CONSTRAINT unique_variants_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES variants (id) ON DELETE RESTRICT,
+    CONSTRAINT unique_variants_unique_id_variant_id_key UNIQUE (unique_id, variant_id)
+);
+
+CREATE INDEX IF NOT EXISTS unique_variants_variant_id_idx ON unique_variants (variant_id);
@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS uniques_name_idx ON uniques (name);
+
+DROP INDEX IF EXISTS groups_updated_at_id_idx;
+DROP INDEX IF EXISTS variants_updated_at_id_idx;
+DROP INDEX IF EXISTS variants_name_id_idx;
+DROP INDEX IF EXISTS uniques_updated_at_id_idx;
+DROP INDEX IF EXISTS uniques_name_id_idx;
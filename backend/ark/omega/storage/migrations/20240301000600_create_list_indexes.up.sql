@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS uniques_name_id_idx ON uniques (name, id);
+CREATE INDEX IF NOT EXISTS uniques_updated_at_id_idx ON uniques (updated_at, id);
+CREATE INDEX IF NOT EXISTS variants_name_id_idx ON variants (name, id);
+CREATE INDEX IF NOT EXISTS variants_updated_at_id_idx ON variants (updated_at, id);
+CREATE INDEX IF NOT EXISTS groups_updated_at_id_idx ON groups (updated_at, id);
+
+DROP INDEX IF EXISTS uniques_name_idx;
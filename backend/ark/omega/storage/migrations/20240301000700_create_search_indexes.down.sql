@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS groups_name_trgm_idx;
+DROP INDEX IF EXISTS groups_name_tsv_idx;
+DROP INDEX IF EXISTS variants_name_trgm_idx;
+DROP INDEX IF EXISTS variants_name_tsv_idx;
+DROP INDEX IF EXISTS dinosaurs_name_trgm_idx;
+DROP INDEX IF EXISTS dinosaurs_name_tsv_idx;
+DROP INDEX IF EXISTS uniques_name_trgm_idx;
+DROP INDEX IF EXISTS uniques_name_tsv_idx;
+
+DROP EXTENSION IF EXISTS pg_trgm;
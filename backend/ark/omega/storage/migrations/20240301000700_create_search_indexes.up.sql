@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS uniques_name_tsv_idx ON uniques USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS uniques_name_trgm_idx ON uniques USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS dinosaurs_name_tsv_idx ON dinosaurs USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS dinosaurs_name_trgm_idx ON dinosaurs USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS variants_name_tsv_idx ON variants USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS variants_name_trgm_idx ON variants USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS groups_name_tsv_idx ON groups USING GIN (to_tsvector('simple', name));
+CREATE INDEX IF NOT EXISTS groups_name_trgm_idx ON groups USING GIN (name gin_trgm_ops);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS variant_descriptions
+(
+    id           SERIAL       PRIMARY KEY,
+    variant_id   INTEGER      NOT NULL,
+    position     INTEGER      NOT NULL,
+    description  VARCHAR(500) NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT variant_descriptions_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES variants (id) ON DELETE CASCADE,
+    CONSTRAINT variant_descriptions_variant_id_position_key UNIQUE (variant_id, position),
+    CONSTRAINT variant_descriptions_position_check CHECK (position >= 0),
+    CONSTRAINT variant_descriptions_description_check CHECK (description <> '')
+);
+
+CREATE INDEX IF NOT EXISTS variant_descriptions_description_tsv_idx
+    ON variant_descriptions USING GIN (to_tsvector('simple', description));
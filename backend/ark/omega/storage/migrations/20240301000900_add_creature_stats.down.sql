@@ -0,0 +1,17 @@
+ALTER TABLE uniques
+    DROP COLUMN IF EXISTS stamina_multiplier,
+    DROP COLUMN IF EXISTS oxygen_multiplier,
+    DROP COLUMN IF EXISTS food_multiplier,
+    DROP COLUMN IF EXISTS weight_multiplier,
+    DROP COLUMN IF EXISTS movement_speed_multiplier,
+    DROP COLUMN IF EXISTS torpidity_multiplier,
+    DROP COLUMN IF EXISTS armor_multiplier;
+
+ALTER TABLE dinosaurs
+    DROP COLUMN IF EXISTS stamina,
+    DROP COLUMN IF EXISTS oxygen,
+    DROP COLUMN IF EXISTS food,
+    DROP COLUMN IF EXISTS weight,
+    DROP COLUMN IF EXISTS movement_speed,
+    DROP COLUMN IF EXISTS torpidity,
+    DROP COLUMN IF EXISTS armor;
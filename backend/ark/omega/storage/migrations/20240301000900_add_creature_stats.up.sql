@@ -0,0 +1,32 @@
+-- 既存の生物は標準的な値で埋め、ユニークの倍率は等倍とする
+ALTER TABLE dinosaurs
+    ADD COLUMN IF NOT EXISTS stamina        INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS oxygen         INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS food           INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS weight         INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS movement_speed INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS torpidity      INTEGER NOT NULL DEFAULT 100,
+    ADD COLUMN IF NOT EXISTS armor          INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT dinosaurs_stamina_check CHECK (stamina > 0),
+    ADD CONSTRAINT dinosaurs_oxygen_check CHECK (oxygen >= 0),
+    ADD CONSTRAINT dinosaurs_food_check CHECK (food > 0),
+    ADD CONSTRAINT dinosaurs_weight_check CHECK (weight > 0),
+    ADD CONSTRAINT dinosaurs_movement_speed_check CHECK (movement_speed > 0 AND movement_speed <= 1000),
+    ADD CONSTRAINT dinosaurs_torpidity_check CHECK (torpidity > 0),
+    ADD CONSTRAINT dinosaurs_armor_check CHECK (armor >= 0);
+
+ALTER TABLE uniques
+    ADD COLUMN IF NOT EXISTS stamina_multiplier        REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS oxygen_multiplier         REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS food_multiplier           REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS weight_multiplier         REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS movement_speed_multiplier REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS torpidity_multiplier      REAL NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS armor_multiplier          REAL NOT NULL DEFAULT 1,
+    ADD CONSTRAINT uniques_stamina_multiplier_check CHECK (stamina_multiplier > 0),
+    ADD CONSTRAINT uniques_oxygen_multiplier_check CHECK (oxygen_multiplier > 0),
+    ADD CONSTRAINT uniques_food_multiplier_check CHECK (food_multiplier > 0),
+    ADD CONSTRAINT uniques_weight_multiplier_check CHECK (weight_multiplier > 0),
+    ADD CONSTRAINT uniques_movement_speed_multiplier_check CHECK (movement_speed_multiplier > 0),
+    ADD CONSTRAINT uniques_torpidity_multiplier_check CHECK (torpidity_multiplier > 0),
+    ADD CONSTRAINT uniques_armor_multiplier_check CHECK (armor_multiplier > 0);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS dinosaur_stat_growths;
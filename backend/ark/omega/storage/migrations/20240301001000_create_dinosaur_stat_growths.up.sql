@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS dinosaur_stat_growths
+(
+    dinosaur_id     INTEGER     NOT NULL,
+    stat            VARCHAR(20) NOT NULL,
+    wild_increase   REAL        NOT NULL DEFAULT 0,
+    tamed_increase  REAL        NOT NULL DEFAULT 0,
+    tamed_add       REAL        NOT NULL DEFAULT 0,
+    tamed_affinity  REAL        NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT dinosaur_stat_growths_pkey PRIMARY KEY (dinosaur_id, stat),
+    CONSTRAINT dinosaur_stat_growths_dinosaur_id_fkey FOREIGN KEY (dinosaur_id) REFERENCES dinosaurs (id) ON DELETE CASCADE,
+    CONSTRAINT dinosaur_stat_growths_stat_check CHECK (
+        stat IN ('health', 'stamina', 'oxygen', 'food', 'weight', 'melee', 'movement_speed', 'torpidity', 'armor')
+    ),
+    CONSTRAINT dinosaur_stat_growths_wild_increase_check CHECK (wild_increase >= 0),
+    CONSTRAINT dinosaur_stat_growths_tamed_increase_check CHECK (tamed_increase >= 0)
+);
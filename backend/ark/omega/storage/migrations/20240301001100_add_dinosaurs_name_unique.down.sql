@@ -0,0 +1 @@
+DROP INDEX IF EXISTS dinosaurs_lower_name_key;
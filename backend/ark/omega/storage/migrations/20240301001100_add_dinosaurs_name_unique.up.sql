@@ -0,0 +1,17 @@
+-- 大文字小文字のみ異なる重複した種は最も古い種へ寄せてから削除する
+WITH canonical AS (
+    SELECT id, MIN(id) OVER (PARTITION BY LOWER(name)) AS canonical_id
+    FROM dinosaurs
+)
+UPDATE uniques u
+SET dinosaur_id = c.canonical_id
+FROM canonical c
+WHERE u.dinosaur_id = c.id
+  AND c.id <> c.canonical_id;
+
+DELETE FROM dinosaurs d
+USING dinosaurs kept
+WHERE LOWER(d.name) = LOWER(kept.name)
+  AND d.id > kept.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS dinosaurs_lower_name_key ON dinosaurs (LOWER(name));
@@ -0,0 +1,4 @@
+ALTER TABLE unique_variants
+    DROP CONSTRAINT IF EXISTS unique_variants_position_check,
+    DROP CONSTRAINT IF EXISTS unique_variants_unique_id_position_key,
+    DROP COLUMN IF EXISTS position;
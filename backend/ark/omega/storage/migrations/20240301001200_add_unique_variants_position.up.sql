@@ -0,0 +1,14 @@
+ALTER TABLE unique_variants ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+-- 既存のバリアントは登録順を付与した順序とする
+UPDATE unique_variants AS uv
+SET position = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY unique_id ORDER BY id) - 1 AS position
+    FROM unique_variants
+) AS ordered
+WHERE uv.id = ordered.id;
+
+ALTER TABLE unique_variants
+    ADD CONSTRAINT unique_variants_unique_id_position_key UNIQUE (unique_id, position),
+    ADD CONSTRAINT unique_variants_position_check CHECK (position >= 0);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS variant_rules
+(
+    id                SERIAL      PRIMARY KEY,
+    kind              VARCHAR(20) NOT NULL,
+    group_id          INTEGER,
+    variant_id        INTEGER,
+    other_variant_id  INTEGER,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT variant_rules_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE,
+    CONSTRAINT variant_rules_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES variants (id) ON DELETE CASCADE,
+    CONSTRAINT variant_rules_other_variant_id_fkey FOREIGN KEY (other_variant_id) REFERENCES variants (id) ON DELETE CASCADE,
+    CONSTRAINT variant_rules_kind_check CHECK (kind IN ('group_exclusive', 'incompatible', 'requires')),
+    CONSTRAINT variant_rules_target_check CHECK (
+        (kind = 'group_exclusive' AND group_id IS NOT NULL AND variant_id IS NULL AND other_variant_id IS NULL)
+        OR (kind <> 'group_exclusive' AND group_id IS NULL AND variant_id IS NOT NULL
+            AND other_variant_id IS NOT NULL AND variant_id <> other_variant_id)
+    )
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS variant_rules_group_id_key ON variant_rules (group_id) WHERE kind = 'group_exclusive';
+CREATE UNIQUE INDEX IF NOT EXISTS variant_rules_variants_key ON variant_rules (kind, variant_id, other_variant_id)
+    WHERE kind <> 'group_exclusive';
+CREATE INDEX IF NOT EXISTS variant_rules_variant_id_idx ON variant_rules (variant_id);
+CREATE INDEX IF NOT EXISTS variant_rules_other_variant_id_idx ON variant_rules (other_variant_id);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS group_names;
+DROP TABLE IF EXISTS variant_names;
+DROP TABLE IF EXISTS unique_names;
+DROP TABLE IF EXISTS dinosaur_names;
@@ -0,0 +1,43 @@
+CREATE TABLE IF NOT EXISTS dinosaur_names
+(
+    dinosaur_id INTEGER      NOT NULL,
+    locale      VARCHAR(8)   NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (dinosaur_id, locale),
+    CONSTRAINT dinosaur_names_dinosaur_id_fkey FOREIGN KEY (dinosaur_id) REFERENCES dinosaurs (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS unique_names
+(
+    unique_id   INTEGER      NOT NULL,
+    locale      VARCHAR(8)   NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (unique_id, locale),
+    CONSTRAINT unique_names_unique_id_fkey FOREIGN KEY (unique_id) REFERENCES uniques (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS variant_names
+(
+    variant_id  INTEGER      NOT NULL,
+    locale      VARCHAR(8)   NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (variant_id, locale),
+    CONSTRAINT variant_names_variant_id_fkey FOREIGN KEY (variant_id) REFERENCES variants (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS group_names
+(
+    group_id    INTEGER      NOT NULL,
+    locale      VARCHAR(8)   NOT NULL,
+    name        VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (group_id, locale),
+    CONSTRAINT group_names_group_id_fkey FOREIGN KEY (group_id) REFERENCES groups (id) ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE uniques DROP COLUMN IF EXISTS version;
+ALTER TABLE variants DROP COLUMN IF EXISTS version;
+ALTER TABLE groups DROP COLUMN IF EXISTS version;
@@ -0,0 +1,4 @@
+-- 楽観的排他制御のため、更新の度に1つ増やす行のバージョンを持たせる
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE uniques ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_logs
+(
+    id          BIGSERIAL    PRIMARY KEY,
+    entity_type VARCHAR(20)  NOT NULL,
+    entity_id   INTEGER      NOT NULL,
+    action      VARCHAR(10)  NOT NULL,
+    before      JSONB,
+    after       JSONB,
+    actor       VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    CONSTRAINT audit_logs_entity_type_check CHECK (entity_type IN ('dinosaur', 'unique', 'variant', 'group', 'rule')),
+    CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete'))
+);
+
+CREATE INDEX IF NOT EXISTS audit_logs_entity_idx ON audit_logs (entity_type, entity_id, id);
+CREATE INDEX IF NOT EXISTS audit_logs_created_at_idx ON audit_logs (created_at);
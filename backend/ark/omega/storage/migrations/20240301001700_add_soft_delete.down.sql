@@ -0,0 +1,22 @@
+-- 復元の履歴は更新の履歴として残す
+UPDATE audit_logs SET action = 'update' WHERE action = 'restore';
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete'));
+
+DROP INDEX IF EXISTS uniques_deleted_at_idx;
+DROP INDEX IF EXISTS dinosaurs_deleted_at_idx;
+DROP INDEX IF EXISTS variants_deleted_at_idx;
+DROP INDEX IF EXISTS groups_deleted_at_idx;
+
+-- ゴミ箱の行は削除されずに残るため、名前が重複する場合は戻せない
+DROP INDEX IF EXISTS dinosaurs_lower_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS dinosaurs_lower_name_key ON dinosaurs (LOWER(name));
+DROP INDEX IF EXISTS variants_group_id_name_key;
+ALTER TABLE variants ADD CONSTRAINT variants_group_id_name_key UNIQUE (group_id, name);
+DROP INDEX IF EXISTS groups_name_key;
+ALTER TABLE groups ADD CONSTRAINT groups_name_key UNIQUE (name);
+
+ALTER TABLE uniques DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE dinosaurs DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE variants DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE groups DROP COLUMN IF EXISTS deleted_at;
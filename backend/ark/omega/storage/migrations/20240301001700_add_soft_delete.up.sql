@@ -0,0 +1,20 @@
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE variants ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE dinosaurs ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE uniques ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- ゴミ箱の行と同じ名前で作成できるよう、名前の一意性は削除されていない行のみで判定する
+ALTER TABLE groups DROP CONSTRAINT IF EXISTS groups_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS groups_name_key ON groups (name) WHERE deleted_at IS NULL;
+ALTER TABLE variants DROP CONSTRAINT IF EXISTS variants_group_id_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS variants_group_id_name_key ON variants (group_id, name) WHERE deleted_at IS NULL;
+DROP INDEX IF EXISTS dinosaurs_lower_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS dinosaurs_lower_name_key ON dinosaurs (LOWER(name)) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS groups_deleted_at_idx ON groups (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS variants_deleted_at_idx ON variants (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS dinosaurs_deleted_at_idx ON dinosaurs (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS uniques_deleted_at_idx ON uniques (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE audit_logs DROP CONSTRAINT IF EXISTS audit_logs_action_check;
+ALTER TABLE audit_logs ADD CONSTRAINT audit_logs_action_check CHECK (action IN ('create', 'update', 'delete', 'restore'));
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS api_tokens
+(
+    id         SERIAL       PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    token_hash CHAR(64)     NOT NULL UNIQUE,
+    role       VARCHAR(10)  NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ,
+    CONSTRAINT api_tokens_role_check CHECK (role IN ('viewer', 'editor', 'admin'))
+);
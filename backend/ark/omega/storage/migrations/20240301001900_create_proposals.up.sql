@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS proposals
+(
+    id             SERIAL       PRIMARY KEY,
+    target         VARCHAR(10)  NOT NULL,
+    target_id      INTEGER      NOT NULL,
+    base_version   INTEGER      NOT NULL,
+    before         JSONB        NOT NULL,
+    changes        JSONB        NOT NULL,
+    comment        VARCHAR(500) NOT NULL DEFAULT '',
+    status         VARCHAR(10)  NOT NULL DEFAULT 'pending',
+    proposer       VARCHAR(100) NOT NULL,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    reviewer       VARCHAR(100),
+    review_comment VARCHAR(500) NOT NULL DEFAULT '',
+    reviewed_at    TIMESTAMPTZ,
+    CONSTRAINT proposals_target_check CHECK (target IN ('unique', 'variant', 'group')),
+    CONSTRAINT proposals_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+-- 審査待ちの一覧を提出順に取得する
+CREATE INDEX IF NOT EXISTS proposals_status_idx ON proposals (status, id);
@@ -0,0 +1 @@
+ALTER TABLE dinosaurs DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+-- 種も他の対象と同じく、If-Matchで同時の変更による上書きを防ぐため行のバージョンを持たせる
+ALTER TABLE dinosaurs ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
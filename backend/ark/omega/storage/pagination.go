@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"mods-explore/ark/omega/logic"
+)
+
+// sortColumn キーセットページネーションに用いるソート列の式と、カーソルの値をSQLで比較する際の型
+type sortColumn struct {
+	expr     string
+	castType string
+}
+
+// keyset ソート列とidの組で前ページの最後のレコードより後ろを取得する条件を組み立てる
+type keyset struct {
+	column sortColumn
+	idExpr string
+	key    string
+	page   logic.PageRequest
+}
+
+// where カーソルが無い先頭ページでは空文字を返す。値はargに格納する
+func (k keyset) where(arg map[string]any) (string, error) {
+	position, err := k.page.Cursor().Position(k.key)
+	if err != nil {
+		return "", err
+	}
+	if position == nil {
+		return "", nil
+	}
+
+	arg["cursor_value"] = position.Value
+	arg["cursor_id"] = position.ID
+	operator := ">"
+	if k.page.Order() == logic.Desc {
+		operator = "<"
+	}
+	return fmt.Sprintf(
+		"(%s, %s) %s (CAST(:cursor_value AS %s), :cursor_id)",
+		k.column.expr, k.idExpr, operator, k.column.castType,
+	), nil
+}
+
+// orderBy 次ページの有無を判定するためにlimitより1件多く取得する
+func (k keyset) orderBy(arg map[string]any) string {
+	direction := "ASC"
+	if k.page.Order() == logic.Desc {
+		direction = "DESC"
+	}
+	arg["limit"] = k.page.Limit() + 1
+	return fmt.Sprintf("ORDER BY %s %s, %s %s LIMIT :limit", k.column.expr, direction, k.idExpr, direction)
+}
+
+// newPage limitを超えて取得できた場合は最後に返すレコードの位置から次ページのカーソルを生成する
+func newPage[M, T any](
+	k keyset,
+	rows []M,
+	position func(M) (value any, id int),
+	convert func(M) (T, error),
+) (*logic.Page[T], error) {
+	var next logic.Cursor
+	if uint(len(rows)) > k.page.Limit() {
+		rows = rows[:k.page.Limit()]
+		value, id := position(rows[len(rows)-1])
+		cursor, err := logic.NewCursor(k.key, value, id)
+		if err != nil {
+			return nil, err
+		}
+		next = cursor
+	}
+
+	items := make([]T, 0, len(rows))
+	for _, row := range rows {
+		item, err := convert(row)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	page := logic.NewPage(items, next)
+	return &page, nil
+}
+
+// whereClause 空の条件を除いてANDで結合する
+func whereClause(conditions []string) string {
+	var cs []string
+	for _, c := range conditions {
+		if c != "" {
+			cs = append(cs, c)
+		}
+	}
+	if len(cs) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(cs, " AND ")
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"testing"
+
+	"mods-explore/ark/omega/logic"
+)
+
+type testPageRow struct {
+	id   int
+	name string
+}
+
+func TestKeysetPage(t *testing.T) {
+	page, err := logic.NewPageRequest(2, "", logic.Asc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := keyset{column: sortColumn{expr: "name", castType: "VARCHAR"}, idExpr: "id", key: "name", page: page}
+	position := func(r testPageRow) (any, int) { return r.name, r.id }
+	convert := func(r testPageRow) (int, error) { return r.id, nil }
+
+	t.Run("limitより多く取得できた場合は次ページのカーソルを返すか", func(t *testing.T) {
+		rows := []testPageRow{{1, "a"}, {2, "b"}, {3, "c"}}
+		result, err := newPage(k, rows, position, convert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Items()) != 2 {
+			t.Errorf("limit分のレコードになっていません %v", result.Items())
+		}
+
+		next, err := result.NextCursor().Position("name")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if next.Value != "b" || next.ID != 2 {
+			t.Errorf("最後のレコードの位置になっていません %+v", next)
+		}
+	})
+
+	t.Run("最終ページでは次ページのカーソルを返さないか", func(t *testing.T) {
+		rows := []testPageRow{{1, "a"}}
+		result, err := newPage(k, rows, position, convert)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.NextCursor() != "" {
+			t.Errorf("最終ページでカーソルが返されました %s", result.NextCursor())
+		}
+	})
+
+	t.Run("カーソルの位置から後ろを取得する条件になるか", func(t *testing.T) {
+		cursor, err := logic.NewCursor("name", "b", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page, err := logic.NewPageRequest(2, cursor, logic.Desc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arg := map[string]any{}
+		where, err := keyset{column: k.column, idExpr: k.idExpr, key: k.key, page: page}.where(arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if where != "(name, id) < (CAST(:cursor_value AS VARCHAR), :cursor_id)" {
+			t.Errorf("条件が異なります %s", where)
+		}
+		if arg["cursor_value"] != "b" || arg["cursor_id"] != 2 {
+			t.Errorf("カーソルの値が格納されていません %v", arg)
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+	variantService "mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// ProposalModel 審査待ちの提案ではreviewerとreviewed_atがNULLになる
+type ProposalModel struct {
+	ID            int        `db:"id"`
+	Target        string     `db:"target"`
+	TargetID      int        `db:"target_id"`
+	BaseVersion   int        `db:"base_version"`
+	Before        []byte     `db:"before"`
+	Changes       []byte     `db:"changes"`
+	Comment       string     `db:"comment"`
+	Status        string     `db:"status"`
+	Proposer      string     `db:"proposer"`
+	CreatedAt     time.Time  `db:"created_at"`
+	Reviewer      *string    `db:"reviewer"`
+	ReviewComment string     `db:"review_comment"`
+	ReviewedAt    *time.Time `db:"reviewed_at"`
+}
+
+const proposalColumns = `id, target, target_id, base_version, before, changes, comment, status, proposer, created_at,
+	reviewer, review_comment, reviewed_at`
+
+func (m ProposalModel) toProposal() (model.Proposal, error) {
+	before, err := model.NewFields(m.Before)
+	if err != nil {
+		return model.Proposal{}, err
+	}
+	changes, err := model.NewFields(m.Changes)
+	if err != nil {
+		return model.Proposal{}, err
+	}
+
+	var review *model.Review
+	if m.Reviewer != nil && m.ReviewedAt != nil {
+		review = model.NewReview(logic.Actor(*m.Reviewer), model.Comment(m.ReviewComment), *m.ReviewedAt)
+	}
+	return model.NewProposal(
+		model.ProposalID(m.ID),
+		model.Target(m.Target),
+		m.TargetID,
+		logic.Version(m.BaseVersion),
+		before,
+		changes,
+		model.Comment(m.Comment),
+		model.Status(m.Status),
+		logic.Actor(m.Proposer),
+		m.CreatedAt,
+		review,
+	), nil
+}
+
+// proposalNotFound NamedGetはバリアントのNotFoundを返すため提案のエラーに置き換える
+func proposalNotFound(err error, replace error) error {
+	if errors.Is(err, variantService.NotFound) {
+		return replace
+	}
+	return err
+}
+
+type ProposalClient struct {
+	*Client
+}
+
+func NewProposalClient(injector *do.Injector) (service.ProposalRepository, error) {
+	return ProposalClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (p ProposalClient) Select(ctx context.Context, id model.ProposalID) (*model.Proposal, error) {
+	row, err := NamedGet[ProposalModel](
+		ctx,
+		p.Client,
+		`SELECT `+proposalColumns+` FROM proposals WHERE id = :id;`,
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return nil, proposalNotFound(err, service.NotFound)
+	}
+
+	proposal, err := row.toProposal()
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+func (p ProposalClient) List(ctx context.Context, query service.ListProposals) (*logic.Page[model.Proposal], error) {
+	k := keyset{
+		column: sortColumn{expr: "id", castType: "INTEGER"},
+		idExpr: "id",
+		key:    "id",
+		page:   query.PageRequest,
+	}
+	arg := map[string]any{}
+	var conditions []string
+	if query.Status() != "" {
+		arg["status"] = query.Status().Value()
+		conditions = append(conditions, "status = :status")
+	}
+	if query.Target() != "" {
+		arg["target"] = query.Target().Value()
+		conditions = append(conditions, "target = :target")
+	}
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[ProposalModel](
+		ctx,
+		p.Client,
+		fmt.Sprintf(
+			`SELECT `+proposalColumns+` FROM proposals %s %s;`,
+			whereClause(append(conditions, where)), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r ProposalModel) (any, int) { return r.ID, r.ID },
+		func(r ProposalModel) (model.Proposal, error) { return r.toProposal() },
+	)
+}
+
+func (p ProposalClient) Insert(ctx context.Context, create service.CreateProposal) (*model.Proposal, error) {
+	before, err := json.Marshal(create.Base().Fields())
+	if err != nil {
+		return nil, err
+	}
+	changes, err := json.Marshal(create.Changes())
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := NamedGet[ProposalModel](
+		ctx,
+		p.Client,
+		`INSERT INTO proposals (target, target_id, base_version, before, changes, comment, proposer)
+				VALUES (:target, :target_id, :base_version, CAST(:before AS JSONB), CAST(:changes AS JSONB), :comment, :proposer)
+				RETURNING `+proposalColumns+`;`,
+		map[string]any{
+			"target":       create.Target().Value(),
+			"target_id":    create.TargetID(),
+			"base_version": create.Base().Version(),
+			"before":       string(before),
+			"changes":      string(changes),
+			"comment":      create.Comment().Value(),
+			"proposer":     create.Proposer().Value(),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal, err := row.toProposal()
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
+
+// Review 審査待ちの行のみを更新し、同時に審査された場合は後の審査を失敗させる
+func (p ProposalClient) Review(ctx context.Context, review service.ReviewProposal) (*model.Proposal, error) {
+	row, err := NamedGet[ProposalModel](
+		ctx,
+		p.Client,
+		`UPDATE proposals SET status = :status, reviewer = :reviewer, review_comment = :comment, reviewed_at = NOW()
+				WHERE id = :id AND status = 'pending'
+				RETURNING `+proposalColumns+`;`,
+		map[string]any{
+			"id":       review.ID(),
+			"status":   review.Status().Value(),
+			"reviewer": review.Reviewer().Value(),
+			"comment":  review.Comment().Value(),
+		},
+	)
+	if err != nil {
+		return nil, proposalNotFound(err, service.AlreadyReviewed)
+	}
+
+	proposal, err := row.toProposal()
+	if err != nil {
+		return nil, err
+	}
+	return &proposal, nil
+}
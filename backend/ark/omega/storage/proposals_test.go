@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/proposal/domain/model"
+	"mods-explore/ark/omega/logic/proposal/domain/service"
+)
+
+type testProposalSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestProposalSuite(t *testing.T) {
+	suite.Run(t, &testProposalSuite{})
+}
+
+func (s *testProposalSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+var proposalRowColumns = []string{
+	"id", "target", "target_id", "base_version", "before", "changes", "comment", "status", "proposer", "created_at",
+	"reviewer", "review_comment", "reviewed_at",
+}
+
+func (s *testProposalSuite) TestList() {
+	ctx := context.Background()
+	client := ProposalClient{&s.cli}
+	createdAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	page, err := logic.NewPageRequest(1, "", logic.Asc)
+	s.Require().NoError(err)
+
+	s.T().Log("審査待ちの提案を提出順に取得するテスト")
+	s.mock.ExpectQuery(`FROM proposals WHERE status = \? AND target = \? ORDER BY id ASC, id ASC LIMIT \?`).
+		WithArgs("pending", "unique", 2).
+		WillReturnRows(
+			sqlxmock.NewRows(proposalRowColumns).
+				AddRow(
+					1, "unique", 3, 2, []byte(`{"health_multiplier":2}`), []byte(`{"health_multiplier":2.5}`),
+					"", "pending", "anonymous", createdAt, nil, "", nil,
+				),
+		)
+
+	proposals, err := client.List(ctx, service.NewListProposals(page, model.StatusPending, model.TargetUnique))
+	s.Require().NoError(err)
+	s.Require().Len(proposals.Items(), 1)
+	proposal := proposals.Items()[0]
+	s.Equal(logic.Version(2), proposal.BaseVersion())
+	s.Equal([]model.FieldChange{{
+		Field: "health_multiplier", Before: []byte(`2`), After: []byte(`2.5`),
+	}}, proposal.Diff())
+	s.Nil(proposal.Review())
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testProposalSuite) TestReview() {
+	ctx := context.Background()
+	client := ProposalClient{&s.cli}
+
+	s.T().Log("審査待ちでない提案はAlreadyReviewedになるテスト")
+	s.mock.ExpectQuery(`UPDATE proposals SET status = \?, reviewer = \?, review_comment = \?, reviewed_at = NOW\(\)\s+WHERE id = \? AND status = 'pending'`).
+		WithArgs("approved", "moderator", "ok", 1).
+		WillReturnRows(sqlxmock.NewRows(proposalRowColumns))
+
+	_, err := client.Review(ctx, service.NewReviewProposal(1, model.StatusApproved, "moderator", "ok"))
+	s.ErrorIs(err, service.AlreadyReviewed)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/i18n"
+	"mods-explore/ark/omega/logic/search/domain/model"
+	"mods-explore/ark/omega/logic/search/domain/service"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
+)
+
+// searchHeadlineOptions ts_headlineで一致箇所を囲むタグ。名前と説明文は短いので全体を返す
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+
+// searchQueries 種類毎の検索クエリ。全文検索で前方一致したものとトライグラムで類似したものを対象にする
+// pg_trgmの%演算子を含むのでfmt.Sprintfで組み立てないこと。表示名は一致した対象の名前のみをリクエストの言語にする
+var searchQueries = map[model.HitType]string{
+	model.HitUnique: `SELECT 'unique' AS hit_type, u.id, ` + localizedName(translation.TargetUnique, "u") + ` AS name,
+			ts_headline('simple', u.name || ' (' || d.name || ')', to_tsquery('simple', :tsquery), :headline) AS snippet,
+			ts_rank(
+				setweight(to_tsvector('simple', u.name), 'A') || setweight(to_tsvector('simple', d.name), 'B'),
+				to_tsquery('simple', :tsquery)
+			) + GREATEST(similarity(u.name, :keyword), similarity(d.name, :keyword)) AS rank
+		FROM uniques AS u JOIN dinosaurs AS d ON u.dinosaur_id = d.id
+		WHERE u.deleted_at IS NULL AND (
+			to_tsvector('simple', u.name) @@ to_tsquery('simple', :tsquery)
+			OR to_tsvector('simple', d.name) @@ to_tsquery('simple', :tsquery)
+			OR u.name % :keyword OR d.name % :keyword
+		)`,
+	model.HitVariant: `SELECT 'variant' AS hit_type, v.id, ` + localizedName(translation.TargetVariant, "v") + ` AS name,
+			ts_headline(
+				'simple', v.name || ' (' || g.name || ')' || COALESCE(' — ' || vd.text, ''),
+				to_tsquery('simple', :tsquery), :headline
+			) AS snippet,
+			ts_rank(
+				setweight(to_tsvector('simple', v.name), 'A') || setweight(to_tsvector('simple', g.name), 'B')
+					|| setweight(to_tsvector('simple', COALESCE(vd.text, '')), 'C'),
+				to_tsquery('simple', :tsquery)
+			) + similarity(v.name, :keyword) AS rank
+		FROM variants AS v JOIN groups AS g ON v.group_id = g.id
+			LEFT JOIN LATERAL (
+				SELECT string_agg(description, ' / ' ORDER BY position, id) AS text
+				FROM variant_descriptions WHERE variant_id = v.id
+			) AS vd ON TRUE
+		WHERE v.deleted_at IS NULL AND (
+			to_tsvector('simple', v.name) @@ to_tsquery('simple', :tsquery)
+			OR to_tsvector('simple', g.name) @@ to_tsquery('simple', :tsquery)
+			OR v.name % :keyword
+			OR EXISTS (
+				SELECT 1 FROM variant_descriptions AS d
+				WHERE d.variant_id = v.id AND to_tsvector('simple', d.description) @@ to_tsquery('simple', :tsquery)
+			)
+		)`,
+	model.HitGroup: `SELECT 'group' AS hit_type, g.id, ` + localizedName(translation.TargetGroup, "g") + ` AS name,
+			ts_headline('simple', g.name, to_tsquery('simple', :tsquery), :headline) AS snippet,
+			ts_rank(setweight(to_tsvector('simple', g.name), 'A'), to_tsquery('simple', :tsquery))
+				+ similarity(g.name, :keyword) AS rank
+		FROM groups AS g
+		WHERE g.deleted_at IS NULL AND (
+			to_tsvector('simple', g.name) @@ to_tsquery('simple', :tsquery)
+			OR g.name % :keyword
+		)`,
+}
+
+type SearchHitModel struct {
+	HitType string  `db:"hit_type"`
+	ID      int     `db:"id"`
+	Name    string  `db:"name"`
+	Snippet string  `db:"snippet"`
+	Rank    float32 `db:"rank"`
+}
+
+type SearchClient struct {
+	*Client
+}
+
+func NewSearchClient(injector *do.Injector) (service.SearchRepository, error) {
+	return SearchClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (c SearchClient) Search(ctx context.Context, query service.SearchQuery) (model.Hits, error) {
+	parts := lo.FilterMap(query.Types(), func(t model.HitType, _ int) (string, bool) {
+		q, ok := searchQueries[t]
+		return q, ok
+	})
+	if len(parts) == 0 {
+		return model.Hits{}, nil
+	}
+
+	rows, err := NamedSelect[SearchHitModel](
+		ctx,
+		c.Client,
+		`SELECT hit_type, id, name, snippet, rank FROM (`+strings.Join(parts, " UNION ALL ")+`) AS hits
+			ORDER BY rank DESC, hit_type, id LIMIT :limit;`,
+		map[string]any{
+			"tsquery":  prefixTSQuery(query.Keyword().Value()),
+			"keyword":  query.Keyword().Value(),
+			"headline": searchHeadlineOptions,
+			"limit":    query.Limit(),
+			"locale":   i18n.LocaleOf(ctx),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(r SearchHitModel, _ int) model.Hit {
+		return model.NewHit(model.HitType(r.HitType), r.ID, r.Name, model.Snippet(r.Snippet), model.Rank(r.Rank))
+	}), nil
+}
+
+// prefixTSQuery 入力途中の単語にも一致するよう、記号を除いた各単語を前方一致でAND検索するtsqueryにする
+func prefixTSQuery(keyword string) string {
+	words := strings.FieldsFunc(keyword, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(lo.Map(words, func(w string, _ int) string { return w + ":*" }), " & ")
+}
@@ -0,0 +1,19 @@
+package storage
+
+import "testing"
+
+func TestPrefixTSQuery(t *testing.T) {
+	for _, c := range []struct {
+		keyword  string
+		expected string
+	}{
+		{"kenny", "kenny:*"},
+		{"Cosmic Singularity", "Cosmic:* & Singularity:*"},
+		{"dodo's & !rex", "dodo:* & s:* & rex:*"},
+		{"!!", ""},
+	} {
+		if actual := prefixTSQuery(c.keyword); actual != c.expected {
+			t.Errorf("tsqueryが異なります (keyword: %q, actual: %q, expected: %q)", c.keyword, actual, c.expected)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic/creature/domain/model"
+	"mods-explore/ark/omega/logic/creature/domain/service"
+)
+
+type StatGrowthModel struct {
+	Stat          string  `db:"stat"`
+	WildIncrease  float32 `db:"wild_increase"`
+	TamedIncrease float32 `db:"tamed_increase"`
+	TamedAdd      float32 `db:"tamed_add"`
+	TamedAffinity float32 `db:"tamed_affinity"`
+}
+
+type StatGrowthClient struct {
+	*Client
+}
+
+func NewStatGrowthClient(injector *do.Injector) (service.StatGrowthRepository, error) {
+	return StatGrowthClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (c StatGrowthClient) FindStatGrowth(ctx context.Context, id model.DinosaurID) (model.SpeciesStatGrowth, error) {
+	return selectStatGrowth(ctx, c.Client, id)
+}
+
+func selectStatGrowth(ctx context.Context, client *Client, id model.DinosaurID) (model.SpeciesStatGrowth, error) {
+	rows, err := NamedSelect[StatGrowthModel](
+		ctx,
+		client,
+		`SELECT stat, wild_increase, tamed_increase, tamed_add, tamed_affinity
+			FROM dinosaur_stat_growths WHERE dinosaur_id = :dinosaur_id;`,
+		map[string]any{"dinosaur_id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	growth := model.SpeciesStatGrowth{}
+	for _, row := range rows {
+		if err = row.addTo(growth); err != nil {
+			return nil, err
+		}
+	}
+	return growth, nil
+}
+
+// statGrowthListModel 複数の種の成長値をまとめて取得するため、種のIDも合わせて取得する
+type statGrowthListModel struct {
+	StatGrowthModel
+	DinosaurID int `db:"dinosaur_id"`
+}
+
+// selectStatGrowths 成長値が登録されていない種は結果に含めない
+func selectStatGrowths(
+	ctx context.Context, client *Client, ids []model.DinosaurID,
+) (map[model.DinosaurID]model.SpeciesStatGrowth, error) {
+	rows, err := NamedSelect[statGrowthListModel](
+		ctx,
+		client,
+		`SELECT dinosaur_id, stat, wild_increase, tamed_increase, tamed_add, tamed_affinity
+			FROM dinosaur_stat_growths WHERE dinosaur_id = ANY(:dinosaur_ids);`,
+		map[string]any{
+			"dinosaur_ids": pq.Array(lo.Map(ids, func(id model.DinosaurID, _ int) int64 { return int64(id) })),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	growths := make(map[model.DinosaurID]model.SpeciesStatGrowth)
+	for _, row := range rows {
+		id := model.DinosaurID(row.DinosaurID)
+		if growths[id] == nil {
+			growths[id] = model.SpeciesStatGrowth{}
+		}
+		if err = row.addTo(growths[id]); err != nil {
+			return nil, err
+		}
+	}
+	return growths, nil
+}
+
+func (m StatGrowthModel) addTo(growth model.SpeciesStatGrowth) error {
+	kind, err := model.NewStatKind(m.Stat)
+	if err != nil {
+		return err
+	}
+	g, err := model.NewStatGrowth(m.WildIncrease, m.TamedIncrease, m.TamedAdd, m.TamedAffinity)
+	if err != nil {
+		return err
+	}
+	growth[kind] = g
+	return nil
+}
+
+// replaceStatGrowth 登録済みの成長値を全て削除してから挿入する。トランザクション内で呼び出すこと
+func replaceStatGrowth(ctx context.Context, client *Client, id model.DinosaurID, growth model.SpeciesStatGrowth) error {
+	if err := NamedDelete(
+		ctx,
+		client,
+		`DELETE FROM dinosaur_stat_growths WHERE dinosaur_id = :dinosaur_id;`,
+		map[string]any{"dinosaur_id": id},
+	); err != nil {
+		return err
+	}
+	if len(growth) == 0 {
+		return nil
+	}
+
+	records := make([]map[string]any, 0, len(growth))
+	for _, kind := range model.StatKinds() {
+		g, ok := growth[kind]
+		if !ok {
+			continue
+		}
+		records = append(records, map[string]any{
+			"dinosaur_id":    id,
+			"stat":           kind,
+			"wild_increase":  g.WildIncrease(),
+			"tamed_increase": g.TamedIncrease(),
+			"tamed_add":      g.TamedAdd(),
+			"tamed_affinity": g.TamedAffinity(),
+		})
+	}
+	return NamedExec(
+		ctx,
+		client,
+		`INSERT INTO dinosaur_stat_growths (dinosaur_id, stat, wild_increase, tamed_increase, tamed_add, tamed_affinity)
+			VALUES (:dinosaur_id, :stat, :wild_increase, :tamed_increase, :tamed_add, :tamed_affinity);`,
+		records,
+	)
+}
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -12,6 +13,11 @@ import (
 )
 
 func (c *Client) WithTransaction(ctx context.Context, fn func(context.Context) (any, error)) (_ any, err error) {
+	// 既にトランザクション中であればネストしたトランザクションとしてセーブポイントを用いる
+	if tx, ok := GetTx(ctx); ok {
+		return c.withSavepoint(ctx, tx, fn)
+	}
+
 	timeout, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
@@ -36,11 +42,43 @@ func (c *Client) WithTransaction(ctx context.Context, fn func(context.Context) (
 		}
 		if e := tx.Commit(); e != nil {
 			c.logger.ErrorContext(ctx, "failed to commit transaction", slog.Any("error", e))
+			err = e
 		}
 	}()
 	return fn(SetTx(timeout, tx))
 }
 
+// withSavepoint 外側のトランザクションはそのままに、失敗時はセーブポイントまでのみ巻き戻す
+func (c *Client) withSavepoint(ctx context.Context, tx *sqlx.Tx, fn func(context.Context) (any, error)) (_ any, err error) {
+	depth := savepointDepth(ctx) + 1
+	name := fmt.Sprintf("sp_%d", depth)
+
+	if _, err = tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			if _, e := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); e != nil {
+				c.logger.ErrorContext(ctx, "failed to rollback savepoint in panic", slog.Any("error", e))
+			}
+			// 外側のトランザクションでもロールバックさせる
+			panic(p)
+		}
+		if err != nil {
+			if _, e := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); e != nil {
+				c.logger.ErrorContext(ctx, "failed to rollback savepoint", slog.Any("error", e))
+			}
+			return
+		}
+		if _, e := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); e != nil {
+			c.logger.ErrorContext(ctx, "failed to release savepoint", slog.Any("error", e))
+			err = e
+		}
+	}()
+	return fn(context.WithValue(ctx, savepointKey{}, depth))
+}
+
 type txKey struct{}
 
 func SetTx(ctx context.Context, tx *sqlx.Tx) context.Context {
@@ -51,3 +89,15 @@ func SetTx(ctx context.Context, tx *sqlx.Tx) context.Context {
 
 	return context.WithValue(ctx, txKey{}, tx)
 }
+
+func GetTx(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+type savepointKey struct{}
+
+func savepointDepth(ctx context.Context) int {
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	return depth
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
@@ -9,6 +10,9 @@ import (
 	"github.com/stretchr/testify/suite"
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 
+	"mods-explore/ark/omega/logic"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	variantModel "mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 )
 
@@ -40,7 +44,6 @@ func (s *testTransactionSuite) committed(_ context.Context) (any, error) {
 func (s *testTransactionSuite) panicked(_ context.Context) (any, error) {
 	s.T().Log("panicked func")
 	panic("test")
-	return nil, service.IntervalServerError
 }
 func (s *testTransactionSuite) errored(_ context.Context) (any, error) {
 	s.T().Log("errored func")
@@ -76,3 +79,99 @@ func (s *testTransactionSuite) TestErrTransaction() {
 	_, err := s.cli.WithTransaction(ctx, s.errored)
 	s.ErrorIs(err, service.NotFound)
 }
+
+func (s *testTransactionSuite) TestExecutor() {
+	ctx := context.Background()
+	s.Equal(s.cli.DB, s.cli.Executor(ctx))
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectRollback()
+
+	tx, err := s.cli.Beginx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	s.Equal(tx, s.cli.Executor(SetTx(ctx, tx)))
+	s.Nil(tx.Rollback())
+}
+
+func (s *testTransactionSuite) TestNestedTransaction() {
+	ctx := context.Background()
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlxmock.NewResult(0, 0))
+	s.mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlxmock.NewResult(0, 0))
+	s.mock.ExpectCommit()
+
+	r, err := s.cli.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return s.cli.WithTransaction(ctx, s.committed)
+	})
+	s.Nil(err)
+	s.Equal(&struct{}{}, r)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testTransactionSuite) TestNestedErrTransaction() {
+	ctx := context.Background()
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlxmock.NewResult(0, 0))
+	s.mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlxmock.NewResult(0, 0))
+	s.mock.ExpectRollback()
+
+	_, err := s.cli.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return s.cli.WithTransaction(ctx, s.errored)
+	})
+	s.ErrorIs(err, service.NotFound)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testTransactionSuite) TestRollbackUniqueCreation() {
+	ctx := logic.SetTransactioner(context.Background(), &s.cli)
+	e := errors.New("test")
+
+	dinoClient := DinosaurClient{&s.cli}
+	uniqueClient := UniqueCommandRepo{&s.cli}
+	variantsClient := UniqueVariantsClient{&s.cli}
+
+	row := UniqueQueryModel{
+		HealthMultiplier: 36, StaminaMultiplier: 1, OxygenMultiplier: 1, FoodMultiplier: 1, WeightMultiplier: 1,
+		DamageMultiplier: 36, MovementSpeedMultiplier: 1, TorpidityMultiplier: 1, ArmorMultiplier: 1,
+		BaseHealth: 1, BaseStamina: 100, BaseFood: 100, BaseWeight: 50, BaseMelee: 1,
+		BaseMovementSpeed: 100, BaseTorpidity: 50,
+	}
+	stats, err := row.stats()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	multipliers, err := row.multipliers()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+	dino := creatureSvc.NewCreateDinosaur("Dodo", stats)
+
+	s.mock.ExpectBegin()
+	s.mock.ExpectPrepare("INSERT INTO dinosaurs").
+		ExpectQuery().
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectPrepare("INSERT INTO uniques").
+		ExpectQuery().
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(1))
+	s.mock.ExpectExec("INSERT INTO unique_variants").WillReturnError(e)
+	s.mock.ExpectRollback()
+
+	err = logic.UseTransactioner0(ctx, func(ctx context.Context) error {
+		dinoID, err := dinoClient.Insert(ctx, dino)
+		if err != nil {
+			return err
+		}
+		create := creatureSvc.NewCreateCreature(dinoID, "Kenny", multipliers, []variantModel.VariantID{1, 2})
+		uniqueID, err := uniqueClient.Insert(ctx, create.UniqueDinosaur())
+		if err != nil {
+			return err
+		}
+		return variantsClient.Insert(ctx, create.UniqueVariants(uniqueID))
+	})
+	s.ErrorIs(err, e)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samber/do"
+
+	"mods-explore/ark/omega/logic"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+)
+
+type TrashItemModel struct {
+	ItemType  string    `db:"item_type"`
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	DeletedAt time.Time `db:"deleted_at"`
+	TrashKey  int       `db:"trash_key"`
+}
+
+func (m TrashItemModel) toItem() model.Item {
+	return model.NewItem(model.ItemType(m.ItemType), m.ID, m.Name, m.DeletedAt)
+}
+
+// trashSources ゴミ箱を構成するテーブル。rankはtrash_keyで種類毎のidを重複させないために用いる
+var trashSources = []struct {
+	itemType model.ItemType
+	table    string
+	target   translation.Target
+	rank     int
+}{
+	{itemType: model.ItemDinosaur, table: "dinosaurs", target: translation.TargetDinosaur, rank: 0},
+	{itemType: model.ItemUnique, table: "uniques", target: translation.TargetUnique, rank: 1},
+	{itemType: model.ItemVariant, table: "variants", target: translation.TargetVariant, rank: 2},
+	{itemType: model.ItemGroup, table: "groups", target: translation.TargetGroup, rank: 3},
+}
+
+// trashUnion typesが空の場合は全てのテーブルを対象とする
+func trashUnion(types []model.ItemType) string {
+	selects := make([]string, 0, len(trashSources))
+	for _, s := range trashSources {
+		if len(types) != 0 && !containsItemType(types, s.itemType) {
+			continue
+		}
+		selects = append(selects, fmt.Sprintf(
+			`SELECT '%s' AS item_type, id, %s AS name, deleted_at, id * %d + %d AS trash_key
+				FROM %s WHERE deleted_at IS NOT NULL`,
+			s.itemType, localizedName(s.target, s.table), len(trashSources), s.rank, s.table,
+		))
+	}
+	return strings.Join(selects, " UNION ALL ")
+}
+
+func containsItemType(types []model.ItemType, t model.ItemType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// trashKeyset 削除日時は種類を跨いで重複し得るため、種類とidから作るtrash_keyを同順位の並びに用いる
+func trashKeyset(page logic.PageRequest) keyset {
+	return keyset{
+		column: sortColumn{expr: "deleted_at", castType: "TIMESTAMPTZ"},
+		idExpr: "trash_key",
+		key:    "deleted_at",
+		page:   page,
+	}
+}
+
+type TrashClient struct {
+	*Client
+}
+
+func NewTrashClient(injector *do.Injector) (service.TrashRepository, error) {
+	return TrashClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (t TrashClient) List(ctx context.Context, query service.ListTrash) (*logic.Page[model.Item], error) {
+	k := trashKeyset(query.PageRequest)
+	arg := localeArg(ctx, map[string]any{})
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[TrashItemModel](
+		ctx,
+		t.Client,
+		fmt.Sprintf(
+			`SELECT item_type, id, name, deleted_at, trash_key FROM (%s) AS trash %s %s;`,
+			trashUnion(query.Types()), whereClause([]string{where}), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r TrashItemModel) (any, int) { return r.DeletedAt, r.TrashKey },
+		func(r TrashItemModel) (model.Item, error) { return r.toItem(), nil },
+	)
+}
+
+// trashPurges 参照する側から順に削除し、ゴミ箱にない行から参照されている行は残す
+var trashPurges = []struct {
+	itemType model.ItemType
+	query    string
+}{
+	{
+		itemType: model.ItemUnique,
+		query:    `DELETE FROM uniques WHERE deleted_at < :before;`,
+	},
+	{
+		itemType: model.ItemVariant,
+		query: `DELETE FROM variants AS v WHERE v.deleted_at < :before
+				AND NOT EXISTS (SELECT 1 FROM unique_variants AS uv WHERE uv.variant_id = v.id);`,
+	},
+	{
+		itemType: model.ItemDinosaur,
+		query: `DELETE FROM dinosaurs AS d WHERE d.deleted_at < :before
+				AND NOT EXISTS (SELECT 1 FROM uniques AS u WHERE u.dinosaur_id = d.id);`,
+	},
+	{
+		itemType: model.ItemGroup,
+		query: `DELETE FROM groups AS g WHERE g.deleted_at < :before
+				AND NOT EXISTS (SELECT 1 FROM variants AS v WHERE v.group_id = g.id);`,
+	},
+}
+
+func (t TrashClient) Purge(ctx context.Context, before time.Time) (model.Purged, error) {
+	purged := make(model.Purged, len(trashPurges))
+	for _, p := range trashPurges {
+		result, err := t.Executor(ctx).NamedExecContext(ctx, p.query, map[string]any{"before": before})
+		if err != nil {
+			return nil, translateError(err)
+		}
+		n, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		purged[p.itemType] = int(n)
+	}
+	return purged, nil
+}
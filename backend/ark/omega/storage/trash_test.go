@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/trash/domain/model"
+	"mods-explore/ark/omega/logic/trash/domain/service"
+)
+
+type testTrashSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestTrashSuite(t *testing.T) {
+	suite.Run(t, &testTrashSuite{})
+}
+
+func (s *testTrashSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+func (s *testTrashSuite) TestList() {
+	ctx := context.Background()
+	client := TrashClient{&s.cli}
+	deletedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	page, err := logic.NewPageRequest(1, "", logic.Desc)
+	s.Require().NoError(err)
+
+	s.T().Log("指定した種類のみを削除日時の新しい順に取得するテスト")
+	s.mock.ExpectQuery(`FROM variants WHERE deleted_at IS NOT NULL UNION ALL .+ FROM groups WHERE deleted_at IS NOT NULL\) AS trash ORDER BY deleted_at DESC, trash_key DESC LIMIT \?`).
+		WillReturnRows(
+			sqlxmock.NewRows([]string{"item_type", "id", "name", "deleted_at", "trash_key"}).
+				AddRow("variant", 2, "meteor", deletedAt, 10).
+				AddRow("group", 1, "cosmic", deletedAt, 7),
+		)
+
+	items, err := client.List(ctx, service.NewListTrash(page, []model.ItemType{model.ItemVariant, model.ItemGroup}))
+	s.Require().NoError(err)
+	s.Require().Len(items.Items(), 1)
+	item := items.Items()[0]
+	s.Equal(model.ItemVariant, item.Type())
+	s.Equal(2, item.ID())
+	s.Equal("meteor", item.Name())
+	s.NotEmpty(items.NextCursor())
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testTrashSuite) TestPurge() {
+	ctx := context.Background()
+	client := TrashClient{&s.cli}
+	before := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	s.T().Log("参照する側から順に削除し、種類毎の件数を返すテスト")
+	s.mock.ExpectExec(`DELETE FROM uniques WHERE deleted_at < \?`).
+		WithArgs(before).
+		WillReturnResult(sqlxmock.NewResult(0, 2))
+	s.mock.ExpectExec(`DELETE FROM variants AS v WHERE v.deleted_at < \?`).
+		WithArgs(before).
+		WillReturnResult(sqlxmock.NewResult(0, 1))
+	s.mock.ExpectExec(`DELETE FROM dinosaurs AS d WHERE d.deleted_at < \?`).
+		WithArgs(before).
+		WillReturnResult(sqlxmock.NewResult(0, 0))
+	s.mock.ExpectExec(`DELETE FROM groups AS g WHERE g.deleted_at < \?`).
+		WithArgs(before).
+		WillReturnResult(sqlxmock.NewResult(0, 1))
+
+	purged, err := client.Purge(ctx, before)
+	s.Require().NoError(err)
+	s.Equal(model.Purged{model.ItemUnique: 2, model.ItemVariant: 1, model.ItemDinosaur: 0, model.ItemGroup: 1}, purged)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
@@ -3,36 +3,73 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/samber/do"
 	"github.com/samber/lo"
 
+	"mods-explore/ark/omega/logic"
 	"mods-explore/ark/omega/logic/creature/domain/model"
 	"mods-explore/ark/omega/logic/creature/domain/service"
 	"mods-explore/ark/omega/logic/creature/usecase"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
 	variant "mods-explore/ark/omega/logic/variant/domain/model"
+	variantService "mods-explore/ark/omega/logic/variant/domain/service"
 )
 
 type UniqueQueryModel struct {
-	UniqueID         int            `db:"unique_id"`
-	UniqueName       string         `db:"unique_name"`
-	HealthMultiplier float32        `db:"health_multiplier"`
-	DamageMultiplier float32        `db:"damage_multiplier"`
-	BaseID           int            `db:"base_id"`
-	BaseName         string         `db:"base_name"`
-	BaseHealth       uint           `db:"base_health"`
-	BaseMelee        int            `db:"base_melee"`
-	UniqueVariants   UniqueVariants `db:"unique_variants"`
+	UniqueID                int            `db:"unique_id"`
+	UniqueName              string         `db:"unique_name"`
+	UniqueDisplayName       string         `db:"unique_display_name"`
+	HealthMultiplier        float32        `db:"health_multiplier"`
+	StaminaMultiplier       float32        `db:"stamina_multiplier"`
+	OxygenMultiplier        float32        `db:"oxygen_multiplier"`
+	FoodMultiplier          float32        `db:"food_multiplier"`
+	WeightMultiplier        float32        `db:"weight_multiplier"`
+	DamageMultiplier        float32        `db:"damage_multiplier"`
+	MovementSpeedMultiplier float32        `db:"movement_speed_multiplier"`
+	TorpidityMultiplier     float32        `db:"torpidity_multiplier"`
+	ArmorMultiplier         float32        `db:"armor_multiplier"`
+	BaseID                  int            `db:"base_id"`
+	BaseName                string         `db:"base_name"`
+	BaseDisplayName         string         `db:"base_display_name"`
+	BaseHealth              uint           `db:"base_health"`
+	BaseStamina             uint           `db:"base_stamina"`
+	BaseOxygen              uint           `db:"base_oxygen"`
+	BaseFood                uint           `db:"base_food"`
+	BaseWeight              uint           `db:"base_weight"`
+	BaseMelee               int            `db:"base_melee"`
+	BaseMovementSpeed       uint           `db:"base_movement_speed"`
+	BaseTorpidity           uint           `db:"base_torpidity"`
+	BaseArmor               uint           `db:"base_armor"`
+	UniqueVariants          UniqueVariants `db:"unique_variants"`
+	Version                 int            `db:"version"`
 }
 
+// uniqueQueryColumns UniqueQueryModelのうちバリアント以外の列。名前は保存された値のまま、表示名を別の列で取得する
+var uniqueQueryColumns = `u.id as unique_id, u.name as unique_name,
+					` + localizedName(translation.TargetUnique, "u") + ` as unique_display_name,
+					u.health_multiplier, u.stamina_multiplier, u.oxygen_multiplier, u.food_multiplier,
+					u.weight_multiplier, u.damage_multiplier, u.movement_speed_multiplier,
+					u.torpidity_multiplier, u.armor_multiplier,
+					d.id as base_id, d.name as base_name,
+					` + localizedName(translation.TargetDinosaur, "d") + ` as base_display_name,
+					d.health as base_health, d.stamina as base_stamina, d.oxygen as base_oxygen,
+					d.food as base_food, d.weight as base_weight, d.melee as base_melee,
+					d.movement_speed as base_movement_speed, d.torpidity as base_torpidity, d.armor as base_armor,
+					u.version`
+
 type UniqueVariant struct {
-	VariantID   int    `db:"variant_id" json:"variant_id"`
-	VariantName string `db:"variant_name" json:"variant_name"`
-	GroupName   string `db:"group_name" json:"group_name"`
+	VariantID          int      `db:"variant_id" json:"variant_id"`
+	VariantName        string   `db:"variant_name" json:"variant_name"`
+	VariantDisplayName string   `db:"variant_display_name" json:"variant_display_name"`
+	GroupName          string   `db:"group_name" json:"group_name"`
+	GroupDisplayName   string   `db:"group_display_name" json:"group_display_name"`
+	Descriptions       []string `db:"descriptions" json:"descriptions"`
 }
 
-type UniqueVariants [2]UniqueVariant
+type UniqueVariants []UniqueVariant
 
 func (v *UniqueVariants) Scan(value any) error {
 	switch vv := value.(type) {
@@ -46,25 +83,23 @@ func (v *UniqueVariants) Scan(value any) error {
 }
 
 func (v UniqueQueryModel) toResponseCreature() (*service.ResponseCreature, error) {
-	variants := v.UniqueVariants
-	vs := lo.Map(variants[:], func(v UniqueVariant, _ int) model.DinosaurVariant {
+	vs := lo.Map(v.UniqueVariants, func(v UniqueVariant, _ int) model.DinosaurVariant {
 		return model.NewDinosaurVariant(
 			variant.NewVariant(
 				variant.VariantID(v.VariantID),
 				variant.VariantGroupName(v.GroupName),
-				variant.Name(v.VariantName)),
-			model.VariantDescriptions{},
+				variant.Name(v.VariantName),
+			).WithDisplayName(variant.Name(v.VariantDisplayName), variant.VariantGroupName(v.GroupDisplayName)),
+			lo.Map(v.Descriptions, func(d string, _ int) model.VariantDescription {
+				return model.VariantDescription(d)
+			}),
 		)
 	})
-	health, err := model.NewHealth(v.BaseHealth)
-	if err != nil {
-		return nil, err
-	}
-	healthMultiplier, err := model.NewUniqueMultiplier[model.Health](model.StatusMultiplier(v.HealthMultiplier))
+	stats, err := v.stats()
 	if err != nil {
 		return nil, err
 	}
-	damageMultiplier, err := model.NewUniqueMultiplier[model.Melee](model.StatusMultiplier(v.DamageMultiplier))
+	multipliers, err := v.multipliers()
 	if err != nil {
 		return nil, err
 	}
@@ -73,19 +108,59 @@ func (v UniqueQueryModel) toResponseCreature() (*service.ResponseCreature, error
 		ResponseDinosaur: service.NewResponseDinosaur(
 			model.DinosaurID(v.BaseID),
 			model.DinosaurName(v.BaseName),
-			health,
-			model.Melee(v.BaseMelee),
-		),
-		ResponseVariants: service.NewResponseVariants(([2]model.DinosaurVariant)(vs)),
+			stats,
+		).WithDisplayName(model.DinosaurName(v.BaseDisplayName)),
+		ResponseVariants: service.NewResponseVariants(vs),
 		ResponseUnique: service.NewResponseUnique(
 			model.UniqueDinosaurID(v.UniqueID),
 			model.UniqueName(v.UniqueName),
-			*healthMultiplier,
-			*damageMultiplier,
-		),
+			multipliers,
+		).WithDisplayName(model.UniqueName(v.UniqueDisplayName)).WithVersion(logic.Version(v.Version)),
 	}, nil
 }
 
+func (v UniqueQueryModel) stats() (model.DinosaurStats, error) {
+	health, e1 := model.NewHealth(v.BaseHealth)
+	stamina, e2 := model.NewStamina(v.BaseStamina)
+	food, e3 := model.NewFood(v.BaseFood)
+	weight, e4 := model.NewWeight(v.BaseWeight)
+	speed, e5 := model.NewMovementSpeed(v.BaseMovementSpeed)
+	torpidity, e6 := model.NewTorpidity(v.BaseTorpidity)
+	if err := errors.Join(e1, e2, e3, e4, e5, e6); err != nil {
+		return model.DinosaurStats{}, err
+	}
+
+	return model.NewDinosaurStats(
+		health, stamina, model.NewOxygen(v.BaseOxygen), food, weight,
+		model.NewMelee(uint(v.BaseMelee)), speed, torpidity, model.NewArmor(v.BaseArmor),
+	), nil
+}
+
+func (v UniqueQueryModel) multipliers() (model.UniqueMultipliers, error) {
+	health, e1 := toUniqueMultiplier[model.Health](v.HealthMultiplier)
+	stamina, e2 := toUniqueMultiplier[model.Stamina](v.StaminaMultiplier)
+	oxygen, e3 := toUniqueMultiplier[model.Oxygen](v.OxygenMultiplier)
+	food, e4 := toUniqueMultiplier[model.Food](v.FoodMultiplier)
+	weight, e5 := toUniqueMultiplier[model.Weight](v.WeightMultiplier)
+	damage, e6 := toUniqueMultiplier[model.Melee](v.DamageMultiplier)
+	speed, e7 := toUniqueMultiplier[model.MovementSpeed](v.MovementSpeedMultiplier)
+	torpidity, e8 := toUniqueMultiplier[model.Torpidity](v.TorpidityMultiplier)
+	armor, e9 := toUniqueMultiplier[model.Armor](v.ArmorMultiplier)
+	if err := errors.Join(e1, e2, e3, e4, e5, e6, e7, e8, e9); err != nil {
+		return model.UniqueMultipliers{}, err
+	}
+
+	return model.NewUniqueMultipliers(health, stamina, oxygen, food, weight, damage, speed, torpidity, armor), nil
+}
+
+func toUniqueMultiplier[T model.DinosaurStatus](v float32) (model.UniqueMultiplier[T], error) {
+	m, err := model.NewUniqueMultiplier[T](model.StatusMultiplier(v))
+	if err != nil {
+		return model.UniqueMultiplier[T]{}, err
+	}
+	return *m, nil
+}
+
 type UniqueQueryRepo struct {
 	*Client
 }
@@ -101,26 +176,29 @@ func (r UniqueQueryRepo) Select(ctx context.Context, id model.UniqueDinosaurID)
 		ctx,
 		r.Client,
 		`SELECT
-    				u.id as unique_id, u.name as unique_name,
-    				u.health_multiplier, u.damage_multiplier,
-    				d.id as base_id, d.name as base_name,
-    				d.health as base_health, d.melee as base_melee,
+					`+uniqueQueryColumns+`,
 					JSONB_AGG(JSONB_BUILD_OBJECT(
 							'variant_id', v.id,
-							'variant_name', v.name, 
-							'group_name', g.name
-					    )
+							'variant_name', v.name,
+							'variant_display_name', `+localizedName(translation.TargetVariant, "v")+`,
+							'group_name', g.name,
+							'group_display_name', `+localizedName(translation.TargetGroup, "g")+`,
+							'descriptions', (
+								SELECT COALESCE(JSONB_AGG(vd.description ORDER BY vd.position, vd.id), '[]')
+								FROM variant_descriptions AS vd WHERE vd.variant_id = v.id
+							)
+					    ) ORDER BY uv.position, uv.id
 					) as unique_variants
 				FROM uniques as u 
 				    JOIN dinosaurs as d ON u.dinosaur_id = d.id 
 				    JOIN unique_variants as uv ON u.id = uv.unique_id 
 				    JOIN variants as v ON uv.variant_id = v.id 
 				    JOIN groups as g ON g.id = v.group_id 
-				WHERE u.id = :id GROUP BY u.id, d.id;`,
-		map[string]any{"id": id},
+				WHERE u.id = :id AND u.deleted_at IS NULL GROUP BY u.id, d.id;`,
+		localeArg(ctx, map[string]any{"id": id}),
 	)
 	if err != nil {
-		return nil, err
+		return nil, creatureNotFound(err)
 	}
 
 	unique, err := row.toResponseCreature()
@@ -131,48 +209,150 @@ func (r UniqueQueryRepo) Select(ctx context.Context, id model.UniqueDinosaurID)
 	return unique, nil
 }
 
-func (r UniqueQueryRepo) List(ctx context.Context) (service.ResponseCreatures, error) {
-	rowsList, err := Select[UniqueQueryModel](
+var uniqueSortColumns = map[service.UniqueSortKey]sortColumn{
+	service.UniqueSortByID:        {expr: "u.id", castType: "INTEGER"},
+	service.UniqueSortByName:      {expr: "u.name", castType: "VARCHAR"},
+	service.UniqueSortByHealth:    {expr: "CAST(d.health AS DOUBLE PRECISION) * u.health_multiplier", castType: "DOUBLE PRECISION"},
+	service.UniqueSortByDamage:    {expr: "CAST(d.melee AS DOUBLE PRECISION) * u.damage_multiplier", castType: "DOUBLE PRECISION"},
+	service.UniqueSortByUpdatedAt: {expr: "u.updated_at", castType: "TIMESTAMPTZ"},
+}
+
+// uniqueListModel ページングのため、ソートキーの値も合わせて取得する
+type uniqueListModel struct {
+	UniqueQueryModel
+	SortValue any `db:"sort_value"`
+}
+
+// uniqueFilterConditions バリアントでの絞り込みはJSONB_AGGで集約するバリアントを欠落させないようにEXISTSで行う
+func uniqueFilterConditions(filter service.UniqueFilter, arg map[string]any) []string {
+	var conditions []string
+	if filter.DinosaurID != nil {
+		conditions = append(conditions, "u.dinosaur_id = :dinosaur_id")
+		arg["dinosaur_id"] = *filter.DinosaurID
+	}
+	if filter.VariantGroupID != nil {
+		conditions = append(conditions, `EXISTS (
+					SELECT 1 FROM unique_variants AS fuv JOIN variants AS fv ON fuv.variant_id = fv.id
+					WHERE fuv.unique_id = u.id AND fv.group_id = :group_id)`)
+		arg["group_id"] = *filter.VariantGroupID
+	}
+	if filter.VariantID != nil {
+		conditions = append(conditions, `EXISTS (
+					SELECT 1 FROM unique_variants AS fuv WHERE fuv.unique_id = u.id AND fuv.variant_id = :variant_id)`)
+		arg["variant_id"] = *filter.VariantID
+	}
+	if filter.BaseName != nil {
+		conditions = append(conditions, "LOWER(d.name) = LOWER(:base_name)")
+		arg["base_name"] = *filter.BaseName
+	}
+	if filter.MinHealthMultiplier != nil {
+		conditions = append(conditions, "u.health_multiplier >= :min_health_multiplier")
+		arg["min_health_multiplier"] = filter.MinHealthMultiplier.ToFloat32()
+	}
+	if filter.MaxHealthMultiplier != nil {
+		conditions = append(conditions, "u.health_multiplier <= :max_health_multiplier")
+		arg["max_health_multiplier"] = filter.MaxHealthMultiplier.ToFloat32()
+	}
+	if filter.MinDamageMultiplier != nil {
+		conditions = append(conditions, "u.damage_multiplier >= :min_damage_multiplier")
+		arg["min_damage_multiplier"] = filter.MinDamageMultiplier.ToFloat32()
+	}
+	if filter.MaxDamageMultiplier != nil {
+		conditions = append(conditions, "u.damage_multiplier <= :max_damage_multiplier")
+		arg["max_damage_multiplier"] = filter.MaxDamageMultiplier.ToFloat32()
+	}
+	return conditions
+}
+
+func (r UniqueQueryRepo) List(ctx context.Context, query service.ListUniques) (*logic.Page[service.ResponseCreature], error) {
+	k := keyset{
+		column: uniqueSortColumns[query.SortKey()],
+		idExpr: "u.id",
+		key:    query.SortKey().Value(),
+		page:   query.PageRequest,
+	}
+	arg := localeArg(ctx, map[string]any{})
+	conditions := append([]string{"u.deleted_at IS NULL"}, uniqueFilterConditions(query.Filter(), arg)...)
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, where)
+
+	rowsList, err := NamedSelect[uniqueListModel](
 		ctx,
 		r.Client,
-		`SELECT
-					u.id as unique_id, u.name as unique_name,
-					u.health_multiplier, u.damage_multiplier,
-					d.id as base_id, d.name as base_name,
-					d.health as base_health, d.melee as base_melee,
+		fmt.Sprintf(`SELECT
+					`+uniqueQueryColumns+`,
 					JSONB_AGG(JSONB_BUILD_OBJECT(
 							'variant_id', v.id,
-							'variant_name', v.name, 
-							'group_name', g.name
-					    )
-					) as unique_variants
+							'variant_name', v.name,
+							'variant_display_name', `+localizedName(translation.TargetVariant, "v")+`,
+							'group_name', g.name,
+							'group_display_name', `+localizedName(translation.TargetGroup, "g")+`,
+							'descriptions', (
+								SELECT COALESCE(JSONB_AGG(vd.description ORDER BY vd.position, vd.id), '[]')
+								FROM variant_descriptions AS vd WHERE vd.variant_id = v.id
+							)
+					    ) ORDER BY uv.position, uv.id
+					) as unique_variants,
+					%s as sort_value
 				FROM uniques as u
 				    JOIN dinosaurs as d ON u.dinosaur_id = d.id
 				    JOIN unique_variants as uv ON u.id = uv.unique_id
 				    JOIN variants as v ON uv.variant_id = v.id
 				    JOIN groups as g ON g.id = v.group_id
-				GROUP BY u.id, d.id;`,
+				%s
+				GROUP BY u.id, d.id
+				%s;`,
+			k.column.expr, whereClause(conditions), k.orderBy(arg),
+		),
+		arg,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var response service.ResponseCreatures
-	for _, rows := range rowsList {
-		resp, err := rows.toResponseCreature()
-		if err != nil {
-			return nil, err
-		}
-		response = append(response, *resp)
-	}
-	return response, nil
+	return newPage(
+		k,
+		rowsList,
+		func(row uniqueListModel) (any, int) { return row.SortValue, row.UniqueID },
+		func(row uniqueListModel) (service.ResponseCreature, error) {
+			resp, err := row.toResponseCreature()
+			if err != nil {
+				return service.ResponseCreature{}, err
+			}
+			return *resp, nil
+		},
+	)
 }
 
 type UniqueModel struct {
-	ID               int     `db:"id"`
-	Name             string  `db:"name"`
-	HealthMultiplier float32 `db:"health_multiplier"`
-	DamageMultiplier float32 `db:"damage_multiplier"`
+	ID                      int     `db:"id"`
+	Name                    string  `db:"name"`
+	HealthMultiplier        float32 `db:"health_multiplier"`
+	StaminaMultiplier       float32 `db:"stamina_multiplier"`
+	OxygenMultiplier        float32 `db:"oxygen_multiplier"`
+	FoodMultiplier          float32 `db:"food_multiplier"`
+	WeightMultiplier        float32 `db:"weight_multiplier"`
+	DamageMultiplier        float32 `db:"damage_multiplier"`
+	MovementSpeedMultiplier float32 `db:"movement_speed_multiplier"`
+	TorpidityMultiplier     float32 `db:"torpidity_multiplier"`
+	ArmorMultiplier         float32 `db:"armor_multiplier"`
+}
+
+// uniqueMultipliersArg 倍率をクエリの名前付きパラメータに展開する
+func uniqueMultipliersArg(multipliers model.UniqueMultipliers, arg map[string]any) map[string]any {
+	arg["health_multiplier"] = multipliers.Health().Value()
+	arg["stamina_multiplier"] = multipliers.Stamina().Value()
+	arg["oxygen_multiplier"] = multipliers.Oxygen().Value()
+	arg["food_multiplier"] = multipliers.Food().Value()
+	arg["weight_multiplier"] = multipliers.Weight().Value()
+	arg["damage_multiplier"] = multipliers.Melee().Value()
+	arg["movement_speed_multiplier"] = multipliers.MovementSpeed().Value()
+	arg["torpidity_multiplier"] = multipliers.Torpidity().Value()
+	arg["armor_multiplier"] = multipliers.Armor().Value()
+	return arg
 }
 
 type UniqueCommandRepo struct {
@@ -189,14 +369,17 @@ func (r UniqueCommandRepo) Insert(ctx context.Context, create service.CreateUniq
 	id, err := NamedStore[int](
 		ctx,
 		r.Client,
-		`INSERT INTO uniques (dinosaur_id, name, health_multiplier, damage_multiplier)
-			VALUES (:dinosaur_id, :name, :health_multiplier, :damage_multiplier)
-			RETURNING id;`,
-		map[string]any{
-			"dinosaur_id": create.DinosaurID(), "name": create.Name(),
-			"health_multiplier": create.HealthMultiplier().Value(),
-			"damage_multiplier": create.DamageMultiplier().Value(),
-		},
+		`INSERT INTO uniques (
+				dinosaur_id, name, health_multiplier, stamina_multiplier, oxygen_multiplier, food_multiplier,
+				weight_multiplier, damage_multiplier, movement_speed_multiplier, torpidity_multiplier, armor_multiplier
+			) VALUES (
+				:dinosaur_id, :name, :health_multiplier, :stamina_multiplier, :oxygen_multiplier, :food_multiplier,
+				:weight_multiplier, :damage_multiplier, :movement_speed_multiplier, :torpidity_multiplier, :armor_multiplier
+			) RETURNING id;`,
+		uniqueMultipliersArg(
+			create.Multipliers(),
+			map[string]any{"dinosaur_id": create.DinosaurID(), "name": create.Name()},
+		),
 	)
 	if err != nil {
 		return model.UniqueDinosaurID(0), err
@@ -204,27 +387,71 @@ func (r UniqueCommandRepo) Insert(ctx context.Context, create service.CreateUniq
 	return model.UniqueDinosaurID(id), nil
 }
 
+// Update 指定したバージョンの場合のみ更新する。対象が無い場合は、存在を確認した上で呼ばれるためVersionMismatchを返す
 func (r UniqueCommandRepo) Update(ctx context.Context, update service.UpdateUniqueDinosaur) error {
 	_, err := NamedStore[int](
 		ctx,
 		r.Client,
-		`UPDATE uniques 
-			SET dinosaur_id = :dinosaur_id, name = :name, 
-			    health_multiplier = :health_multiplier, damage_multiplier = :damage_multiplier, updated_at = NOW() 
-			WHERE id = :id;`,
-		map[string]any{
-			"id": update.ID(), "dinosaur_id": update.DinosaurID(), "name": update.Name(),
-			"health_multiplier": update.HealthMultiplier().Value(),
-			"damage_multiplier": update.DamageMultiplier().Value(),
-		},
+		`UPDATE uniques
+			SET dinosaur_id = :dinosaur_id, name = :name,
+			    health_multiplier = :health_multiplier, stamina_multiplier = :stamina_multiplier,
+			    oxygen_multiplier = :oxygen_multiplier, food_multiplier = :food_multiplier,
+			    weight_multiplier = :weight_multiplier, damage_multiplier = :damage_multiplier,
+			    movement_speed_multiplier = :movement_speed_multiplier, torpidity_multiplier = :torpidity_multiplier,
+			    armor_multiplier = :armor_multiplier, version = version + 1, updated_at = NOW()
+			WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		uniqueMultipliersArg(
+			update.Multipliers(),
+			map[string]any{
+				"id": update.ID(), "dinosaur_id": update.DinosaurID(), "name": update.Name(), "version": update.Version(),
+			},
+		),
 	)
 	if err != nil {
-		return err
+		return versionMismatch(err)
 	}
 
 	return nil
 }
 
-func (r UniqueCommandRepo) Delete(ctx context.Context, id model.UniqueDinosaurID) error {
-	return NamedDelete(ctx, r.Client, `DELETE FROM uniques WHERE id = :id;`, map[string]any{"id": id})
+// Delete ゴミ箱へ移すのみで、付与したバリアントは復元できるよう残す
+func (r UniqueCommandRepo) Delete(ctx context.Context, id model.UniqueDinosaurID, version logic.Version) error {
+	_, err := NamedStore[int](
+		ctx,
+		r.Client,
+		`UPDATE uniques SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+			WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": id, "version": version},
+	)
+	return versionMismatch(err)
+}
+
+// Restore ゴミ箱に無い場合はNotFound、種か付与したバリアントがゴミ箱にある場合はParentDeletedを返す
+func (r UniqueCommandRepo) Restore(ctx context.Context, id model.UniqueDinosaurID) error {
+	parentDeleted, err := NamedStore[bool](
+		ctx,
+		r.Client,
+		`UPDATE uniques SET deleted_at = NULL, version = uniques.version + 1, updated_at = NOW()
+			FROM dinosaurs WHERE uniques.id = :id AND uniques.deleted_at IS NOT NULL AND dinosaurs.id = uniques.dinosaur_id
+			RETURNING dinosaurs.deleted_at IS NOT NULL OR EXISTS (
+			    SELECT 1 FROM unique_variants AS uv JOIN variants AS v ON v.id = uv.variant_id
+			    WHERE uv.unique_id = uniques.id AND v.deleted_at IS NOT NULL
+			);`,
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return creatureNotFound(err)
+	}
+	if parentDeleted {
+		return service.ParentDeleted
+	}
+	return nil
+}
+
+// versionMismatch 条件付きの更新で対象の行が無い場合を、ユニークのバージョンの不一致とする
+func versionMismatch(err error) error {
+	if errors.Is(err, variantService.NotFound) {
+		return service.VersionMismatch
+	}
+	return err
 }
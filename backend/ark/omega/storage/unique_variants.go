@@ -2,9 +2,9 @@ package storage
 
 import (
 	"context"
-	"github.com/samber/lo"
 
 	"github.com/samber/do"
+	"github.com/samber/lo"
 
 	"mods-explore/ark/omega/logic/creature/domain/model"
 	"mods-explore/ark/omega/logic/creature/domain/service"
@@ -33,37 +33,33 @@ func NewUniqueVariantsClient(injector *do.Injector) (service.UniqueVariantsComma
 }
 
 func (c UniqueVariantsClient) Insert(ctx context.Context, create service.CreateVariants) error {
-	ids := create.VariantIDs()
-	records := lo.Map(ids[:], func(id variantModel.VariantID, _ int) map[string]any {
-		return map[string]any{"unique_id": create.UniqueDinosaurID(), "variant_id": id}
-	})
-	_, err := NamedStore[int](
+	return c.insert(ctx, create.UniqueDinosaurID(), create.VariantIDs())
+}
+
+// Update 行毎に更新すると順序や件数の変更を扱えないため、全て削除してから登録し直す
+func (c UniqueVariantsClient) Update(ctx context.Context, update service.UpdateVariants) error {
+	if err := NamedDelete(
 		ctx,
 		c.Client,
-		`INSERT INTO unique_variants (unique_id, variant_id) VALUES (:unique_id, :variant_id);`,
-		records,
-	)
-	if err != nil {
+		`DELETE FROM unique_variants WHERE unique_id = :unique_id;`,
+		map[string]any{"unique_id": update.UniqueDinosaurID()},
+	); err != nil {
 		return err
 	}
-	return nil
+	return c.insert(ctx, update.UniqueDinosaurID(), update.VariantIDs())
 }
 
-func (c UniqueVariantsClient) Update(ctx context.Context, update service.UpdateVariants) error {
-	ids := update.VariantIDs() // メソッドで戻ってきた配列のアドレスを直接sliceに変換できないので、一度アドレスを格納する変数に入れ直す
-	records := lo.Map(ids[:], func(id variantModel.VariantID, _ int) map[string]any {
-		return map[string]any{"variant_id": id, "unique_id": update.UniqueDinosaurID()}
+// insert 指定された順序をpositionとして保存する
+func (c UniqueVariantsClient) insert(ctx context.Context, id model.UniqueDinosaurID, variantIDs []variantModel.VariantID) error {
+	records := lo.Map(variantIDs, func(variantID variantModel.VariantID, i int) map[string]any {
+		return map[string]any{"unique_id": id, "variant_id": variantID, "position": i}
 	})
-	_, err := NamedStore[int](
+	return NamedExec(
 		ctx,
 		c.Client,
-		`UPDATE unique_variants SET unique_id = :unique_id, variant_id = :variant_id, updated_at = NOW() WHERE id = :id;`,
+		`INSERT INTO unique_variants (unique_id, variant_id, position) VALUES (:unique_id, :variant_id, :position);`,
 		records,
 	)
-	if err != nil {
-		return err
-	}
-	return err
 }
 
 func (c UniqueVariantsClient) Delete(ctx context.Context, id model.UniqueVariantID) error {
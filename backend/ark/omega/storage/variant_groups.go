@@ -2,17 +2,30 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/samber/do"
 
+	"mods-explore/ark/omega/logic"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 )
 
 // VariantGroupModel variantsに集約しても良さそうだったがgroups単体で取り扱う可能性があるので分離しておく
 type VariantGroupModel struct {
-	ID   int    `db:"id"`
-	Name string `db:"name"`
+	ID          int    `db:"id"`
+	Name        string `db:"name"`
+	DisplayName string `db:"display_name"`
+	Version     int    `db:"version"`
+}
+
+func (m VariantGroupModel) toVariantGroup() model.VariantGroup {
+	return model.NewVariantGroup(
+		model.VariantGroupID(m.ID),
+		model.VariantGroupName(m.Name),
+	).WithDisplayName(model.VariantGroupName(m.DisplayName)).WithVersion(logic.Version(m.Version))
 }
 
 type VariantGroupClient struct {
@@ -29,42 +42,63 @@ func (v VariantGroupClient) Select(ctx context.Context, id model.VariantGroupID)
 	row, err := NamedGet[VariantGroupModel](
 		ctx,
 		v.Client,
-		`SELECT id, name FROM groups WHERE id = :id;`,
-		map[string]any{"id": id},
+		`SELECT id, name, `+localizedName(translation.TargetGroup, "groups")+` AS display_name, version FROM groups
+				WHERE id = :id AND deleted_at IS NULL;`,
+		localeArg(ctx, map[string]any{"id": id}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var variant = model.NewVariantGroup(
-		model.VariantGroupID(row.ID),
-		model.VariantGroupName(row.Name),
-	)
+	var variant = row.toVariantGroup()
 	return &variant, nil
 }
 
-func (v VariantGroupClient) List(ctx context.Context) (model.VariantGroups, error) {
-	rows, err := Select[VariantGroupModel](
+var variantGroupSortColumns = map[service.VariantGroupSortKey]sortColumn{
+	service.VariantGroupSortByID:        {expr: "id", castType: "INTEGER"},
+	service.VariantGroupSortByName:      {expr: "groups.name", castType: "VARCHAR"},
+	service.VariantGroupSortByUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+}
+
+// variantGroupListModel ページングのため、ソートキーの値も合わせて取得する
+type variantGroupListModel struct {
+	VariantGroupModel
+	SortValue any `db:"sort_value"`
+}
+
+func (v VariantGroupClient) List(ctx context.Context, query service.ListVariantGroups) (*logic.Page[model.VariantGroup], error) {
+	k := keyset{
+		column: variantGroupSortColumns[query.SortKey()],
+		idExpr: "id",
+		key:    query.SortKey().Value(),
+		page:   query.PageRequest,
+	}
+	arg := localeArg(ctx, map[string]any{})
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[variantGroupListModel](
 		ctx,
 		v.Client,
-		`SELECT id, name FROM groups;`,
+		fmt.Sprintf(
+			`SELECT id, name, %s AS display_name, version, %s AS sort_value FROM groups %s %s;`,
+			localizedName(translation.TargetGroup, "groups"), k.column.expr,
+			whereClause([]string{"deleted_at IS NULL", where}), k.orderBy(arg),
+		),
+		arg,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var results model.VariantGroups
-	for _, r := range rows {
-		results = append(
-			results,
-			model.NewVariantGroup(
-				model.VariantGroupID(r.ID),
-				model.VariantGroupName(r.Name),
-			),
-		)
-	}
-
-	return results, nil
+	return newPage(
+		k,
+		rows,
+		func(r variantGroupListModel) (any, int) { return r.SortValue, r.ID },
+		func(r variantGroupListModel) (model.VariantGroup, error) { return r.toVariantGroup(), nil },
+	)
 }
 
 func (v VariantGroupClient) Insert(ctx context.Context, create service.CreateVariantGroup) (*model.VariantGroup, error) {
@@ -86,14 +120,18 @@ func (v VariantGroupClient) Insert(ctx context.Context, create service.CreateVar
 	return result, nil
 }
 
+// Update 指定したバージョンの場合のみ更新する。対象が無い場合は、存在を確認した上で呼ばれるためVersionMismatchを返す
 func (v VariantGroupClient) Update(ctx context.Context, update service.UpdateVariantGroup) (*model.VariantGroup, error) {
 	_, err := NamedStore[int](
 		ctx,
 		v.Client,
-		`UPDATE groups SET name = :name, updated_at = NOW() WHERE id = :id;`,
-		map[string]any{"id": update.ID(), "name": update.Name()},
+		`UPDATE groups SET name = :name, version = version + 1, updated_at = NOW()
+				WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": update.ID(), "name": update.Name(), "version": update.Version()},
 	)
-	if err != nil {
+	if errors.Is(err, service.NotFound) {
+		return nil, service.VersionMismatch
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -104,6 +142,55 @@ func (v VariantGroupClient) Update(ctx context.Context, update service.UpdateVar
 
 	return result, nil
 }
-func (v VariantGroupClient) Delete(ctx context.Context, id model.VariantGroupID) error {
-	return NamedDelete(ctx, v.Client, `DELETE FROM groups WHERE id = :id;`, map[string]any{"id": id})
+
+// Delete ゴミ箱へ移すのみで、行の削除はpurgeで行う
+func (v VariantGroupClient) Delete(ctx context.Context, id model.VariantGroupID, version logic.Version) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE groups SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+				WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": id, "version": version},
+	)
+	if errors.Is(err, service.NotFound) {
+		return service.VersionMismatch
+	}
+	return err
+}
+
+// Restore ゴミ箱に無い場合はNotFoundを返す
+func (v VariantGroupClient) Restore(ctx context.Context, id model.VariantGroupID) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE groups SET deleted_at = NULL, version = version + 1, updated_at = NOW()
+				WHERE id = :id AND deleted_at IS NOT NULL RETURNING id;`,
+		map[string]any{"id": id},
+	)
+	return err
+}
+
+func (v VariantGroupClient) SelectDependents(ctx context.Context, id model.VariantGroupID) (logic.Dependents, error) {
+	rows, err := NamedSelect[DependentModel](
+		ctx,
+		v.Client,
+		`SELECT id, `+localizedName(translation.TargetVariant, "variants")+` AS name FROM variants
+				WHERE group_id = :id AND deleted_at IS NULL ORDER BY id;`,
+		localeArg(ctx, map[string]any{"id": id}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return toDependents(logic.AuditVariant, rows), nil
+}
+
+// MoveVariants 移す先のグループに同じ名前のバリアントがある場合は一意制約によりConflictとなる
+func (v VariantGroupClient) MoveVariants(ctx context.Context, from, to model.VariantGroupID) error {
+	return NamedExec(
+		ctx,
+		v.Client,
+		`UPDATE variants SET group_id = :to, version = version + 1, updated_at = NOW()
+				WHERE group_id = :from AND deleted_at IS NULL;`,
+		map[string]any{"from": from, "to": to},
+	)
 }
@@ -0,0 +1,220 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/samber/do"
+	"github.com/samber/lo"
+
+	"mods-explore/ark/omega/logic"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// VariantRuleModel 規則の種類によってgroup_idかvariant_idとother_variant_idのいずれかがNULLになる
+type VariantRuleModel struct {
+	ID             int    `db:"id"`
+	Kind           string `db:"kind"`
+	GroupID        *int   `db:"group_id"`
+	VariantID      *int   `db:"variant_id"`
+	OtherVariantID *int   `db:"other_variant_id"`
+}
+
+const variantRuleColumns = `id, kind, group_id, variant_id, other_variant_id`
+
+func (m VariantRuleModel) toVariantRule() (model.VariantRule, error) {
+	kind, err := model.NewVariantRuleKind(m.Kind)
+	if err != nil {
+		return model.VariantRule{}, err
+	}
+
+	var groupID *model.VariantGroupID
+	if m.GroupID != nil {
+		groupID = lo.ToPtr(model.VariantGroupID(*m.GroupID))
+	}
+	var variantID, otherVariantID *model.VariantID
+	if m.VariantID != nil {
+		variantID = lo.ToPtr(model.VariantID(*m.VariantID))
+	}
+	if m.OtherVariantID != nil {
+		otherVariantID = lo.ToPtr(model.VariantID(*m.OtherVariantID))
+	}
+	return model.NewVariantRule(model.VariantRuleID(m.ID), kind, groupID, variantID, otherVariantID)
+}
+
+func variantRuleArg(rule model.VariantRule) map[string]any {
+	return map[string]any{
+		"id":               rule.ID(),
+		"kind":             rule.Kind(),
+		"group_id":         rule.GroupID(),
+		"variant_id":       rule.VariantID(),
+		"other_variant_id": rule.OtherVariantID(),
+	}
+}
+
+type VariantRuleClient struct {
+	*Client
+}
+
+func NewVariantRuleClient(injector *do.Injector) (service.VariantRuleRepository, error) {
+	return VariantRuleClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+func (v VariantRuleClient) Select(ctx context.Context, id model.VariantRuleID) (*model.VariantRule, error) {
+	row, err := NamedGet[VariantRuleModel](
+		ctx,
+		v.Client,
+		`SELECT `+variantRuleColumns+` FROM variant_rules WHERE id = :id;`,
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := row.toVariantRule()
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+var variantRuleSortColumns = map[service.VariantRuleSortKey]sortColumn{
+	service.VariantRuleSortByID:        {expr: "id", castType: "INTEGER"},
+	service.VariantRuleSortByKind:      {expr: "kind", castType: "VARCHAR"},
+	service.VariantRuleSortByUpdatedAt: {expr: "updated_at", castType: "TIMESTAMPTZ"},
+}
+
+// variantRuleListModel ページングのため、ソートキーの値も合わせて取得する
+type variantRuleListModel struct {
+	VariantRuleModel
+	SortValue any `db:"sort_value"`
+}
+
+func (v VariantRuleClient) List(ctx context.Context, query service.ListVariantRules) (*logic.Page[model.VariantRule], error) {
+	k := keyset{
+		column: variantRuleSortColumns[query.SortKey()],
+		idExpr: "id",
+		key:    query.SortKey().Value(),
+		page:   query.PageRequest,
+	}
+	arg := map[string]any{}
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := NamedSelect[variantRuleListModel](
+		ctx,
+		v.Client,
+		fmt.Sprintf(
+			`SELECT `+variantRuleColumns+`, %s AS sort_value FROM variant_rules %s %s;`,
+			k.column.expr, whereClause([]string{where}), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r variantRuleListModel) (any, int) { return r.SortValue, r.ID },
+		func(r variantRuleListModel) (model.VariantRule, error) { return r.toVariantRule() },
+	)
+}
+
+func (v VariantRuleClient) Insert(ctx context.Context, create service.CreateVariantRule) (*model.VariantRule, error) {
+	id, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`INSERT INTO variant_rules (kind, group_id, variant_id, other_variant_id)
+				VALUES (:kind, :group_id, :variant_id, :other_variant_id) RETURNING id;`,
+		variantRuleArg(create.Rule()),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Select(ctx, model.VariantRuleID(id))
+}
+
+func (v VariantRuleClient) Update(ctx context.Context, update service.UpdateVariantRule) (*model.VariantRule, error) {
+	if _, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE variant_rules
+				SET kind = :kind, group_id = :group_id, variant_id = :variant_id,
+				    other_variant_id = :other_variant_id, updated_at = NOW()
+				WHERE id = :id RETURNING id;`,
+		variantRuleArg(update.Rule()),
+	); err != nil {
+		return nil, err
+	}
+
+	return v.Select(ctx, update.ID())
+}
+
+func (v VariantRuleClient) Delete(ctx context.Context, id model.VariantRuleID) error {
+	return NamedDelete(ctx, v.Client, `DELETE FROM variant_rules WHERE id = :id;`, map[string]any{"id": id})
+}
+
+func (v VariantRuleClient) SelectApplicable(
+	ctx context.Context, variants model.ComposedVariants,
+) (model.VariantRules, error) {
+	rows, err := NamedSelect[VariantRuleModel](
+		ctx,
+		v.Client,
+		`SELECT `+variantRuleColumns+` FROM variant_rules
+				WHERE group_id = ANY(:group_ids) OR variant_id = ANY(:variant_ids) OR other_variant_id = ANY(:variant_ids)
+				ORDER BY id;`,
+		map[string]any{
+			"group_ids": pq.Array(lo.Map(variants, func(c model.ComposedVariant, _ int) int64 {
+				return int64(c.GroupID())
+			})),
+			"variant_ids": pq.Array(lo.Map(variants, func(c model.ComposedVariant, _ int) int64 {
+				return int64(c.ID())
+			})),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make(model.VariantRules, 0, len(rows))
+	for _, row := range rows {
+		rule, err := row.toVariantRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+type composedVariantModel struct {
+	ID      int `db:"id"`
+	GroupID int `db:"group_id"`
+}
+
+func (v VariantRuleClient) SelectComposed(ctx context.Context, ids []model.VariantID) (model.ComposedVariants, error) {
+	rows, err := NamedSelect[composedVariantModel](
+		ctx,
+		v.Client,
+		`SELECT id, group_id FROM variants WHERE id = ANY(:ids) AND deleted_at IS NULL;`,
+		map[string]any{
+			"ids": pq.Array(lo.Map(ids, func(id model.VariantID, _ int) int64 { return int64(id) })),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(r composedVariantModel, _ int) model.ComposedVariant {
+		return model.NewComposedVariant(model.VariantID(r.ID), model.VariantGroupID(r.GroupID))
+	}), nil
+}
@@ -2,9 +2,15 @@ package storage
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/samber/do"
+	"github.com/samber/lo"
 
+	"mods-explore/ark/omega/logic"
+	translation "mods-explore/ark/omega/logic/translation/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/model"
 	"mods-explore/ark/omega/logic/variant/domain/service"
 )
@@ -21,55 +27,136 @@ func NewVariantClient(injector *do.Injector) (service.VariantRepository, error)
 
 // VariantModel Listでも1度に取得されるレコード量は決まっているので、domain modelで異なるバインド用モデルを定義する
 type VariantModel struct {
-	ID    int    `db:"id"`
-	Name  string `db:"name"`
-	Group string `db:"group"`
+	ID               int    `db:"id"`
+	Name             string `db:"name"`
+	DisplayName      string `db:"display_name"`
+	GroupID          int    `db:"group_id"`
+	Group            string `db:"group"`
+	GroupDisplayName string `db:"group_display_name"`
+	Version          int    `db:"version"`
 }
 
+func (m VariantModel) toVariant() model.Variant {
+	return model.NewVariant(
+		model.VariantID(m.ID),
+		model.VariantGroupName(m.Group),
+		model.Name(m.Name),
+	).WithGroupID(model.VariantGroupID(m.GroupID)).
+		WithDisplayName(model.Name(m.DisplayName), model.VariantGroupName(m.GroupDisplayName)).
+		WithVersion(logic.Version(m.Version))
+}
+
+// localizedVariantColumns 名前は保存された値のまま、リクエストの言語の表示名を別の列で取得する
+var localizedVariantColumns = `variants.id, variants.group_id, variants.name, groups.name AS "group", ` +
+	localizedName(translation.TargetVariant, "variants") + ` AS display_name, ` +
+	localizedName(translation.TargetGroup, "groups") + ` AS group_display_name, variants.version`
+
 func (v VariantClient) FindVariant(ctx context.Context, id model.VariantID) (*model.Variant, error) {
 	row, err := NamedGet[VariantModel](
 		ctx,
 		v.Client,
-		`SELECT variants.id, variants.name, groups.name AS "group" FROM variants
-    INNER JOIN groups ON (variants.group_id = groups.id) WHERE variants.id = :id;`,
-		map[string]any{"id": id},
+		`SELECT `+localizedVariantColumns+` FROM variants
+    INNER JOIN groups ON (variants.group_id = groups.id) WHERE variants.id = :id AND variants.deleted_at IS NULL;`,
+		localeArg(ctx, map[string]any{"id": id}),
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var variant = model.NewVariant(
-		model.VariantID(row.ID),
-		model.VariantGroupName(row.Group),
-		model.Name(row.Name),
-	)
+	descriptions, err := v.listDescriptions(ctx, model.VariantID(row.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	var variant = row.toVariant().WithDescriptions(descriptions)
 	return &variant, nil
 }
 
-func (v VariantClient) ListVariants(ctx context.Context) (model.Variants, error) {
-	rows, err := Select[VariantModel](
+var variantSortColumns = map[service.VariantSortKey]sortColumn{
+	service.VariantSortByID:        {expr: "variants.id", castType: "INTEGER"},
+	service.VariantSortByName:      {expr: "variants.name", castType: "VARCHAR"},
+	service.VariantSortByUpdatedAt: {expr: "variants.updated_at", castType: "TIMESTAMPTZ"},
+}
+
+// variantListModel ページングのため、ソートキーの値も合わせて取得する
+type variantListModel struct {
+	VariantModel
+	SortValue any `db:"sort_value"`
+}
+
+func (v VariantClient) ListVariants(ctx context.Context, query service.ListVariants) (*logic.Page[model.Variant], error) {
+	k := keyset{
+		column: variantSortColumns[query.SortKey()],
+		idExpr: "variants.id",
+		key:    query.SortKey().Value(),
+		page:   query.PageRequest,
+	}
+	arg := localeArg(ctx, map[string]any{})
+	conditions := []string{"variants.deleted_at IS NULL"}
+	if groupID := query.GroupID(); groupID != nil {
+		conditions = append(conditions, "variants.group_id = :group_id")
+		arg["group_id"] = *groupID
+	}
+	where, err := k.where(arg)
+	if err != nil {
+		return nil, err
+	}
+	if where != "" {
+		conditions = append(conditions, where)
+	}
+
+	rows, err := NamedSelect[variantListModel](
+		ctx,
+		v.Client,
+		fmt.Sprintf(
+			`SELECT `+localizedVariantColumns+`, %s AS sort_value FROM variants
+    INNER JOIN groups ON (variants.group_id = groups.id) %s %s;`,
+			k.column.expr, whereClause(conditions), k.orderBy(arg),
+		),
+		arg,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPage(
+		k,
+		rows,
+		func(r variantListModel) (any, int) { return r.SortValue, r.ID },
+		func(r variantListModel) (model.Variant, error) { return r.toVariant(), nil },
+	)
+}
+
+// variantDescriptionListModel 複数のバリアントの説明文をまとめて取得するため、バリアントのIDも合わせて取得する
+type variantDescriptionListModel struct {
+	VariantDescriptionModel
+	VariantID int `db:"variant_id"`
+}
+
+func (v VariantClient) SelectDescriptions(
+	ctx context.Context, ids []model.VariantID,
+) (map[model.VariantID]model.Descriptions, error) {
+	rows, err := NamedSelect[variantDescriptionListModel](
 		ctx,
 		v.Client,
-		`SELECT variants.id, variants.name, groups.name AS "group" FROM variants
-    INNER JOIN groups ON (variants.group_id = groups.id);`,
+		`SELECT variant_id, id, description FROM variant_descriptions
+			WHERE variant_id = ANY(:variant_ids) ORDER BY variant_id, position, id;`,
+		map[string]any{
+			"variant_ids": pq.Array(lo.Map(ids, func(id model.VariantID, _ int) int64 { return int64(id) })),
+		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	var results model.Variants
+	descriptions := make(map[model.VariantID]model.Descriptions)
 	for _, r := range rows {
-		results = append(
-			results,
-			model.NewVariant(
-				model.VariantID(r.ID),
-				model.VariantGroupName(r.Group),
-				model.Name(r.Name),
-			),
+		id := model.VariantID(r.VariantID)
+		descriptions[id] = append(
+			descriptions[id], model.NewDescription(model.DescriptionID(r.ID), model.DescriptionText(r.Description)),
 		)
 	}
-
-	return results, nil
+	return descriptions, nil
 }
 
 func (v VariantClient) CreateVariant(ctx context.Context, create service.CreateVariant) (*model.Variant, error) {
@@ -91,14 +178,20 @@ func (v VariantClient) CreateVariant(ctx context.Context, create service.CreateV
 	return result, nil
 }
 
+// UpdateVariant 指定したバージョンの場合のみ更新する。対象が無い場合は、存在を確認した上で呼ばれるためVersionMismatchを返す
 func (v VariantClient) UpdateVariant(ctx context.Context, update service.UpdateVariant) (*model.Variant, error) {
 	id, err := NamedStore[int](
 		ctx,
 		v.Client,
-		`UPDATE variants SET name = :name, group_id = :groupID, updated_at = NOW() WHERE id = :id RETURNING id;`,
-		map[string]any{"id": update.ID(), "name": update.Name(), "groupID": update.GroupID()},
+		`UPDATE variants SET name = :name, group_id = :groupID, version = version + 1, updated_at = NOW()
+				WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{
+			"id": update.ID(), "name": update.Name(), "groupID": update.GroupID(), "version": update.Version(),
+		},
 	)
-	if err != nil {
+	if errors.Is(err, service.NotFound) {
+		return nil, service.VersionMismatch
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -109,6 +202,172 @@ func (v VariantClient) UpdateVariant(ctx context.Context, update service.UpdateV
 
 	return result, nil
 }
-func (v VariantClient) DeleteVariant(ctx context.Context, id model.VariantID) error {
-	return NamedDelete(ctx, v.Client, `DELETE FROM variants WHERE id = :id;`, map[string]any{"id": id})
+
+// DeleteVariant ゴミ箱へ移すのみで、説明文や表示名は復元できるよう残す
+func (v VariantClient) DeleteVariant(ctx context.Context, id model.VariantID, version logic.Version) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE variants SET deleted_at = NOW(), version = version + 1, updated_at = NOW()
+				WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": id, "version": version},
+	)
+	if errors.Is(err, service.NotFound) {
+		return service.VersionMismatch
+	}
+	return err
+}
+
+// RestoreVariant ゴミ箱に無い場合はNotFound、グループがゴミ箱にある場合はParentDeletedを返す
+func (v VariantClient) RestoreVariant(ctx context.Context, id model.VariantID) error {
+	parentDeleted, err := NamedStore[bool](
+		ctx,
+		v.Client,
+		`UPDATE variants SET deleted_at = NULL, version = variants.version + 1, updated_at = NOW()
+				FROM groups WHERE variants.id = :id AND variants.deleted_at IS NOT NULL AND groups.id = variants.group_id
+				RETURNING groups.deleted_at IS NOT NULL;`,
+		map[string]any{"id": id},
+	)
+	if err != nil {
+		return err
+	}
+	if parentDeleted {
+		return service.ParentDeleted
+	}
+	return nil
+}
+
+// DependentModel 削除する行を参照している行
+type DependentModel struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func toDependents(entity logic.AuditEntity, rows []DependentModel) logic.Dependents {
+	dependents := make(logic.Dependents, 0, len(rows))
+	for _, r := range rows {
+		dependents = append(dependents, logic.Dependent{Entity: entity, ID: r.ID, Name: r.Name})
+	}
+	return dependents
+}
+
+func (v VariantClient) SelectDependents(ctx context.Context, id model.VariantID) (logic.Dependents, error) {
+	rows, err := NamedSelect[DependentModel](
+		ctx,
+		v.Client,
+		`SELECT DISTINCT u.id, `+localizedName(translation.TargetUnique, "u")+` AS name FROM uniques AS u
+				JOIN unique_variants AS uv ON uv.unique_id = u.id
+				WHERE uv.variant_id = :id AND u.deleted_at IS NULL ORDER BY u.id;`,
+		localeArg(ctx, map[string]any{"id": id}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return toDependents(logic.AuditUnique, rows), nil
+}
+
+func NewVariantDescriptionClient(injector *do.Injector) (service.VariantDescriptionRepository, error) {
+	return VariantClient{
+		do.MustInvoke[*Client](injector),
+	}, nil
+}
+
+type VariantDescriptionModel struct {
+	ID          int    `db:"id"`
+	Description string `db:"description"`
+}
+
+func (v VariantClient) listDescriptions(ctx context.Context, id model.VariantID) (model.Descriptions, error) {
+	rows, err := NamedSelect[VariantDescriptionModel](
+		ctx,
+		v.Client,
+		`SELECT id, description FROM variant_descriptions WHERE variant_id = :variant_id ORDER BY position, id;`,
+		map[string]any{"variant_id": id},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Map(rows, func(r VariantDescriptionModel, _ int) model.Description {
+		return model.NewDescription(model.DescriptionID(r.ID), model.DescriptionText(r.Description))
+	}), nil
+}
+
+// TouchVariant 説明文の変更をバリアントの更新として扱うため、指定したバージョンの場合のみバージョンを上げる
+func (v VariantClient) TouchVariant(ctx context.Context, id model.VariantID, version logic.Version) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE variants SET version = version + 1, updated_at = NOW()
+				WHERE id = :id AND version = :version AND deleted_at IS NULL RETURNING id;`,
+		map[string]any{"id": id, "version": version},
+	)
+	if errors.Is(err, service.NotFound) {
+		return service.VersionMismatch
+	}
+	return err
+}
+
+func (v VariantClient) CreateDescription(ctx context.Context, create service.CreateDescription) (model.DescriptionID, error) {
+	id, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`INSERT INTO variant_descriptions (variant_id, position, description)
+			VALUES (
+				:variant_id,
+				(SELECT COALESCE(MAX(position) + 1, 0) FROM variant_descriptions WHERE variant_id = :variant_id),
+				:description
+			) RETURNING id;`,
+		map[string]any{"variant_id": create.VariantID(), "description": create.Text()},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return model.DescriptionID(id), nil
+}
+
+func (v VariantClient) UpdateDescription(ctx context.Context, update service.UpdateDescription) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`UPDATE variant_descriptions SET description = :description, updated_at = NOW()
+			WHERE id = :id AND variant_id = :variant_id RETURNING id;`,
+		map[string]any{"id": update.ID(), "variant_id": update.VariantID(), "description": update.Text()},
+	)
+	return err
+}
+
+func (v VariantClient) DeleteDescription(ctx context.Context, remove service.DeleteDescription) error {
+	_, err := NamedStore[int](
+		ctx,
+		v.Client,
+		`DELETE FROM variant_descriptions WHERE id = :id AND variant_id = :variant_id RETURNING id;`,
+		map[string]any{"id": remove.ID(), "variant_id": remove.VariantID()},
+	)
+	return err
+}
+
+// ReplaceDescriptions 表示順を振り直すため一度全て削除してから挿入する。トランザクション内で呼び出すこと
+func (v VariantClient) ReplaceDescriptions(ctx context.Context, replace service.ReplaceDescriptions) error {
+	if err := NamedDelete(
+		ctx,
+		v.Client,
+		`DELETE FROM variant_descriptions WHERE variant_id = :variant_id;`,
+		map[string]any{"variant_id": replace.VariantID()},
+	); err != nil {
+		return err
+	}
+	if len(replace.Texts()) == 0 {
+		return nil
+	}
+
+	records := lo.Map(replace.Texts(), func(text model.DescriptionText, i int) map[string]any {
+		return map[string]any{"variant_id": replace.VariantID(), "position": i, "description": text}
+	})
+	return NamedExec(
+		ctx,
+		v.Client,
+		`INSERT INTO variant_descriptions (variant_id, position, description) VALUES (:variant_id, :position, :description);`,
+		records,
+	)
 }
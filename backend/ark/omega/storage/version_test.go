@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"mods-explore/ark/omega/logic"
+	creatureModel "mods-explore/ark/omega/logic/creature/domain/model"
+	creatureSvc "mods-explore/ark/omega/logic/creature/domain/service"
+	"mods-explore/ark/omega/logic/variant/domain/model"
+	"mods-explore/ark/omega/logic/variant/domain/service"
+)
+
+// testVersionSuite 条件付きの更新、削除で対象の行が無い場合にVersionMismatchになるかのテスト
+type testVersionSuite struct {
+	suite.Suite
+
+	cli  Client
+	mock sqlxmock.Sqlmock
+}
+
+func TestVersionSuite(t *testing.T) {
+	suite.Run(t, &testVersionSuite{})
+}
+
+func (s *testVersionSuite) SetupTest() {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		s.T().Fatal(err)
+	}
+
+	s.cli = Client{db, slog.New(slog.NewJSONHandler(os.Stdout, nil))}
+	s.mock = mock
+}
+
+func (s *testVersionSuite) TestUpdateVariantGroup() {
+	ctx := context.Background()
+	client := VariantGroupClient{&s.cli}
+
+	s.mock.ExpectPrepare(`UPDATE groups SET name = \?, version = version \+ 1`).
+		ExpectQuery().
+		WithArgs("cosmic", 1, 3).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+	_, err := client.Update(ctx, service.NewUpdateVariantGroup(1, "cosmic", logic.Version(3)))
+	s.ErrorIs(err, service.VersionMismatch)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testVersionSuite) TestDeleteVariant() {
+	ctx := context.Background()
+	client := VariantClient{&s.cli}
+
+	s.mock.ExpectPrepare(`UPDATE variants SET deleted_at = NOW\(\)`).
+		ExpectQuery().
+		WithArgs(1, 3).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+	s.ErrorIs(client.DeleteVariant(ctx, model.VariantID(1), logic.Version(3)), service.VersionMismatch)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testVersionSuite) TestDeleteUnique() {
+	ctx := context.Background()
+	client := UniqueCommandRepo{&s.cli}
+
+	s.mock.ExpectPrepare(`UPDATE uniques SET deleted_at = NOW\(\)`).
+		ExpectQuery().
+		WithArgs(1, 3).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}).AddRow(1))
+	s.Nil(client.Delete(ctx, creatureModel.UniqueDinosaurID(1), logic.Version(3)))
+
+	s.mock.ExpectPrepare(`UPDATE uniques SET deleted_at = NOW\(\)`).
+		ExpectQuery().
+		WithArgs(1, 3).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+	s.ErrorIs(client.Delete(ctx, creatureModel.UniqueDinosaurID(1), logic.Version(3)), creatureSvc.VersionMismatch)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testVersionSuite) TestUpdateDinosaur() {
+	ctx := context.Background()
+	client := DinosaurClient{&s.cli}
+
+	s.mock.ExpectPrepare(`UPDATE dinosaurs`).
+		ExpectQuery().
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+	update := creatureSvc.NewUpdateDinosaur(1, "Rex", creatureModel.DinosaurStats{}, logic.Version(3))
+	s.ErrorIs(client.Update(ctx, update), creatureSvc.VersionMismatch)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
+
+func (s *testVersionSuite) TestDeleteDinosaur() {
+	ctx := context.Background()
+	client := DinosaurClient{&s.cli}
+
+	s.mock.ExpectPrepare(`UPDATE dinosaurs SET deleted_at = NOW\(\), version = version \+ 1`).
+		ExpectQuery().
+		WithArgs(1, 3).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+	s.ErrorIs(client.Delete(ctx, creatureModel.DinosaurID(1), logic.Version(3)), creatureSvc.VersionMismatch)
+	s.Nil(s.mock.ExpectationsWereMet())
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"mods-explore/ark/omega"
+	"mods-explore/ark/omega/logic"
+	authModel "mods-explore/ark/omega/logic/auth/domain/model"
+	authUsecase "mods-explore/ark/omega/logic/auth/usecase"
+	trashModel "mods-explore/ark/omega/logic/trash/domain/model"
+	trashUsecase "mods-explore/ark/omega/logic/trash/usecase"
+	"mods-explore/ark/omega/storage"
+)
+
+const usage = `usage: admin <command> [options]
+
+commands:
+  purge [--older-than DURATION]          ゴミ箱で指定した期間を過ぎた行を完全に削除する (既定は720h)
+  token issue --name NAME --role ROLE    APIトークンを発行し、平文を一度だけ表示する (roleはviewer、editor、admin)
+  token list                             発行したAPIトークンの一覧を表示する
+  token revoke ID                        APIトークンを失効させる
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	injector, err := wired()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	ctx := logic.SetTransactioner(context.Background(), do.MustInvoke[*storage.Client](injector))
+
+	switch command, args := flag.Arg(0), flag.Args()[1:]; command {
+	case "purge":
+		err = purge(ctx, injector, args)
+	case "token":
+		err = token(ctx, injector, args)
+	default:
+		flag.Usage()
+		err = fmt.Errorf("unknown command: %s", command)
+	}
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+// wired 管理コマンドで用いる依存のみを登録する
+func wired() (*do.Injector, error) {
+	conf, err := omega.LoadDBConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	injector := do.New()
+	do.Provide(injector, func(_ *do.Injector) (*sqlx.DB, error) {
+		return storage.ConnectPostgres(conf.DSN())
+	})
+	do.ProvideValue(injector, slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+	do.Provide(injector, storage.NewSQLxClient)
+
+	do.Provide(injector, storage.NewTrashClient)
+	do.Provide(injector, trashUsecase.NewTrash)
+
+	do.Provide(injector, storage.NewAPITokenClient)
+	do.Provide(injector, authUsecase.NewToken)
+
+	return injector, nil
+}
+
+func purge(ctx context.Context, injector *do.Injector, args []string) error {
+	flags := flag.NewFlagSet("purge", flag.ExitOnError)
+	olderThan := flags.Duration("older-than", 30*24*time.Hour, "purge rows deleted before this duration")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *olderThan < 0 {
+		return fmt.Errorf("invalid older-than: %s", *olderThan)
+	}
+
+	purged, err := do.MustInvoke[trashUsecase.TrashUsecase](injector).Purge(ctx, time.Now().Add(-*olderThan))
+	if err != nil {
+		return err
+	}
+	logrus.Infof(
+		"purged %d rows (dinosaurs: %d, uniques: %d, variants: %d, groups: %d)",
+		purged.Total(),
+		purged[trashModel.ItemDinosaur], purged[trashModel.ItemUnique],
+		purged[trashModel.ItemVariant], purged[trashModel.ItemGroup],
+	)
+	return nil
+}
+
+func token(ctx context.Context, injector *do.Injector, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("token requires a subcommand: issue, list or revoke")
+	}
+	tokens := do.MustInvoke[authUsecase.TokenUsecase](injector)
+
+	switch command, args := args[0], args[1:]; command {
+	case "issue":
+		return issueToken(ctx, tokens, args)
+	case "list":
+		return listTokens(ctx, tokens)
+	case "revoke":
+		return revokeToken(ctx, tokens, args)
+	default:
+		return fmt.Errorf("unknown token command: %s", command)
+	}
+}
+
+// issueToken 平文は再表示できないため、ログではなく標準出力にのみ書き出す
+func issueToken(ctx context.Context, tokens authUsecase.TokenUsecase, args []string) error {
+	flags := flag.NewFlagSet("token issue", flag.ExitOnError)
+	name := flags.String("name", "", "name recorded as the actor of changes")
+	role := flags.String("role", "", "viewer, editor or admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	tokenName, err := authModel.NewTokenName(*name)
+	if err != nil {
+		return err
+	}
+	tokenRole, err := logic.NewRole(*role)
+	if err != nil {
+		return err
+	}
+
+	issued, err := tokens.Issue(ctx, tokenName, tokenRole)
+	if err != nil {
+		return err
+	}
+	logrus.Infof("issued token %d (name: %s, role: %s)", issued.ID(), issued.Name(), issued.Role())
+	fmt.Println(issued.Secret().Value())
+	return nil
+}
+
+func listTokens(ctx context.Context, tokens authUsecase.TokenUsecase) error {
+	list, err := tokens.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tCREATED\tREVOKED")
+	for _, t := range list {
+		revoked := "-"
+		if t.Revoked() {
+			revoked = t.RevokedAt().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", t.ID(), t.Name(), t.Role(), t.CreatedAt().Format(time.RFC3339), revoked)
+	}
+	return w.Flush()
+}
+
+func revokeToken(ctx context.Context, tokens authUsecase.TokenUsecase, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("token revoke requires exactly one token id")
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid token id: %s", args[0])
+	}
+
+	if err = tokens.Revoke(ctx, authModel.TokenID(id)); err != nil {
+		return err
+	}
+	logrus.Infof("revoked token %d", id)
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/samber/do"
+	"github.com/sirupsen/logrus"
+
+	"mods-explore/ark/omega"
+	"mods-explore/ark/omega/logic"
+	creatureUsecase "mods-explore/ark/omega/logic/creature/usecase"
+	"mods-explore/ark/omega/logic/i18n"
+	variantUsecase "mods-explore/ark/omega/logic/variant/usecase"
+	"mods-explore/ark/omega/server"
+	"mods-explore/ark/omega/site"
+	"mods-explore/ark/omega/storage"
+)
+
+const usage = `usage: export-site [options]
+
+カタログ全体を静的なサイトとして書き出す。前回から内容が変わったファイルのみを書き出し直す
+
+options:
+  --out DIR        出力先のディレクトリ (既定はsite)
+  --locale LOCALE  画面の文言と名前の言語 (jaまたはen、既定はja)
+  --force          変更の無いファイルも書き出し直す
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	out := flag.String("out", "site", "output directory")
+	lang := flag.String("locale", i18n.Japanese.Value(), "ja or en")
+	force := flag.Bool("force", false, "rewrite unchanged files")
+	flag.Parse()
+
+	if err := export(*out, *lang, *force); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func export(out, lang string, force bool) error {
+	locale, err := i18n.NewLocale(lang)
+	if err != nil {
+		return err
+	}
+
+	injector, err := wired()
+	if err != nil {
+		return err
+	}
+	ctx := logic.SetTransactioner(context.Background(), do.MustInvoke[*storage.Client](injector))
+	ctx = i18n.SetLocale(ctx, locale)
+
+	catalog, err := site.Load(
+		ctx,
+		do.MustInvoke[creatureUsecase.UniqueUsecase](injector),
+		do.MustInvoke[variantUsecase.VariantUsecase](injector),
+		do.MustInvoke[variantUsecase.VariantGroupUsecase](injector),
+	)
+	if err != nil {
+		return err
+	}
+	logrus.Infof(
+		"loaded %d uniques, %d variants and %d groups",
+		len(catalog.Uniques), len(catalog.Variants), len(catalog.Groups),
+	)
+
+	result, err := site.NewGenerator(out, locale, force).Generate(*catalog)
+	if err != nil {
+		return err
+	}
+	logrus.Infof(
+		"exported to %s (written: %d, unchanged: %d, removed: %d)",
+		out, result.Written, result.Unchanged, result.Removed,
+	)
+	return nil
+}
+
+// wired サーバーと同じ依存を用いるが、待ち受けるアドレスは不要なため設定を差し替える
+func wired() (*do.Injector, error) {
+	conf, err := omega.LoadCatalogConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	injector, err := server.Wired()
+	if err != nil {
+		return nil, err
+	}
+	do.OverrideValue(injector, *conf)
+	return injector, nil
+}
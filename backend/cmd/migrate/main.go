@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/sirupsen/logrus"
+
+	"mods-explore/ark/omega"
+	"mods-explore/ark/omega/storage"
+)
+
+const usage = `usage: migrate [--verify] <command> [arg] [--verify]
+
+commands:
+  up              未適用のマイグレーションを全て適用する
+  down N          N個分のマイグレーションを戻す
+  down --all      全てのマイグレーションを戻す (全てのテーブルが削除される)
+  goto VERSION    指定したバージョンまでup/downする
+  force VERSION   dirtyなバージョンを強制的に指定したバージョンにする
+  version         現在のバージョンを表示する
+
+--verify はコマンドの前後どちらにも指定できる
+--verify のみを指定した場合はコマンドを実行せずにバージョンの検証だけを行う
+`
+
+func main() {
+	verify := flag.Bool("verify", false, "verify that the database is migrated to the latest embedded version")
+	flag.Usage = func() { fmt.Fprint(flag.CommandLine.Output(), usage) }
+	flag.Parse()
+
+	if flag.NArg() == 0 && !*verify {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	conf, err := omega.LoadDBConfig()
+	if err != nil {
+		logrus.Fatal(err)
+	}
+
+	if flag.NArg() > 0 {
+		action, err := parseCommand(flag.Arg(0), flag.Args()[1:], verify)
+		if err != nil {
+			flag.Usage()
+			logrus.Fatal(err)
+		}
+		if err = storage.MigrateDatabase(conf.DSN(), action); err != nil {
+			if !errors.Is(err, migrate.ErrNoChange) {
+				logrus.Fatal(err)
+			}
+			logrus.Info("no change")
+		}
+	}
+
+	if *verify {
+		latest, err := storage.LatestMigrationVersion()
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		if err = storage.MigrateDatabase(conf.DSN(), storage.VerifyMigrationVersion(latest)); err != nil {
+			logrus.Fatal(err)
+		}
+		logrus.Infof("verified migration version: %d", latest)
+	}
+}
+
+// parseCommand コマンド毎のフラグを解析する。flag.Parseは最初の引数で止まるため、コマンドより後ろのフラグはここで解析する
+func parseCommand(command string, args []string, verify *bool) (storage.MigrateAction, error) {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(verify, "verify", *verify, "verify that the database is migrated to the latest embedded version")
+	all := fs.Bool("all", false, "roll back all migrations")
+
+	// 引数の後ろのフラグも解析できるよう、引数を1つずつ取り出しながら解析する
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if *all && command != "down" {
+		return nil, fmt.Errorf("--all is only for down: %s", command)
+	}
+	return parseAction(command, positional, *all)
+}
+
+func parseAction(command string, args []string, all bool) (storage.MigrateAction, error) {
+	switch command {
+	case "up":
+		if len(args) > 0 {
+			return nil, fmt.Errorf("unexpected arguments: %v", args)
+		}
+		return storage.MigrateUp(), nil
+	case "down":
+		// 誤って全てのテーブルを削除しないよう、全て戻す場合は--allの指定を必須にする
+		if all {
+			if len(args) > 0 {
+				return nil, fmt.Errorf("down --all takes no N: %v", args)
+			}
+			return storage.MigrateDown(), nil
+		}
+		if len(args) != 1 {
+			return nil, errors.New("down requires N or --all")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid down steps: %s", args[0])
+		}
+		return storage.MigrateSteps(-n), nil
+	case "goto":
+		if len(args) != 1 {
+			return nil, errors.New("goto requires VERSION")
+		}
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %s", args[0])
+		}
+		return storage.MigrateGoto(uint(version)), nil
+	case "force":
+		if len(args) != 1 {
+			return nil, errors.New("force requires VERSION")
+		}
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %s", args[0])
+		}
+		return storage.MigrateForce(version), nil
+	case "version":
+		if len(args) > 0 {
+			return nil, fmt.Errorf("unexpected arguments: %v", args)
+		}
+		return storage.MigrateVersion(func(version uint, dirty bool) {
+			logrus.Infof("version: %d, dirty: %v", version, dirty)
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown command: %s", command)
+	}
+}